@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Checkpoint is the position of a Tailer in the organization audit log.
+type Checkpoint struct {
+
+	// Time is the time of the latest event delivered.
+	Time time.Time `json:"time"`
+
+	// IDs are the events delivered within the overlap window before Time,
+	// they're used to skip the events fetched again by the next poll.
+	IDs map[string]time.Time `json:"ids,omitempty"`
+}
+
+// Store persists the Tailer checkpoint, so the tailer can resume after a restart.
+type Store interface {
+
+	// Load returns the latest checkpoint saved, or nil if the tailer has never run.
+	Load(ctx context.Context) (*Checkpoint, error)
+
+	// Save persists the checkpoint, it's called by the first poll and after each batch of events is delivered.
+	Save(ctx context.Context, checkpoint *Checkpoint) error
+}
+
+// NewMemoryStore returns a Store that keeps the checkpoint in memory, useful for tests
+// or for tailers that don't need to resume after a restart.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+type MemoryStore struct {
+	mu         sync.Mutex
+	checkpoint *Checkpoint
+}
+
+func (m *MemoryStore) Load(ctx context.Context) (*Checkpoint, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.checkpoint, nil
+}
+
+func (m *MemoryStore) Save(ctx context.Context, checkpoint *Checkpoint) error {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.checkpoint = checkpoint
+	return nil
+}
+
+// NewFileStore returns a Store that persists the checkpoint as a JSON document on the path provided.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+type FileStore struct {
+	path string
+}
+
+func (f *FileStore) Load(ctx context.Context) (*Checkpoint, error) {
+
+	content, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := new(Checkpoint)
+	if err = json.Unmarshal(content, checkpoint); err != nil {
+		return nil, err
+	}
+
+	return checkpoint, nil
+}
+
+// Save writes the checkpoint on a temporary file renamed over the previous one,
+// so a crash while saving doesn't corrupt the checkpoint.
+func (f *FileStore) Save(ctx context.Context, checkpoint *Checkpoint) error {
+
+	content, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	temporary, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+
+	if _, err = temporary.Write(content); err != nil {
+		temporary.Close()
+		os.Remove(temporary.Name())
+		return err
+	}
+
+	if err = temporary.Close(); err != nil {
+		os.Remove(temporary.Name())
+		return err
+	}
+
+	return os.Rename(temporary.Name(), f.path)
+}
@@ -0,0 +1,283 @@
+// Package audit provides a tailer that continuously exports the audit log events of an
+// Atlassian organization, e.g: to feed a SIEM.
+package audit
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/admin"
+)
+
+const (
+	defaultInterval = time.Minute
+	defaultOverlap  = 5 * time.Minute
+)
+
+var (
+	ErrNoOrganizationConnectorError = errors.New("audit: no organization connector set")
+	ErrNoStoreError                 = errors.New("audit: no checkpoint store set")
+)
+
+// Handler receives the events of a poll, sorted from the oldest to the newest.
+//
+// The checkpoint is only saved when the handler succeeds, so the events are delivered again
+// by the next poll if the handler fails.
+type Handler func(ctx context.Context, events []*model.OrganizationEventModelScheme) error
+
+type Options struct {
+
+	// Interval is the time between two polls, one minute by default.
+	Interval time.Duration
+
+	// Overlap is how far before the checkpoint each poll starts, so the events indexed late
+	// by the audit log aren't missed. The events already delivered are skipped. Five minutes by default.
+	Overlap time.Duration
+
+	// Since is where the tailer starts when the store has no checkpoint, now by default.
+	Since time.Time
+
+	// Query and Action filter the events, see the OrganizationEventOptScheme.
+	Query, Action string
+
+	// Now returns the end of the window of each poll, and its start when there is no checkpoint, time.Now by default.
+	Now func() time.Time
+}
+
+// Tailer polls the audit log of an organization with overlapping time windows, de-duplicates
+// the events by ID and persists its checkpoint, so it can be resumed across restarts without gaps.
+type Tailer struct {
+	organization   admin.OrganizationConnector
+	organizationID string
+	store          Store
+	options        Options
+}
+
+// NewTailer returns a tailer of the organization audit log, the organization connector is
+// usually the Organization service of the admin client.
+func NewTailer(organization admin.OrganizationConnector, organizationID string, store Store, options *Options) (*Tailer, error) {
+
+	if organization == nil {
+		return nil, ErrNoOrganizationConnectorError
+	}
+
+	if organizationID == "" {
+		return nil, model.ErrNoAdminOrganizationError
+	}
+
+	if store == nil {
+		return nil, ErrNoStoreError
+	}
+
+	tailer := &Tailer{
+		organization:   organization,
+		organizationID: organizationID,
+		store:          store,
+	}
+
+	if options != nil {
+		tailer.options = *options
+	}
+
+	if tailer.options.Interval <= 0 {
+		tailer.options.Interval = defaultInterval
+	}
+
+	if tailer.options.Overlap <= 0 {
+		tailer.options.Overlap = defaultOverlap
+	}
+
+	if tailer.options.Now == nil {
+		tailer.options.Now = time.Now
+	}
+
+	return tailer, nil
+}
+
+// Run polls the audit log until the context is cancelled or the handler fails.
+func (t *Tailer) Run(ctx context.Context, handler Handler) error {
+
+	ticker := time.NewTicker(t.options.Interval)
+	defer ticker.Stop()
+
+	for {
+
+		if err := t.Poll(ctx, handler); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stream polls the audit log in the background and sends the events on the returned channel.
+//
+// Both channels are closed when the context is cancelled or a poll fails, the error channel
+// receives the error that stopped the tailer, if any.
+func (t *Tailer) Stream(ctx context.Context) (<-chan *model.OrganizationEventModelScheme, <-chan error) {
+
+	events := make(chan *model.OrganizationEventModelScheme)
+	errs := make(chan error, 1)
+
+	go func() {
+
+		defer close(errs)
+		defer close(events)
+
+		err := t.Run(ctx, func(ctx context.Context, batch []*model.OrganizationEventModelScheme) error {
+
+			for _, event := range batch {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+
+			return nil
+		})
+
+		if err != nil && !errors.Is(err, context.Canceled) {
+			errs <- err
+		}
+	}()
+
+	return events, errs
+}
+
+// Poll fetches the events since the checkpoint, delivers the new ones to the handler and saves the checkpoint.
+func (t *Tailer) Poll(ctx context.Context, handler Handler) error {
+
+	checkpoint, err := t.store.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	initial := checkpoint == nil
+	if initial {
+
+		since := t.options.Since
+		if since.IsZero() {
+			since = t.options.Now()
+		}
+
+		checkpoint = &Checkpoint{Time: since}
+	}
+
+	if checkpoint.IDs == nil {
+		checkpoint.IDs = make(map[string]time.Time)
+	}
+
+	options := &model.OrganizationEventOptScheme{
+		Q:      t.options.Query,
+		Action: t.options.Action,
+		From:   checkpoint.Time.Add(-t.options.Overlap),
+		To:     t.options.Now(),
+	}
+
+	fetched, err := t.fetch(ctx, options)
+	if err != nil {
+		return err
+	}
+
+	var events []*model.OrganizationEventModelScheme
+	for _, event := range fetched {
+
+		if _, delivered := checkpoint.IDs[event.ID]; delivered {
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+
+	if len(events) == 0 {
+
+		// The first checkpoint is saved even without events, otherwise the next poll, or the
+		// tailer resumed after a restart, would start from now again and miss the events between.
+		if initial {
+			return t.store.Save(ctx, checkpoint)
+		}
+
+		return nil
+	}
+
+	if err = handler(ctx, events); err != nil {
+		return err
+	}
+
+	for _, event := range events {
+
+		occurred := eventTime(event)
+
+		// The events without a valid time are kept as if they occurred at the end of the poll,
+		// so they aren't pruned, and delivered again, until the overlap window has passed.
+		if occurred.IsZero() {
+			checkpoint.IDs[event.ID] = options.To
+			continue
+		}
+
+		checkpoint.IDs[event.ID] = occurred
+
+		if occurred.After(checkpoint.Time) {
+			checkpoint.Time = occurred
+		}
+	}
+
+	// The IDs older than the overlap window can't be fetched again.
+	for id, occurred := range checkpoint.IDs {
+		if occurred.Before(checkpoint.Time.Add(-t.options.Overlap)) {
+			delete(checkpoint.IDs, id)
+		}
+	}
+
+	return t.store.Save(ctx, checkpoint)
+}
+
+func (t *Tailer) fetch(ctx context.Context, options *model.OrganizationEventOptScheme) ([]*model.OrganizationEventModelScheme, error) {
+
+	var (
+		events []*model.OrganizationEventModelScheme
+		cursor string
+	)
+
+	for {
+
+		page, _, err := t.organization.Events(ctx, t.organizationID, options, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, page.Data...)
+
+		if page.Meta.Next == "" || page.Meta.Next == cursor {
+			return events, nil
+		}
+
+		cursor = page.Meta.Next
+	}
+}
+
+func eventTime(event *model.OrganizationEventModelScheme) time.Time {
+
+	if event.Attributes == nil {
+		return time.Time{}
+	}
+
+	occurred, err := time.Parse(time.RFC3339, event.Attributes.Time)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return occurred
+}
@@ -0,0 +1,239 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/admin"
+	"github.com/stretchr/testify/assert"
+)
+
+type organizationFake struct {
+	admin.OrganizationConnector
+
+	pages   map[string]*model.OrganizationEventPageScheme
+	options []*model.OrganizationEventOptScheme
+}
+
+func (o *organizationFake) Events(ctx context.Context, organizationID string, options *model.OrganizationEventOptScheme,
+	cursor string) (*model.OrganizationEventPageScheme, *model.ResponseScheme, error) {
+
+	o.options = append(o.options, options)
+
+	page, ok := o.pages[cursor]
+	if !ok {
+		return nil, nil, errors.New("unexpected cursor " + cursor)
+	}
+
+	return page, nil, nil
+}
+
+func newEvent(id, occurred string) *model.OrganizationEventModelScheme {
+	return &model.OrganizationEventModelScheme{
+		ID:         id,
+		Attributes: &model.OrganizationEventModelAttributesScheme{Time: occurred, Action: "user_login"},
+	}
+}
+
+func newPage(next string, events ...*model.OrganizationEventModelScheme) *model.OrganizationEventPageScheme {
+
+	page := &model.OrganizationEventPageScheme{Data: events}
+	page.Meta.Next = next
+
+	return page
+}
+
+func TestTailer_Poll(t *testing.T) {
+
+	now := time.Date(2023, 5, 2, 10, 0, 0, 0, time.UTC)
+
+	organization := &organizationFake{
+		pages: map[string]*model.OrganizationEventPageScheme{
+			"": newPage("cursor-2",
+				newEvent("event-3", "2023-05-02T09:58:00Z"),
+				newEvent("event-2", "2023-05-02T09:57:00Z")),
+			"cursor-2": newPage("",
+				newEvent("event-1", "2023-05-02T09:56:00Z")),
+		},
+	}
+
+	store := NewFileStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+
+	tailer, err := NewTailer(organization, "organization-id", store, &Options{
+		Overlap: 10 * time.Minute,
+		Since:   now.Add(-time.Hour),
+		Now:     func() time.Time { return now },
+	})
+	assert.NoError(t, err)
+
+	var delivered []string
+	handler := func(ctx context.Context, events []*model.OrganizationEventModelScheme) error {
+		for _, event := range events {
+			delivered = append(delivered, event.ID)
+		}
+		return nil
+	}
+
+	assert.NoError(t, tailer.Poll(context.Background(), handler))
+	assert.Equal(t, []string{"event-1", "event-2", "event-3"}, delivered)
+	assert.Equal(t, now.Add(-70*time.Minute), organization.options[0].From)
+	assert.Equal(t, now, organization.options[0].To)
+
+	// The next poll overlaps the previous one, only the new events are delivered.
+	organization.pages = map[string]*model.OrganizationEventPageScheme{
+		"": newPage("",
+			newEvent("event-4", "2023-05-02T09:59:00Z"),
+			newEvent("event-3", "2023-05-02T09:58:00Z"),
+			newEvent("event-2", "2023-05-02T09:57:00Z")),
+	}
+
+	// A new tailer resumes from the checkpoint persisted by the previous one.
+	tailer, err = NewTailer(organization, "organization-id", store, &Options{
+		Overlap: 10 * time.Minute,
+		Now:     func() time.Time { return now },
+	})
+	assert.NoError(t, err)
+
+	delivered = nil
+	assert.NoError(t, tailer.Poll(context.Background(), handler))
+	assert.Equal(t, []string{"event-4"}, delivered)
+	assert.Equal(t, time.Date(2023, 5, 2, 9, 48, 0, 0, time.UTC), organization.options[len(organization.options)-1].From)
+
+	checkpoint, err := store.Load(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 5, 2, 9, 59, 0, 0, time.UTC), checkpoint.Time)
+	assert.Len(t, checkpoint.IDs, 4)
+}
+
+func TestTailer_Poll_HandlerError(t *testing.T) {
+
+	now := time.Date(2023, 5, 2, 10, 0, 0, 0, time.UTC)
+
+	organization := &organizationFake{
+		pages: map[string]*model.OrganizationEventPageScheme{
+			"": newPage("", newEvent("event-1", "2023-05-02T09:56:00Z")),
+		},
+	}
+
+	store := NewMemoryStore()
+
+	tailer, err := NewTailer(organization, "organization-id", store, &Options{
+		Since: now.Add(-time.Hour),
+		Now:   func() time.Time { return now },
+	})
+	assert.NoError(t, err)
+
+	err = tailer.Poll(context.Background(), func(ctx context.Context, events []*model.OrganizationEventModelScheme) error {
+		return errors.New("siem unavailable")
+	})
+	assert.EqualError(t, err, "siem unavailable")
+
+	checkpoint, err := store.Load(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+}
+
+func TestTailer_Poll_WithoutEvents(t *testing.T) {
+
+	now := time.Date(2023, 5, 2, 10, 0, 0, 0, time.UTC)
+
+	organization := &organizationFake{
+		pages: map[string]*model.OrganizationEventPageScheme{"": newPage("")},
+	}
+
+	store := NewMemoryStore()
+
+	tailer, err := NewTailer(organization, "organization-id", store, &Options{
+		Now: func() time.Time { return now },
+	})
+	assert.NoError(t, err)
+
+	handler := func(ctx context.Context, events []*model.OrganizationEventModelScheme) error { return nil }
+
+	assert.NoError(t, tailer.Poll(context.Background(), handler))
+
+	checkpoint, err := store.Load(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, now, checkpoint.Time)
+
+	// The next poll starts from the checkpoint saved, not from the time it runs.
+	now = now.Add(time.Hour)
+
+	assert.NoError(t, tailer.Poll(context.Background(), handler))
+	assert.Equal(t, time.Date(2023, 5, 2, 9, 55, 0, 0, time.UTC), organization.options[1].From)
+}
+
+func TestTailer_Poll_InvalidTime(t *testing.T) {
+
+	now := time.Date(2023, 5, 2, 10, 0, 0, 0, time.UTC)
+
+	organization := &organizationFake{
+		pages: map[string]*model.OrganizationEventPageScheme{
+			"": newPage("",
+				newEvent("event-1", "2023-05-02T09:56:00Z"),
+				newEvent("event-2", "not a time")),
+		},
+	}
+
+	tailer, err := NewTailer(organization, "organization-id", NewMemoryStore(), &Options{
+		Since: now.Add(-time.Hour),
+		Now:   func() time.Time { return now },
+	})
+	assert.NoError(t, err)
+
+	var delivered []string
+	handler := func(ctx context.Context, events []*model.OrganizationEventModelScheme) error {
+		for _, event := range events {
+			delivered = append(delivered, event.ID)
+		}
+		return nil
+	}
+
+	assert.NoError(t, tailer.Poll(context.Background(), handler))
+	assert.NoError(t, tailer.Poll(context.Background(), handler))
+
+	// The event without a valid time is delivered once.
+	assert.Equal(t, []string{"event-2", "event-1"}, delivered)
+}
+
+func TestTailer_Stream(t *testing.T) {
+
+	organization := &organizationFake{
+		pages: map[string]*model.OrganizationEventPageScheme{
+			"": newPage("", newEvent("event-1", "2023-05-02T09:56:00Z")),
+		},
+	}
+
+	tailer, err := NewTailer(organization, "organization-id", NewMemoryStore(), &Options{
+		Since: time.Date(2023, 5, 2, 9, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events, errs := tailer.Stream(ctx)
+	assert.Equal(t, "event-1", (<-events).ID)
+
+	cancel()
+
+	for range events {
+	}
+
+	assert.NoError(t, <-errs)
+}
+
+func TestNewTailer(t *testing.T) {
+
+	_, err := NewTailer(nil, "organization-id", NewMemoryStore(), nil)
+	assert.EqualError(t, err, ErrNoOrganizationConnectorError.Error())
+
+	_, err = NewTailer(&organizationFake{}, "", NewMemoryStore(), nil)
+	assert.EqualError(t, err, model.ErrNoAdminOrganizationError.Error())
+
+	_, err = NewTailer(&organizationFake{}, "organization-id", nil, nil)
+	assert.EqualError(t, err, ErrNoStoreError.Error())
+}
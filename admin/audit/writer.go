@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// NDJSONHandler returns a Handler that writes each event on the writer as a JSON document per line.
+func NDJSONHandler(w io.Writer) Handler {
+
+	return func(ctx context.Context, events []*model.OrganizationEventModelScheme) error {
+
+		buffer := bufio.NewWriter(w)
+		encoder := json.NewEncoder(buffer)
+
+		for _, event := range events {
+			if err := encoder.Encode(event); err != nil {
+				return err
+			}
+		}
+
+		return buffer.Flush()
+	}
+}
+
+// CEFHandler returns a Handler that writes each event on the writer as an ArcSight Common Event Format line.
+//
+// The severity is the CEF severity of every event, from 0 to 10.
+func CEFHandler(w io.Writer, severity int) Handler {
+
+	return func(ctx context.Context, events []*model.OrganizationEventModelScheme) error {
+
+		buffer := bufio.NewWriter(w)
+
+		for _, event := range events {
+			if _, err := buffer.WriteString(FormatCEF(event, severity) + "\n"); err != nil {
+				return err
+			}
+		}
+
+		return buffer.Flush()
+	}
+}
+
+// FormatCEF formats an organization event as a Common Event Format line.
+func FormatCEF(event *model.OrganizationEventModelScheme, severity int) string {
+
+	attributes := event.Attributes
+	if attributes == nil {
+		attributes = &model.OrganizationEventModelAttributesScheme{}
+	}
+
+	action := cefHeader(attributes.Action)
+
+	var extension []string
+	appendExtension := func(key, value string) {
+		if value != "" {
+			extension = append(extension, key+"="+cefExtension(value))
+		}
+	}
+
+	if occurred := eventTime(event); !occurred.IsZero() {
+		appendExtension("rt", fmt.Sprint(occurred.UnixNano()/int64(time.Millisecond)))
+	}
+
+	if attributes.Actor != nil {
+		appendExtension("suid", attributes.Actor.ID)
+		appendExtension("suser", attributes.Actor.Name)
+	}
+
+	if attributes.Location != nil {
+		appendExtension("src", attributes.Location.IP)
+
+		if attributes.Location.Geo != "" {
+			appendExtension("cs1Label", "geo")
+			appendExtension("cs1", attributes.Location.Geo)
+		}
+	}
+
+	appendExtension("externalId", event.ID)
+
+	return fmt.Sprintf("CEF:0|Atlassian|Organization|1.0|%v|%v|%v|%v",
+		action, action, severity, strings.Join(extension, " "))
+}
+
+func cefHeader(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ").Replace(value)
+}
+
+func cefExtension(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`).Replace(value)
+}
@@ -0,0 +1,44 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNDJSONHandler(t *testing.T) {
+
+	buffer := new(bytes.Buffer)
+
+	err := NDJSONHandler(buffer)(context.Background(), []*model.OrganizationEventModelScheme{
+		newEvent("event-1", "2023-05-02T09:56:00Z"),
+		newEvent("event-2", "2023-05-02T09:57:00Z"),
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t,
+		`{"id":"event-1","attributes":{"time":"2023-05-02T09:56:00Z","action":"user_login"}}`+"\n"+
+			`{"id":"event-2","attributes":{"time":"2023-05-02T09:57:00Z","action":"user_login"}}`+"\n",
+		buffer.String())
+}
+
+func TestCEFHandler(t *testing.T) {
+
+	event := newEvent("event-1", "2023-05-02T09:56:00Z")
+	event.Attributes.Action = "policy|updated"
+	event.Attributes.Actor = &model.OrganizationEventActorModel{ID: "account-id", Name: "Carlos = Treminio"}
+	event.Attributes.Location = &model.OrganizationEventLocationModel{IP: "10.0.0.1"}
+
+	buffer := new(bytes.Buffer)
+
+	err := CEFHandler(buffer, 3)(context.Background(), []*model.OrganizationEventModelScheme{event})
+	assert.NoError(t, err)
+
+	assert.Equal(t,
+		`CEF:0|Atlassian|Organization|1.0|policy\|updated|policy\|updated|3|rt=1683021360000 suid=account-id `+
+			`suser=Carlos \= Treminio src=10.0.0.1 externalId=event-1`+"\n",
+		buffer.String())
+}
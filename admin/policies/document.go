@@ -0,0 +1,178 @@
+// Package policies exports the policies of an Atlassian organization to a declarative document,
+// detects the drift between organizations or against a baseline, and applies the differences.
+package policies
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/admin"
+	"gopkg.in/yaml.v3"
+)
+
+const documentVersion = 1
+
+var (
+	ErrNoPolicyConnectorError = errors.New("policies: no organization policy connector set")
+	ErrNoDocumentError        = errors.New("policies: no document set")
+	ErrDuplicatedPolicyError  = errors.New("policies: duplicated policy type and name")
+	ErrUnmappedResourceError  = errors.New("policies: resource not mapped")
+	ErrApplyError             = errors.New("policies: some changes failed")
+)
+
+// Document is the declarative state of the policies of an organization, e.g: the IP allowlist,
+// data residency and authentication policies.
+//
+// The policies are identified by type and name, so a document exported from an organization
+// can be compared against another one.
+type Document struct {
+	Version  int       `json:"version" yaml:"version"`
+	Policies []*Policy `json:"policies" yaml:"policies"`
+}
+
+// Policy is the declarative state of an organization policy.
+type Policy struct {
+	Type   string `json:"type" yaml:"type"`
+	Name   string `json:"name" yaml:"name"`
+	Status string `json:"status,omitempty" yaml:"status,omitempty"`
+
+	// Rule is the content of the policy as returned by the organization, e.g: the IP ranges of an
+	// ip-allowlist policy, the realm of a data-residency policy or the settings of an authentication policy.
+	Rule map[string]interface{} `json:"rule,omitempty" yaml:"rule,omitempty"`
+
+	// Resources are the IDs of the resources the policy applies to.
+	Resources []string `json:"resources,omitempty" yaml:"resources,omitempty"`
+
+	id string
+}
+
+// Export returns the policies of the organization as a document, only the policy types provided
+// are exported, or all of them when no type is provided.
+func Export(ctx context.Context, connector admin.OrganizationPolicyConnector, organizationID string, policyTypes ...string) (*Document, error) {
+
+	if connector == nil {
+		return nil, ErrNoPolicyConnectorError
+	}
+
+	if len(policyTypes) == 0 {
+		policyTypes = []string{""}
+	}
+
+	document := &Document{Version: documentVersion}
+	for _, policyType := range policyTypes {
+
+		var cursor string
+		for {
+
+			page, _, err := connector.Gets(ctx, organizationID, policyType, cursor)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, data := range page.Data {
+				document.Policies = append(document.Policies, newPolicy(data))
+			}
+
+			if page.Meta.Next == "" || page.Meta.Next == cursor {
+				break
+			}
+
+			cursor = page.Meta.Next
+		}
+	}
+
+	document.sort()
+	return document, nil
+}
+
+// ReadDocument reads a document written as YAML or JSON.
+func ReadDocument(r io.Reader) (*Document, error) {
+
+	document := new(Document)
+	if err := yaml.NewDecoder(r).Decode(document); err != nil {
+		return nil, err
+	}
+
+	document.sort()
+	return document, nil
+}
+
+// WriteYAML writes the document as YAML.
+func (d *Document) WriteYAML(w io.Writer) error {
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(d); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// WriteJSON writes the document as an indented JSON document.
+func (d *Document) WriteJSON(w io.Writer) error {
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(d)
+}
+
+func (d *Document) sort() {
+
+	for _, policy := range d.Policies {
+		sort.Strings(policy.Resources)
+	}
+
+	sort.SliceStable(d.Policies, func(i, j int) bool {
+
+		if d.Policies[i].Type != d.Policies[j].Type {
+			return d.Policies[i].Type < d.Policies[j].Type
+		}
+
+		return d.Policies[i].Name < d.Policies[j].Name
+	})
+}
+
+func newPolicy(data *model.OrganizationPolicyData) *Policy {
+
+	policy := &Policy{Type: data.Type, id: data.ID}
+
+	if data.Attributes != nil {
+
+		policy.Name = data.Attributes.Name
+		policy.Status = data.Attributes.Status
+		policy.Rule = data.Attributes.Rule
+
+		if data.Attributes.Type != "" {
+			policy.Type = data.Attributes.Type
+		}
+
+		for _, resource := range data.Attributes.Resources {
+			policy.Resources = append(policy.Resources, resource.ID)
+		}
+	}
+
+	return policy
+}
+
+func (p *Policy) payload() *model.OrganizationPolicyData {
+
+	attributes := &model.OrganizationPolicyAttributes{
+		Type:   p.Type,
+		Name:   p.Name,
+		Status: p.Status,
+		Rule:   p.Rule,
+	}
+
+	for _, id := range p.Resources {
+		attributes.Resources = append(attributes.Resources, &model.OrganizationPolicyResource{ID: id})
+	}
+
+	return &model.OrganizationPolicyData{ID: p.id, Type: "policy", Attributes: attributes}
+}
@@ -0,0 +1,309 @@
+package policies
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/service/admin"
+)
+
+// Action is whether a policy is created, updated or deleted on the organization.
+type Action string
+
+const (
+	CreateAction Action = "create"
+	UpdateAction Action = "update"
+	DeleteAction Action = "delete"
+)
+
+type CompareOptions struct {
+
+	// IgnoreResources skips the comparison of the policy resources, e.g: when comparing
+	// organizations whose resources have different IDs. The policies updated keep their resources,
+	// the policies created have none unless the resources are mapped.
+	IgnoreResources bool
+
+	// Resources maps the resource IDs of the desired document to the resources of the current
+	// organization, e.g: the sites of a staging organization to the production ones. When set,
+	// every resource of the desired policies must be mapped.
+	Resources map[string]string
+
+	// Prune deletes the policies of the current document not included on the desired one.
+	Prune bool
+}
+
+// Plan is the list of changes required to turn the current policies into the desired ones.
+type Plan struct {
+	Changes []*Change
+}
+
+// Change is a single policy created, updated or deleted.
+type Change struct {
+	Action      Action
+	Type        string
+	Name        string
+	Differences []*Difference
+
+	policy *Policy
+	id     string
+}
+
+// Difference is a policy attribute whose current value differs from the desired one.
+type Difference struct {
+	Attribute string
+	Current   string
+	Desired   string
+}
+
+// IsEmpty reports whether there's no drift between the documents.
+func (p *Plan) IsEmpty() bool {
+	return len(p.Changes) == 0
+}
+
+// Compare returns the changes required to turn the current document into the desired one,
+// e.g: the document exported from an organization into a stored baseline or another organization.
+func Compare(current, desired *Document, options *CompareOptions) (*Plan, error) {
+
+	if current == nil || desired == nil {
+		return nil, ErrNoDocumentError
+	}
+
+	if options == nil {
+		options = &CompareOptions{}
+	}
+
+	currentPolicies, err := index(current)
+	if err != nil {
+		return nil, err
+	}
+
+	desiredPolicies, err := index(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := new(Plan)
+	for _, desiredPolicy := range desired.Policies {
+
+		policy, err := options.resolve(desiredPolicy)
+		if err != nil {
+			return nil, err
+		}
+
+		existing, ok := currentPolicies[identity(policy)]
+		if !ok {
+
+			// The resources of another organization don't exist on the current one.
+			if options.IgnoreResources && options.Resources == nil {
+				policy.Resources = nil
+			}
+
+			plan.Changes = append(plan.Changes, &Change{Action: CreateAction, Type: policy.Type, Name: policy.Name, policy: policy})
+			continue
+		}
+
+		var differences []*Difference
+		if existing.Status != policy.Status {
+			differences = append(differences, &Difference{Attribute: "status", Current: existing.Status, Desired: policy.Status})
+		}
+
+		if currentRule, desiredRule := rule(existing), rule(policy); currentRule != desiredRule {
+			differences = append(differences, &Difference{Attribute: "rule", Current: currentRule, Desired: desiredRule})
+		}
+
+		if currentResources, desiredResources := resources(existing), resources(policy); !options.IgnoreResources &&
+			currentResources != desiredResources {
+			differences = append(differences, &Difference{Attribute: "resources", Current: currentResources, Desired: desiredResources})
+		}
+
+		if len(differences) == 0 {
+			continue
+		}
+
+		if options.IgnoreResources {
+			policy.Resources = existing.Resources
+		}
+
+		plan.Changes = append(plan.Changes, &Change{
+			Action:      UpdateAction,
+			Type:        policy.Type,
+			Name:        policy.Name,
+			Differences: differences,
+			policy:      policy,
+			id:          existing.id,
+		})
+	}
+
+	if options.Prune {
+
+		for _, policy := range current.Policies {
+
+			if _, ok := desiredPolicies[identity(policy)]; ok {
+				continue
+			}
+
+			plan.Changes = append(plan.Changes, &Change{Action: DeleteAction, Type: policy.Type, Name: policy.Name, id: policy.id})
+		}
+	}
+
+	return plan, nil
+}
+
+// Drift exports the policies of the organization and compares them with the desired document.
+//
+// The plan returned can be applied on the organization with Apply.
+func Drift(ctx context.Context, connector admin.OrganizationPolicyConnector, organizationID string, desired *Document,
+	options *CompareOptions) (*Plan, error) {
+
+	current, err := Export(ctx, connector, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return Compare(current, desired, options)
+}
+
+// Apply applies the changes of a plan computed by Drift, only the policies that differ are touched.
+//
+// The changes are applied even if some of them fail, the error of each change is returned on its result.
+func Apply(ctx context.Context, connector admin.OrganizationPolicyConnector, organizationID string, plan *Plan) ([]*Result, error) {
+
+	if connector == nil {
+		return nil, ErrNoPolicyConnectorError
+	}
+
+	if plan == nil {
+		return nil, nil
+	}
+
+	var (
+		results []*Result
+		failed  int
+	)
+
+	for _, change := range plan.Changes {
+
+		var err error
+		switch change.Action {
+		case CreateAction:
+			_, _, err = connector.Create(ctx, organizationID, change.policy.payload())
+		case UpdateAction:
+			payload := change.policy.payload()
+			payload.ID = change.id
+			_, _, err = connector.Update(ctx, organizationID, change.id, payload)
+		case DeleteAction:
+			_, err = connector.Delete(ctx, organizationID, change.id)
+		}
+
+		if err != nil {
+			failed++
+		}
+
+		results = append(results, &Result{Change: change, Err: err})
+	}
+
+	if failed != 0 {
+		return results, fmt.Errorf("%w: %v of %v", ErrApplyError, failed, len(results))
+	}
+
+	return results, nil
+}
+
+// Result is a policy change applied to the organization, Err is the error of its request, if any.
+type Result struct {
+	Change *Change
+	Err    error
+}
+
+// WriteDiff writes a line per policy created (+), updated (~) or deleted (-), the policies updated
+// are followed by their attributes changed.
+func (p *Plan) WriteDiff(w io.Writer) error {
+
+	buffer := bufio.NewWriter(w)
+
+	for _, change := range p.Changes {
+
+		switch change.Action {
+		case CreateAction:
+			fmt.Fprintf(buffer, "+ %v %q\n", change.Type, change.Name)
+		case UpdateAction:
+			fmt.Fprintf(buffer, "~ %v %q\n", change.Type, change.Name)
+			for _, difference := range change.Differences {
+				fmt.Fprintf(buffer, "    %v: %q -> %q\n", difference.Attribute, difference.Current, difference.Desired)
+			}
+		case DeleteAction:
+			fmt.Fprintf(buffer, "- %v %q\n", change.Type, change.Name)
+		}
+	}
+
+	return buffer.Flush()
+}
+
+func index(document *Document) (map[string]*Policy, error) {
+
+	policies := make(map[string]*Policy, len(document.Policies))
+	for _, policy := range document.Policies {
+
+		if _, ok := policies[identity(policy)]; ok {
+			return nil, fmt.Errorf("%w: %v %q", ErrDuplicatedPolicyError, policy.Type, policy.Name)
+		}
+
+		policies[identity(policy)] = policy
+	}
+
+	return policies, nil
+}
+
+// resolve returns a copy of the desired policy with its resources mapped to the current organization.
+func (o *CompareOptions) resolve(policy *Policy) (*Policy, error) {
+
+	resolved := *policy
+	if o.Resources == nil {
+		return &resolved, nil
+	}
+
+	resolved.Resources = make([]string, 0, len(policy.Resources))
+	for _, id := range policy.Resources {
+
+		mapped, ok := o.Resources[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %v %q %v", ErrUnmappedResourceError, policy.Type, policy.Name, id)
+		}
+
+		resolved.Resources = append(resolved.Resources, mapped)
+	}
+
+	return &resolved, nil
+}
+
+// rule returns the rule of the policy as JSON, the keys are sorted so the rules can be compared.
+func rule(policy *Policy) string {
+
+	if len(policy.Rule) == 0 {
+		return ""
+	}
+
+	content, err := json.Marshal(policy.Rule)
+	if err != nil {
+		return fmt.Sprint(policy.Rule)
+	}
+
+	return string(content)
+}
+
+func resources(policy *Policy) string {
+
+	ids := append([]string(nil), policy.Resources...)
+	sort.Strings(ids)
+
+	return strings.Join(ids, ", ")
+}
+
+func identity(policy *Policy) string {
+	return strings.ToLower(policy.Type) + "\x00" + strings.ToLower(policy.Name)
+}
@@ -0,0 +1,235 @@
+package policies
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/admin"
+	"github.com/stretchr/testify/assert"
+)
+
+type policyFake struct {
+	admin.OrganizationPolicyConnector
+
+	pages   map[string]*model.OrganizationPolicyPageScheme
+	created []*model.OrganizationPolicyData
+	updated map[string]*model.OrganizationPolicyData
+	deleted []string
+}
+
+func (p *policyFake) Gets(ctx context.Context, organizationID, policyType, cursor string) (*model.OrganizationPolicyPageScheme,
+	*model.ResponseScheme, error) {
+	return p.pages[cursor], nil, nil
+}
+
+func (p *policyFake) Create(ctx context.Context, organizationID string, payload *model.OrganizationPolicyData) (*model.OrganizationPolicyScheme,
+	*model.ResponseScheme, error) {
+	p.created = append(p.created, payload)
+	return &model.OrganizationPolicyScheme{Data: *payload}, nil, nil
+}
+
+func (p *policyFake) Update(ctx context.Context, organizationID, policyID string, payload *model.OrganizationPolicyData) (*model.OrganizationPolicyScheme,
+	*model.ResponseScheme, error) {
+	p.updated[policyID] = payload
+	return &model.OrganizationPolicyScheme{Data: *payload}, nil, nil
+}
+
+func (p *policyFake) Delete(ctx context.Context, organizationID, policyID string) (*model.ResponseScheme, error) {
+	p.deleted = append(p.deleted, policyID)
+	return nil, nil
+}
+
+func newPolicyData(id, policyType, name, status string, resources ...string) *model.OrganizationPolicyData {
+
+	data := &model.OrganizationPolicyData{
+		ID:         id,
+		Type:       "policy",
+		Attributes: &model.OrganizationPolicyAttributes{Type: policyType, Name: name, Status: status},
+	}
+
+	for _, resource := range resources {
+		data.Attributes.Resources = append(data.Attributes.Resources, &model.OrganizationPolicyResource{ID: resource})
+	}
+
+	return data
+}
+
+func newPolicies() *policyFake {
+
+	policies := &policyFake{
+		updated: make(map[string]*model.OrganizationPolicyData),
+		pages: map[string]*model.OrganizationPolicyPageScheme{
+			"": {Data: []*model.OrganizationPolicyData{
+				newPolicyData("policy-1", "ip-allowlist", "Office network", "enabled", "site-b", "site-a"),
+			}},
+			"cursor-2": {Data: []*model.OrganizationPolicyData{
+				newPolicyData("policy-2", "data-residency", "EU", "disabled", "site-a"),
+				newPolicyData("policy-3", "authentication", "Legacy SSO", "enabled"),
+			}},
+		},
+	}
+
+	policies.pages[""].Meta.Next = "cursor-2"
+	return policies
+}
+
+func TestExport(t *testing.T) {
+
+	document, err := Export(context.Background(), newPolicies(), "org-id")
+	assert.NoError(t, err)
+
+	buffer := new(bytes.Buffer)
+	assert.NoError(t, document.WriteYAML(buffer))
+
+	assert.Equal(t, `version: 1
+policies:
+  - type: authentication
+    name: Legacy SSO
+    status: enabled
+  - type: data-residency
+    name: EU
+    status: disabled
+    resources:
+      - site-a
+  - type: ip-allowlist
+    name: Office network
+    status: enabled
+    resources:
+      - site-a
+      - site-b
+`, buffer.String())
+
+	read, err := ReadDocument(buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, len(document.Policies), len(read.Policies))
+
+	buffer.Reset()
+	assert.NoError(t, document.WriteJSON(buffer))
+
+	read, err = ReadDocument(buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"site-a", "site-b"}, read.Policies[2].Resources)
+}
+
+func TestDriftAndApply(t *testing.T) {
+
+	baseline, err := ReadDocument(strings.NewReader(`
+version: 1
+policies:
+  - type: ip-allowlist
+    name: Office network
+    status: enabled
+    resources: [site-a, site-b]
+  - type: data-residency
+    name: EU
+    status: enabled
+    resources: [site-a]
+  - type: authentication
+    name: Okta
+    status: enabled
+`))
+	assert.NoError(t, err)
+
+	policies := newPolicies()
+
+	plan, err := Drift(context.Background(), policies, "org-id", baseline, &CompareOptions{Prune: true})
+	assert.NoError(t, err)
+
+	buffer := new(bytes.Buffer)
+	assert.NoError(t, plan.WriteDiff(buffer))
+	assert.Equal(t, `+ authentication "Okta"
+~ data-residency "EU"
+    status: "disabled" -> "enabled"
+- authentication "Legacy SSO"
+`, buffer.String())
+
+	results, err := Apply(context.Background(), policies, "org-id", plan)
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+
+	assert.Len(t, policies.created, 1)
+	assert.Equal(t, "Okta", policies.created[0].Attributes.Name)
+	assert.Equal(t, "enabled", policies.updated["policy-2"].Attributes.Status)
+	assert.Equal(t, "policy-2", policies.updated["policy-2"].ID)
+	assert.Equal(t, []string{"policy-3"}, policies.deleted)
+}
+
+func TestDriftAndApply_Rule(t *testing.T) {
+
+	policies := newPolicies()
+	policies.pages[""].Data[0].Attributes.Rule = map[string]interface{}{"ipRanges": []interface{}{"10.0.0.0/8"}}
+
+	baseline, err := ReadDocument(strings.NewReader(`
+version: 1
+policies:
+  - type: ip-allowlist
+    name: Office network
+    status: enabled
+    resources: [site-a, site-b]
+    rule:
+      ipRanges: [10.0.0.0/8, 192.168.0.0/16]
+`))
+	assert.NoError(t, err)
+
+	plan, err := Drift(context.Background(), policies, "org-id", baseline, nil)
+	assert.NoError(t, err)
+
+	buffer := new(bytes.Buffer)
+	assert.NoError(t, plan.WriteDiff(buffer))
+	assert.Equal(t, `~ ip-allowlist "Office network"
+    rule: "{\"ipRanges\":[\"10.0.0.0/8\"]}" -> "{\"ipRanges\":[\"10.0.0.0/8\",\"192.168.0.0/16\"]}"
+`, buffer.String())
+
+	_, err = Apply(context.Background(), policies, "org-id", plan)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"10.0.0.0/8", "192.168.0.0/16"}, policies.updated["policy-1"].Attributes.Rule["ipRanges"])
+
+	document, err := Export(context.Background(), policies, "org-id")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"ipRanges": []interface{}{"10.0.0.0/8"}}, document.Policies[2].Rule)
+}
+
+func TestCompare_Resources(t *testing.T) {
+
+	current := &Document{}
+	desired := &Document{Policies: []*Policy{{Type: "data-residency", Name: "EU", Rule: map[string]interface{}{"realm": "EU"},
+		Resources: []string{"staging-site"}}}}
+
+	// The resources of the desired organization don't exist on the current one.
+	plan, err := Compare(current, desired, &CompareOptions{IgnoreResources: true})
+	assert.NoError(t, err)
+	assert.Empty(t, plan.Changes[0].policy.payload().Attributes.Resources)
+	assert.Equal(t, "EU", plan.Changes[0].policy.payload().Attributes.Rule["realm"])
+
+	plan, err = Compare(current, desired, &CompareOptions{Resources: map[string]string{"staging-site": "production-site"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"production-site"}, plan.Changes[0].policy.Resources)
+	assert.Equal(t, []string{"staging-site"}, desired.Policies[0].Resources)
+
+	_, err = Compare(current, desired, &CompareOptions{Resources: map[string]string{}})
+	assert.EqualError(t, err, `policies: resource not mapped: data-residency "EU" staging-site`)
+}
+
+func TestCompare(t *testing.T) {
+
+	current := &Document{Policies: []*Policy{{Type: "ip-allowlist", Name: "Office", Status: "enabled", Resources: []string{"site-a"}}}}
+	desired := &Document{Policies: []*Policy{{Type: "ip-allowlist", Name: "office", Status: "enabled", Resources: []string{"site-z"}}}}
+
+	plan, err := Compare(current, desired, &CompareOptions{IgnoreResources: true})
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+
+	plan, err = Compare(current, desired, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Difference{{Attribute: "resources", Current: "site-a", Desired: "site-z"}}, plan.Changes[0].Differences)
+
+	desired.Policies = append(desired.Policies, desired.Policies[0])
+	_, err = Compare(current, desired, nil)
+	assert.EqualError(t, err, `policies: duplicated policy type and name: ip-allowlist "office"`)
+
+	_, err = Compare(nil, desired, nil)
+	assert.EqualError(t, err, ErrNoDocumentError.Error())
+}
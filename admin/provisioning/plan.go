@@ -0,0 +1,94 @@
+package provisioning
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// Action is the SCIM operation of a change on the directory, on a user, a group or a group membership.
+type Action string
+
+const (
+	CreateUserAction      Action = "create-user"
+	UpdateUserAction      Action = "update-user"
+	DeactivateUserAction  Action = "deactivate-user"
+	CreateGroupAction     Action = "create-group"
+	AddToGroupAction      Action = "add-to-group"
+	RemoveFromGroupAction Action = "remove-from-group"
+)
+
+// Plan is the list of changes required to reconcile a directory with the desired state.
+type Plan struct {
+	DirectoryID string
+	Changes     []*Change
+}
+
+// Change is a single change of a plan.
+type Change struct {
+	Action Action
+
+	// UserName is the user created, updated, deactivated or added to/removed from the group.
+	UserName string
+
+	// Group is the display name of the group created or whose membership changes.
+	Group string
+
+	// Differences are the attributes changed by an update.
+	Differences []*Difference
+
+	user    *model.SCIMUserScheme
+	userID  string
+	groupID string
+	patch   *model.SCIMUserToPathScheme
+}
+
+// Difference is an attribute whose value on the directory differs from the desired state.
+type Difference struct {
+	Attribute string
+	Current   string
+	Desired   string
+}
+
+// IsEmpty reports whether the directory is already reconciled.
+func (p *Plan) IsEmpty() bool {
+	return len(p.Changes) == 0
+}
+
+// WriteDiff writes a line per change, + for the users, groups and members added and - for the users
+// deactivated and the members removed, the users updated are followed by the attributes changed.
+func (p *Plan) WriteDiff(w io.Writer) error {
+
+	buffer := bufio.NewWriter(w)
+
+	for _, change := range p.Changes {
+
+		switch change.Action {
+		case CreateUserAction:
+			fmt.Fprintf(buffer, "+ user %v\n", change.UserName)
+		case UpdateUserAction:
+			fmt.Fprintf(buffer, "~ user %v\n", change.UserName)
+			for _, difference := range change.Differences {
+				fmt.Fprintf(buffer, "    %v: %q -> %q\n", difference.Attribute, difference.Current, difference.Desired)
+			}
+		case DeactivateUserAction:
+			fmt.Fprintf(buffer, "- user %v\n", change.UserName)
+		case CreateGroupAction:
+			fmt.Fprintf(buffer, "+ group %v\n", change.Group)
+		case AddToGroupAction:
+			fmt.Fprintf(buffer, "+ member %v of group %v\n", change.UserName, change.Group)
+		case RemoveFromGroupAction:
+			fmt.Fprintf(buffer, "- member %v of group %v\n", change.UserName, change.Group)
+		}
+	}
+
+	return buffer.Flush()
+}
+
+// Result is a change applied to the directory, Err is the error of its SCIM request, if any.
+type Result struct {
+	Change *Change
+	Err    error
+}
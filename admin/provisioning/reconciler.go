@@ -0,0 +1,545 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ctreminiom/go-atlassian/internal/batch"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/admin"
+)
+
+const (
+	defaultConcurrency = 4
+	pageSize           = 100
+)
+
+var (
+	ErrNoSCIMConnectorError = errors.New("provisioning: no scim user or group connector set")
+	ErrNoSourceError        = errors.New("provisioning: no source set")
+	ErrUnknownMemberError   = errors.New("provisioning: group member not found on the directory nor the desired users")
+	ErrApplyError           = errors.New("provisioning: some changes failed")
+	ErrUserNotCreatedError  = errors.New("provisioning: the user was not created")
+	ErrGroupNotCreatedError = errors.New("provisioning: the group was not created")
+)
+
+type Options struct {
+
+	// Concurrency is the maximum number of requests in flight while applying a plan, 4 by default.
+	Concurrency int
+
+	// DeactivateMissing deactivates the active users of the directory not included on the desired state.
+	DeactivateMissing bool
+
+	// DryRun computes the plan on Reconcile without applying it.
+	DryRun bool
+}
+
+// Reconciler computes and applies the changes required to reconcile a SCIM directory with a desired state.
+type Reconciler struct {
+	users       admin.SCIMUserConnector
+	groups      admin.SCIMGroupConnector
+	directoryID string
+	options     Options
+}
+
+// NewReconciler returns a reconciler of the SCIM directory, the connectors are usually the
+// SCIM.User and SCIM.Group services of the admin client.
+func NewReconciler(users admin.SCIMUserConnector, groups admin.SCIMGroupConnector, directoryID string, options *Options) (*Reconciler, error) {
+
+	if users == nil || groups == nil {
+		return nil, ErrNoSCIMConnectorError
+	}
+
+	if directoryID == "" {
+		return nil, model.ErrNoAdminDirectoryIDError
+	}
+
+	reconciler := &Reconciler{
+		users:       users,
+		groups:      groups,
+		directoryID: directoryID,
+	}
+
+	if options != nil {
+		reconciler.options = *options
+	}
+
+	if reconciler.options.Concurrency <= 0 {
+		reconciler.options.Concurrency = defaultConcurrency
+	}
+
+	return reconciler, nil
+}
+
+// Reconcile computes the plan of the source and applies it, unless the reconciler is on dry-run.
+func (r *Reconciler) Reconcile(ctx context.Context, source Source) (*Plan, []*Result, error) {
+
+	plan, err := r.Plan(ctx, source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if r.options.DryRun {
+		return plan, nil, nil
+	}
+
+	results, err := r.Apply(ctx, plan)
+	return plan, results, err
+}
+
+// Plan compares the directory with the desired state of the source and returns the changes required.
+func (r *Reconciler) Plan(ctx context.Context, source Source) (*Plan, error) {
+
+	if source == nil {
+		return nil, ErrNoSourceError
+	}
+
+	state, err := source.State(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := r.directoryUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := r.directoryGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{DirectoryID: r.directoryID}
+
+	usersByName := make(map[string]*model.SCIMUserScheme, len(users))
+	userNamesByID := make(map[string]string, len(users))
+	for _, user := range users {
+		usersByName[key(user.UserName)] = user
+		userNamesByID[user.ID] = user.UserName
+	}
+
+	desiredByName := make(map[string]*model.SCIMUserScheme, len(state.Users))
+	for _, desired := range state.Users {
+
+		desiredByName[key(desired.UserName)] = desired
+
+		current, ok := usersByName[key(desired.UserName)]
+		if !ok {
+			plan.Changes = append(plan.Changes, &Change{Action: CreateUserAction, UserName: desired.UserName, user: desired})
+			continue
+		}
+
+		patch, differences, err := userPatch(current, desired)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(differences) != 0 {
+			plan.Changes = append(plan.Changes, &Change{
+				Action:      UpdateUserAction,
+				UserName:    current.UserName,
+				Differences: differences,
+				userID:      current.ID,
+				patch:       patch,
+			})
+		}
+	}
+
+	if r.options.DeactivateMissing {
+
+		for _, user := range users {
+
+			if _, ok := desiredByName[key(user.UserName)]; ok || !user.Active {
+				continue
+			}
+
+			plan.Changes = append(plan.Changes, &Change{Action: DeactivateUserAction, UserName: user.UserName, userID: user.ID})
+		}
+	}
+
+	groupsByName := make(map[string]*model.ScimGroupScheme, len(groups))
+	for _, group := range groups {
+		groupsByName[key(group.DisplayName)] = group
+	}
+
+	names := make([]string, 0, len(state.Groups))
+	for name := range state.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+
+		current, ok := groupsByName[key(name)]
+		if !ok {
+			current = &model.ScimGroupScheme{DisplayName: name}
+			plan.Changes = append(plan.Changes, &Change{Action: CreateGroupAction, Group: name})
+		}
+
+		desiredMembers := make(map[string]bool)
+		for _, member := range state.Groups[name] {
+
+			_, known := usersByName[key(member)]
+			_, desired := desiredByName[key(member)]
+			if !known && !desired {
+				return nil, fmt.Errorf("%w: %v of group %v", ErrUnknownMemberError, member, name)
+			}
+
+			desiredMembers[key(member)] = true
+		}
+
+		currentMembers := make(map[string]bool)
+		for _, member := range current.Members {
+
+			// The display names aren't unique, so the members missing from the directory users
+			// are matched by ID only, they're never desired.
+			userName, ok := userNamesByID[member.Value]
+			if !ok {
+				userName = member.Value
+			}
+
+			currentMembers[key(userName)] = true
+
+			if !desiredMembers[key(userName)] {
+				plan.Changes = append(plan.Changes, &Change{
+					Action:   RemoveFromGroupAction,
+					UserName: userName,
+					Group:    current.DisplayName,
+					userID:   member.Value,
+					groupID:  current.ID,
+				})
+			}
+		}
+
+		for _, member := range state.Groups[name] {
+
+			if currentMembers[key(member)] {
+				continue
+			}
+
+			change := &Change{Action: AddToGroupAction, UserName: member, Group: current.DisplayName, groupID: current.ID}
+			if user, ok := usersByName[key(member)]; ok {
+				change.userID = user.ID
+			}
+
+			plan.Changes = append(plan.Changes, change)
+		}
+	}
+
+	return plan, nil
+}
+
+// Apply applies the changes of the plan with bounded concurrency.
+//
+// The users and groups are created, updated and deactivated first, then the group memberships
+// are changed with a single PATCH request per group. The changes are applied even if some
+// of them fail, the error of each change is returned on its result.
+func (r *Reconciler) Apply(ctx context.Context, plan *Plan) ([]*Result, error) {
+
+	var (
+		mu         sync.Mutex
+		userIDs    = make(map[string]string)
+		groupIDs   = make(map[string]string)
+		results    []*Result
+		membership []*Change
+		changes    []*Change
+	)
+
+	record := func(change *Change, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		results = append(results, &Result{Change: change, Err: err})
+	}
+
+	for _, change := range plan.Changes {
+
+		if change.Action == AddToGroupAction || change.Action == RemoveFromGroupAction {
+			membership = append(membership, change)
+			continue
+		}
+
+		changes = append(changes, change)
+	}
+
+	err := batch.Run(ctx, len(changes), r.options.Concurrency, func(index int) {
+
+		change := changes[index]
+
+		switch change.Action {
+		case CreateUserAction:
+
+			payload := *change.user
+			payload.Active = true
+
+			user, _, err := r.users.Create(ctx, r.directoryID, &payload, nil, nil)
+			if err == nil {
+				mu.Lock()
+				userIDs[key(change.UserName)] = user.ID
+				mu.Unlock()
+			}
+
+			record(change, err)
+
+		case UpdateUserAction:
+			_, _, err := r.users.Path(ctx, r.directoryID, change.userID, change.patch, nil, nil)
+			record(change, err)
+
+		case DeactivateUserAction:
+			_, err := r.users.Deactivate(ctx, r.directoryID, change.userID)
+			record(change, err)
+
+		case CreateGroupAction:
+
+			group, _, err := r.groups.Create(ctx, r.directoryID, change.Group)
+			if err == nil {
+				mu.Lock()
+				groupIDs[key(change.Group)] = group.ID
+				mu.Unlock()
+			}
+
+			record(change, err)
+		}
+	})
+
+	// The memberships aren't changed once the context is done, the changes left have no result.
+	if err != nil {
+		return results, err
+	}
+
+	var (
+		order   []string
+		byGroup = make(map[string][]*Change)
+	)
+
+	for _, change := range membership {
+
+		if _, ok := byGroup[key(change.Group)]; !ok {
+			order = append(order, key(change.Group))
+		}
+
+		byGroup[key(change.Group)] = append(byGroup[key(change.Group)], change)
+	}
+
+	err = batch.Run(ctx, len(order), r.options.Concurrency, func(index int) {
+
+		group := byGroup[order[index]]
+
+		groupID := group[0].groupID
+		if groupID == "" {
+			groupID = groupIDs[order[index]]
+		}
+
+		if groupID == "" {
+			for _, change := range group {
+				record(change, ErrGroupNotCreatedError)
+			}
+			return
+		}
+
+		var (
+			added, removed []*model.SCIMGroupOperationValueScheme
+			applied        []*Change
+		)
+
+		for _, change := range group {
+
+			userID := change.userID
+			if userID == "" {
+				userID = userIDs[key(change.UserName)]
+			}
+
+			if userID == "" {
+				record(change, ErrUserNotCreatedError)
+				continue
+			}
+
+			value := &model.SCIMGroupOperationValueScheme{Value: userID, Display: change.UserName}
+			if change.Action == AddToGroupAction {
+				added = append(added, value)
+			} else {
+				removed = append(removed, value)
+			}
+
+			applied = append(applied, change)
+		}
+
+		if len(applied) == 0 {
+			return
+		}
+
+		payload := &model.SCIMGroupPathScheme{
+			Schemas: []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+		}
+
+		if len(added) != 0 {
+			payload.Operations = append(payload.Operations, &model.SCIMGroupOperationScheme{Op: "add", Path: "members", Value: added})
+		}
+
+		if len(removed) != 0 {
+			payload.Operations = append(payload.Operations, &model.SCIMGroupOperationScheme{Op: "remove", Path: "members", Value: removed})
+		}
+
+		_, _, err := r.groups.Path(ctx, r.directoryID, groupID, payload)
+		for _, change := range applied {
+			record(change, err)
+		}
+	})
+
+	if err != nil {
+		return results, err
+	}
+
+	var failed int
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+
+	if failed != 0 {
+		return results, fmt.Errorf("%w: %v of %v", ErrApplyError, failed, len(results))
+	}
+
+	return results, nil
+}
+
+func (r *Reconciler) directoryUsers(ctx context.Context) ([]*model.SCIMUserScheme, error) {
+
+	var users []*model.SCIMUserScheme
+	for startIndex := 1; ; startIndex += pageSize {
+
+		page, _, err := r.users.Gets(ctx, r.directoryID, nil, startIndex, pageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, page.Resources...)
+
+		if len(page.Resources) == 0 || len(users) >= page.TotalResults {
+			return users, nil
+		}
+	}
+}
+
+func (r *Reconciler) directoryGroups(ctx context.Context) ([]*model.ScimGroupScheme, error) {
+
+	var groups []*model.ScimGroupScheme
+	for startIndex := 1; ; startIndex += pageSize {
+
+		page, _, err := r.groups.Gets(ctx, r.directoryID, "", startIndex, pageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, page.Resources...)
+
+		if len(page.Resources) == 0 || len(groups) >= page.TotalResults {
+			return groups, nil
+		}
+	}
+}
+
+type userAttribute struct {
+	path  string
+	value func(user *model.SCIMUserScheme) string
+}
+
+var userAttributes = []*userAttribute{
+	{"displayName", func(user *model.SCIMUserScheme) string { return user.DisplayName }},
+	{"nickName", func(user *model.SCIMUserScheme) string { return user.NickName }},
+	{"title", func(user *model.SCIMUserScheme) string { return user.Title }},
+	{"preferredLanguage", func(user *model.SCIMUserScheme) string { return user.PreferredLanguage }},
+	{"department", func(user *model.SCIMUserScheme) string { return user.Department }},
+	{"organization", func(user *model.SCIMUserScheme) string { return user.Organization }},
+	{"timezone", func(user *model.SCIMUserScheme) string { return user.Timezone }},
+	{"name.givenName", func(user *model.SCIMUserScheme) string {
+		if user.Name == nil {
+			return ""
+		}
+		return user.Name.GivenName
+	}},
+	{"name.familyName", func(user *model.SCIMUserScheme) string {
+		if user.Name == nil {
+			return ""
+		}
+		return user.Name.FamilyName
+	}},
+}
+
+// userPatch returns the PATCH operations that set the attributes of the desired user on the current one.
+func userPatch(current, desired *model.SCIMUserScheme) (*model.SCIMUserToPathScheme, []*Difference, error) {
+
+	var (
+		patch       = &model.SCIMUserToPathScheme{Schemas: []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"}}
+		differences []*Difference
+	)
+
+	for _, attribute := range userAttributes {
+
+		currentValue, desiredValue := attribute.value(current), attribute.value(desired)
+		if desiredValue == "" || desiredValue == currentValue {
+			continue
+		}
+
+		if err := patch.AddStringOperation("replace", attribute.path, desiredValue); err != nil {
+			return nil, nil, err
+		}
+
+		differences = append(differences, &Difference{Attribute: attribute.path, Current: currentValue, Desired: desiredValue})
+	}
+
+	if currentEmail, desiredEmail := primaryEmail(current), primaryEmail(desired); desiredEmail != nil &&
+		(currentEmail == nil || !strings.EqualFold(currentEmail.Value, desiredEmail.Value)) {
+
+		err := patch.AddComplexOperation("replace", "emails", []*model.SCIMUserComplexOperationScheme{
+			{Value: desiredEmail.Value, ValueType: desiredEmail.Type, Primary: true},
+		})
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var currentValue string
+		if currentEmail != nil {
+			currentValue = currentEmail.Value
+		}
+
+		differences = append(differences, &Difference{Attribute: "emails", Current: currentValue, Desired: desiredEmail.Value})
+	}
+
+	if !current.Active {
+
+		if err := patch.AddBoolOperation("replace", "active", true); err != nil {
+			return nil, nil, err
+		}
+
+		differences = append(differences, &Difference{Attribute: "active", Current: strconv.FormatBool(false), Desired: strconv.FormatBool(true)})
+	}
+
+	return patch, differences, nil
+}
+
+func primaryEmail(user *model.SCIMUserScheme) *model.SCIMUserEmailScheme {
+
+	for _, email := range user.Emails {
+		if email.Primary {
+			return email
+		}
+	}
+
+	if len(user.Emails) != 0 {
+		return user.Emails[0]
+	}
+
+	return nil
+}
+
+func key(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
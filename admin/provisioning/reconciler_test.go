@@ -0,0 +1,228 @@
+package provisioning
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/admin"
+	"github.com/stretchr/testify/assert"
+)
+
+type scimUserFake struct {
+	admin.SCIMUserConnector
+
+	mu          sync.Mutex
+	users       []*model.SCIMUserScheme
+	created     []string
+	patched     map[string]*model.SCIMUserToPathScheme
+	deactivated []string
+}
+
+func (s *scimUserFake) Gets(ctx context.Context, directoryID string, opts *model.SCIMUserGetsOptionsScheme, startIndex,
+	count int) (*model.SCIMUserPageScheme, *model.ResponseScheme, error) {
+
+	page := &model.SCIMUserPageScheme{TotalResults: len(s.users), StartIndex: startIndex}
+	if startIndex-1 < len(s.users) {
+		page.Resources = s.users[startIndex-1:]
+	}
+
+	return page, nil, nil
+}
+
+func (s *scimUserFake) Create(ctx context.Context, directoryID string, payload *model.SCIMUserScheme, attributes,
+	excludedAttributes []string) (*model.SCIMUserScheme, *model.ResponseScheme, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !payload.Active {
+		return nil, nil, errors.New("inactive user created")
+	}
+
+	s.created = append(s.created, payload.UserName)
+	return &model.SCIMUserScheme{ID: "id-" + payload.UserName, UserName: payload.UserName}, nil, nil
+}
+
+func (s *scimUserFake) Path(ctx context.Context, directoryID, userID string, payload *model.SCIMUserToPathScheme, attributes,
+	excludedAttributes []string) (*model.SCIMUserScheme, *model.ResponseScheme, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.patched[userID] = payload
+	return &model.SCIMUserScheme{ID: userID}, nil, nil
+}
+
+func (s *scimUserFake) Deactivate(ctx context.Context, directoryID, userID string) (*model.ResponseScheme, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deactivated = append(s.deactivated, userID)
+	return nil, nil
+}
+
+type scimGroupFake struct {
+	admin.SCIMGroupConnector
+
+	mu      sync.Mutex
+	groups  []*model.ScimGroupScheme
+	created []string
+	patched map[string]*model.SCIMGroupPathScheme
+}
+
+func (s *scimGroupFake) Gets(ctx context.Context, directoryID, filter string, startAt, maxResults int) (*model.ScimGroupPageScheme,
+	*model.ResponseScheme, error) {
+
+	return &model.ScimGroupPageScheme{TotalResults: len(s.groups), Resources: s.groups}, nil, nil
+}
+
+func (s *scimGroupFake) Create(ctx context.Context, directoryID, groupName string) (*model.ScimGroupScheme, *model.ResponseScheme, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.created = append(s.created, groupName)
+	return &model.ScimGroupScheme{ID: "group-" + groupName, DisplayName: groupName}, nil, nil
+}
+
+func (s *scimGroupFake) Path(ctx context.Context, directoryID, groupID string, payload *model.SCIMGroupPathScheme) (*model.ScimGroupScheme,
+	*model.ResponseScheme, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.patched[groupID] = payload
+	return &model.ScimGroupScheme{ID: groupID}, nil, nil
+}
+
+func newDirectory() (*scimUserFake, *scimGroupFake) {
+
+	users := &scimUserFake{
+		patched: make(map[string]*model.SCIMUserToPathScheme),
+		users: []*model.SCIMUserScheme{
+			{ID: "id-alice", UserName: "alice@example.com", Title: "Developer", Active: true,
+				Emails: []*model.SCIMUserEmailScheme{{Value: "alice@example.com", Type: "work", Primary: true}}},
+			{ID: "id-bob", UserName: "bob@example.com", Active: true},
+			{ID: "id-carol", UserName: "carol@example.com", Title: "Designer"},
+		},
+	}
+
+	groups := &scimGroupFake{
+		patched: make(map[string]*model.SCIMGroupPathScheme),
+		groups: []*model.ScimGroupScheme{
+			{ID: "group-engineering", DisplayName: "Engineering", Members: []*model.ScimGroupMemberScheme{
+				{Value: "id-alice", Display: "Alice"},
+				{Value: "id-bob", Display: "Bob"},
+				{Value: "id-former", Display: "dave@example.com"},
+			}},
+		},
+	}
+
+	return users, groups
+}
+
+var desiredState = &State{
+	Users: []*model.SCIMUserScheme{
+		{UserName: "Alice@example.com", Title: "Lead Developer"},
+		{UserName: "carol@example.com", Title: "Designer"},
+		{UserName: "dave@example.com", DisplayName: "Dave"},
+	},
+	Groups: map[string][]string{
+		"engineering": {"alice@example.com", "dave@example.com"},
+		"Design":      {"carol@example.com"},
+	},
+}
+
+func TestReconciler_Plan(t *testing.T) {
+
+	users, groups := newDirectory()
+
+	reconciler, err := NewReconciler(users, groups, "directory-id", &Options{DeactivateMissing: true, DryRun: true})
+	assert.NoError(t, err)
+
+	plan, results, err := reconciler.Reconcile(context.Background(), StaticSource(desiredState))
+	assert.NoError(t, err)
+	assert.Nil(t, results)
+
+	buffer := new(bytes.Buffer)
+	assert.NoError(t, plan.WriteDiff(buffer))
+
+	assert.Equal(t, `~ user alice@example.com
+    title: "Developer" -> "Lead Developer"
+~ user carol@example.com
+    active: "false" -> "true"
++ user dave@example.com
+- user bob@example.com
++ group Design
++ member carol@example.com of group Design
+- member bob@example.com of group Engineering
+- member id-former of group Engineering
++ member dave@example.com of group Engineering
+`, buffer.String())
+
+	assert.Empty(t, users.created)
+	assert.Empty(t, groups.created)
+}
+
+func TestReconciler_Apply(t *testing.T) {
+
+	users, groups := newDirectory()
+
+	reconciler, err := NewReconciler(users, groups, "directory-id", &Options{DeactivateMissing: true, Concurrency: 2})
+	assert.NoError(t, err)
+
+	_, results, err := reconciler.Reconcile(context.Background(), StaticSource(desiredState))
+	assert.NoError(t, err)
+	assert.Len(t, results, 9)
+
+	assert.Equal(t, []string{"dave@example.com"}, users.created)
+	assert.Equal(t, []string{"id-bob"}, users.deactivated)
+	assert.Equal(t, []*model.SCIMUserToPathOperationScheme{{Op: "replace", Path: "title", Value: "Lead Developer"}},
+		users.patched["id-alice"].Operations)
+	assert.Equal(t, []*model.SCIMUserToPathOperationScheme{{Op: "replace", Path: "active", Value: true}},
+		users.patched["id-carol"].Operations)
+
+	assert.Equal(t, []string{"Design"}, groups.created)
+	assert.Equal(t, []*model.SCIMGroupOperationScheme{
+		{Op: "add", Path: "members", Value: []*model.SCIMGroupOperationValueScheme{{Value: "id-carol", Display: "carol@example.com"}}},
+	}, groups.patched["group-Design"].Operations)
+
+	operations := groups.patched["group-engineering"].Operations
+	sort.Slice(operations, func(i, j int) bool { return operations[i].Op < operations[j].Op })
+	assert.Equal(t, []*model.SCIMGroupOperationScheme{
+		{Op: "add", Path: "members", Value: []*model.SCIMGroupOperationValueScheme{{Value: "id-dave@example.com", Display: "dave@example.com"}}},
+		{Op: "remove", Path: "members", Value: []*model.SCIMGroupOperationValueScheme{
+			{Value: "id-bob", Display: "bob@example.com"},
+			{Value: "id-former", Display: "id-former"},
+		}},
+	}, operations)
+}
+
+func TestReconciler_Plan_UnknownMember(t *testing.T) {
+
+	users, groups := newDirectory()
+
+	reconciler, err := NewReconciler(users, groups, "directory-id", nil)
+	assert.NoError(t, err)
+
+	_, err = reconciler.Plan(context.Background(), StaticSource(&State{
+		Groups: map[string][]string{"Engineering": {"erin@example.com"}},
+	}))
+	assert.EqualError(t, err, "provisioning: group member not found on the directory nor the desired users: "+
+		"erin@example.com of group Engineering")
+}
+
+func TestNewReconciler(t *testing.T) {
+
+	_, err := NewReconciler(nil, &scimGroupFake{}, "directory-id", nil)
+	assert.EqualError(t, err, ErrNoSCIMConnectorError.Error())
+
+	_, err = NewReconciler(&scimUserFake{}, &scimGroupFake{}, "", nil)
+	assert.EqualError(t, err, model.ErrNoAdminDirectoryIDError.Error())
+}
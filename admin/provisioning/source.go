@@ -0,0 +1,42 @@
+// Package provisioning reconciles the users and groups of an Atlassian SCIM directory with a
+// desired state, e.g: loaded from an HR export.
+package provisioning
+
+import (
+	"context"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// State is the desired state of a SCIM directory.
+type State struct {
+
+	// Users are the users expected as active on the directory, they're matched by user name.
+	//
+	// Only the attributes set are compared with the directory, e.g: a user without title
+	// keeps the title set on the directory.
+	Users []*model.SCIMUserScheme
+
+	// Groups are the members expected on each group, the key is the group display name and
+	// the values are user names. The groups not included are left untouched.
+	Groups map[string][]string
+}
+
+// Source provides the desired state of a SCIM directory.
+type Source interface {
+	State(ctx context.Context) (*State, error)
+}
+
+// SourceFunc adapts a function to the Source interface.
+type SourceFunc func(ctx context.Context) (*State, error)
+
+func (f SourceFunc) State(ctx context.Context) (*State, error) {
+	return f(ctx)
+}
+
+// StaticSource returns a Source that always provides the same state.
+func StaticSource(state *State) Source {
+	return SourceFunc(func(ctx context.Context) (*State, error) {
+		return state, nil
+	})
+}
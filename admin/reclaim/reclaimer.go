@@ -0,0 +1,355 @@
+// Package reclaim detects the inactive users of an Atlassian organization and reclaims their
+// licenses by suspending their product access.
+package reclaim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/internal/batch"
+	"github.com/ctreminiom/go-atlassian/internal/paging"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/admin"
+)
+
+const (
+	defaultThreshold   = 90 * 24 * time.Hour
+	defaultConcurrency = 4
+)
+
+var (
+	ErrNoOrganizationConnectorError = errors.New("reclaim: no organization or directory connector set")
+	ErrNoReportError                = errors.New("reclaim: no report set")
+	ErrSuspendError                 = errors.New("reclaim: some users couldn't be suspended")
+)
+
+type Options struct {
+
+	// Thresholds is the inactivity allowed per product key, e.g: jira-software. The products
+	// without threshold use the DefaultThreshold.
+	Thresholds map[string]time.Duration
+
+	// DefaultThreshold is the inactivity allowed on the products without threshold, 90 days by default.
+	DefaultThreshold time.Duration
+
+	// Products restricts the products reported to the product keys provided, all the products by default.
+	// The suspension removes the access to every product, so the users are only inactive when they're
+	// inactive on all their products, including the products not reported.
+	Products []string
+
+	// ExcludedAccountIDs and ExcludedEmails are never suspended, e.g: service accounts or executives.
+	// The emails starting with "@" exclude a whole domain.
+	ExcludedAccountIDs []string
+	ExcludedEmails     []string
+
+	// Concurrency is the maximum number of requests in flight, 4 by default.
+	Concurrency int
+
+	// RequestsPerSecond limits the requests sent to the organization API, unlimited by default.
+	RequestsPerSecond float64
+
+	// DryRun records the suspensions on the trail without suspending the users.
+	DryRun bool
+
+	// Trail records the suspensions, optional.
+	Trail Trail
+
+	// Now dates the report and the suspensions, the inactivity is measured from it, time.Now by default.
+	Now func() time.Time
+}
+
+// Reclaimer classifies the users of an organization as inactive per product and suspends their access.
+type Reclaimer struct {
+	organization   admin.OrganizationConnector
+	directory      admin.OrganizationDirectoryConnector
+	organizationID string
+	options        Options
+}
+
+// NewReclaimer returns a reclaimer of the organization, the connectors are usually the
+// Organization and Organization.Directory services of the admin client.
+func NewReclaimer(organization admin.OrganizationConnector, directory admin.OrganizationDirectoryConnector, organizationID string,
+	options *Options) (*Reclaimer, error) {
+
+	if organization == nil || directory == nil {
+		return nil, ErrNoOrganizationConnectorError
+	}
+
+	if organizationID == "" {
+		return nil, model.ErrNoAdminOrganizationError
+	}
+
+	reclaimer := &Reclaimer{
+		organization:   organization,
+		directory:      directory,
+		organizationID: organizationID,
+	}
+
+	if options != nil {
+		reclaimer.options = *options
+	}
+
+	if reclaimer.options.DefaultThreshold <= 0 {
+		reclaimer.options.DefaultThreshold = defaultThreshold
+	}
+
+	if reclaimer.options.Concurrency <= 0 {
+		reclaimer.options.Concurrency = defaultConcurrency
+	}
+
+	if reclaimer.options.Now == nil {
+		reclaimer.options.Now = time.Now
+	}
+
+	return reclaimer, nil
+}
+
+// Run scans the organization and suspends the inactive users found.
+func (r *Reclaimer) Run(ctx context.Context) (*Report, error) {
+
+	report, err := r.Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, r.Suspend(ctx, report)
+}
+
+// Scan lists the organization users, fetches their activity concurrently and classifies them.
+//
+// The users whose activity can't be fetched are reported with the error and never classified as inactive.
+func (r *Reclaimer) Scan(ctx context.Context) (*Report, error) {
+
+	users, err := paging.Users(ctx, r.organization, r.organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{OrganizationID: r.organizationID, GeneratedAt: r.options.Now()}
+	for _, user := range users {
+		report.Users = append(report.Users, &UserReport{AccountID: user.AccountID, Name: user.Name, Email: user.Email})
+	}
+
+	limiter := batch.NewLimiter(ctx, r.options.RequestsPerSecond)
+	defer limiter.Stop()
+
+	err = batch.Run(ctx, len(report.Users), r.options.Concurrency, func(index int) {
+
+		user := report.Users[index]
+
+		if err := limiter.Wait(); err != nil {
+			user.Error = err.Error()
+			return
+		}
+
+		activity, _, err := r.directory.Activity(ctx, r.organizationID, user.AccountID)
+		if err != nil {
+			user.Error = err.Error()
+			return
+		}
+
+		r.classify(user, activity)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// Suspend suspends the product access of the inactive users of the report, or only records
+// them on the trail when the reclaimer is on dry-run. The action taken is set on each user.
+func (r *Reclaimer) Suspend(ctx context.Context, report *Report) error {
+
+	if report == nil {
+		return ErrNoReportError
+	}
+
+	var (
+		inactive = report.Inactive()
+		failed   int
+		mu       sync.Mutex
+	)
+
+	limiter := batch.NewLimiter(ctx, r.options.RequestsPerSecond)
+	defer limiter.Stop()
+
+	err := batch.Run(ctx, len(inactive), r.options.Concurrency, func(index int) {
+
+		user := inactive[index]
+
+		entry := &Entry{
+			AccountID: user.AccountID,
+			Email:     user.Email,
+			Action:    WouldSuspendAction,
+			DryRun:    r.options.DryRun,
+			Reason:    reason(user),
+		}
+
+		if !r.options.DryRun {
+
+			err := limiter.Wait()
+			if err == nil {
+				_, _, err = r.directory.Suspend(ctx, r.organizationID, user.AccountID)
+			}
+
+			entry.Action = SuspendedAction
+			if err != nil {
+				entry.Action, entry.Error, user.Error = SuspendFailedAction, err.Error(), err.Error()
+
+				mu.Lock()
+				failed++
+				mu.Unlock()
+			}
+		}
+
+		user.Action = entry.Action
+		entry.Time = r.options.Now()
+
+		if r.options.Trail != nil {
+			if err := r.options.Trail.Record(ctx, entry); err != nil && user.Error == "" {
+				user.Error = err.Error()
+			}
+		}
+	})
+
+	// The users left once the context is done have no action.
+	if err != nil {
+		return err
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("%w: %v of %v", ErrSuspendError, failed, len(inactive))
+	}
+
+	return nil
+}
+
+func (r *Reclaimer) classify(user *UserReport, activity *model.UserProductAccessScheme) {
+
+	if activity == nil || activity.Data == nil {
+		return
+	}
+
+	now := r.options.Now()
+	addedToOrg := parseDate(activity.Data.AddedToOrg)
+
+	var active, undetermined bool
+	for _, product := range activity.Data.ProductAccess {
+
+		threshold, ok := r.options.Thresholds[product.Key]
+		if !ok {
+			threshold = r.options.DefaultThreshold
+		}
+
+		lastActive := parseDate(product.LastActive)
+
+		// The users that never accessed a product are given the threshold since they joined the organization.
+		since := lastActive
+		if since.IsZero() {
+			since = addedToOrg
+		}
+
+		// Without any date, the activity on the product is unknown and the user isn't reclaimed.
+		inactive := !since.IsZero() && now.Sub(since) > threshold
+
+		switch {
+		case since.IsZero():
+			undetermined = true
+		case !inactive:
+			active = true
+		}
+
+		if r.reported(product.Key) {
+			user.Products = append(user.Products, &ProductActivity{
+				Key:        product.Key,
+				Name:       product.Name,
+				LastActive: lastActive,
+				Threshold:  threshold,
+				Inactive:   inactive,
+			})
+		}
+	}
+
+	sort.Slice(user.Products, func(i, j int) bool { return user.Products[i].Key < user.Products[j].Key })
+
+	// The suspension removes the access to every product, so the user must be inactive on all of them,
+	// not only on the products reported.
+	user.Inactive = len(activity.Data.ProductAccess) != 0 && !active && !undetermined
+	user.Unknown = !active && undetermined
+
+	user.Excluded = r.excluded(user)
+}
+
+func (r *Reclaimer) reported(productKey string) bool {
+
+	if len(r.options.Products) == 0 {
+		return true
+	}
+
+	for _, key := range r.options.Products {
+		if strings.EqualFold(key, productKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Reclaimer) excluded(user *UserReport) bool {
+
+	for _, accountID := range r.options.ExcludedAccountIDs {
+		if accountID == user.AccountID {
+			return true
+		}
+	}
+
+	email := strings.ToLower(user.Email)
+	for _, excluded := range r.options.ExcludedEmails {
+
+		excluded = strings.ToLower(excluded)
+
+		if strings.HasPrefix(excluded, "@") && strings.HasSuffix(email, excluded) {
+			return true
+		}
+
+		if email != "" && excluded == email {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseDate(value string) time.Time {
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed
+		}
+	}
+
+	return time.Time{}
+}
+
+func reason(user *UserReport) string {
+
+	var products []string
+	for _, product := range user.Products {
+
+		lastActive := "never"
+		if !product.LastActive.IsZero() {
+			lastActive = product.LastActive.Format("2006-01-02")
+		}
+
+		products = append(products, fmt.Sprintf("%v last active %v", product.Key, lastActive))
+	}
+
+	return "inactive on " + strings.Join(products, ", ")
+}
@@ -0,0 +1,218 @@
+package reclaim
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/admin"
+	"github.com/stretchr/testify/assert"
+)
+
+type organizationFake struct {
+	admin.OrganizationConnector
+	pages map[string]*model.OrganizationUserPageScheme
+}
+
+func (o *organizationFake) Users(ctx context.Context, organizationID, cursor string) (*model.OrganizationUserPageScheme,
+	*model.ResponseScheme, error) {
+	return o.pages[cursor], nil, nil
+}
+
+type directoryFake struct {
+	admin.OrganizationDirectoryConnector
+
+	mu        sync.Mutex
+	activity  map[string]*model.UserProductAccessDataScheme
+	suspended []string
+}
+
+func (d *directoryFake) Activity(ctx context.Context, organizationID, accountID string) (*model.UserProductAccessScheme,
+	*model.ResponseScheme, error) {
+
+	data, ok := d.activity[accountID]
+	if !ok {
+		return nil, nil, errors.New("user not found")
+	}
+
+	return &model.UserProductAccessScheme{Data: data}, nil, nil
+}
+
+func (d *directoryFake) Suspend(ctx context.Context, organizationID, accountID string) (*model.GenericActionSuccessScheme,
+	*model.ResponseScheme, error) {
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.suspended = append(d.suspended, accountID)
+	return &model.GenericActionSuccessScheme{}, nil, nil
+}
+
+func newOrganization() (*organizationFake, *directoryFake) {
+
+	organization := &organizationFake{
+		pages: map[string]*model.OrganizationUserPageScheme{
+			"": {
+				Data: []*model.AdminOrganizationUserScheme{
+					{AccountID: "active", Email: "active@example.com"},
+					{AccountID: "inactive", Email: "inactive@example.com"},
+				},
+				Links: &model.LinkPageModelScheme{Next: "https://api.atlassian.com/admin/v1/orgs/org-id/users?cursor=page-2"},
+			},
+			"page-2": {
+				Data: []*model.AdminOrganizationUserScheme{
+					{AccountID: "bot", Email: "bot@service.example.com"},
+					{AccountID: "new", Email: "new@example.com"},
+					{AccountID: "unknown", Email: "unknown@example.com"},
+				},
+			},
+		},
+	}
+
+	directory := &directoryFake{
+		activity: map[string]*model.UserProductAccessDataScheme{
+			"active": {ProductAccess: []*model.UserProductLastActiveScheme{
+				{Key: "jira-software", LastActive: "2023-04-30"},
+				{Key: "confluence", LastActive: "2022-01-01"},
+			}},
+			"inactive": {ProductAccess: []*model.UserProductLastActiveScheme{
+				{Key: "jira-software", LastActive: "2023-01-01"},
+				{Key: "confluence"},
+			}, AddedToOrg: "2021-01-01"},
+			"bot": {ProductAccess: []*model.UserProductLastActiveScheme{
+				{Key: "jira-software", LastActive: "2022-01-01"},
+			}},
+			"new": {ProductAccess: []*model.UserProductLastActiveScheme{
+				{Key: "jira-software"},
+			}, AddedToOrg: "2023-04-01"},
+		},
+	}
+
+	return organization, directory
+}
+
+func TestReclaimer_Run(t *testing.T) {
+
+	organization, directory := newOrganization()
+	trail := new(bytes.Buffer)
+
+	reclaimer, err := NewReclaimer(organization, directory, "org-id", &Options{
+		Thresholds:        map[string]time.Duration{"jira-software": 60 * 24 * time.Hour},
+		ExcludedEmails:    []string{"@service.example.com"},
+		RequestsPerSecond: 1000,
+		Trail:             NewJSONTrail(trail),
+		Now:               func() time.Time { return time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC) },
+	})
+	assert.NoError(t, err)
+
+	report, err := reclaimer.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, report.Users, 5)
+
+	classified := make(map[string]*UserReport)
+	for _, user := range report.Users {
+		classified[user.AccountID] = user
+	}
+
+	assert.False(t, classified["active"].Inactive)
+	assert.True(t, classified["active"].Products[0].Inactive)
+	assert.True(t, classified["inactive"].Inactive)
+	assert.Equal(t, SuspendedAction, classified["inactive"].Action)
+	assert.True(t, classified["bot"].Inactive)
+	assert.True(t, classified["bot"].Excluded)
+	assert.False(t, classified["new"].Inactive)
+	assert.Equal(t, "user not found", classified["unknown"].Error)
+
+	assert.Equal(t, []string{"inactive"}, directory.suspended)
+	assert.Equal(t, `{"time":"2023-05-02T00:00:00Z","accountId":"inactive","email":"inactive@example.com",`+
+		`"action":"suspended","reason":"inactive on confluence last active never, jira-software last active 2023-01-01"}`+"\n",
+		trail.String())
+}
+
+func TestReclaimer_DryRun(t *testing.T) {
+
+	organization, directory := newOrganization()
+	trail := new(bytes.Buffer)
+
+	reclaimer, err := NewReclaimer(organization, directory, "org-id", &Options{
+		Products:           []string{"jira-software"},
+		ExcludedAccountIDs: []string{"bot"},
+		DryRun:             true,
+		Trail:              NewJSONTrail(trail),
+		Now:                func() time.Time { return time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC) },
+	})
+	assert.NoError(t, err)
+
+	report, err := reclaimer.Run(context.Background())
+	assert.NoError(t, err)
+
+	var inactive []string
+	for _, user := range report.Inactive() {
+		assert.Equal(t, WouldSuspendAction, user.Action)
+		inactive = append(inactive, user.AccountID)
+	}
+	sort.Strings(inactive)
+
+	assert.Equal(t, []string{"inactive"}, inactive)
+	assert.Empty(t, directory.suspended)
+	assert.Contains(t, trail.String(), `"action":"would-suspend","dryRun":true`)
+
+	csv := new(bytes.Buffer)
+	assert.NoError(t, report.WriteCSV(csv))
+	assert.Contains(t, csv.String(), "inactive,,inactive@example.com,jira-software,2023-01-01T00:00:00Z,true,true,false,would-suspend,\n")
+}
+
+func TestReclaimer_Products(t *testing.T) {
+
+	organization := &organizationFake{
+		pages: map[string]*model.OrganizationUserPageScheme{
+			"": {Data: []*model.AdminOrganizationUserScheme{{AccountID: "confluence-user"}, {AccountID: "undated"}}},
+		},
+	}
+
+	directory := &directoryFake{
+		activity: map[string]*model.UserProductAccessDataScheme{
+			"confluence-user": {ProductAccess: []*model.UserProductLastActiveScheme{
+				{Key: "jira-software", LastActive: "2022-01-01"},
+				{Key: "confluence", LastActive: "2023-04-30"},
+			}},
+			"undated": {ProductAccess: []*model.UserProductLastActiveScheme{
+				{Key: "jira-software"},
+			}},
+		},
+	}
+
+	reclaimer, err := NewReclaimer(organization, directory, "org-id", &Options{
+		Products: []string{"jira-software"},
+		Now:      func() time.Time { return time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC) },
+	})
+	assert.NoError(t, err)
+
+	report, err := reclaimer.Run(context.Background())
+	assert.NoError(t, err)
+
+	// The user inactive on Jira is still active on Confluence, the suspension would remove both.
+	assert.Len(t, report.Users[0].Products, 1)
+	assert.True(t, report.Users[0].Products[0].Inactive)
+	assert.False(t, report.Users[0].Inactive)
+
+	// The user without activity dates is reported as unknown instead of inactive.
+	assert.False(t, report.Users[1].Inactive)
+	assert.True(t, report.Users[1].Unknown)
+
+	assert.Empty(t, directory.suspended)
+}
+
+func TestNewReclaimer(t *testing.T) {
+
+	_, err := NewReclaimer(nil, &directoryFake{}, "org-id", nil)
+	assert.EqualError(t, err, ErrNoOrganizationConnectorError.Error())
+
+	_, err = NewReclaimer(&organizationFake{}, &directoryFake{}, "", nil)
+	assert.EqualError(t, err, model.ErrNoAdminOrganizationError.Error())
+}
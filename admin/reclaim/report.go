@@ -0,0 +1,114 @@
+package reclaim
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Report is the activity of the organization users classified against the reclaimer thresholds.
+type Report struct {
+	OrganizationID string        `json:"organizationId"`
+	GeneratedAt    time.Time     `json:"generatedAt"`
+	Users          []*UserReport `json:"users"`
+}
+
+// UserReport is the classification of a single user.
+type UserReport struct {
+	AccountID string `json:"accountId"`
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+
+	// Products is the last activity of the user on each product reported.
+	Products []*ProductActivity `json:"products,omitempty"`
+
+	// Inactive reports whether the user is inactive on every product, reported or not.
+	Inactive bool `json:"inactive"`
+
+	// Unknown reports whether the activity of the user can't be determined, e.g: a product without
+	// last activity for a user without the date they joined the organization. Unknown users aren't inactive.
+	Unknown bool `json:"unknown,omitempty"`
+
+	// Excluded reports whether the user matches the exclusion lists, excluded users are never suspended.
+	Excluded bool `json:"excluded,omitempty"`
+
+	// Action is the action taken by Suspend, e.g: suspended.
+	Action Action `json:"action,omitempty"`
+
+	// Error is the error found while fetching the activity or suspending the user.
+	Error string `json:"error,omitempty"`
+}
+
+// ProductActivity is the last activity of a user on a product.
+type ProductActivity struct {
+	Key  string `json:"key"`
+	Name string `json:"name,omitempty"`
+
+	// LastActive is zero when the user has never accessed the product.
+	LastActive time.Time     `json:"lastActive,omitempty"`
+	Threshold  time.Duration `json:"threshold"`
+	Inactive   bool          `json:"inactive"`
+}
+
+// Inactive returns the users that are inactive and not excluded.
+func (r *Report) Inactive() []*UserReport {
+
+	var users []*UserReport
+	for _, user := range r.Users {
+		if user.Inactive && !user.Excluded && user.Error == "" {
+			users = append(users, user)
+		}
+	}
+
+	return users
+}
+
+// WriteJSON writes the report as an indented JSON document.
+func (r *Report) WriteJSON(w io.Writer) error {
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+// WriteCSV writes the report with a row per user and product.
+func (r *Report) WriteCSV(w io.Writer) error {
+
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{"account_id", "name", "email", "product", "last_active", "product_inactive",
+		"inactive", "excluded", "action", "error"})
+	if err != nil {
+		return err
+	}
+
+	for _, user := range r.Users {
+
+		products := user.Products
+		if len(products) == 0 {
+			products = []*ProductActivity{{}}
+		}
+
+		for _, product := range products {
+
+			var lastActive string
+			if !product.LastActive.IsZero() {
+				lastActive = product.LastActive.Format(time.RFC3339)
+			}
+
+			err = writer.Write([]string{user.AccountID, user.Name, user.Email, product.Key, lastActive,
+				strconv.FormatBool(product.Inactive), strconv.FormatBool(user.Inactive),
+				strconv.FormatBool(user.Excluded), string(user.Action), strings.TrimSpace(user.Error)})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,54 @@
+package reclaim
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Action is the action taken on an inactive user.
+type Action string
+
+const (
+	SuspendedAction     Action = "suspended"
+	WouldSuspendAction  Action = "would-suspend"
+	SuspendFailedAction Action = "suspend-failed"
+)
+
+// Entry is a record of the audit trail.
+type Entry struct {
+	Time      time.Time `json:"time"`
+	AccountID string    `json:"accountId"`
+	Email     string    `json:"email,omitempty"`
+	Action    Action    `json:"action"`
+	DryRun    bool      `json:"dryRun,omitempty"`
+
+	// Reason describes the inactivity that caused the action.
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Trail records the actions taken by the reclaimer, e.g: to keep evidence of the licenses reclaimed.
+type Trail interface {
+	Record(ctx context.Context, entry *Entry) error
+}
+
+// NewJSONTrail returns a Trail that writes each entry on the writer as a JSON document per line.
+func NewJSONTrail(w io.Writer) Trail {
+	return &jsonTrail{encoder: json.NewEncoder(w)}
+}
+
+type jsonTrail struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+func (j *jsonTrail) Record(ctx context.Context, entry *Entry) error {
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.encoder.Encode(entry)
+}
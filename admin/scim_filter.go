@@ -0,0 +1,547 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// SCIM filter comparison operators, see RFC 7644 section 3.4.2.2.
+const (
+	SCIMEqualOperator          = "eq"
+	SCIMNotEqualOperator       = "ne"
+	SCIMContainsOperator       = "co"
+	SCIMStartsWithOperator     = "sw"
+	SCIMEndsWithOperator       = "ew"
+	SCIMPresentOperator        = "pr"
+	SCIMGreaterThanOperator    = "gt"
+	SCIMGreaterOrEqualOperator = "ge"
+	SCIMLessThanOperator       = "lt"
+	SCIMLessOrEqualOperator    = "le"
+)
+
+var scimComparisonOperators = map[string]bool{
+	SCIMEqualOperator: true, SCIMNotEqualOperator: true, SCIMContainsOperator: true, SCIMStartsWithOperator: true,
+	SCIMEndsWithOperator: true, SCIMGreaterThanOperator: true, SCIMGreaterOrEqualOperator: true,
+	SCIMLessThanOperator: true, SCIMLessOrEqualOperator: true,
+}
+
+// SCIMFilter is a node of a SCIM filter expression, it's one of *SCIMAttributeExpression,
+// *SCIMLogicalExpression, *SCIMNotExpression or *SCIMValuePathExpression.
+//
+// String returns the filter as expected by the filter parameter, e.g: on SCIMUserGetsOptionsScheme.Filter.
+type SCIMFilter interface {
+	String() string
+}
+
+// SCIMAttributeExpression compares an attribute with a value, e.g: userName eq "bjensen".
+type SCIMAttributeExpression struct {
+	Path     string
+	Operator string
+
+	// Value is a string, a number, a bool or nil, it's ignored by the "pr" operator.
+	Value interface{}
+}
+
+func (e *SCIMAttributeExpression) String() string {
+
+	if e.Operator == SCIMPresentOperator {
+		return e.Path + " " + e.Operator
+	}
+
+	value, err := json.Marshal(e.Value)
+	if err != nil {
+		value = []byte(fmt.Sprintf("%q", fmt.Sprint(e.Value)))
+	}
+
+	return e.Path + " " + e.Operator + " " + string(value)
+}
+
+// SCIMLogicalExpression joins two filters with the "and" or "or" operators.
+type SCIMLogicalExpression struct {
+	Operator    string
+	Left, Right SCIMFilter
+}
+
+func (e *SCIMLogicalExpression) String() string {
+	return groupSCIMFilter(e.Left, e.Operator) + " " + e.Operator + " " + groupSCIMFilter(e.Right, e.Operator)
+}
+
+// SCIMNotExpression negates a filter.
+type SCIMNotExpression struct {
+	Filter SCIMFilter
+}
+
+func (e *SCIMNotExpression) String() string {
+	return "not (" + e.Filter.String() + ")"
+}
+
+// SCIMValuePathExpression filters the values of a multi-valued attribute, e.g: emails[type eq "work"].
+type SCIMValuePathExpression struct {
+	Path   string
+	Filter SCIMFilter
+}
+
+func (e *SCIMValuePathExpression) String() string {
+	return e.Path + "[" + e.Filter.String() + "]"
+}
+
+// groupSCIMFilter wraps the "or" expressions nested on an "and" expression with parentheses.
+func groupSCIMFilter(filter SCIMFilter, operator string) string {
+
+	if logical, ok := filter.(*SCIMLogicalExpression); ok && logical.Operator == "or" && operator == "and" {
+		return "(" + filter.String() + ")"
+	}
+
+	return filter.String()
+}
+
+// SCIMEq returns the filter path eq value.
+func SCIMEq(path string, value interface{}) SCIMFilter {
+	return &SCIMAttributeExpression{Path: path, Operator: SCIMEqualOperator, Value: value}
+}
+
+// SCIMNe returns the filter path ne value.
+func SCIMNe(path string, value interface{}) SCIMFilter {
+	return &SCIMAttributeExpression{Path: path, Operator: SCIMNotEqualOperator, Value: value}
+}
+
+// SCIMCo returns the filter path co value.
+func SCIMCo(path, value string) SCIMFilter {
+	return &SCIMAttributeExpression{Path: path, Operator: SCIMContainsOperator, Value: value}
+}
+
+// SCIMSw returns the filter path sw value.
+func SCIMSw(path, value string) SCIMFilter {
+	return &SCIMAttributeExpression{Path: path, Operator: SCIMStartsWithOperator, Value: value}
+}
+
+// SCIMEw returns the filter path ew value.
+func SCIMEw(path, value string) SCIMFilter {
+	return &SCIMAttributeExpression{Path: path, Operator: SCIMEndsWithOperator, Value: value}
+}
+
+// SCIMPr returns the filter path pr.
+func SCIMPr(path string) SCIMFilter {
+	return &SCIMAttributeExpression{Path: path, Operator: SCIMPresentOperator}
+}
+
+// SCIMGt returns the filter path gt value.
+func SCIMGt(path string, value interface{}) SCIMFilter {
+	return &SCIMAttributeExpression{Path: path, Operator: SCIMGreaterThanOperator, Value: value}
+}
+
+// SCIMGe returns the filter path ge value.
+func SCIMGe(path string, value interface{}) SCIMFilter {
+	return &SCIMAttributeExpression{Path: path, Operator: SCIMGreaterOrEqualOperator, Value: value}
+}
+
+// SCIMLt returns the filter path lt value.
+func SCIMLt(path string, value interface{}) SCIMFilter {
+	return &SCIMAttributeExpression{Path: path, Operator: SCIMLessThanOperator, Value: value}
+}
+
+// SCIMLe returns the filter path le value.
+func SCIMLe(path string, value interface{}) SCIMFilter {
+	return &SCIMAttributeExpression{Path: path, Operator: SCIMLessOrEqualOperator, Value: value}
+}
+
+// SCIMAnd joins the filters with the "and" operator.
+func SCIMAnd(filters ...SCIMFilter) SCIMFilter {
+	return joinSCIMFilters("and", filters)
+}
+
+// SCIMOr joins the filters with the "or" operator.
+func SCIMOr(filters ...SCIMFilter) SCIMFilter {
+	return joinSCIMFilters("or", filters)
+}
+
+// SCIMNot negates the filter.
+func SCIMNot(filter SCIMFilter) SCIMFilter {
+	return &SCIMNotExpression{Filter: filter}
+}
+
+// SCIMValuePath filters the values of a multi-valued attribute, e.g: SCIMValuePath("emails", SCIMEq("type", "work")).
+func SCIMValuePath(path string, filter SCIMFilter) SCIMFilter {
+	return &SCIMValuePathExpression{Path: path, Filter: filter}
+}
+
+func joinSCIMFilters(operator string, filters []SCIMFilter) SCIMFilter {
+
+	var joined SCIMFilter
+	for _, filter := range filters {
+
+		if filter == nil {
+			continue
+		}
+
+		if joined == nil {
+			joined = filter
+			continue
+		}
+
+		joined = &SCIMLogicalExpression{Operator: operator, Left: joined, Right: filter}
+	}
+
+	return joined
+}
+
+// SCIMPath is the target of a PATCH operation, e.g: emails[type eq "work"].value.
+type SCIMPath struct {
+
+	// Attribute is the attribute name, optionally prefixed by the schema URN, e.g: name.givenName.
+	Attribute string
+
+	// Filter selects the values of a multi-valued attribute, optional.
+	Filter SCIMFilter
+
+	// SubAttribute is the sub-attribute of the values selected by the filter, optional.
+	SubAttribute string
+}
+
+// NewSCIMPath returns the path of an attribute, e.g: NewSCIMPath("name.givenName").
+func NewSCIMPath(attribute string) *SCIMPath {
+	return &SCIMPath{Attribute: attribute}
+}
+
+// Where returns a copy of the path that selects the values matched by the filter.
+func (p *SCIMPath) Where(filter SCIMFilter) *SCIMPath {
+	path := *p
+	path.Filter = filter
+	return &path
+}
+
+// Sub returns a copy of the path that targets a sub-attribute of the values selected.
+func (p *SCIMPath) Sub(subAttribute string) *SCIMPath {
+	path := *p
+	path.SubAttribute = subAttribute
+	return &path
+}
+
+func (p *SCIMPath) String() string {
+
+	path := p.Attribute
+
+	if p.Filter != nil {
+		path += "[" + p.Filter.String() + "]"
+	}
+
+	if p.SubAttribute != "" {
+		path += "." + p.SubAttribute
+	}
+
+	return path
+}
+
+// ParseSCIMFilter parses a RFC 7644 filter expression, e.g: userName eq "bjensen" and not (emails co "example.org").
+func ParseSCIMFilter(filter string) (SCIMFilter, error) {
+
+	parser, err := newSCIMParser(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	expression, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if !parser.done() {
+		return nil, parser.errorf("unexpected %q", parser.peek().text)
+	}
+
+	return expression, nil
+}
+
+// ParseSCIMPath parses a PATCH operation path, e.g: emails[type eq "work"].value.
+func ParseSCIMPath(path string) (*SCIMPath, error) {
+
+	parser, err := newSCIMParser(path)
+	if err != nil {
+		return nil, err
+	}
+
+	attribute := parser.next()
+	if attribute.kind != scimWordToken {
+		return nil, parser.errorf("expected an attribute path")
+	}
+
+	parsed := &SCIMPath{Attribute: attribute.text}
+
+	if parser.peek().kind == '[' {
+
+		parser.next()
+
+		if parsed.Filter, err = parser.parseOr(); err != nil {
+			return nil, err
+		}
+
+		if parser.next().kind != ']' {
+			return nil, parser.errorf("expected ]")
+		}
+
+		if token := parser.peek(); token.kind == scimWordToken && strings.HasPrefix(token.text, ".") {
+			parser.next()
+			parsed.SubAttribute = token.text[1:]
+		}
+	}
+
+	if !parser.done() {
+		return nil, parser.errorf("unexpected %q", parser.peek().text)
+	}
+
+	return parsed, nil
+}
+
+const (
+	scimEOFToken = iota
+	scimWordToken
+	scimStringToken
+)
+
+type scimToken struct {
+	kind     rune
+	text     string
+	position int
+}
+
+type scimParser struct {
+	input  string
+	tokens []scimToken
+	index  int
+}
+
+func newSCIMParser(input string) (*scimParser, error) {
+
+	parser := &scimParser{input: input}
+	runes := []rune(input)
+
+	for position := 0; position < len(runes); {
+
+		character := runes[position]
+
+		switch {
+		case unicode.IsSpace(character):
+			position++
+
+		case strings.ContainsRune("()[]", character):
+			parser.tokens = append(parser.tokens, scimToken{kind: character, text: string(character), position: position})
+			position++
+
+		case character == '"':
+
+			end := position + 1
+			for ; end < len(runes) && runes[end] != '"'; end++ {
+				if runes[end] == '\\' {
+					end++
+				}
+			}
+
+			if end >= len(runes) {
+				return nil, fmt.Errorf("%w: unterminated string at position %v", model.ErrInvalidSCIMFilterError, position)
+			}
+
+			var value string
+			if err := json.Unmarshal([]byte(string(runes[position:end+1])), &value); err != nil {
+				return nil, fmt.Errorf("%w: invalid string at position %v", model.ErrInvalidSCIMFilterError, position)
+			}
+
+			parser.tokens = append(parser.tokens, scimToken{kind: scimStringToken, text: value, position: position})
+			position = end + 1
+
+		default:
+
+			end := position
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("()[]\"", runes[end]) {
+				end++
+			}
+
+			parser.tokens = append(parser.tokens, scimToken{kind: scimWordToken, text: string(runes[position:end]), position: position})
+			position = end
+		}
+	}
+
+	return parser, nil
+}
+
+func (p *scimParser) peek() scimToken {
+
+	if p.index >= len(p.tokens) {
+		return scimToken{kind: scimEOFToken, position: len([]rune(p.input))}
+	}
+
+	return p.tokens[p.index]
+}
+
+func (p *scimParser) next() scimToken {
+	token := p.peek()
+	p.index++
+	return token
+}
+
+func (p *scimParser) done() bool {
+	return p.index >= len(p.tokens)
+}
+
+func (p *scimParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %v at position %v", model.ErrInvalidSCIMFilterError, fmt.Sprintf(format, args...), p.peek().position)
+}
+
+func (p *scimParser) isKeyword(keyword string) bool {
+	token := p.peek()
+	return token.kind == scimWordToken && strings.EqualFold(token.text, keyword)
+}
+
+func (p *scimParser) parseOr() (SCIMFilter, error) {
+
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("or") {
+
+		p.next()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &SCIMLogicalExpression{Operator: "or", Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *scimParser) parseAnd() (SCIMFilter, error) {
+
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("and") {
+
+		p.next()
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &SCIMLogicalExpression{Operator: "and", Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *scimParser) parseUnary() (SCIMFilter, error) {
+
+	if p.isKeyword("not") {
+
+		p.next()
+
+		if p.peek().kind != '(' {
+			return nil, p.errorf("expected ( after not")
+		}
+
+		filter, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &SCIMNotExpression{Filter: filter}, nil
+	}
+
+	if p.peek().kind == '(' {
+
+		p.next()
+
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek().kind != ')' {
+			return nil, p.errorf("expected )")
+		}
+
+		p.next()
+		return filter, nil
+	}
+
+	path := p.next()
+	if path.kind != scimWordToken {
+		p.index--
+		return nil, p.errorf("expected an attribute path")
+	}
+
+	if p.peek().kind == '[' {
+
+		p.next()
+
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek().kind != ']' {
+			return nil, p.errorf("expected ]")
+		}
+
+		p.next()
+		return &SCIMValuePathExpression{Path: path.text, Filter: filter}, nil
+	}
+
+	operator := p.peek()
+	if operator.kind != scimWordToken {
+		return nil, p.errorf("expected an operator after %v", path.text)
+	}
+
+	p.next()
+	name := strings.ToLower(operator.text)
+
+	if name == SCIMPresentOperator {
+		return &SCIMAttributeExpression{Path: path.text, Operator: name}, nil
+	}
+
+	if !scimComparisonOperators[name] {
+		p.index--
+		return nil, p.errorf("unknown operator %q", operator.text)
+	}
+
+	value := p.next()
+	switch value.kind {
+	case scimStringToken:
+		return &SCIMAttributeExpression{Path: path.text, Operator: name, Value: value.text}, nil
+
+	case scimWordToken:
+
+		var parsed interface{}
+		switch strings.ToLower(value.text) {
+		case "true":
+			parsed = true
+		case "false":
+			parsed = false
+		case "null":
+			parsed = nil
+		default:
+
+			if !json.Valid([]byte(value.text)) || !strings.ContainsRune("-0123456789", rune(value.text[0])) {
+				p.index--
+				return nil, p.errorf("invalid value %q", value.text)
+			}
+
+			parsed = json.Number(value.text)
+		}
+
+		return &SCIMAttributeExpression{Path: path.text, Operator: name, Value: parsed}, nil
+	}
+
+	p.index--
+	return nil, p.errorf("expected a value after %v %v", path.text, name)
+}
@@ -0,0 +1,155 @@
+package admin
+
+import (
+	"encoding/json"
+	"testing"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSCIMFilterBuilder(t *testing.T) {
+
+	filter := SCIMAnd(
+		SCIMEq("userName", "bjensen"),
+		SCIMOr(SCIMSw("title", "Lead"), SCIMPr("nickName")),
+		SCIMNot(SCIMValuePath("emails", SCIMAnd(SCIMEq("type", "work"), SCIMCo("value", "example.org")))),
+		SCIMGt("meta.lastModified", "2011-05-13T04:42:34Z"),
+		SCIMEq("active", true),
+	)
+
+	assert.Equal(t, `userName eq "bjensen" and (title sw "Lead" or nickName pr) and `+
+		`not (emails[type eq "work" and value co "example.org"]) and meta.lastModified gt "2011-05-13T04:42:34Z" and active eq true`,
+		filter.String())
+
+	assert.Nil(t, SCIMOr())
+	assert.Equal(t, `displayName eq "a \"quoted\" name"`, SCIMEq("displayName", `a "quoted" name`).String())
+}
+
+func TestParseSCIMFilter(t *testing.T) {
+
+	testCases := []struct {
+		name    string
+		filter  string
+		want    string
+		wantErr bool
+		Err     string
+	}{
+		{
+			name:   "when the filter is a single comparison",
+			filter: `userName Eq "bjensen"`,
+			want:   `userName eq "bjensen"`,
+		},
+		{
+			name:   "when the and operator takes precedence over or",
+			filter: `title pr or userType eq "Employee" and active eq true`,
+			want:   `title pr or userType eq "Employee" and active eq true`,
+		},
+		{
+			name:   "when the filter is grouped",
+			filter: `(title pr or userType eq "Employee") and not (meta.version ne 2.5e1)`,
+			want:   `(title pr or userType eq "Employee") and not (meta.version ne 2.5e1)`,
+		},
+		{
+			name:   "when the filter has a value path and a schema urn",
+			filter: `emails[type eq "work" and value ew "@example.com"] or urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department eq null`,
+			want:   `emails[type eq "work" and value ew "@example.com"] or urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department eq null`,
+		},
+		{
+			name:    "when the operator is unknown",
+			filter:  `userName like "bjensen"`,
+			wantErr: true,
+			Err:     `admin: invalid scim filter: unknown operator "like" at position 9`,
+		},
+		{
+			name:    "when the group is not closed",
+			filter:  `(userName eq "bjensen"`,
+			wantErr: true,
+			Err:     "admin: invalid scim filter: expected ) at position 22",
+		},
+		{
+			name:    "when the string is not terminated",
+			filter:  `userName eq "bjensen`,
+			wantErr: true,
+			Err:     "admin: invalid scim filter: unterminated string at position 12",
+		},
+		{
+			name:    "when the value is not valid",
+			filter:  `userName eq bjensen`,
+			wantErr: true,
+			Err:     `admin: invalid scim filter: invalid value "bjensen" at position 12`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			got, err := ParseSCIMFilter(testCase.filter)
+
+			if testCase.wantErr {
+				assert.EqualError(t, err, testCase.Err)
+				assert.ErrorIs(t, err, model.ErrInvalidSCIMFilterError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, got.String())
+
+			// The filter formatted is parsed again to the same expression.
+			again, err := ParseSCIMFilter(got.String())
+			assert.NoError(t, err)
+			assert.Equal(t, got, again)
+		})
+	}
+}
+
+func TestParseSCIMPath(t *testing.T) {
+
+	path, err := ParseSCIMPath(`emails[type eq "work"].value`)
+	assert.NoError(t, err)
+	assert.Equal(t, &SCIMPath{
+		Attribute:    "emails",
+		Filter:       &SCIMAttributeExpression{Path: "type", Operator: "eq", Value: "work"},
+		SubAttribute: "value",
+	}, path)
+	assert.Equal(t, `emails[type eq "work"].value`, path.String())
+
+	path, err = ParseSCIMPath("name.givenName")
+	assert.NoError(t, err)
+	assert.Equal(t, NewSCIMPath("name.givenName"), path)
+
+	_, err = ParseSCIMPath(`emails[type eq "work"`)
+	assert.EqualError(t, err, "admin: invalid scim filter: expected ] at position 21")
+}
+
+func TestSCIMUserPatch(t *testing.T) {
+
+	payload, err := NewSCIMUserPatch().
+		Replace(NewSCIMPath("title"), "Engineer").
+		Replace(NewSCIMPath("emails").Where(SCIMEq("type", "work")).Sub("value"), "bjensen@example.com").
+		Add(NewSCIMPath("active"), true).
+		Remove(NewSCIMPath("nickName")).
+		Build()
+	assert.NoError(t, err)
+
+	body, err := json.Marshal(payload)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"operations": [
+			{"op": "replace", "path": "title", "value": "Engineer"},
+			{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "bjensen@example.com"},
+			{"op": "add", "path": "active", "value": true},
+			{"op": "remove", "path": "nickName"}
+		]
+	}`, string(body))
+
+	_, err = NewSCIMUserPatch().Replace(NewSCIMPath("title"), "").Build()
+	assert.EqualError(t, err, model.ErrNoSCIMValueError.Error())
+
+	_, err = NewSCIMUserPatch().Remove(nil).Build()
+	assert.EqualError(t, err, model.ErrNoSCIMPathError.Error())
+
+	_, err = NewSCIMUserPatch().Build()
+	assert.EqualError(t, err, model.ErrNoSCIMOperationError.Error())
+}
@@ -0,0 +1,102 @@
+package admin
+
+import (
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// SCIM PATCH operations, see RFC 7644 section 3.5.2.
+const (
+	SCIMAddOperation     = "add"
+	SCIMRemoveOperation  = "remove"
+	SCIMReplaceOperation = "replace"
+)
+
+const scimPatchOpSchema = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+
+// SCIMUserPatch builds the payload of the SCIMUserConnector.Path method from typed paths, e.g:
+//
+//	payload, err := admin.NewSCIMUserPatch().
+//		Replace(admin.NewSCIMPath("title"), "Engineer").
+//		Replace(admin.NewSCIMPath("emails").Where(admin.SCIMEq("type", "work")).Sub("value"), "bjensen@example.com").
+//		Remove(admin.NewSCIMPath("nickName")).
+//		Build()
+type SCIMUserPatch struct {
+	operations []*model.SCIMUserToPathOperationScheme
+	err        error
+}
+
+// NewSCIMUserPatch returns an empty SCIM user PATCH builder.
+func NewSCIMUserPatch() *SCIMUserPatch {
+	return &SCIMUserPatch{}
+}
+
+// Add adds the value to the attribute, the value is a string, a bool or a slice of *model.SCIMUserComplexOperationScheme.
+func (s *SCIMUserPatch) Add(path *SCIMPath, value interface{}) *SCIMUserPatch {
+	return s.operation(SCIMAddOperation, path, value)
+}
+
+// Replace replaces the value of the attribute, the value is a string, a bool or a slice of *model.SCIMUserComplexOperationScheme.
+func (s *SCIMUserPatch) Replace(path *SCIMPath, value interface{}) *SCIMUserPatch {
+	return s.operation(SCIMReplaceOperation, path, value)
+}
+
+// Remove removes the attribute, or the values selected by the path filter.
+func (s *SCIMUserPatch) Remove(path *SCIMPath) *SCIMUserPatch {
+	return s.operation(SCIMRemoveOperation, path, nil)
+}
+
+// Build returns the PATCH payload, or the first error found while building it.
+func (s *SCIMUserPatch) Build() (*model.SCIMUserToPathScheme, error) {
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	if len(s.operations) == 0 {
+		return nil, model.ErrNoSCIMOperationError
+	}
+
+	return &model.SCIMUserToPathScheme{
+		Schemas:    []string{scimPatchOpSchema},
+		Operations: s.operations,
+	}, nil
+}
+
+func (s *SCIMUserPatch) operation(operation string, path *SCIMPath, value interface{}) *SCIMUserPatch {
+
+	if s.err != nil {
+		return s
+	}
+
+	if path == nil || path.Attribute == "" {
+		s.err = model.ErrNoSCIMPathError
+		return s
+	}
+
+	if operation != SCIMRemoveOperation {
+
+		switch typed := value.(type) {
+		case nil:
+			s.err = model.ErrNoSCIMValueError
+			return s
+		case string:
+			if typed == "" {
+				s.err = model.ErrNoSCIMValueError
+				return s
+			}
+		case []*model.SCIMUserComplexOperationScheme:
+			if len(typed) == 0 {
+				s.err = model.ErrNoSCIMComplexValueError
+				return s
+			}
+		}
+	}
+
+	s.operations = append(s.operations, &model.SCIMUserToPathOperationScheme{
+		Op:    operation,
+		Path:  path.String(),
+		Value: value,
+	})
+
+	return s
+}
@@ -0,0 +1,131 @@
+package tokens
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// Finding is the reason a token breaks the token policy.
+type Finding string
+
+const (
+	ExpiredFinding Finding = "expired"
+	UnusedFinding  Finding = "unused"
+)
+
+// Action is the action taken on a flagged token.
+type Action string
+
+const (
+	RevokedAction      Action = "revoked"
+	WouldRevokeAction  Action = "would-revoke"
+	RevokeFailedAction Action = "revoke-failed"
+)
+
+// Report is the API tokens of the managed accounts of an organization.
+type Report struct {
+	OrganizationID string           `json:"organizationId"`
+	GeneratedAt    time.Time        `json:"generatedAt"`
+	MaxAge         time.Duration    `json:"maxAge,omitempty"`
+	MaxUnused      time.Duration    `json:"maxUnused,omitempty"`
+	Accounts       []*AccountReport `json:"accounts"`
+}
+
+// AccountReport is the API tokens of a managed account.
+type AccountReport struct {
+	AccountID string         `json:"accountId"`
+	Name      string         `json:"name,omitempty"`
+	Email     string         `json:"email,omitempty"`
+	Tokens    []*TokenReport `json:"tokens,omitempty"`
+
+	// Error is the error found while listing the tokens of the account.
+	Error string `json:"error,omitempty"`
+}
+
+// TokenReport is an API token and the findings against the token policy.
+type TokenReport struct {
+	ID         string    `json:"id"`
+	Label      string    `json:"label,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastAccess time.Time `json:"lastAccess"`
+	Findings   []Finding `json:"findings,omitempty"`
+	Action     Action    `json:"action,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Flagged returns the number of tokens with findings.
+func (r *Report) Flagged() int {
+
+	var flagged int
+	for _, account := range r.Accounts {
+		for _, token := range account.Tokens {
+			if len(token.Findings) != 0 {
+				flagged++
+			}
+		}
+	}
+
+	return flagged
+}
+
+// WriteJSON writes the report as an indented JSON document.
+func (r *Report) WriteJSON(w io.Writer) error {
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+// WriteCSV writes the report with a row per token, the accounts without tokens are skipped unless
+// their tokens couldn't be listed.
+func (r *Report) WriteCSV(w io.Writer) error {
+
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{"account_id", "name", "email", "token_id", "label", "created_at", "last_access",
+		"findings", "action", "error"})
+	if err != nil {
+		return err
+	}
+
+	for _, account := range r.Accounts {
+
+		if account.Error != "" {
+			if err = writer.Write([]string{account.AccountID, account.Name, account.Email, "", "", "", "", "", "", account.Error}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, token := range account.Tokens {
+
+			var findings []string
+			for _, finding := range token.Findings {
+				findings = append(findings, string(finding))
+			}
+
+			err = writer.Write([]string{account.AccountID, account.Name, account.Email, token.ID, token.Label,
+				formatTime(token.CreatedAt), formatTime(token.LastAccess), strings.Join(findings, ";"),
+				string(token.Action), token.Error})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatTime(value time.Time) string {
+
+	if value.IsZero() {
+		return ""
+	}
+
+	return value.Format(time.RFC3339)
+}
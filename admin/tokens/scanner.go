@@ -0,0 +1,315 @@
+// Package tokens scans the API tokens of the managed accounts of an Atlassian organization,
+// flags the tokens that break the token policy and revokes them.
+package tokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/internal/batch"
+	"github.com/ctreminiom/go-atlassian/internal/paging"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/admin"
+)
+
+const defaultConcurrency = 4
+
+// The account type of the user accounts, and the claim status of the domains verified by the organization.
+const (
+	atlassianAccountType = "atlassian"
+	verifiedClaimStatus  = "verified"
+)
+
+var (
+	ErrNoConnectorError = errors.New("tokens: no organization or user token connector set")
+	ErrNoPolicyError    = errors.New("tokens: no maximum age nor maximum unused time set")
+	ErrNoReportError    = errors.New("tokens: no report set")
+	ErrRevokeError      = errors.New("tokens: some tokens couldn't be revoked")
+)
+
+type Options struct {
+
+	// MaxAge flags the tokens created before it, disabled when zero.
+	MaxAge time.Duration
+
+	// MaxUnused flags the tokens not used for it, or never used since created before it. Disabled when zero.
+	MaxUnused time.Duration
+
+	// ExcludedAccountIDs are not scanned, e.g: the service accounts whose tokens are rotated elsewhere.
+	ExcludedAccountIDs []string
+
+	// Concurrency is the maximum number of requests in flight, 4 by default.
+	Concurrency int
+
+	// DryRun reports the tokens that would be revoked without revoking them.
+	DryRun bool
+
+	// Now dates the report and the age of the tokens is measured from it, time.Now by default.
+	Now func() time.Time
+}
+
+// Scanner lists the API tokens of the managed accounts of an organization and flags them against the token policy.
+type Scanner struct {
+	organization   admin.OrganizationConnector
+	tokens         admin.UserTokenConnector
+	organizationID string
+	options        Options
+}
+
+// NewScanner returns a scanner of the organization managed accounts, the connectors are usually the
+// Organization and User.Token services of the admin client.
+func NewScanner(organization admin.OrganizationConnector, tokens admin.UserTokenConnector, organizationID string,
+	options *Options) (*Scanner, error) {
+
+	if organization == nil || tokens == nil {
+		return nil, ErrNoConnectorError
+	}
+
+	if organizationID == "" {
+		return nil, model.ErrNoAdminOrganizationError
+	}
+
+	scanner := &Scanner{
+		organization:   organization,
+		tokens:         tokens,
+		organizationID: organizationID,
+	}
+
+	if options != nil {
+		scanner.options = *options
+	}
+
+	if scanner.options.MaxAge <= 0 && scanner.options.MaxUnused <= 0 {
+		return nil, ErrNoPolicyError
+	}
+
+	if scanner.options.Concurrency <= 0 {
+		scanner.options.Concurrency = defaultConcurrency
+	}
+
+	if scanner.options.Now == nil {
+		scanner.options.Now = time.Now
+	}
+
+	return scanner, nil
+}
+
+// Scan lists the tokens of every managed account and flags them. The managed accounts are the
+// organization users whose email domain is verified by the organization, see OrganizationConnector.Domains.
+//
+// The accounts whose tokens can't be listed are reported with the error.
+func (s *Scanner) Scan(ctx context.Context) (*Report, error) {
+
+	users, err := s.managed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{OrganizationID: s.organizationID, GeneratedAt: s.options.Now(), MaxAge: s.options.MaxAge,
+		MaxUnused: s.options.MaxUnused}
+
+	var accounts []*AccountReport
+	for _, user := range users {
+
+		if s.excluded(user.AccountID) {
+			continue
+		}
+
+		accounts = append(accounts, &AccountReport{AccountID: user.AccountID, Name: user.Name, Email: user.Email})
+	}
+
+	err = batch.Run(ctx, len(accounts), s.options.Concurrency, func(index int) {
+
+		account := accounts[index]
+
+		tokens, _, err := s.tokens.Gets(ctx, account.AccountID)
+		if err != nil {
+			account.Error = err.Error()
+			return
+		}
+
+		for _, token := range tokens {
+			account.Tokens = append(account.Tokens, s.classify(token))
+		}
+
+		sort.Slice(account.Tokens, func(i, j int) bool { return account.Tokens[i].CreatedAt.Before(account.Tokens[j].CreatedAt) })
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	report.Accounts = accounts
+	return report, nil
+}
+
+// Revoke deletes the flagged tokens of the report, or only marks them as would-revoke when the
+// scanner is on dry-run. The action taken is set on each token.
+func (s *Scanner) Revoke(ctx context.Context, report *Report) error {
+
+	if report == nil {
+		return ErrNoReportError
+	}
+
+	type flagged struct {
+		account *AccountReport
+		token   *TokenReport
+	}
+
+	var targets []*flagged
+	for _, account := range report.Accounts {
+		for _, token := range account.Tokens {
+			if len(token.Findings) != 0 {
+				targets = append(targets, &flagged{account: account, token: token})
+			}
+		}
+	}
+
+	var (
+		mu     sync.Mutex
+		failed int
+	)
+
+	err := batch.Run(ctx, len(targets), s.options.Concurrency, func(index int) {
+
+		target := targets[index]
+
+		if s.options.DryRun {
+			target.token.Action = WouldRevokeAction
+			return
+		}
+
+		if _, err := s.tokens.Delete(ctx, target.account.AccountID, target.token.ID); err != nil {
+
+			target.token.Action, target.token.Error = RevokeFailedAction, err.Error()
+
+			mu.Lock()
+			failed++
+			mu.Unlock()
+			return
+		}
+
+		target.token.Action = RevokedAction
+	})
+
+	// The tokens left once the context is done have no action.
+	if err != nil {
+		return err
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("%w: %v of %v", ErrRevokeError, failed, len(targets))
+	}
+
+	return nil
+}
+
+func (s *Scanner) classify(token *model.UserTokensScheme) *TokenReport {
+
+	now := s.options.Now()
+	report := &TokenReport{ID: token.ID, Label: token.Label, CreatedAt: token.CreatedAt, LastAccess: token.LastAccess}
+
+	if s.options.MaxAge > 0 && !token.CreatedAt.IsZero() && now.Sub(token.CreatedAt) > s.options.MaxAge {
+		report.Findings = append(report.Findings, ExpiredFinding)
+	}
+
+	if s.options.MaxUnused > 0 {
+
+		lastUsed := token.LastAccess
+		if lastUsed.IsZero() {
+			lastUsed = token.CreatedAt
+		}
+
+		if !lastUsed.IsZero() && now.Sub(lastUsed) > s.options.MaxUnused {
+			report.Findings = append(report.Findings, UnusedFinding)
+		}
+	}
+
+	return report
+}
+
+func (s *Scanner) excluded(accountID string) bool {
+
+	for _, excluded := range s.options.ExcludedAccountIDs {
+		if excluded == accountID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// managed returns the managed accounts of the organization users: the Atlassian accounts whose
+// email domain is verified by the organization, the tokens of the other accounts can't be managed.
+func (s *Scanner) managed(ctx context.Context) ([]*model.AdminOrganizationUserScheme, error) {
+
+	domains, err := s.domains(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := paging.Users(ctx, s.organization, s.organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var managed []*model.AdminOrganizationUserScheme
+	for _, user := range users {
+
+		if user.AccountType != "" && user.AccountType != atlassianAccountType {
+			continue
+		}
+
+		at := strings.LastIndex(user.Email, "@")
+		if at == -1 || !domains[strings.ToLower(user.Email[at+1:])] {
+			continue
+		}
+
+		managed = append(managed, user)
+	}
+
+	return managed, nil
+}
+
+// domains returns the domains verified by the organization, lower-cased.
+func (s *Scanner) domains(ctx context.Context) (map[string]bool, error) {
+
+	var (
+		domains = make(map[string]bool)
+		cursor  string
+	)
+
+	for {
+
+		page, _, err := s.organization.Domains(ctx, s.organizationID, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, domain := range page.Data {
+
+			if domain.Attributes == nil || domain.Attributes.Claim == nil ||
+				!strings.EqualFold(domain.Attributes.Claim.Status, verifiedClaimStatus) {
+				continue
+			}
+
+			domains[strings.ToLower(domain.Attributes.Name)] = true
+		}
+
+		if page.Links == nil || page.Links.Next == "" {
+			return domains, nil
+		}
+
+		next := paging.Cursor(page.Links.Next)
+		if next == cursor {
+			return domains, nil
+		}
+
+		cursor = next
+	}
+}
@@ -0,0 +1,153 @@
+package tokens
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/admin"
+	"github.com/stretchr/testify/assert"
+)
+
+type organizationFake struct {
+	admin.OrganizationConnector
+	users []*model.AdminOrganizationUserScheme
+}
+
+func (o *organizationFake) Users(ctx context.Context, organizationID, cursor string) (*model.OrganizationUserPageScheme,
+	*model.ResponseScheme, error) {
+	return &model.OrganizationUserPageScheme{Data: o.users}, nil, nil
+}
+
+func (o *organizationFake) Domains(ctx context.Context, organizationID, cursor string) (*model.OrganizationDomainPageScheme,
+	*model.ResponseScheme, error) {
+
+	return &model.OrganizationDomainPageScheme{Data: []*model.OrganizationDomainModelScheme{
+		{Attributes: &model.OrganizationDomainModelAttributesScheme{Name: "Example.com",
+			Claim: &model.OrganizationDomainModelAttributeClaimScheme{Type: "dns", Status: "VERIFIED"}}},
+		{Attributes: &model.OrganizationDomainModelAttributesScheme{Name: "example.org",
+			Claim: &model.OrganizationDomainModelAttributeClaimScheme{Type: "dns", Status: "UNVERIFIED"}}},
+	}}, nil, nil
+}
+
+type tokenFake struct {
+	admin.UserTokenConnector
+
+	mu      sync.Mutex
+	tokens  map[string][]*model.UserTokensScheme
+	deleted []string
+}
+
+func (t *tokenFake) Gets(ctx context.Context, accountID string) ([]*model.UserTokensScheme, *model.ResponseScheme, error) {
+
+	tokens, ok := t.tokens[accountID]
+	if !ok {
+		return nil, nil, errors.New("forbidden")
+	}
+
+	return tokens, nil, nil
+}
+
+func (t *tokenFake) Delete(ctx context.Context, accountID, tokenID string) (*model.ResponseScheme, error) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.deleted = append(t.deleted, tokenID)
+	return nil, nil
+}
+
+func newFakes() (*organizationFake, *tokenFake) {
+
+	organization := &organizationFake{users: []*model.AdminOrganizationUserScheme{
+		{AccountID: "alice", Email: "alice@example.com"},
+		{AccountID: "bob", Email: "bob@example.com"},
+		{AccountID: "bot", Email: "bot@example.com"},
+		{AccountID: "guest", Email: "guest@example.org"},
+		{AccountID: "app", AccountType: "app", Email: "app@example.com"},
+	}}
+
+	tokens := &tokenFake{tokens: map[string][]*model.UserTokensScheme{
+		"alice": {
+			{ID: "old", Label: "ci", CreatedAt: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+				LastAccess: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)},
+			{ID: "fresh", Label: "cli", CreatedAt: time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+				LastAccess: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		"bob": {
+			{ID: "never-used", Label: "test", CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		"guest": {
+			{ID: "unmanaged", Label: "test", CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}}
+
+	return organization, tokens
+}
+
+func TestScanner(t *testing.T) {
+
+	organization, tokens := newFakes()
+
+	scanner, err := NewScanner(organization, tokens, "org-id", &Options{
+		MaxAge:             365 * 24 * time.Hour,
+		MaxUnused:          90 * 24 * time.Hour,
+		ExcludedAccountIDs: []string{"bot"},
+		Now:                func() time.Time { return time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC) },
+	})
+	assert.NoError(t, err)
+
+	report, err := scanner.Scan(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, report.Accounts, 2)
+	assert.Equal(t, 2, report.Flagged())
+
+	assert.Equal(t, []Finding{ExpiredFinding}, report.Accounts[0].Tokens[0].Findings)
+	assert.Empty(t, report.Accounts[0].Tokens[1].Findings)
+	assert.Equal(t, []Finding{UnusedFinding}, report.Accounts[1].Tokens[0].Findings)
+
+	assert.NoError(t, scanner.Revoke(context.Background(), report))
+	assert.ElementsMatch(t, []string{"old", "never-used"}, tokens.deleted)
+	assert.Equal(t, RevokedAction, report.Accounts[0].Tokens[0].Action)
+
+	buffer := new(bytes.Buffer)
+	assert.NoError(t, report.WriteCSV(buffer))
+	assert.Equal(t, `account_id,name,email,token_id,label,created_at,last_access,findings,action,error
+alice,,alice@example.com,old,ci,2022-01-01T00:00:00Z,2023-05-01T00:00:00Z,expired,revoked,
+alice,,alice@example.com,fresh,cli,2023-04-01T00:00:00Z,2023-05-01T00:00:00Z,,,
+bob,,bob@example.com,never-used,test,2023-01-01T00:00:00Z,,unused,revoked,
+`, buffer.String())
+}
+
+func TestScanner_DryRun(t *testing.T) {
+
+	organization, tokens := newFakes()
+
+	scanner, err := NewScanner(organization, tokens, "org-id", &Options{MaxUnused: 90 * 24 * time.Hour, DryRun: true,
+		Now: func() time.Time { return time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC) }})
+	assert.NoError(t, err)
+
+	report, err := scanner.Scan(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "forbidden", report.Accounts[2].Error)
+
+	assert.NoError(t, scanner.Revoke(context.Background(), report))
+	assert.Empty(t, tokens.deleted)
+	assert.Equal(t, WouldRevokeAction, report.Accounts[1].Tokens[0].Action)
+}
+
+func TestNewScanner(t *testing.T) {
+
+	_, err := NewScanner(nil, &tokenFake{}, "org-id", &Options{MaxAge: time.Hour})
+	assert.EqualError(t, err, ErrNoConnectorError.Error())
+
+	_, err = NewScanner(&organizationFake{}, &tokenFake{}, "", &Options{MaxAge: time.Hour})
+	assert.EqualError(t, err, model.ErrNoAdminOrganizationError.Error())
+
+	_, err = NewScanner(&organizationFake{}, &tokenFake{}, "org-id", nil)
+	assert.EqualError(t, err, ErrNoPolicyError.Error())
+}
@@ -57,6 +57,8 @@ type Client struct {
 	ObjectSchema        *internal.ObjectSchemaService
 	ObjectType          *internal.ObjectTypeService
 	ObjectTypeAttribute *internal.ObjectTypeAttributeService
+
+	middlewares []common.Middleware
 }
 
 func (c *Client) NewRequest(ctx context.Context, method, urlStr, type_ string, body interface{}) (*http.Request, error) {
@@ -100,9 +102,16 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr, type_ string, b
 	return req, nil
 }
 
+// Use appends middlewares to the pipeline the requests are sent through, e.g: to log or trace them.
+//
+// The middlewares must be configured before the client is used.
+func (c *Client) Use(middlewares ...common.Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
 func (c *Client) Call(request *http.Request, structure interface{}) (*model.ResponseScheme, error) {
 
-	response, err := c.HTTP.Do(request)
+	response, err := common.Chain(c.HTTP, c.middlewares...)(request)
 	if err != nil {
 		return nil, err
 	}
@@ -55,6 +55,8 @@ type Client struct {
 	Site      *url.URL
 	Auth      common.Authentication
 	Workspace *internal.WorkspaceService
+
+	middlewares []common.Middleware
 }
 
 func (c *Client) NewRequest(ctx context.Context, method, urlStr, type_ string, body interface{}) (*http.Request, error) {
@@ -111,9 +113,16 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr, type_ string, b
 	return req, nil
 }
 
+// Use appends middlewares to the pipeline the requests are sent through, e.g: to log or trace them.
+//
+// The middlewares must be configured before the client is used.
+func (c *Client) Use(middlewares ...common.Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
 func (c *Client) Call(request *http.Request, structure interface{}) (*models.ResponseScheme, error) {
 
-	response, err := c.HTTP.Do(request)
+	response, err := common.Chain(c.HTTP, c.middlewares...)(request)
 	if err != nil {
 		return nil, err
 	}
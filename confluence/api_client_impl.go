@@ -49,7 +49,8 @@ func New(httpClient common.HttpClient, site string) (*Client, error) {
 		Restriction: internal.NewRestrictionService(client,
 			internal.NewRestrictionOperationService(client,
 				internal.NewRestrictionOperationGroupService(client),
-				internal.NewRestrictionOperationUserService(client))),
+				internal.NewRestrictionOperationUserService(client)),
+			internal.NewRestrictionAuditService(client)),
 	}
 
 	client.Auth = internal.NewAuthenticationService(client)
@@ -73,6 +74,8 @@ type Client struct {
 	Search    *internal.SearchService
 	LongTask  *internal.TaskService
 	Analytics *internal.AnalyticsService
+
+	middlewares []common.Middleware
 }
 
 func (c *Client) NewRequest(ctx context.Context, method, urlStr, type_ string, body interface{}) (*http.Request, error) {
@@ -129,9 +132,16 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr, type_ string, b
 	return req, nil
 }
 
+// Use appends middlewares to the pipeline the requests are sent through, e.g: to log or trace them.
+//
+// The middlewares must be configured before the client is used.
+func (c *Client) Use(middlewares ...common.Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
 func (c *Client) Call(request *http.Request, structure interface{}) (*models.ResponseScheme, error) {
 
-	response, err := c.HTTP.Do(request)
+	response, err := common.Chain(c.HTTP, c.middlewares...)(request)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,196 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service"
+	"github.com/ctreminiom/go-atlassian/service/confluence"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// NewContentPropertyV2Service returns a new Confluence V2 content property service
+// for the content type provided, e.g: whiteboards or databases
+func NewContentPropertyV2Service(client service.Connector, contentType string) *ContentPropertyV2Service {
+
+	return &ContentPropertyV2Service{
+		internalClient: &internalContentPropertyV2Impl{c: client, contentType: contentType},
+	}
+}
+
+type ContentPropertyV2Service struct {
+	internalClient confluence.ContentPropertyV2Connector
+}
+
+// Gets returns all content properties of a piece of content.
+//
+// GET /wiki/api/v2/{content-type}/{id}/properties
+func (p *ContentPropertyV2Service) Gets(ctx context.Context, contentID int, key, sort, cursor string, limit int) (*model.ContentPropertyV2ChunkScheme, *model.ResponseScheme, error) {
+	return p.internalClient.Gets(ctx, contentID, key, sort, cursor, limit)
+}
+
+// Get returns a specific content property of a piece of content.
+//
+// GET /wiki/api/v2/{content-type}/{id}/properties/{property-id}
+func (p *ContentPropertyV2Service) Get(ctx context.Context, contentID, propertyID int) (*model.ContentPropertyV2Scheme, *model.ResponseScheme, error) {
+	return p.internalClient.Get(ctx, contentID, propertyID)
+}
+
+// Create creates a new content property for a piece of content.
+//
+// POST /wiki/api/v2/{content-type}/{id}/properties
+func (p *ContentPropertyV2Service) Create(ctx context.Context, contentID int, payload *model.ContentPropertyV2PayloadScheme) (*model.ContentPropertyV2Scheme, *model.ResponseScheme, error) {
+	return p.internalClient.Create(ctx, contentID, payload)
+}
+
+// Update updates a content property of a piece of content.
+//
+// PUT /wiki/api/v2/{content-type}/{id}/properties/{property-id}
+func (p *ContentPropertyV2Service) Update(ctx context.Context, contentID, propertyID int, payload *model.ContentPropertyV2PayloadScheme) (*model.ContentPropertyV2Scheme, *model.ResponseScheme, error) {
+	return p.internalClient.Update(ctx, contentID, propertyID, payload)
+}
+
+// Delete deletes a content property of a piece of content.
+//
+// DELETE /wiki/api/v2/{content-type}/{id}/properties/{property-id}
+func (p *ContentPropertyV2Service) Delete(ctx context.Context, contentID, propertyID int) (*model.ResponseScheme, error) {
+	return p.internalClient.Delete(ctx, contentID, propertyID)
+}
+
+type internalContentPropertyV2Impl struct {
+	c           service.Connector
+	contentType string
+}
+
+func (i *internalContentPropertyV2Impl) Gets(ctx context.Context, contentID int, key, sort, cursor string, limit int) (*model.ContentPropertyV2ChunkScheme, *model.ResponseScheme, error) {
+
+	if contentID == 0 {
+		return nil, nil, model.ErrNoContentIDError
+	}
+
+	query := url.Values{}
+	query.Add("limit", strconv.Itoa(limit))
+
+	if key != "" {
+		query.Add("key", key)
+	}
+
+	if sort != "" {
+		query.Add("sort", sort)
+	}
+
+	if cursor != "" {
+		query.Add("cursor", cursor)
+	}
+
+	endpoint := fmt.Sprintf("wiki/api/v2/%v/%v/properties?%v", i.contentType, contentID, query.Encode())
+
+	request, err := i.c.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chunk := new(model.ContentPropertyV2ChunkScheme)
+	response, err := i.c.Call(request, chunk)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return chunk, response, nil
+}
+
+func (i *internalContentPropertyV2Impl) Get(ctx context.Context, contentID, propertyID int) (*model.ContentPropertyV2Scheme, *model.ResponseScheme, error) {
+
+	if contentID == 0 {
+		return nil, nil, model.ErrNoContentIDError
+	}
+
+	if propertyID == 0 {
+		return nil, nil, model.ErrNoContentPropertyIDError
+	}
+
+	endpoint := fmt.Sprintf("wiki/api/v2/%v/%v/properties/%v", i.contentType, contentID, propertyID)
+
+	request, err := i.c.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	property := new(model.ContentPropertyV2Scheme)
+	response, err := i.c.Call(request, property)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return property, response, nil
+}
+
+func (i *internalContentPropertyV2Impl) Create(ctx context.Context, contentID int, payload *model.ContentPropertyV2PayloadScheme) (*model.ContentPropertyV2Scheme, *model.ResponseScheme, error) {
+
+	if contentID == 0 {
+		return nil, nil, model.ErrNoContentIDError
+	}
+
+	endpoint := fmt.Sprintf("wiki/api/v2/%v/%v/properties", i.contentType, contentID)
+
+	request, err := i.c.NewRequest(ctx, http.MethodPost, endpoint, "", payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	property := new(model.ContentPropertyV2Scheme)
+	response, err := i.c.Call(request, property)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return property, response, nil
+}
+
+func (i *internalContentPropertyV2Impl) Update(ctx context.Context, contentID, propertyID int, payload *model.ContentPropertyV2PayloadScheme) (*model.ContentPropertyV2Scheme, *model.ResponseScheme, error) {
+
+	if contentID == 0 {
+		return nil, nil, model.ErrNoContentIDError
+	}
+
+	if propertyID == 0 {
+		return nil, nil, model.ErrNoContentPropertyIDError
+	}
+
+	endpoint := fmt.Sprintf("wiki/api/v2/%v/%v/properties/%v", i.contentType, contentID, propertyID)
+
+	request, err := i.c.NewRequest(ctx, http.MethodPut, endpoint, "", payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	property := new(model.ContentPropertyV2Scheme)
+	response, err := i.c.Call(request, property)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return property, response, nil
+}
+
+func (i *internalContentPropertyV2Impl) Delete(ctx context.Context, contentID, propertyID int) (*model.ResponseScheme, error) {
+
+	if contentID == 0 {
+		return nil, model.ErrNoContentIDError
+	}
+
+	if propertyID == 0 {
+		return nil, model.ErrNoContentPropertyIDError
+	}
+
+	endpoint := fmt.Sprintf("wiki/api/v2/%v/%v/properties/%v", i.contentType, contentID, propertyID)
+
+	request, err := i.c.NewRequest(ctx, http.MethodDelete, endpoint, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.c.Call(request, nil)
+}
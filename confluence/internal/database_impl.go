@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service"
+	"github.com/ctreminiom/go-atlassian/service/confluence"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// NewDatabaseService returns a new Confluence V2 Database service
+func NewDatabaseService(client service.Connector, property *ContentPropertyV2Service) *DatabaseService {
+
+	return &DatabaseService{
+		internalClient: &internalDatabaseImpl{c: client},
+		Property:       property,
+	}
+}
+
+type DatabaseService struct {
+	internalClient confluence.DatabaseConnector
+	Property       *ContentPropertyV2Service
+}
+
+// Create creates a database in the space.
+//
+// POST /wiki/api/v2/databases
+//
+// https://docs.go-atlassian.io/confluence-cloud/v2/database#create-database
+func (d *DatabaseService) Create(ctx context.Context, payload *model.DatabaseCreatePayloadScheme, private bool) (*model.DatabaseScheme, *model.ResponseScheme, error) {
+	return d.internalClient.Create(ctx, payload, private)
+}
+
+// Get returns a specific database.
+//
+// GET /wiki/api/v2/databases/{id}
+//
+// https://docs.go-atlassian.io/confluence-cloud/v2/database#get-database-by-id
+func (d *DatabaseService) Get(ctx context.Context, databaseID int) (*model.DatabaseScheme, *model.ResponseScheme, error) {
+	return d.internalClient.Get(ctx, databaseID)
+}
+
+// Delete deletes a database by id.
+//
+// Deleting a database moves the database to the trash, where it can be restored later.
+//
+// DELETE /wiki/api/v2/databases/{id}
+//
+// https://docs.go-atlassian.io/confluence-cloud/v2/database#delete-database
+func (d *DatabaseService) Delete(ctx context.Context, databaseID int) (*model.ResponseScheme, error) {
+	return d.internalClient.Delete(ctx, databaseID)
+}
+
+// Ancestors returns all ancestors for a given database by ID in top-to-bottom order.
+//
+// That is, the highest ancestor is the first item in the response payload.
+//
+// GET /wiki/api/v2/databases/{id}/ancestors
+//
+// https://docs.go-atlassian.io/confluence-cloud/v2/database#get-ancestors-of-database
+func (d *DatabaseService) Ancestors(ctx context.Context, databaseID, limit int) (*model.ContentAncestorChunkScheme, *model.ResponseScheme, error) {
+	return d.internalClient.Ancestors(ctx, databaseID, limit)
+}
+
+type internalDatabaseImpl struct {
+	c service.Connector
+}
+
+func (i *internalDatabaseImpl) Create(ctx context.Context, payload *model.DatabaseCreatePayloadScheme, private bool) (*model.DatabaseScheme, *model.ResponseScheme, error) {
+
+	endpoint := "wiki/api/v2/databases"
+
+	if private {
+		query := url.Values{}
+		query.Add("private", "true")
+
+		endpoint = fmt.Sprintf("%v?%v", endpoint, query.Encode())
+	}
+
+	request, err := i.c.NewRequest(ctx, http.MethodPost, endpoint, "", payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	database := new(model.DatabaseScheme)
+	response, err := i.c.Call(request, database)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return database, response, nil
+}
+
+func (i *internalDatabaseImpl) Get(ctx context.Context, databaseID int) (*model.DatabaseScheme, *model.ResponseScheme, error) {
+
+	if databaseID == 0 {
+		return nil, nil, model.ErrNoDatabaseIDError
+	}
+
+	endpoint := fmt.Sprintf("wiki/api/v2/databases/%v", databaseID)
+
+	request, err := i.c.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	database := new(model.DatabaseScheme)
+	response, err := i.c.Call(request, database)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return database, response, nil
+}
+
+func (i *internalDatabaseImpl) Delete(ctx context.Context, databaseID int) (*model.ResponseScheme, error) {
+
+	if databaseID == 0 {
+		return nil, model.ErrNoDatabaseIDError
+	}
+
+	endpoint := fmt.Sprintf("wiki/api/v2/databases/%v", databaseID)
+
+	request, err := i.c.NewRequest(ctx, http.MethodDelete, endpoint, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.c.Call(request, nil)
+}
+
+func (i *internalDatabaseImpl) Ancestors(ctx context.Context, databaseID, limit int) (*model.ContentAncestorChunkScheme, *model.ResponseScheme, error) {
+
+	if databaseID == 0 {
+		return nil, nil, model.ErrNoDatabaseIDError
+	}
+
+	query := url.Values{}
+	query.Add("limit", strconv.Itoa(limit))
+
+	endpoint := fmt.Sprintf("wiki/api/v2/databases/%v/ancestors?%v", databaseID, query.Encode())
+
+	request, err := i.c.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ancestors := new(model.ContentAncestorChunkScheme)
+	response, err := i.c.Call(request, ancestors)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return ancestors, response, nil
+}
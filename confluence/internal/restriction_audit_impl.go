@@ -0,0 +1,450 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service"
+	"github.com/ctreminiom/go-atlassian/service/confluence"
+	"net/http"
+	"net/url"
+)
+
+const defaultRestrictionAuditMaxResults = 50
+
+func NewRestrictionAuditService(client service.Connector) *RestrictionAuditService {
+
+	return &RestrictionAuditService{
+		internalClient: &internalRestrictionAuditImpl{
+			c:           client,
+			content:     &internalContentImpl{c: client},
+			restriction: &internalRestrictionImpl{c: client},
+			operation:   &internalRestrictionOperationImpl{c: client},
+		},
+	}
+}
+
+type RestrictionAuditService struct {
+	internalClient confluence.RestrictionAuditConnector
+}
+
+// Space walks all the pages of a space and resolves the effective view and edit restrictions of every page.
+//
+// View restrictions are inherited from the ancestors, and the restricted users and groups are checked,
+// so pages restricted to departed users or to empty groups are flagged, the findings of the
+// inherited restrictions carry the ID of the ancestor setting them.
+//
+// GET /wiki/rest/api/content
+//
+// GET /wiki/rest/api/content/{id}/restriction
+//
+// GET /wiki/rest/api/content/{id}/restriction/byOperation/{operationKey}
+func (r *RestrictionAuditService) Space(ctx context.Context, spaceKey string, options *model.RestrictionAuditOptionsScheme) (*model.RestrictionAuditReportScheme, *model.ResponseScheme, error) {
+	return r.internalClient.Space(ctx, spaceKey, options)
+}
+
+type internalRestrictionAuditImpl struct {
+	c           service.Connector
+	content     confluence.ContentConnector
+	restriction confluence.ContentRestrictionConnector
+	operation   confluence.RestrictionOperationConnector
+}
+
+type restrictionAuditState struct {
+	options      *model.RestrictionAuditOptionsScheme
+	pages        map[string]*model.ContentScheme
+	restrictions map[string]*model.RestrictionAuditPageScheme
+	users        map[string]bool
+	groups       map[string]string
+}
+
+func (i *internalRestrictionAuditImpl) Space(ctx context.Context, spaceKey string, options *model.RestrictionAuditOptionsScheme) (*model.RestrictionAuditReportScheme, *model.ResponseScheme, error) {
+
+	if spaceKey == "" {
+		return nil, nil, model.ErrNoSpaceKeyError
+	}
+
+	// The options are copied, so the defaults aren't written on the options of the caller.
+	config := model.RestrictionAuditOptionsScheme{}
+	if options != nil {
+		config = *options
+	}
+
+	if config.MaxResults <= 0 {
+		config.MaxResults = defaultRestrictionAuditMaxResults
+	}
+
+	options = &config
+
+	state := &restrictionAuditState{
+		options:      options,
+		pages:        make(map[string]*model.ContentScheme),
+		restrictions: make(map[string]*model.RestrictionAuditPageScheme),
+		users:        make(map[string]bool),
+		groups:       make(map[string]string),
+	}
+
+	for _, accountID := range options.DepartedAccountIDs {
+		state.users[accountID] = true
+	}
+
+	pages, response, err := i.walk(ctx, spaceKey, state)
+	if err != nil {
+		return nil, response, err
+	}
+
+	report := &model.RestrictionAuditReportScheme{SpaceKey: spaceKey}
+
+	for _, page := range pages {
+
+		own, response, err := i.resolve(ctx, page.ID, state)
+		if err != nil {
+			return nil, response, err
+		}
+
+		audit := &model.RestrictionAuditPageScheme{
+			ID:     page.ID,
+			Title:  page.Title,
+			Read:   own.Read,
+			Update: own.Update,
+		}
+
+		// Confluence only propagates the view restrictions to the descendants,
+		// the edit restrictions apply to the restricted page itself.
+		for _, ancestor := range page.Ancestors {
+
+			audit.AncestorIDs = append(audit.AncestorIDs, ancestor.ID)
+
+			inherited, response, err := i.resolve(ctx, ancestor.ID, state)
+			if err != nil {
+				return nil, response, err
+			}
+
+			if inherited.Read.IsEmpty() {
+				continue
+			}
+
+			audit.Inherited = append(audit.Inherited, &model.RestrictionAuditInheritanceScheme{
+				SourceID:    ancestor.ID,
+				SourceTitle: ancestor.Title,
+				Read:        inherited.Read,
+			})
+		}
+
+		if !options.SkipSubjectValidation {
+
+			findings, response, err := i.validate(ctx, "", own.Read, own.Update, state)
+			if err != nil {
+				return nil, response, err
+			}
+
+			audit.Findings = findings
+
+			// The inherited view restrictions gate the page too, their findings are attributed to the ancestor.
+			for _, inherited := range audit.Inherited {
+
+				findings, response, err := i.validate(ctx, inherited.SourceID, inherited.Read, nil, state)
+				if err != nil {
+					return nil, response, err
+				}
+
+				audit.Findings = append(audit.Findings, findings...)
+			}
+		}
+
+		report.Pages = append(report.Pages, audit)
+	}
+
+	return report, nil, nil
+}
+
+// walk returns all the pages of the space, including their ancestors.
+func (i *internalRestrictionAuditImpl) walk(ctx context.Context, spaceKey string, state *restrictionAuditState) ([]*model.ContentScheme, *model.ResponseScheme, error) {
+
+	options := &model.GetContentOptionsScheme{
+		ContextType: "page",
+		SpaceKey:    spaceKey,
+		Status:      state.options.Status,
+		Expand:      []string{"ancestors"},
+	}
+
+	var pages []*model.ContentScheme
+	for startAt := 0; ; {
+
+		chunk, response, err := i.content.Gets(ctx, options, startAt, state.options.MaxResults)
+		if err != nil {
+			return nil, response, err
+		}
+
+		for _, page := range chunk.Results {
+			state.pages[page.ID] = page
+			pages = append(pages, page)
+		}
+
+		// Confluence lowers the page size when the ancestors are expanded,
+		// so the pages are followed until there's no next link.
+		if chunk.Links == nil || chunk.Links.Next == "" || len(chunk.Results) == 0 {
+			break
+		}
+
+		startAt += len(chunk.Results)
+	}
+
+	return pages, nil, nil
+}
+
+// resolve returns the restrictions set directly on a page, the results are cached, so
+// the ancestors shared by several pages are only fetched once.
+func (i *internalRestrictionAuditImpl) resolve(ctx context.Context, contentID string, state *restrictionAuditState) (*model.RestrictionAuditPageScheme, *model.ResponseScheme, error) {
+
+	if cached, ok := state.restrictions[contentID]; ok {
+		return cached, nil, nil
+	}
+
+	expand := []string{"restrictions.user", "restrictions.group"}
+
+	page, response, err := i.restriction.Gets(ctx, contentID, expand, 0, state.options.MaxResults)
+	if err != nil {
+		return nil, response, err
+	}
+
+	resolved := &model.RestrictionAuditPageScheme{ID: contentID}
+	if content, ok := state.pages[contentID]; ok {
+		resolved.Title = content.Title
+	}
+
+	for _, result := range page.Results {
+
+		if result.Restrictions == nil {
+			continue
+		}
+
+		operation := &model.RestrictionAuditOperationScheme{}
+
+		users, response, err := i.users(ctx, contentID, result, state)
+		if err != nil {
+			return nil, response, err
+		}
+
+		for _, user := range users {
+			operation.Users = append(operation.Users, &model.RestrictionAuditSubjectScheme{
+				Type: "user",
+				ID:   user.AccountID,
+				Name: user.DisplayName,
+			})
+		}
+
+		groups, response, err := i.groups(ctx, contentID, result, state)
+		if err != nil {
+			return nil, response, err
+		}
+
+		for _, group := range groups {
+			operation.Groups = append(operation.Groups, &model.RestrictionAuditSubjectScheme{
+				Type: "group",
+				ID:   group.ID,
+				Name: group.Name,
+			})
+		}
+
+		switch result.Operation {
+		case "read":
+			resolved.Read = operation
+		case "update":
+			resolved.Update = operation
+		}
+	}
+
+	state.restrictions[contentID] = resolved
+	return resolved, nil, nil
+}
+
+// users returns the users of an operation restriction, the next pages of users are
+// fetched through the operation while the restriction links to them.
+func (i *internalRestrictionAuditImpl) users(ctx context.Context, contentID string, restriction *model.ContentRestrictionScheme, state *restrictionAuditState) ([]*model.ContentUserScheme, *model.ResponseScheme, error) {
+
+	page := restriction.Restrictions.User
+	if page == nil {
+		return nil, nil, nil
+	}
+
+	users := page.Results
+	for page.Links != nil && page.Links.Next != "" && len(page.Results) != 0 {
+
+		next, response, err := i.operation.Get(ctx, contentID, restriction.Operation, []string{"restrictions.user"}, len(users), state.options.MaxResults)
+		if err != nil {
+			return nil, response, err
+		}
+
+		if next.Restrictions == nil || next.Restrictions.User == nil {
+			break
+		}
+
+		page = next.Restrictions.User
+		users = append(users, page.Results...)
+	}
+
+	return users, nil, nil
+}
+
+// groups returns the groups of an operation restriction, the next pages of groups are
+// fetched through the operation while the restriction links to them.
+func (i *internalRestrictionAuditImpl) groups(ctx context.Context, contentID string, restriction *model.ContentRestrictionScheme, state *restrictionAuditState) ([]*model.SpaceGroupScheme, *model.ResponseScheme, error) {
+
+	page := restriction.Restrictions.Group
+	if page == nil {
+		return nil, nil, nil
+	}
+
+	groups := page.Results
+	for page.Links != nil && page.Links.Next != "" && len(page.Results) != 0 {
+
+		next, response, err := i.operation.Get(ctx, contentID, restriction.Operation, []string{"restrictions.group"}, len(groups), state.options.MaxResults)
+		if err != nil {
+			return nil, response, err
+		}
+
+		if next.Restrictions == nil || next.Restrictions.Group == nil {
+			break
+		}
+
+		page = next.Restrictions.Group
+		groups = append(groups, page.Results...)
+	}
+
+	return groups, nil, nil
+}
+
+// validate flags the users and groups of the restrictions that can no longer access the page, the
+// source is the ancestor setting the restrictions, empty for the restrictions of the page itself.
+func (i *internalRestrictionAuditImpl) validate(ctx context.Context, sourceID string, read, update *model.RestrictionAuditOperationScheme,
+	state *restrictionAuditState) ([]*model.RestrictionAuditFindingScheme, *model.ResponseScheme, error) {
+
+	var findings []*model.RestrictionAuditFindingScheme
+
+	operations := []struct {
+		name        string
+		restriction *model.RestrictionAuditOperationScheme
+	}{
+		{"read", read},
+		{"update", update},
+	}
+
+	for _, operation := range operations {
+
+		if operation.restriction.IsEmpty() {
+			continue
+		}
+
+		for _, user := range operation.restriction.Users {
+
+			departed, response, err := i.departed(ctx, user.ID, state)
+			if err != nil {
+				return nil, response, err
+			}
+
+			if departed {
+				findings = append(findings, &model.RestrictionAuditFindingScheme{
+					Type:      model.RestrictionAuditDepartedUserFinding,
+					Operation: operation.name,
+					Subject:   user,
+					SourceID:  sourceID,
+				})
+			}
+		}
+
+		for _, group := range operation.restriction.Groups {
+
+			finding, response, err := i.group(ctx, group, state)
+			if err != nil {
+				return nil, response, err
+			}
+
+			if finding != "" {
+				findings = append(findings, &model.RestrictionAuditFindingScheme{
+					Type:      finding,
+					Operation: operation.name,
+					Subject:   group,
+					SourceID:  sourceID,
+				})
+			}
+		}
+	}
+
+	return findings, nil, nil
+}
+
+func (i *internalRestrictionAuditImpl) departed(ctx context.Context, accountID string, state *restrictionAuditState) (bool, *model.ResponseScheme, error) {
+
+	if departed, ok := state.users[accountID]; ok {
+		return departed, nil, nil
+	}
+
+	query := url.Values{}
+	query.Add("accountId", accountID)
+
+	endpoint := fmt.Sprintf("wiki/rest/api/user?%v", query.Encode())
+
+	request, err := i.c.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return false, nil, err
+	}
+
+	user := new(model.ContentUserScheme)
+	response, err := i.c.Call(request, user)
+	if err != nil && !errors.Is(err, model.ErrNotFound) {
+		return false, response, err
+	}
+
+	departed := err != nil || user.Type == "unknown"
+	state.users[accountID] = departed
+
+	return departed, nil, nil
+}
+
+func (i *internalRestrictionAuditImpl) group(ctx context.Context, group *model.RestrictionAuditSubjectScheme, state *restrictionAuditState) (string, *model.ResponseScheme, error) {
+
+	key := group.ID
+	if key == "" {
+		key = group.Name
+	}
+
+	if finding, ok := state.groups[key]; ok {
+		return finding, nil, nil
+	}
+
+	query := url.Values{}
+	query.Add("start", "0")
+	query.Add("limit", "1")
+
+	var endpoint string
+	if group.ID != "" {
+		endpoint = fmt.Sprintf("wiki/rest/api/group/%v/membersByGroupId?%v", group.ID, query.Encode())
+	} else {
+		query.Add("name", group.Name)
+		endpoint = fmt.Sprintf("wiki/rest/api/group/member?%v", query.Encode())
+	}
+
+	request, err := i.c.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	members := new(model.UserPermissionScheme)
+	response, err := i.c.Call(request, members)
+	if err != nil && !errors.Is(err, model.ErrNotFound) {
+		return "", response, err
+	}
+
+	var finding string
+	switch {
+	case err != nil:
+		finding = model.RestrictionAuditMissingGroupFinding
+	case len(members.Results) == 0:
+		finding = model.RestrictionAuditEmptyGroupFinding
+	}
+
+	state.groups[key] = finding
+	return finding, nil, nil
+}
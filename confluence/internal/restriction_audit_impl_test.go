@@ -0,0 +1,365 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service"
+	"github.com/ctreminiom/go-atlassian/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func Test_internalRestrictionAuditImpl_Space(t *testing.T) {
+
+	type fields struct {
+		c service.Connector
+	}
+
+	type args struct {
+		ctx      context.Context
+		spaceKey string
+		options  *model.RestrictionAuditOptionsScheme
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		want    *model.RestrictionAuditReportScheme
+		wantErr bool
+		Err     error
+	}{
+		{
+			name: "when the parameters are correct",
+			args: args{
+				ctx:      context.Background(),
+				spaceKey: "DUMMY",
+				options: &model.RestrictionAuditOptionsScheme{
+					MaxResults: 2,
+				},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"wiki/rest/api/content?expand=ancestors&limit=2&spaceKey=DUMMY&start=0&type=page",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.ContentPageScheme{}).
+					Run(func(arguments mock.Arguments) {
+						page := arguments.Get(1).(*model.ContentPageScheme)
+						page.Results = []*model.ContentScheme{{ID: "100", Title: "Parent"}}
+						page.Links = &model.LinkScheme{Next: "/rest/api/content?start=1"}
+					}).
+					Return(&model.ResponseScheme{}, nil).
+					Once()
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"wiki/rest/api/content?expand=ancestors&limit=2&spaceKey=DUMMY&start=1&type=page",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.ContentPageScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.ContentPageScheme).Results = []*model.ContentScheme{
+							{ID: "200", Title: "Child", Ancestors: []*model.ContentScheme{{ID: "100", Title: "Parent"}}},
+						}
+					}).
+					Return(&model.ResponseScheme{}, nil).
+					Once()
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"wiki/rest/api/content/100/restriction?expand=restrictions.user%2Crestrictions.group&limit=2&start=0",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.ContentRestrictionPageScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.ContentRestrictionPageScheme).Results = []*model.ContentRestrictionScheme{
+							{
+								Operation: "read",
+								Restrictions: &model.ContentRestrictionDetailScheme{
+									User: &model.UserPermissionScheme{
+										Results: []*model.ContentUserScheme{{AccountID: "account-departed", DisplayName: "Former user"}},
+										Links:   &model.LinkScheme{Next: "/rest/api/content/100/restriction/byOperation/read/user?start=1"},
+									},
+									Group: &model.GroupPermissionScheme{
+										Results: []*model.SpaceGroupScheme{{ID: "group-empty", Name: "empty"}},
+									},
+								},
+							},
+							{Operation: "update"},
+						}
+					}).
+					Return(&model.ResponseScheme{}, nil).
+					Once()
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"wiki/rest/api/content/100/restriction/byOperation/read?expand=restrictions.user&limit=2&start=1",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.ContentRestrictionScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.ContentRestrictionScheme).Restrictions = &model.ContentRestrictionDetailScheme{
+							User: &model.UserPermissionScheme{
+								Results: []*model.ContentUserScheme{{AccountID: "account-id", DisplayName: "Current user"}},
+							},
+						}
+					}).
+					Return(&model.ResponseScheme{}, nil).
+					Once()
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"wiki/rest/api/user?accountId=account-departed",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.ContentUserScheme{}).
+					Return(&model.ResponseScheme{}, model.ErrNotFound).
+					Once()
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"wiki/rest/api/user?accountId=account-id",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.ContentUserScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.ContentUserScheme).Type = "known"
+					}).
+					Return(&model.ResponseScheme{}, nil).
+					Once()
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"wiki/rest/api/group/group-empty/membersByGroupId?limit=1&start=0",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.UserPermissionScheme{}).
+					Return(&model.ResponseScheme{}, nil).
+					Once()
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"wiki/rest/api/content/200/restriction?expand=restrictions.user%2Crestrictions.group&limit=2&start=0",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.ContentRestrictionPageScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.ContentRestrictionPageScheme).Results = []*model.ContentRestrictionScheme{
+							{Operation: "read"},
+							{
+								Operation: "update",
+								Restrictions: &model.ContentRestrictionDetailScheme{
+									Group: &model.GroupPermissionScheme{
+										Results: []*model.SpaceGroupScheme{{ID: "group-editors", Name: "editors"}},
+									},
+								},
+							},
+						}
+					}).
+					Return(&model.ResponseScheme{}, nil).
+					Once()
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"wiki/rest/api/group/group-editors/membersByGroupId?limit=1&start=0",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.UserPermissionScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.UserPermissionScheme).Results = []*model.ContentUserScheme{{AccountID: "account-id"}}
+					}).
+					Return(&model.ResponseScheme{}, nil).
+					Once()
+
+				fields.c = client
+			},
+			want: &model.RestrictionAuditReportScheme{
+				SpaceKey: "DUMMY",
+				Pages: []*model.RestrictionAuditPageScheme{
+					{
+						ID:    "100",
+						Title: "Parent",
+						Read: &model.RestrictionAuditOperationScheme{
+							Users: []*model.RestrictionAuditSubjectScheme{
+								{Type: "user", ID: "account-departed", Name: "Former user"},
+								{Type: "user", ID: "account-id", Name: "Current user"},
+							},
+							Groups: []*model.RestrictionAuditSubjectScheme{{Type: "group", ID: "group-empty", Name: "empty"}},
+						},
+						Findings: []*model.RestrictionAuditFindingScheme{
+							{
+								Type:      model.RestrictionAuditDepartedUserFinding,
+								Operation: "read",
+								Subject:   &model.RestrictionAuditSubjectScheme{Type: "user", ID: "account-departed", Name: "Former user"},
+							},
+							{
+								Type:      model.RestrictionAuditEmptyGroupFinding,
+								Operation: "read",
+								Subject:   &model.RestrictionAuditSubjectScheme{Type: "group", ID: "group-empty", Name: "empty"},
+							},
+						},
+					},
+					{
+						ID:          "200",
+						Title:       "Child",
+						AncestorIDs: []string{"100"},
+						Update: &model.RestrictionAuditOperationScheme{
+							Groups: []*model.RestrictionAuditSubjectScheme{{Type: "group", ID: "group-editors", Name: "editors"}},
+						},
+						Inherited: []*model.RestrictionAuditInheritanceScheme{
+							{
+								SourceID:    "100",
+								SourceTitle: "Parent",
+								Read: &model.RestrictionAuditOperationScheme{
+									Users: []*model.RestrictionAuditSubjectScheme{
+										{Type: "user", ID: "account-departed", Name: "Former user"},
+										{Type: "user", ID: "account-id", Name: "Current user"},
+									},
+									Groups: []*model.RestrictionAuditSubjectScheme{{Type: "group", ID: "group-empty", Name: "empty"}},
+								},
+							},
+						},
+						Findings: []*model.RestrictionAuditFindingScheme{
+							{
+								Type:      model.RestrictionAuditDepartedUserFinding,
+								Operation: "read",
+								Subject:   &model.RestrictionAuditSubjectScheme{Type: "user", ID: "account-departed", Name: "Former user"},
+								SourceID:  "100",
+							},
+							{
+								Type:      model.RestrictionAuditEmptyGroupFinding,
+								Operation: "read",
+								Subject:   &model.RestrictionAuditSubjectScheme{Type: "group", ID: "group-empty", Name: "empty"},
+								SourceID:  "100",
+							},
+						},
+					},
+				},
+			},
+		},
+
+		{
+			name: "when the pages cannot be listed",
+			args: args{
+				ctx:      context.Background(),
+				spaceKey: "DUMMY",
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"wiki/rest/api/content?expand=ancestors&limit=50&spaceKey=DUMMY&start=0&type=page",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.ContentPageScheme{}).
+					Return(&model.ResponseScheme{}, model.ErrUnauthorized)
+
+				fields.c = client
+			},
+			wantErr: true,
+			Err:     model.ErrUnauthorized,
+		},
+
+		{
+			name: "when the space key is not provided",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+			Err:     model.ErrNoSpaceKeyError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			newService := NewRestrictionAuditService(testCase.fields.c)
+
+			gotResult, _, err := newService.Space(testCase.args.ctx, testCase.args.spaceKey, testCase.args.options)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+			} else {
+
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, gotResult)
+
+				buffer := new(bytes.Buffer)
+				assert.NoError(t, gotResult.WriteCSV(buffer))
+				assert.Equal(t, 8, strings.Count(buffer.String(), "\n"))
+				assert.Contains(t, buffer.String(), "200,Child,read,100,Parent,user,account-departed,Former user,departed_user")
+			}
+		})
+	}
+}
@@ -12,17 +12,19 @@ import (
 	"strings"
 )
 
-func NewRestrictionService(client service.Connector, operation *RestrictionOperationService) *RestrictionService {
+func NewRestrictionService(client service.Connector, operation *RestrictionOperationService, audit *RestrictionAuditService) *RestrictionService {
 
 	return &RestrictionService{
 		internalClient: &internalRestrictionImpl{c: client},
 		Operation:      operation,
+		Audit:          audit,
 	}
 }
 
 type RestrictionService struct {
 	internalClient confluence.ContentRestrictionConnector
 	Operation      *RestrictionOperationService
+	Audit          *RestrictionAuditService
 }
 
 // Gets returns the restrictions on a piece of content.
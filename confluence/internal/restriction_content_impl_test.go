@@ -107,7 +107,7 @@ func Test_internalRestrictionImpl_Gets(t *testing.T) {
 				testCase.on(&testCase.fields)
 			}
 
-			newService := NewRestrictionService(testCase.fields.c, nil)
+			newService := NewRestrictionService(testCase.fields.c, nil, nil)
 
 			gotResult, gotResponse, err := newService.Gets(testCase.args.ctx, testCase.args.contentID, testCase.args.expand,
 				testCase.args.startAt, testCase.args.maxResults)
@@ -226,7 +226,7 @@ func Test_internalRestrictionImpl_Add(t *testing.T) {
 				testCase.on(&testCase.fields)
 			}
 
-			newService := NewRestrictionService(testCase.fields.c, nil)
+			newService := NewRestrictionService(testCase.fields.c, nil, nil)
 
 			gotResult, gotResponse, err := newService.Add(testCase.args.ctx, testCase.args.contentID, testCase.args.payload,
 				testCase.args.expand)
@@ -340,7 +340,7 @@ func Test_internalRestrictionImpl_Delete(t *testing.T) {
 				testCase.on(&testCase.fields)
 			}
 
-			newService := NewRestrictionService(testCase.fields.c, nil)
+			newService := NewRestrictionService(testCase.fields.c, nil, nil)
 
 			gotResult, gotResponse, err := newService.Delete(testCase.args.ctx, testCase.args.contentID, testCase.args.expand)
 
@@ -458,7 +458,7 @@ func Test_internalRestrictionImpl_Update(t *testing.T) {
 				testCase.on(&testCase.fields)
 			}
 
-			newService := NewRestrictionService(testCase.fields.c, nil)
+			newService := NewRestrictionService(testCase.fields.c, nil, nil)
 
 			gotResult, gotResponse, err := newService.Update(testCase.args.ctx, testCase.args.contentID, testCase.args.payload,
 				testCase.args.expand)
@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service"
+	"github.com/ctreminiom/go-atlassian/service/confluence"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// NewWhiteboardService returns a new Confluence V2 Whiteboard service
+func NewWhiteboardService(client service.Connector, property *ContentPropertyV2Service) *WhiteboardService {
+
+	return &WhiteboardService{
+		internalClient: &internalWhiteboardImpl{c: client},
+		Property:       property,
+	}
+}
+
+type WhiteboardService struct {
+	internalClient confluence.WhiteboardConnector
+	Property       *ContentPropertyV2Service
+}
+
+// Create creates a whiteboard in the space.
+//
+// POST /wiki/api/v2/whiteboards
+//
+// https://docs.go-atlassian.io/confluence-cloud/v2/whiteboard#create-whiteboard
+func (w *WhiteboardService) Create(ctx context.Context, payload *model.WhiteboardCreatePayloadScheme, private bool) (*model.WhiteboardScheme, *model.ResponseScheme, error) {
+	return w.internalClient.Create(ctx, payload, private)
+}
+
+// Get returns a specific whiteboard.
+//
+// GET /wiki/api/v2/whiteboards/{id}
+//
+// https://docs.go-atlassian.io/confluence-cloud/v2/whiteboard#get-whiteboard-by-id
+func (w *WhiteboardService) Get(ctx context.Context, whiteboardID int) (*model.WhiteboardScheme, *model.ResponseScheme, error) {
+	return w.internalClient.Get(ctx, whiteboardID)
+}
+
+// Delete deletes a whiteboard by id.
+//
+// Deleting a whiteboard moves the whiteboard to the trash, where it can be restored later.
+//
+// DELETE /wiki/api/v2/whiteboards/{id}
+//
+// https://docs.go-atlassian.io/confluence-cloud/v2/whiteboard#delete-whiteboard
+func (w *WhiteboardService) Delete(ctx context.Context, whiteboardID int) (*model.ResponseScheme, error) {
+	return w.internalClient.Delete(ctx, whiteboardID)
+}
+
+// Ancestors returns all ancestors for a given whiteboard by ID in top-to-bottom order.
+//
+// That is, the highest ancestor is the first item in the response payload.
+//
+// GET /wiki/api/v2/whiteboards/{id}/ancestors
+//
+// https://docs.go-atlassian.io/confluence-cloud/v2/whiteboard#get-ancestors-of-whiteboard
+func (w *WhiteboardService) Ancestors(ctx context.Context, whiteboardID, limit int) (*model.ContentAncestorChunkScheme, *model.ResponseScheme, error) {
+	return w.internalClient.Ancestors(ctx, whiteboardID, limit)
+}
+
+type internalWhiteboardImpl struct {
+	c service.Connector
+}
+
+func (i *internalWhiteboardImpl) Create(ctx context.Context, payload *model.WhiteboardCreatePayloadScheme, private bool) (*model.WhiteboardScheme, *model.ResponseScheme, error) {
+
+	endpoint := "wiki/api/v2/whiteboards"
+
+	if private {
+		query := url.Values{}
+		query.Add("private", "true")
+
+		endpoint = fmt.Sprintf("%v?%v", endpoint, query.Encode())
+	}
+
+	request, err := i.c.NewRequest(ctx, http.MethodPost, endpoint, "", payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	whiteboard := new(model.WhiteboardScheme)
+	response, err := i.c.Call(request, whiteboard)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return whiteboard, response, nil
+}
+
+func (i *internalWhiteboardImpl) Get(ctx context.Context, whiteboardID int) (*model.WhiteboardScheme, *model.ResponseScheme, error) {
+
+	if whiteboardID == 0 {
+		return nil, nil, model.ErrNoWhiteboardIDError
+	}
+
+	endpoint := fmt.Sprintf("wiki/api/v2/whiteboards/%v", whiteboardID)
+
+	request, err := i.c.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	whiteboard := new(model.WhiteboardScheme)
+	response, err := i.c.Call(request, whiteboard)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return whiteboard, response, nil
+}
+
+func (i *internalWhiteboardImpl) Delete(ctx context.Context, whiteboardID int) (*model.ResponseScheme, error) {
+
+	if whiteboardID == 0 {
+		return nil, model.ErrNoWhiteboardIDError
+	}
+
+	endpoint := fmt.Sprintf("wiki/api/v2/whiteboards/%v", whiteboardID)
+
+	request, err := i.c.NewRequest(ctx, http.MethodDelete, endpoint, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.c.Call(request, nil)
+}
+
+func (i *internalWhiteboardImpl) Ancestors(ctx context.Context, whiteboardID, limit int) (*model.ContentAncestorChunkScheme, *model.ResponseScheme, error) {
+
+	if whiteboardID == 0 {
+		return nil, nil, model.ErrNoWhiteboardIDError
+	}
+
+	query := url.Values{}
+	query.Add("limit", strconv.Itoa(limit))
+
+	endpoint := fmt.Sprintf("wiki/api/v2/whiteboards/%v/ancestors?%v", whiteboardID, query.Encode())
+
+	request, err := i.c.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ancestors := new(model.ContentAncestorChunkScheme)
+	response, err := i.c.Call(request, ancestors)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return ancestors, response, nil
+}
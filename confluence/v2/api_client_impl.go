@@ -43,6 +43,8 @@ func New(httpClient common.HttpClient, site string) (*Client, error) {
 	client.Space = internal.NewSpaceV2Service(client)
 	client.Attachment = internal.NewAttachmentService(client, internal.NewAttachmentVersionService(client))
 	client.CustomContent = internal.NewCustomContentService(client)
+	client.Whiteboard = internal.NewWhiteboardService(client, internal.NewContentPropertyV2Service(client, "whiteboards"))
+	client.Database = internal.NewDatabaseService(client, internal.NewContentPropertyV2Service(client, "databases"))
 
 	return client, nil
 }
@@ -55,6 +57,10 @@ type Client struct {
 	Space         *internal.SpaceV2Service
 	Attachment    *internal.AttachmentService
 	CustomContent *internal.CustomContentService
+	Whiteboard    *internal.WhiteboardService
+	Database      *internal.DatabaseService
+
+	middlewares []common.Middleware
 }
 
 func (c *Client) NewRequest(ctx context.Context, method, urlStr, type_ string, body interface{}) (*http.Request, error) {
@@ -111,9 +117,16 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr, type_ string, b
 	return req, nil
 }
 
+// Use appends middlewares to the pipeline the requests are sent through, e.g: to log or trace them.
+//
+// The middlewares must be configured before the client is used.
+func (c *Client) Use(middlewares ...common.Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
 func (c *Client) Call(request *http.Request, structure interface{}) (*models.ResponseScheme, error) {
 
-	response, err := c.HTTP.Do(request)
+	response, err := common.Chain(c.HTTP, c.middlewares...)(request)
 	if err != nil {
 		return nil, err
 	}
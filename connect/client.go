@@ -0,0 +1,68 @@
+package connect
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/service/common"
+)
+
+const defaultTokenTTL = 3 * time.Minute
+
+// Sign sets the Authorization header of the request with a JWT issued by the app and bound to
+// the request by its query string hash. The request must target the tenant base URL.
+func Sign(request *http.Request, tenant *Tenant, appKey string, ttl time.Duration) error {
+
+	if tenant == nil {
+		return ErrNoTenantError
+	}
+
+	if ttl <= 0 {
+		ttl = defaultTokenTTL
+	}
+
+	now := time.Now()
+	token, err := Encode(&Claims{
+		Issuer:    appKey,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+		QSH:       QSH(request, tenant.BaseURL),
+	}, tenant.SharedSecret)
+
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Authorization", "JWT "+token)
+	return nil
+}
+
+// NewClient returns an HTTP client that signs the requests sent to the tenant, e.g:
+//
+//	instance, err := v3.New(connect.NewClient(nil, tenant, "com.example.app"), tenant.BaseURL)
+func NewClient(httpClient common.HttpClient, tenant *Tenant, appKey string) *Client {
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{http: httpClient, tenant: tenant, appKey: appKey}
+}
+
+// Client is a common.HttpClient that signs the requests with the Connect JWT of a tenant.
+type Client struct {
+	http   common.HttpClient
+	tenant *Tenant
+	appKey string
+}
+
+// Do signs and sends the request.
+func (c *Client) Do(request *http.Request) (*http.Response, error) {
+
+	signed := request.Clone(request.Context())
+	if err := Sign(signed, c.tenant, c.appKey, defaultTokenTTL); err != nil {
+		return nil, err
+	}
+
+	return c.http.Do(signed)
+}
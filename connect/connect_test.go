@@ -0,0 +1,256 @@
+package connect
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalRequest(t *testing.T) {
+
+	testCases := []struct {
+		name    string
+		method  string
+		url     string
+		baseURL string
+		want    string
+	}{
+		{
+			name:    "when the request has repeated and encoded parameters",
+			method:  http.MethodGet,
+			url:     "https://ctreminiom.atlassian.net/wiki/rest/api/content/?type=page&expand=version&expand=space&q=a+b*~&jwt=token",
+			baseURL: "https://ctreminiom.atlassian.net/wiki",
+			want:    "GET&/rest/api/content&expand=space,version&q=a%20b%2A~&type=page",
+		},
+		{
+			name:    "when the request targets the base url",
+			method:  http.MethodPost,
+			url:     "https://app.example.com",
+			baseURL: "https://app.example.com",
+			want:    "POST&/&",
+		},
+		{
+			name:    "when the path only starts like the base path",
+			method:  http.MethodGet,
+			url:     "https://ctreminiom.atlassian.net/wikis/rest",
+			baseURL: "https://ctreminiom.atlassian.net/wiki",
+			want:    "GET&/wikis/rest&",
+		},
+		{
+			name:    "when the path has an ampersand",
+			method:  "put",
+			url:     "https://ctreminiom.atlassian.net/rest/api/3/issue/a&b",
+			baseURL: "https://ctreminiom.atlassian.net/",
+			want:    "PUT&/rest/api/3/issue/a%26b&",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			request, err := http.NewRequest(testCase.method, testCase.url, nil)
+			assert.NoError(t, err)
+
+			assert.Equal(t, testCase.want, CanonicalRequest(request, testCase.baseURL))
+		})
+	}
+}
+
+func TestVerifier_Middleware(t *testing.T) {
+
+	tenant := &Tenant{ClientKey: "client-key", SharedSecret: "shared-secret", BaseURL: "https://ctreminiom.atlassian.net"}
+
+	store := NewMemoryTenantStore()
+	assert.NoError(t, store.Save(context.Background(), tenant))
+
+	verifier := &Verifier{Store: store, BaseURL: "https://app.example.com/connect"}
+	handler := verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verified, ok := TenantFromContext(r.Context())
+		assert.True(t, ok)
+		_, _ = w.Write([]byte(verified.ClientKey))
+	}))
+
+	sign := func(url, issuer, secret string, expiry time.Time, qsh string) *http.Request {
+
+		request := httptest.NewRequest(http.MethodPost, url, nil)
+
+		if qsh == "" {
+			qsh = QSH(request, "https://app.example.com/connect")
+		}
+
+		token, err := Encode(&Claims{Issuer: issuer, IssuedAt: time.Now().Unix(), ExpiresAt: expiry.Unix(), QSH: qsh}, secret)
+		assert.NoError(t, err)
+
+		request.Header.Set("Authorization", "JWT "+token)
+		return request
+	}
+
+	testCases := []struct {
+		name    string
+		request *http.Request
+		status  int
+		body    string
+	}{
+		{
+			name:    "when the jwt is valid",
+			request: sign("https://app.example.com/connect/webhook?issue=1", "client-key", "shared-secret", time.Now().Add(time.Minute), ""),
+			status:  http.StatusOK,
+			body:    "client-key",
+		},
+		{
+			name:    "when the jwt is signed with another secret",
+			request: sign("https://app.example.com/connect/webhook", "client-key", "other-secret", time.Now().Add(time.Minute), ""),
+			status:  http.StatusUnauthorized,
+			body:    "connect: invalid jwt signature\n",
+		},
+		{
+			name:    "when the jwt has expired",
+			request: sign("https://app.example.com/connect/webhook", "client-key", "shared-secret", time.Now().Add(-time.Hour), ""),
+			status:  http.StatusUnauthorized,
+			body:    "connect: the jwt has expired\n",
+		},
+		{
+			name:    "when the jwt has no expiration",
+			request: sign("https://app.example.com/connect/webhook", "client-key", "shared-secret", time.Unix(0, 0), ""),
+			status:  http.StatusUnauthorized,
+			body:    "connect: malformed jwt: the jwt has no expiration\n",
+		},
+		{
+			name:    "when the jwt is bound to another request",
+			request: sign("https://app.example.com/connect/webhook", "client-key", "shared-secret", time.Now().Add(time.Minute), "qsh"),
+			status:  http.StatusUnauthorized,
+			body:    "connect: the jwt query string hash doesn't match the request\n",
+		},
+		{
+			name:    "when the tenant is unknown",
+			request: sign("https://app.example.com/connect/webhook", "other-key", "shared-secret", time.Now().Add(time.Minute), ""),
+			status:  http.StatusUnauthorized,
+			body:    "connect: unknown tenant\n",
+		},
+		{
+			name:    "when the request has no jwt",
+			request: httptest.NewRequest(http.MethodGet, "https://app.example.com/connect/webhook", nil),
+			status:  http.StatusUnauthorized,
+			body:    "connect: no jwt set\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, testCase.request)
+
+			assert.Equal(t, testCase.status, recorder.Code)
+			assert.Equal(t, testCase.body, recorder.Body.String())
+		})
+	}
+}
+
+func TestVerifier_Install(t *testing.T) {
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+
+	var fetched int
+	keys := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.URL.Path != "/key-id" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		fetched++
+		_ = pem.Encode(w, &pem.Block{Type: "PUBLIC KEY", Bytes: public})
+	}))
+	defer keys.Close()
+
+	verifier := &Verifier{
+		Store:       NewMemoryTenantStore(),
+		BaseURL:     "https://app.example.com/connect",
+		InstallKeys: NewInstallKeys(keys.Client(), keys.URL),
+	}
+
+	sign := func(kid, audience string) *http.Request {
+
+		request := httptest.NewRequest(http.MethodPost, "https://app.example.com/connect/installed", nil)
+
+		header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid})
+		payload, _ := json.Marshal(&Claims{
+			Issuer:    "client-key",
+			Audience:  json.RawMessage(strconv.Quote(audience)),
+			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: time.Now().Add(time.Minute).Unix(),
+			QSH:       QSH(request, "https://app.example.com/connect"),
+		})
+
+		unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+		sum := sha256.Sum256([]byte(unsigned))
+
+		signed, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+		assert.NoError(t, err)
+
+		request.Header.Set("Authorization", "JWT "+unsigned+"."+base64.RawURLEncoding.EncodeToString(signed))
+		return request
+	}
+
+	// The tenant isn't stored yet on the installed callback.
+	tenant, claims, err := verifier.Verify(sign("key-id", "https://app.example.com/connect/"))
+	assert.NoError(t, err)
+	assert.Nil(t, tenant)
+	assert.Equal(t, "client-key", claims.Issuer)
+
+	_, _, err = verifier.Verify(sign("key-id", "https://attacker.example.com"))
+	assert.ErrorIs(t, err, ErrInvalidAudienceError)
+
+	_, _, err = verifier.Verify(sign("other-key-id", "https://app.example.com/connect"))
+	assert.ErrorIs(t, err, ErrUnknownKeyError)
+
+	assert.Equal(t, 1, fetched)
+
+	// The RS256 tokens are rejected when the install keys aren't set.
+	verifier.InstallKeys = nil
+
+	_, _, err = verifier.Verify(sign("key-id", "https://app.example.com/connect"))
+	assert.ErrorIs(t, err, ErrUnknownKeyError)
+}
+
+func TestClient_Do(t *testing.T) {
+
+	tenant := &Tenant{ClientKey: "client-key", SharedSecret: "shared-secret"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		claims, err := Verify(r.Header.Get("Authorization")[len("JWT "):], "shared-secret", time.Now(), 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "com.example.app", claims.Issuer)
+		assert.Equal(t, QSH(r, tenant.BaseURL), claims.QSH)
+	}))
+	defer server.Close()
+
+	tenant.BaseURL = server.URL
+
+	request, err := http.NewRequest(http.MethodGet, server.URL+"/rest/api/3/myself?expand=groups", nil)
+	assert.NoError(t, err)
+
+	response, err := NewClient(nil, tenant, "com.example.app").Do(request)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Empty(t, request.Header.Get("Authorization"))
+}
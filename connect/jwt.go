@@ -0,0 +1,295 @@
+// Package connect implements the Atlassian Connect JWT authentication: the query string hash
+// (QSH) signing of the requests sent to the products and the verification of the requests
+// received from them, e.g: lifecycle callbacks and webhooks.
+package connect
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoTenantError         = errors.New("connect: no tenant set")
+	ErrNoTokenError          = errors.New("connect: no jwt set")
+	ErrMalformedTokenError   = errors.New("connect: malformed jwt")
+	ErrInvalidSignatureError = errors.New("connect: invalid jwt signature")
+	ErrExpiredTokenError     = errors.New("connect: the jwt has expired")
+	ErrInvalidQSHError       = errors.New("connect: the jwt query string hash doesn't match the request")
+	ErrUnknownTenantError    = errors.New("connect: unknown tenant")
+	ErrInvalidAudienceError  = errors.New("connect: the jwt audience doesn't match the app")
+	ErrUnknownKeyError       = errors.New("connect: unknown jwt key")
+)
+
+// ContextQSH is the query string hash of the tokens that aren't bound to a request, e.g: the
+// tokens of the app iframes.
+const ContextQSH = "context-qsh"
+
+// Claims are the claims of a Connect JWT.
+type Claims struct {
+	Issuer    string          `json:"iss"`
+	Subject   string          `json:"sub,omitempty"`
+	Audience  json.RawMessage `json:"aud,omitempty"`
+	IssuedAt  int64           `json:"iat"`
+	ExpiresAt int64           `json:"exp"`
+	NotBefore int64           `json:"nbf,omitempty"`
+	QSH       string          `json:"qsh,omitempty"`
+	Context   json.RawMessage `json:"context,omitempty"`
+}
+
+// QSH returns the query string hash of the request, the base URL is the URL of the product or
+// app that receives the request, its path is not part of the canonical request.
+func QSH(request *http.Request, baseURL string) string {
+	sum := sha256.Sum256([]byte(CanonicalRequest(request, baseURL)))
+	return hex.EncodeToString(sum[:])
+}
+
+// CanonicalRequest returns the canonical request the query string hash is computed from:
+// the uppercase method, the path relative to the base URL and the sorted query parameters
+// without the jwt parameter, joined by "&".
+func CanonicalRequest(request *http.Request, baseURL string) string {
+
+	path := request.URL.EscapedPath()
+
+	// The base path is only trimmed on a segment boundary, e.g: /wiki isn't trimmed from /wikis.
+	if base, err := url.Parse(baseURL); err == nil {
+
+		prefix := strings.TrimSuffix(base.Path, "/")
+		if prefix != "" && (path == prefix || strings.HasPrefix(path, prefix+"/")) {
+			path = strings.TrimPrefix(path, prefix)
+		}
+	}
+
+	if path == "" {
+		path = "/"
+	}
+
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	path = strings.ReplaceAll(path, "&", "%26")
+
+	query := request.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		if key != "jwt" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	parameters := make([]string, 0, len(keys))
+	for _, key := range keys {
+
+		values := make([]string, 0, len(query[key]))
+		for _, value := range query[key] {
+			values = append(values, encode(value))
+		}
+		sort.Strings(values)
+
+		parameters = append(parameters, encode(key)+"="+strings.Join(values, ","))
+	}
+
+	return strings.ToUpper(request.Method) + "&" + path + "&" + strings.Join(parameters, "&")
+}
+
+// Encode returns the HS256 signed JWT of the claims.
+func Encode(claims *Claims, sharedSecret string) (string, error) {
+
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + signature(unsigned, sharedSecret), nil
+}
+
+// Decode returns the claims of the JWT without verifying its signature, e.g: to find the tenant
+// of the issuer before verifying it. The tokens are signed with HS256, or RS256 on the lifecycle callbacks.
+func Decode(token string) (*Claims, error) {
+	_, claims, err := parse(token)
+	return claims, err
+}
+
+// Verify returns the claims of the HS256 JWT after verifying its signature and expiration, the
+// leeway is the clock skew tolerated. The tokens without expiration are rejected.
+func Verify(token, sharedSecret string, now time.Time, leeway time.Duration) (*Claims, error) {
+
+	header, claims, err := parse(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.Alg != "HS256" {
+		return nil, fmt.Errorf("%w: the jwt is signed with %v", ErrInvalidSignatureError, header.Alg)
+	}
+
+	index := strings.LastIndex(token, ".")
+	expected := signature(token[:index], sharedSecret)
+
+	if !hmac.Equal([]byte(expected), []byte(token[index+1:])) {
+		return nil, ErrInvalidSignatureError
+	}
+
+	if err = claims.valid(now, leeway); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// VerifyInstall returns the claims of the RS256 JWT of a lifecycle callback after verifying its
+// signature with the install key of the token, its expiration and its audience, the audience is
+// the base URL of the app.
+func VerifyInstall(ctx context.Context, token string, keys KeyProvider, audience string, now time.Time, leeway time.Duration) (*Claims, error) {
+
+	header, claims, err := parse(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("%w: the jwt is signed with %v", ErrInvalidSignatureError, header.Alg)
+	}
+
+	if header.Kid == "" || keys == nil {
+		return nil, ErrUnknownKeyError
+	}
+
+	key, err := keys.PublicKey(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	index := strings.LastIndex(token, ".")
+
+	signed, err := base64.RawURLEncoding.DecodeString(token[index+1:])
+	if err != nil {
+		return nil, ErrMalformedTokenError
+	}
+
+	sum := sha256.Sum256([]byte(token[:index]))
+	if err = rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], signed); err != nil {
+		return nil, ErrInvalidSignatureError
+	}
+
+	if err = claims.valid(now, leeway); err != nil {
+		return nil, err
+	}
+
+	if !claims.HasAudience(audience) {
+		return nil, ErrInvalidAudienceError
+	}
+
+	return claims, nil
+}
+
+// HasAudience reports whether the audience of the claims, a string or an array, includes the
+// audience provided, the trailing slashes are ignored.
+func (c *Claims) HasAudience(audience string) bool {
+
+	var audiences []string
+	if err := json.Unmarshal(c.Audience, &audiences); err != nil {
+
+		var single string
+		if err = json.Unmarshal(c.Audience, &single); err != nil {
+			return false
+		}
+
+		audiences = []string{single}
+	}
+
+	for _, value := range audiences {
+		if strings.TrimSuffix(value, "/") == strings.TrimSuffix(audience, "/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Claims) valid(now time.Time, leeway time.Duration) error {
+
+	if c.ExpiresAt == 0 {
+		return fmt.Errorf("%w: the jwt has no expiration", ErrMalformedTokenError)
+	}
+
+	if now.Add(-leeway).Unix() > c.ExpiresAt {
+		return ErrExpiredTokenError
+	}
+
+	if c.NotBefore != 0 && now.Add(leeway).Unix() < c.NotBefore {
+		return fmt.Errorf("%w: the jwt is not valid yet", ErrExpiredTokenError)
+	}
+
+	return nil
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid,omitempty"`
+}
+
+func parse(token string) (*header, *Claims, error) {
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil, ErrMalformedTokenError
+	}
+
+	content, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, nil, ErrMalformedTokenError
+	}
+
+	decoded := new(header)
+	if err = json.Unmarshal(content, decoded); err != nil {
+		return nil, nil, ErrMalformedTokenError
+	}
+
+	if decoded.Alg != "HS256" && decoded.Alg != "RS256" {
+		return nil, nil, fmt.Errorf("%w: unsupported algorithm %v", ErrMalformedTokenError, decoded.Alg)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, nil, ErrMalformedTokenError
+	}
+
+	claims := new(Claims)
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return nil, nil, ErrMalformedTokenError
+	}
+
+	return decoded, claims, nil
+}
+
+func signature(unsigned, sharedSecret string) string {
+	mac := hmac.New(sha256.New, []byte(sharedSecret))
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// encode percent-encodes the value as RFC 3986, the spaces as %20 instead of +.
+func encode(value string) string {
+	return strings.NewReplacer("+", "%20", "*", "%2A", "%7E", "~").Replace(url.QueryEscape(value))
+}
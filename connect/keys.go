@@ -0,0 +1,102 @@
+package connect
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/ctreminiom/go-atlassian/service/common"
+)
+
+// InstallKeysURL is the URL of the public keys Atlassian signs the lifecycle callbacks with.
+const InstallKeysURL = "https://connect-install-keys.atlassian.com"
+
+// KeyProvider returns the public keys the RS256 tokens are verified with.
+type KeyProvider interface {
+
+	// PublicKey returns the public key of the key ID of the token header.
+	PublicKey(ctx context.Context, keyID string) (*rsa.PublicKey, error)
+}
+
+// NewInstallKeys returns a KeyProvider of the keys published on the base URL, InstallKeysURL by
+// default. The keys are fetched once and cached by key ID.
+func NewInstallKeys(httpClient common.HttpClient, baseURL string) *InstallKeys {
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	if baseURL == "" {
+		baseURL = InstallKeysURL
+	}
+
+	return &InstallKeys{http: httpClient, baseURL: strings.TrimSuffix(baseURL, "/"), keys: make(map[string]*rsa.PublicKey)}
+}
+
+// InstallKeys is the KeyProvider of the Atlassian Connect install keys, it's safe for concurrent use.
+type InstallKeys struct {
+	http    common.HttpClient
+	baseURL string
+
+	mu   sync.Mutex
+	keys map[string]*rsa.PublicKey
+}
+
+func (i *InstallKeys) PublicKey(ctx context.Context, keyID string) (*rsa.PublicKey, error) {
+
+	i.mu.Lock()
+	key, ok := i.keys[keyID]
+	i.mu.Unlock()
+
+	if ok {
+		return key, nil
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, i.baseURL+"/"+url.PathEscape(keyID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := i.http.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %v returned %v", ErrUnknownKeyError, keyID, response.StatusCode)
+	}
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("%w: %v isn't a PEM public key", ErrUnknownKeyError, keyID)
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownKeyError, err)
+	}
+
+	key, ok = parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v isn't an RSA public key", ErrUnknownKeyError, keyID)
+	}
+
+	i.mu.Lock()
+	i.keys[keyID] = key
+	i.mu.Unlock()
+
+	return key, nil
+}
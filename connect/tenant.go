@@ -0,0 +1,72 @@
+package connect
+
+import (
+	"context"
+	"sync"
+)
+
+// Tenant is an installation of the app, as received on the installed lifecycle callback.
+type Tenant struct {
+	Key                      string `json:"key"`
+	ClientKey                string `json:"clientKey"`
+	SharedSecret             string `json:"sharedSecret"`
+	BaseURL                  string `json:"baseUrl"`
+	DisplayURL               string `json:"displayUrl,omitempty"`
+	ProductType              string `json:"productType,omitempty"`
+	Description              string `json:"description,omitempty"`
+	ServiceEntitlementNumber string `json:"serviceEntitlementNumber,omitempty"`
+	EventType                string `json:"eventType,omitempty"`
+}
+
+// TenantStore persists the tenants of the app keyed by client key.
+type TenantStore interface {
+
+	// Load returns the tenant of the client key, or nil if it's not installed.
+	Load(ctx context.Context, clientKey string) (*Tenant, error)
+
+	// Save persists the tenant, e.g: on the installed lifecycle callback.
+	Save(ctx context.Context, tenant *Tenant) error
+
+	// Delete removes the tenant, e.g: on the uninstalled lifecycle callback.
+	Delete(ctx context.Context, clientKey string) error
+}
+
+// NewMemoryTenantStore returns a TenantStore that keeps the tenants in memory.
+func NewMemoryTenantStore() *MemoryTenantStore {
+	return &MemoryTenantStore{tenants: make(map[string]*Tenant)}
+}
+
+type MemoryTenantStore struct {
+	mu      sync.Mutex
+	tenants map[string]*Tenant
+}
+
+func (m *MemoryTenantStore) Load(ctx context.Context, clientKey string) (*Tenant, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.tenants[clientKey], nil
+}
+
+func (m *MemoryTenantStore) Save(ctx context.Context, tenant *Tenant) error {
+
+	if tenant == nil || tenant.ClientKey == "" {
+		return ErrNoTenantError
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tenants[tenant.ClientKey] = tenant
+	return nil
+}
+
+func (m *MemoryTenantStore) Delete(ctx context.Context, clientKey string) error {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.tenants, clientKey)
+	return nil
+}
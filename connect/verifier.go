@@ -0,0 +1,136 @@
+package connect
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Verifier verifies the JWT of the requests received from the products, the tenant of the
+// token issuer is loaded from the store.
+type Verifier struct {
+	Store TenantStore
+
+	// BaseURL is the base URL of the app, its path is not part of the query string hash.
+	BaseURL string
+
+	// AllowContextQSH accepts the tokens not bound to a request, e.g: on the app iframes.
+	AllowContextQSH bool
+
+	// InstallKeys verifies the RS256 tokens Atlassian signs the lifecycle callbacks with, e.g:
+	// NewInstallKeys(nil, ""). The RS256 tokens are rejected when it's not set.
+	InstallKeys KeyProvider
+
+	// Leeway is the clock skew tolerated, 30 seconds by default.
+	Leeway time.Duration
+
+	// Now is the time the expiration and issue time of the tokens are checked against, time.Now by default.
+	Now func() time.Time
+}
+
+type contextKey struct{}
+
+// Verify verifies the JWT of the request, from the Authorization header or the jwt query parameter,
+// and returns the tenant that issued it.
+//
+// The tokens of the lifecycle callbacks are signed by Atlassian with RS256 and their audience is
+// the base URL of the app, the tenant is nil when it isn't stored yet, e.g: on the first installation.
+func (v *Verifier) Verify(request *http.Request) (*Tenant, *Claims, error) {
+
+	token := request.URL.Query().Get("jwt")
+	if header := request.Header.Get("Authorization"); strings.HasPrefix(header, "JWT ") {
+		token = strings.TrimPrefix(header, "JWT ")
+	}
+
+	if token == "" {
+		return nil, nil, ErrNoTokenError
+	}
+
+	header, unverified, err := parse(token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now, leeway := time.Now, v.Leeway
+	if v.Now != nil {
+		now = v.Now
+	}
+
+	if leeway <= 0 {
+		leeway = 30 * time.Second
+	}
+
+	var (
+		tenant *Tenant
+		claims *Claims
+	)
+
+	if header.Alg == "RS256" {
+
+		if v.InstallKeys == nil {
+			return nil, nil, ErrUnknownKeyError
+		}
+
+		if claims, err = VerifyInstall(request.Context(), token, v.InstallKeys, v.BaseURL, now(), leeway); err != nil {
+			return nil, nil, err
+		}
+
+		if v.Store != nil {
+			if tenant, err = v.Store.Load(request.Context(), claims.Issuer); err != nil {
+				return nil, nil, err
+			}
+		}
+
+	} else {
+
+		if v.Store == nil {
+			return nil, nil, ErrUnknownTenantError
+		}
+
+		if tenant, err = v.Store.Load(request.Context(), unverified.Issuer); err != nil {
+			return nil, nil, err
+		}
+
+		if tenant == nil {
+			return nil, nil, ErrUnknownTenantError
+		}
+
+		if claims, err = Verify(token, tenant.SharedSecret, now(), leeway); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if claims.QSH != QSH(request, v.BaseURL) && !(v.AllowContextQSH && claims.QSH == ContextQSH) {
+		return nil, nil, ErrInvalidQSHError
+	}
+
+	return tenant, claims, nil
+}
+
+// Middleware returns an http.Handler middleware that rejects the requests without a valid JWT
+// with 401 (Unauthorized), the tenant is available on the request context through TenantFromContext,
+// except on the lifecycle callbacks of the tenants not stored yet.
+func (v *Verifier) Middleware(next http.Handler) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		tenant, _, err := v.Verify(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		if tenant != nil {
+			r = r.WithContext(context.WithValue(r.Context(), contextKey{}, tenant))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// TenantFromContext returns the tenant verified by the Verifier middleware.
+func TenantFromContext(ctx context.Context) (*Tenant, bool) {
+	tenant, ok := ctx.Value(contextKey{}).(*Tenant)
+	return tenant, ok
+}
@@ -7,4 +7,5 @@ require (
 	github.com/imdario/mergo v0.3.16
 	github.com/stretchr/testify v1.8.4
 	github.com/tidwall/gjson v1.17.0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,89 @@
+// Package batch runs the calls of the bulk operations with bounded concurrency and rate.
+package batch
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Run calls the function for each index, with at most concurrency calls in flight. No call is
+// started once the context is done: the calls in flight are waited for and the context error is
+// returned, so the indexes without a call can be told apart from the ones completed.
+func Run(ctx context.Context, count, concurrency int, fn func(index int)) error {
+
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		wg        sync.WaitGroup
+		semaphore = make(chan struct{}, concurrency)
+	)
+
+	defer wg.Wait()
+
+	for index := 0; index < count; index++ {
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case semaphore <- struct{}{}:
+		}
+
+		// The select picks either case when both are ready.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		wg.Add(1)
+
+		go func(index int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			fn(index)
+		}(index)
+	}
+
+	return nil
+}
+
+// Limiter spaces the calls to the requests per second, the calls aren't limited when it's zero.
+type Limiter struct {
+	ctx    context.Context
+	ticker *time.Ticker
+}
+
+// NewLimiter returns the limiter of the requests per second, it must be stopped once the calls are done.
+func NewLimiter(ctx context.Context, requestsPerSecond float64) *Limiter {
+
+	limiter := &Limiter{ctx: ctx}
+	if requestsPerSecond > 0 {
+		limiter.ticker = time.NewTicker(time.Duration(float64(time.Second) / requestsPerSecond))
+	}
+
+	return limiter
+}
+
+// Wait blocks until the next call is allowed, it returns the context error once the context is done.
+func (l *Limiter) Wait() error {
+
+	if l.ticker == nil {
+		return l.ctx.Err()
+	}
+
+	select {
+	case <-l.ctx.Done():
+		return l.ctx.Err()
+	case <-l.ticker.C:
+		return nil
+	}
+}
+
+// Stop releases the ticker of the limiter.
+func (l *Limiter) Stop() {
+	if l.ticker != nil {
+		l.ticker.Stop()
+	}
+}
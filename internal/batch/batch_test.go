@@ -0,0 +1,79 @@
+package batch
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+
+	var (
+		mu             sync.Mutex
+		inFlight, peak int
+		called         = make([]bool, 10)
+	)
+
+	err := Run(context.Background(), len(called), 3, func(index int) {
+
+		mu.Lock()
+		inFlight++
+		if inFlight > peak {
+			peak = inFlight
+		}
+		called[index] = true
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	})
+
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, peak, 3)
+	assert.NotContains(t, called, false)
+}
+
+func TestRun_Cancelled(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var (
+		mu     sync.Mutex
+		called []int
+	)
+
+	// The first call cancels the context, so no call is started after it.
+	err := Run(ctx, 10, 1, func(index int) {
+
+		mu.Lock()
+		called = append(called, index)
+		mu.Unlock()
+
+		cancel()
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []int{0}, called)
+}
+
+func TestLimiter(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	limiter := NewLimiter(ctx, 1000)
+	defer limiter.Stop()
+
+	started := time.Now()
+	assert.NoError(t, limiter.Wait())
+	assert.NoError(t, limiter.Wait())
+	assert.True(t, time.Since(started) >= time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, NewLimiter(ctx, 0).Wait(), context.Canceled)
+}
@@ -0,0 +1,55 @@
+// Package paging follows the cursor pages of the admin APIs.
+package paging
+
+import (
+	"context"
+	"net/url"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/admin"
+)
+
+// Cursor extracts the cursor of the next page link, the link is either the cursor or an URL with it.
+func Cursor(next string) string {
+
+	link, err := url.Parse(next)
+	if err != nil {
+		return next
+	}
+
+	if cursor := link.Query().Get("cursor"); cursor != "" {
+		return cursor
+	}
+
+	return next
+}
+
+// Users returns the users of the organization, following the next page links until the last page.
+func Users(ctx context.Context, organization admin.OrganizationConnector, organizationID string) ([]*model.AdminOrganizationUserScheme, error) {
+
+	var (
+		users  []*model.AdminOrganizationUserScheme
+		cursor string
+	)
+
+	for {
+
+		page, _, err := organization.Users(ctx, organizationID, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, page.Data...)
+
+		if page.Links == nil || page.Links.Next == "" {
+			return users, nil
+		}
+
+		next := Cursor(page.Links.Next)
+		if next == cursor {
+			return users, nil
+		}
+
+		cursor = next
+	}
+}
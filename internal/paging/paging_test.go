@@ -0,0 +1,44 @@
+package paging
+
+import (
+	"context"
+	"testing"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/admin"
+	"github.com/stretchr/testify/assert"
+)
+
+type organizationFake struct {
+	admin.OrganizationConnector
+	pages map[string]*model.OrganizationUserPageScheme
+}
+
+func (f *organizationFake) Users(ctx context.Context, organizationID, cursor string) (*model.OrganizationUserPageScheme,
+	*model.ResponseScheme, error) {
+	return f.pages[cursor], nil, nil
+}
+
+func TestCursor(t *testing.T) {
+	assert.Equal(t, "page-2", Cursor("https://api.atlassian.com/admin/v1/orgs/org-id/users?cursor=page-2"))
+	assert.Equal(t, "page-2", Cursor("page-2"))
+}
+
+func TestUsers(t *testing.T) {
+
+	organization := &organizationFake{pages: map[string]*model.OrganizationUserPageScheme{
+		"": {
+			Data:  []*model.AdminOrganizationUserScheme{{AccountID: "account-1"}},
+			Links: &model.LinkPageModelScheme{Next: "https://api.atlassian.com/admin/v1/orgs/org-id/users?cursor=page-2"},
+		},
+		"page-2": {
+			Data:  []*model.AdminOrganizationUserScheme{{AccountID: "account-2"}},
+			Links: &model.LinkPageModelScheme{Self: "page-2"},
+		},
+	}}
+
+	users, err := Users(context.Background(), organization, "org-id")
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, "account-2", users[1].AccountID)
+}
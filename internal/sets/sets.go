@@ -0,0 +1,24 @@
+// Package sets builds the sorted sets of the keys and IDs reported by the packages.
+package sets
+
+import "sort"
+
+// Strings returns the values sorted, without duplicates and empty values.
+func Strings(values []string) []string {
+
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+
+	for _, value := range values {
+
+		if value == "" || seen[value] {
+			continue
+		}
+
+		seen[value] = true
+		result = append(result, value)
+	}
+
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,12 @@
+package sets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrings(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, Strings([]string{"b", "", "a", "b"}))
+	assert.Equal(t, []string{}, Strings(nil))
+}
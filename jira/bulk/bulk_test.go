@@ -0,0 +1,405 @@
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/jira"
+	"github.com/stretchr/testify/assert"
+)
+
+type issueFake struct {
+	jira.IssueADFConnector
+
+	mu      sync.Mutex
+	calls   []string
+	limited map[string]int
+	failed  map[string]bool
+	batches []int
+}
+
+func (f *issueFake) record(call, key string) (*model.ResponseScheme, error) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, call+" "+key)
+
+	if f.limited[key] > 0 {
+		f.limited[key]--
+
+		header := http.Header{}
+		header.Set("Retry-After", "0")
+		return &model.ResponseScheme{Response: &http.Response{Header: header}, Code: http.StatusTooManyRequests}, model.ErrInvalidStatusCodeError
+	}
+
+	if f.failed[key] {
+		return &model.ResponseScheme{Code: http.StatusBadRequest}, model.ErrBadRequestError
+	}
+
+	return &model.ResponseScheme{Code: http.StatusNoContent}, nil
+}
+
+func (f *issueFake) Update(ctx context.Context, issueKeyOrId string, notify bool, payload *model.IssueScheme,
+	customFields *model.CustomFields, operations *model.UpdateOperations) (*model.ResponseScheme, error) {
+	return f.record("update", issueKeyOrId)
+}
+
+func (f *issueFake) Assign(ctx context.Context, issueKeyOrId, accountId string) (*model.ResponseScheme, error) {
+	return f.record("assign:"+accountId, issueKeyOrId)
+}
+
+func (f *issueFake) Delete(ctx context.Context, issueKeyOrId string, deleteSubTasks bool) (*model.ResponseScheme, error) {
+	return f.record("delete", issueKeyOrId)
+}
+
+func (f *issueFake) Get(ctx context.Context, issueKeyOrId string, fields, expand []string) (*model.IssueScheme, *model.ResponseScheme, error) {
+
+	response, err := f.record("get", issueKeyOrId)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return &model.IssueScheme{Key: issueKeyOrId, Fields: &model.IssueFieldsScheme{
+		Status: &model.StatusScheme{ID: "1", Name: "To Do"},
+	}}, response, nil
+}
+
+func (f *issueFake) TransitionsWithFields(ctx context.Context, issueKeyOrId string) (*model.IssueTransitionsScheme, *model.ResponseScheme, error) {
+
+	response, err := f.record("transitions", issueKeyOrId)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return &model.IssueTransitionsScheme{Transitions: []*model.IssueTransitionScheme{
+		{ID: "11", Name: "Start", To: &model.StatusScheme{Name: "In Progress"}},
+		{ID: "31", Name: "Finish", To: &model.StatusScheme{Name: "Done"}},
+		{ID: "41", Name: "Reject", To: &model.StatusScheme{Name: "Rejected"}, Fields: map[string]*model.IssueTransitionFieldScheme{
+			"resolution": {Name: "Resolution", Key: "resolution", Required: true},
+		}},
+	}}, response, nil
+}
+
+func (f *issueFake) Move(ctx context.Context, issueKeyOrId, transitionId string, options *model.IssueMoveOptionsV3) (*model.ResponseScheme, error) {
+	return f.record("move:"+transitionId, issueKeyOrId)
+}
+
+func (f *issueFake) Creates(ctx context.Context, payload []*model.IssueBulkSchemeV3) (*model.IssueBulkResponseScheme, *model.ResponseScheme, error) {
+
+	f.mu.Lock()
+	f.batches = append(f.batches, len(payload))
+	f.mu.Unlock()
+
+	created := new(model.IssueBulkResponseScheme)
+	for position, issue := range payload {
+
+		summary := issue.Payload.Fields.Summary
+		if strings.HasPrefix(summary, "invalid") {
+
+			failure := &model.IssueBulkResponseErrorScheme{Status: http.StatusBadRequest, FailedElementNumber: position}
+			failure.ElementErrors.Errors = map[string]string{"summary": "invalid summary"}
+			created.Errors = append(created.Errors, failure)
+			continue
+		}
+
+		created.Issues = append(created.Issues, struct {
+			ID   string `json:"id,omitempty"`
+			Key  string `json:"key,omitempty"`
+			Self string `json:"self,omitempty"`
+		}{Key: "KP-" + strings.TrimPrefix(summary, "issue ")})
+	}
+
+	return created, &model.ResponseScheme{Code: http.StatusCreated}, nil
+}
+
+type searchFake struct {
+	jira.SearchADFConnector
+	keys []string
+}
+
+func (f *searchFake) Post(ctx context.Context, jql string, fields, expands []string, startAt, maxResults int,
+	validate string) (*model.IssueSearchScheme, *model.ResponseScheme, error) {
+
+	page := &model.IssueSearchScheme{StartAt: startAt, Total: len(f.keys)}
+	for index := startAt; index < len(f.keys) && index < startAt+maxResults; index++ {
+		page.Issues = append(page.Issues, &model.IssueScheme{Key: f.keys[index]})
+	}
+
+	return page, &model.ResponseScheme{Code: http.StatusOK}, nil
+}
+
+type watcherFake struct {
+	jira.WatcherConnector
+
+	mu    sync.Mutex
+	added []string
+}
+
+func (f *watcherFake) AddUser(ctx context.Context, issueKeyOrId, accountId string) (*model.ResponseScheme, error) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.added = append(f.added, issueKeyOrId+" "+accountId)
+	return &model.ResponseScheme{Code: http.StatusNoContent}, nil
+}
+
+func sorted(values []string) []string {
+	sort.Strings(values)
+	return values
+}
+
+func TestExecutor_Run(t *testing.T) {
+
+	testCases := []struct {
+		name      string
+		operation *Operation
+		options   *Options
+		calls     []string
+		statuses  []Status
+		wantErr   error
+		err       string
+	}{
+		{
+			name:      "when the issues are transitioned by status name",
+			operation: Transition("done", nil),
+			calls:     []string{"get KP-1", "get KP-2", "move:31 KP-1", "move:31 KP-2", "transitions KP-1", "transitions KP-2"},
+			statuses:  []Status{SucceededStatus, SucceededStatus},
+		},
+		{
+			name:      "when the transition requires a field",
+			operation: Transition("Rejected", map[string]interface{}{"Resolution": map[string]interface{}{"name": "Won't Do"}}),
+			calls:     []string{"get KP-1", "get KP-2", "move:41 KP-1", "move:41 KP-2", "transitions KP-1", "transitions KP-2"},
+			statuses:  []Status{SucceededStatus, SucceededStatus},
+		},
+		{
+			name:      "when the required field is not provided",
+			operation: Transition("Rejected", nil),
+			calls:     []string{"get KP-1", "get KP-2", "transitions KP-1", "transitions KP-2"},
+			statuses:  []Status{FailedStatus, FailedStatus},
+			wantErr:   ErrPartialFailureError,
+			err:       "bulk: the operation failed on some issues: 2 of 2",
+		},
+		{
+			name:      "when the transition doesn't exist",
+			operation: Transition("Closed", nil),
+			calls:     []string{"get KP-1", "get KP-2", "transitions KP-1", "transitions KP-2"},
+			statuses:  []Status{FailedStatus, FailedStatus},
+			wantErr:   ErrPartialFailureError,
+			err:       "bulk: the operation failed on some issues: 2 of 2",
+		},
+		{
+			name:      "when the operation runs on dry-run",
+			operation: Transition("Done", nil),
+			options:   &Options{DryRun: true},
+			calls:     []string{"get KP-1", "get KP-2", "transitions KP-1", "transitions KP-2"},
+			statuses:  []Status{PlannedStatus, PlannedStatus},
+		},
+		{
+			name:      "when the issues are assigned",
+			operation: Assign("account-1"),
+			calls:     []string{"assign:account-1 KP-1", "assign:account-1 KP-2"},
+			statuses:  []Status{SucceededStatus, SucceededStatus},
+		},
+		{
+			name:      "when the run is resumed",
+			operation: AddLabels("archived"),
+			options: &Options{Completed: []*Result{
+				{Key: "KP-1", Operation: AddLabelsKind, Status: SucceededStatus},
+				{Key: "KP-2", Operation: DeleteKind, Status: SucceededStatus},
+			}},
+			calls:    []string{"update KP-2"},
+			statuses: []Status{SkippedStatus, SucceededStatus},
+		},
+		{
+			name:      "when the operation is invalid",
+			operation: RemoveLabels(),
+			wantErr:   ErrInvalidOperationError,
+			err:       "bulk: invalid operation: remove-labels requires the labels",
+		},
+		{
+			name:    "when the operation is not provided",
+			wantErr: ErrNoOperationError,
+			err:     "bulk: no operation set",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			fake := &issueFake{}
+
+			executor, err := NewExecutor(fake, nil, nil, testCase.options)
+			assert.NoError(t, err)
+
+			results, err := executor.Run(context.Background(), []string{"KP-1", "KP-2"}, testCase.operation)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				assert.EqualError(t, err, testCase.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.calls, sorted(fake.calls))
+
+			var statuses []Status
+			for _, result := range results {
+				statuses = append(statuses, result.Status)
+			}
+
+			assert.Equal(t, testCase.statuses, statuses)
+		})
+	}
+}
+
+func TestExecutor_RunJQL(t *testing.T) {
+
+	var keys []string
+	for index := 1; index <= 250; index++ {
+		keys = append(keys, fmt.Sprintf("KP-%v", index))
+	}
+
+	fake := &issueFake{limited: map[string]int{"KP-7": 2}, failed: map[string]bool{"KP-9": true}}
+	results := new(bytes.Buffer)
+
+	var (
+		mu       sync.Mutex
+		progress []*Progress
+	)
+
+	executor, err := NewExecutor(fake, &searchFake{keys: keys}, nil, &Options{
+		Concurrency: 8,
+		RetryWait:   time.Millisecond,
+		Results:     results,
+		Progress: func(p *Progress) {
+			mu.Lock()
+			defer mu.Unlock()
+			progress = append(progress, p)
+		},
+	})
+	assert.NoError(t, err)
+
+	got, err := executor.RunJQL(context.Background(), "project = KP", Delete(false))
+	assert.ErrorIs(t, err, ErrPartialFailureError)
+
+	assert.Len(t, got, 250)
+	assert.Equal(t, "KP-7", got[6].Key)
+	assert.Equal(t, SucceededStatus, got[6].Status)
+	assert.Equal(t, 2, got[6].Retries)
+	assert.Equal(t, FailedStatus, got[8].Status)
+	assert.Equal(t, model.ErrBadRequestError.Error(), got[8].Error)
+
+	last := progress[len(progress)-1]
+	assert.Equal(t, 250, last.Done)
+	assert.Equal(t, 249, last.Succeeded)
+	assert.Equal(t, 1, last.Failed)
+
+	// The failed issue is the only one retried when the run is resumed from the results.
+	completed, err := ReadResults(results)
+	assert.NoError(t, err)
+	assert.Len(t, completed, 250)
+
+	fake.calls, fake.failed = nil, nil
+
+	executor, err = NewExecutor(fake, &searchFake{keys: keys}, nil, &Options{Completed: completed})
+	assert.NoError(t, err)
+
+	_, err = executor.RunJQL(context.Background(), "project = KP", Delete(false))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"delete KP-9"}, fake.calls)
+}
+
+func TestExecutor_Create(t *testing.T) {
+
+	var issues []*model.IssueBulkSchemeV3
+	for index := 1; index <= 120; index++ {
+
+		summary := fmt.Sprintf("issue %v", index)
+		if index == 2 || index == 60 {
+			summary = "invalid"
+		}
+
+		issues = append(issues, &model.IssueBulkSchemeV3{Payload: &model.IssueScheme{Fields: &model.IssueFieldsScheme{Summary: summary}}})
+	}
+
+	issues = append(issues, nil)
+
+	fake := &issueFake{}
+
+	executor, err := NewExecutor(fake, nil, nil, &Options{Concurrency: 1})
+	assert.NoError(t, err)
+
+	results, err := executor.Create(context.Background(), issues)
+	assert.ErrorIs(t, err, ErrPartialFailureError)
+	assert.EqualError(t, err, "bulk: the operation failed on some issues: 3 of 121")
+
+	assert.Equal(t, []int{50, 50, 20}, fake.batches)
+
+	assert.Equal(t, "KP-1", results[0].Key)
+	assert.Equal(t, FailedStatus, results[1].Status)
+	assert.Equal(t, "summary: invalid summary", results[1].Error)
+	assert.Equal(t, "KP-3", results[2].Key)
+	assert.Equal(t, FailedStatus, results[59].Status)
+	assert.Equal(t, "KP-61", results[60].Key)
+	assert.Equal(t, model.ErrNoIssueSchemeError.Error(), results[120].Error)
+
+	_, err = executor.Create(context.Background(), nil)
+	assert.ErrorIs(t, err, model.ErrNoCreateIssuesError)
+}
+
+func TestExecutor_AddWatchers(t *testing.T) {
+
+	executor, err := NewExecutor(&issueFake{}, nil, nil, nil)
+	assert.NoError(t, err)
+
+	_, err = executor.Run(context.Background(), []string{"KP-1"}, AddWatchers("account-1"))
+	assert.ErrorIs(t, err, ErrNoWatcherConnectorError)
+
+	watcher := &watcherFake{}
+
+	executor, err = NewExecutor(&issueFake{}, nil, watcher, nil)
+	assert.NoError(t, err)
+
+	_, err = executor.Run(context.Background(), []string{"KP-1"}, AddWatchers("account-1", "account-2"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"KP-1 account-1", "KP-1 account-2"}, sorted(watcher.added))
+}
+
+func TestRetryAfter(t *testing.T) {
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{name: "when the header is in seconds", header: "5", want: 5 * time.Second},
+		{name: "when the header is a date", header: now.Add(time.Minute).Format(http.TimeFormat), want: time.Minute},
+		{name: "when the header is missing", want: -1},
+		{name: "when the header is invalid", header: "soon", want: -1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			header := http.Header{}
+			if testCase.header != "" {
+				header.Set("Retry-After", testCase.header)
+			}
+
+			response := &model.ResponseScheme{Response: &http.Response{Header: header}}
+			assert.Equal(t, testCase.want, retryAfter(response, now))
+		})
+	}
+}
@@ -0,0 +1,547 @@
+// Package bulk applies an operation to many issues, selected by JQL or by key, with a concurrency
+// limit, rate-limit retries, progress reporting and per-issue results, e.g:
+//
+//	executor, err := bulk.NewExecutor(instance.Issue, instance.Issue.Search, instance.Issue.Watcher, &bulk.Options{
+//		Concurrency: 8,
+//		Results:     file,
+//	})
+//
+//	results, err := executor.RunJQL(ctx, "project = KP AND status = Done", bulk.AddLabels("archived"))
+//
+// The results written on Options.Results are read with ReadResults and set on Options.Completed to
+// resume an interrupted run, the issues already processed are skipped.
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/internal/batch"
+	"github.com/ctreminiom/go-atlassian/jira/fields"
+	"github.com/ctreminiom/go-atlassian/jira/workflow"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/jira"
+)
+
+const (
+	defaultConcurrency = 4
+	defaultMaxRetries  = 3
+	defaultRetryWait   = time.Second
+
+	// maxCreates is the maximum number of issues created per request.
+	maxCreates = 50
+
+	searchPageSize = 100
+)
+
+var (
+	ErrNoIssueConnectorError   = errors.New("bulk: no issue connector set")
+	ErrNoSearchConnectorError  = errors.New("bulk: no search connector set")
+	ErrNoWatcherConnectorError = errors.New("bulk: no watcher connector set")
+	ErrNoOperationError        = errors.New("bulk: no operation set")
+	ErrInvalidOperationError   = errors.New("bulk: invalid operation")
+	ErrNoJQLError              = errors.New("bulk: no jql set")
+	ErrNoTransitionError       = errors.New("bulk: no transition to the status")
+	ErrPartialFailureError     = errors.New("bulk: the operation failed on some issues")
+)
+
+type Options struct {
+
+	// Concurrency is the maximum number of issues processed at once, 4 by default.
+	Concurrency int
+
+	// RequestsPerSecond limits the requests sent to the site, unlimited by default.
+	RequestsPerSecond float64
+
+	// MaxRetries is the number of retries of the requests rate limited, 3 by default. The requests
+	// are retried after the Retry-After header or, when missing, after RetryWait doubled per retry.
+	MaxRetries int
+
+	// RetryWait is the wait before the first retry when the response has no Retry-After header, 1s by default.
+	RetryWait time.Duration
+
+	// DryRun reports the issues the operation would be applied to without changing them. The
+	// read-only lookups, e.g: the transitions of the issues, are still sent.
+	DryRun bool
+
+	// Progress is called after each issue is processed, the calls are serialized.
+	Progress func(progress *Progress)
+
+	// Results receives each result as a JSON line as soon as the issue is processed, optional.
+	Results io.Writer
+
+	// Completed are the results of a previous run, e.g: read with ReadResults, the issues whose
+	// operation succeeded are skipped.
+	Completed []*Result
+
+	// Registry encodes the values of the transition operation by field type, the values are sent
+	// as they are when nil.
+	Registry *fields.Registry
+
+	// Workflow returns the workflow of the issues, it's required by the transition operation to
+	// reach the statuses that aren't directly reachable, e.g: workflow.WorkflowOf.
+	Workflow workflow.WorkflowFunc
+}
+
+// Executor applies the operations to the issues, the connectors are usually the Issue,
+// Issue.Search and Issue.Watcher services of the v3 client.
+type Executor struct {
+	issue   jira.IssueADFConnector
+	search  jira.SearchADFConnector
+	watcher jira.WatcherConnector
+	options Options
+}
+
+// NewExecutor returns an executor, the search connector is only required by RunJQL and the watcher
+// connector by the AddWatchers operation.
+func NewExecutor(issue jira.IssueADFConnector, search jira.SearchADFConnector, watcher jira.WatcherConnector,
+	options *Options) (*Executor, error) {
+
+	if issue == nil {
+		return nil, ErrNoIssueConnectorError
+	}
+
+	executor := &Executor{issue: issue, search: search, watcher: watcher}
+
+	if options != nil {
+		executor.options = *options
+	}
+
+	if executor.options.Concurrency <= 0 {
+		executor.options.Concurrency = defaultConcurrency
+	}
+
+	if executor.options.MaxRetries <= 0 {
+		executor.options.MaxRetries = defaultMaxRetries
+	}
+
+	if executor.options.RetryWait <= 0 {
+		executor.options.RetryWait = defaultRetryWait
+	}
+
+	return executor, nil
+}
+
+// RunJQL applies the operation to the issues matching the query. The keys are collected before the
+// operation is applied, so the issues that stop matching the query once changed aren't skipped.
+func (e *Executor) RunJQL(ctx context.Context, jql string, operation *Operation) ([]*Result, error) {
+
+	if jql == "" {
+		return nil, ErrNoJQLError
+	}
+
+	if e.search == nil {
+		return nil, ErrNoSearchConnectorError
+	}
+
+	if err := operation.validate(e); err != nil {
+		return nil, err
+	}
+
+	limiter := batch.NewLimiter(ctx, e.options.RequestsPerSecond)
+	defer limiter.Stop()
+
+	var keys []string
+	for startAt := 0; ; {
+
+		var page *model.IssueSearchScheme
+
+		_, err := e.call(ctx, limiter, func() (*model.ResponseScheme, error) {
+			var (
+				response *model.ResponseScheme
+				err      error
+			)
+
+			page, response, err = e.search.Post(ctx, jql, []string{"key"}, nil, startAt, searchPageSize, "")
+			return response, err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, issue := range page.Issues {
+			keys = append(keys, issue.Key)
+		}
+
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+
+	return e.run(ctx, limiter, keys, operation)
+}
+
+// Run applies the operation to the issues of the keys, the results are returned in the order of
+// the keys. ErrPartialFailureError is returned along the results when the operation failed on some issues.
+func (e *Executor) Run(ctx context.Context, keys []string, operation *Operation) ([]*Result, error) {
+
+	if err := operation.validate(e); err != nil {
+		return nil, err
+	}
+
+	limiter := batch.NewLimiter(ctx, e.options.RequestsPerSecond)
+	defer limiter.Stop()
+
+	return e.run(ctx, limiter, keys, operation)
+}
+
+func (e *Executor) run(ctx context.Context, limiter *batch.Limiter, keys []string, operation *Operation) ([]*Result, error) {
+
+	var (
+		completed = newCompleted(e.options.Completed)
+		results   = make([]*Result, len(keys))
+		tracker   = e.tracker(len(keys))
+	)
+
+	err := batch.Run(ctx, len(keys), e.options.Concurrency, func(index int) {
+
+		result := &Result{Index: index, Key: keys[index], Operation: operation.Kind}
+
+		switch {
+		case completed[completedKey(operation.Kind, result.Key, index)]:
+			result.Status = SkippedStatus
+
+		case result.Key == "":
+			result.Status, result.Error = FailedStatus, model.ErrNoIssueKeyOrIDError.Error()
+
+		default:
+
+			retries, err := operation.apply(ctx, e, limiter, result.Key)
+
+			result.Retries = retries
+			result.Status = SucceededStatus
+			if e.options.DryRun {
+				result.Status = PlannedStatus
+			}
+
+			if err != nil {
+				result.Status, result.Error = FailedStatus, err.Error()
+			}
+		}
+
+		results[index] = result
+		tracker.record(result)
+	})
+
+	// The issues left once the context is done fail with the context error.
+	if err != nil {
+		for index, result := range results {
+
+			if result != nil {
+				continue
+			}
+
+			results[index] = &Result{Index: index, Key: keys[index], Operation: operation.Kind, Status: FailedStatus, Error: err.Error()}
+			tracker.record(results[index])
+		}
+	}
+
+	return results, tracker.err()
+}
+
+// Create creates the issues in requests of 50 issues, the results are returned in the order of the
+// issues with the keys of the issues created. ErrPartialFailureError is returned along the results
+// when some issues couldn't be created.
+func (e *Executor) Create(ctx context.Context, issues []*model.IssueBulkSchemeV3) ([]*Result, error) {
+
+	if len(issues) == 0 {
+		return nil, model.ErrNoCreateIssuesError
+	}
+
+	limiter := batch.NewLimiter(ctx, e.options.RequestsPerSecond)
+	defer limiter.Stop()
+
+	var (
+		completed = newCompleted(e.options.Completed)
+		results   = make([]*Result, len(issues))
+		tracker   = e.tracker(len(issues))
+		chunks    [][]int
+		chunk     []int
+	)
+
+	for index, issue := range issues {
+
+		switch {
+		case completed[completedKey(CreateKind, "", index)]:
+			results[index] = &Result{Index: index, Operation: CreateKind, Status: SkippedStatus}
+			tracker.record(results[index])
+
+		case issue == nil || issue.Payload == nil:
+			results[index] = &Result{Index: index, Operation: CreateKind, Status: FailedStatus, Error: model.ErrNoIssueSchemeError.Error()}
+			tracker.record(results[index])
+
+		default:
+
+			chunk = append(chunk, index)
+			if len(chunk) == maxCreates {
+				chunks, chunk = append(chunks, chunk), nil
+			}
+		}
+	}
+
+	if len(chunk) != 0 {
+		chunks = append(chunks, chunk)
+	}
+
+	err := batch.Run(ctx, len(chunks), e.options.Concurrency, func(position int) {
+
+		indexes := chunks[position]
+
+		if e.options.DryRun {
+			for _, index := range indexes {
+				results[index] = &Result{Index: index, Operation: CreateKind, Status: PlannedStatus}
+				tracker.record(results[index])
+			}
+			return
+		}
+
+		payload := make([]*model.IssueBulkSchemeV3, len(indexes))
+		for position, index := range indexes {
+			payload[position] = issues[index]
+		}
+
+		var created *model.IssueBulkResponseScheme
+
+		retries, err := e.call(ctx, limiter, func() (*model.ResponseScheme, error) {
+			var (
+				response *model.ResponseScheme
+				err      error
+			)
+
+			created, response, err = e.issue.Creates(ctx, payload)
+
+			// Jira answers 400 when some issues fail, the body has both the issues created and the errors.
+			if err != nil && response != nil && response.Code == http.StatusBadRequest {
+
+				partial := new(model.IssueBulkResponseScheme)
+				if json.Unmarshal(response.Bytes.Bytes(), partial) == nil && len(partial.Errors) != 0 {
+					created = partial
+				}
+			}
+
+			return response, err
+		})
+
+		for _, result := range creates(indexes, created, retries, err) {
+			results[result.Index] = result
+			tracker.record(result)
+		}
+	})
+
+	// The issues left once the context is done fail with the context error.
+	if err != nil {
+		for index, result := range results {
+
+			if result != nil {
+				continue
+			}
+
+			results[index] = &Result{Index: index, Operation: CreateKind, Status: FailedStatus, Error: err.Error()}
+			tracker.record(results[index])
+		}
+	}
+
+	return results, tracker.err()
+}
+
+// creates maps the response of a bulk create to the results of the issues, the issues created are
+// returned in order and the failed ones are identified by their position on the request.
+func creates(indexes []int, created *model.IssueBulkResponseScheme, retries int, err error) []*Result {
+
+	results := make([]*Result, len(indexes))
+	for position, index := range indexes {
+		results[position] = &Result{Index: index, Operation: CreateKind, Status: SucceededStatus, Retries: retries}
+	}
+
+	if created == nil {
+
+		if err == nil {
+			err = errors.New("bulk: empty response")
+		}
+
+		for _, result := range results {
+			result.Status, result.Error = FailedStatus, err.Error()
+		}
+
+		return results
+	}
+
+	for _, failure := range created.Errors {
+
+		if failure == nil || failure.FailedElementNumber < 0 || failure.FailedElementNumber >= len(results) {
+			continue
+		}
+
+		result := results[failure.FailedElementNumber]
+		result.Status, result.Error = FailedStatus, describe(failure)
+	}
+
+	position := 0
+	for _, result := range results {
+
+		if result.Status == FailedStatus {
+			continue
+		}
+
+		if position >= len(created.Issues) {
+			result.Status = FailedStatus
+			result.Error = "bulk: the issue wasn't returned as created"
+			if err != nil {
+				result.Error = err.Error()
+			}
+			continue
+		}
+
+		result.Key = created.Issues[position].Key
+		position++
+	}
+
+	return results
+}
+
+// describe returns the messages of the error of a bulk create.
+func describe(failure *model.IssueBulkResponseErrorScheme) string {
+
+	var messages []string
+	messages = append(messages, failure.ElementErrors.ErrorMessages...)
+
+	fields := make([]string, 0, len(failure.ElementErrors.Errors))
+	for field := range failure.ElementErrors.Errors {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+	for _, field := range fields {
+		messages = append(messages, fmt.Sprintf("%v: %v", field, failure.ElementErrors.Errors[field]))
+	}
+
+	if len(messages) == 0 {
+		return fmt.Sprintf("bulk: the issue couldn't be created, status %v", failure.Status)
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+// call sends the request, retrying it when the response is rate limited, and returns the number of retries.
+func (e *Executor) call(ctx context.Context, limiter *batch.Limiter, fn func() (*model.ResponseScheme, error)) (int, error) {
+
+	wait := e.options.RetryWait
+
+	for retries := 0; ; retries++ {
+
+		if err := limiter.Wait(); err != nil {
+			return retries, err
+		}
+
+		response, err := fn()
+		if err == nil || retries == e.options.MaxRetries || !limited(response) {
+			return retries, err
+		}
+
+		delay := retryAfter(response, time.Now())
+		if delay < 0 {
+			delay, wait = wait, wait*2
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return retries, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// limited reports whether the response was rate limited or the site was temporarily unavailable.
+func limited(response *model.ResponseScheme) bool {
+	return response != nil && (response.Code == http.StatusTooManyRequests || response.Code == http.StatusServiceUnavailable)
+}
+
+// retryAfter returns the wait of the Retry-After header, in seconds or as an HTTP date, or -1 when missing.
+func retryAfter(response *model.ResponseScheme, now time.Time) time.Duration {
+
+	if response.Response == nil {
+		return -1
+	}
+
+	value := response.Header.Get("Retry-After")
+	if value == "" {
+		return -1
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+
+		if delay := date.Sub(now); delay > 0 {
+			return delay
+		}
+
+		return 0
+	}
+
+	return -1
+}
+
+// tracker counts the results and reports the progress and results as the issues are processed.
+type tracker struct {
+	options *Options
+
+	mu       sync.Mutex
+	progress Progress
+}
+
+func (e *Executor) tracker(total int) *tracker {
+	return &tracker{options: &e.options, progress: Progress{Total: total}}
+}
+
+func (t *tracker) record(result *Result) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.progress.Done++
+
+	switch result.Status {
+	case SucceededStatus, PlannedStatus, SkippedStatus:
+		t.progress.Succeeded++
+	case FailedStatus:
+		t.progress.Failed++
+	}
+
+	if t.options.Results != nil {
+		// The results are written best effort, a broken writer must not stop the run.
+		_ = WriteResults(t.options.Results, []*Result{result})
+	}
+
+	if t.options.Progress != nil {
+		progress := t.progress
+		progress.Result = result
+		t.options.Progress(&progress)
+	}
+}
+
+func (t *tracker) err() error {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.progress.Failed != 0 {
+		return fmt.Errorf("%w: %v of %v", ErrPartialFailureError, t.progress.Failed, t.progress.Total)
+	}
+
+	return nil
+}
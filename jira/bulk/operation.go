@@ -0,0 +1,316 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ctreminiom/go-atlassian/internal/batch"
+	"github.com/ctreminiom/go-atlassian/jira/workflow"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/jira"
+)
+
+// Kind is the kind of the operation applied to the issues.
+type Kind string
+
+const (
+	EditKind         Kind = "edit"
+	TransitionKind   Kind = "transition"
+	AssignKind       Kind = "assign"
+	AddLabelsKind    Kind = "add-labels"
+	RemoveLabelsKind Kind = "remove-labels"
+	AddWatchersKind  Kind = "add-watchers"
+	DeleteKind       Kind = "delete"
+	CreateKind       Kind = "create"
+)
+
+// Operation is the change applied to each issue of a run, it's built with Edit, Transition, Assign,
+// AddLabels, RemoveLabels, AddWatchers or Delete.
+type Operation struct {
+	Kind Kind
+
+	// Payload, CustomFields and Operations are the changes of the edit operation.
+	Payload      *model.IssueScheme
+	CustomFields *model.CustomFields
+	Operations   *model.UpdateOperations
+
+	// Status is the name of the status the transition operation moves the issues to.
+	Status string
+
+	// Values are the values of the fields of the transition screens, keyed by field ID, key or name.
+	Values map[string]interface{}
+
+	// AccountID is the assignee of the assign operation, "-1" assigns the project default assignee.
+	AccountID string
+
+	// Labels are added or removed by the label operations.
+	Labels []string
+
+	// AccountIDs are the watchers added by the add watchers operation, the calling user when empty.
+	AccountIDs []string
+
+	// DeleteSubTasks deletes the sub-tasks of the issues deleted.
+	DeleteSubTasks bool
+
+	// Notify notifies the watchers of the edits, true by default.
+	Notify bool
+}
+
+// Edit updates the fields of the issues, e.g: with the custom fields of a fields.Encoder.
+func Edit(payload *model.IssueScheme, customFields *model.CustomFields, operations *model.UpdateOperations) *Operation {
+
+	if payload == nil {
+		payload = &model.IssueScheme{}
+	}
+
+	return &Operation{Kind: EditKind, Payload: payload, CustomFields: customFields, Operations: operations, Notify: true}
+}
+
+// Transition moves the issues to the status with a workflow.Transitioner, the values are set on the
+// transition screens and the fields they require must be provided, see workflow.Transitioner.TransitionTo.
+func Transition(status string, values map[string]interface{}) *Operation {
+	return &Operation{Kind: TransitionKind, Status: status, Values: values}
+}
+
+// Assign assigns the issues to the user of the account ID.
+func Assign(accountID string) *Operation {
+	return &Operation{Kind: AssignKind, AccountID: accountID}
+}
+
+// AddLabels adds the labels to the issues, keeping their current labels.
+func AddLabels(labels ...string) *Operation {
+	return &Operation{Kind: AddLabelsKind, Labels: labels, Notify: true}
+}
+
+// RemoveLabels removes the labels from the issues, keeping their other labels.
+func RemoveLabels(labels ...string) *Operation {
+	return &Operation{Kind: RemoveLabelsKind, Labels: labels, Notify: true}
+}
+
+// AddWatchers adds the users of the account IDs as watchers of the issues, the calling user when none is provided.
+func AddWatchers(accountIDs ...string) *Operation {
+	return &Operation{Kind: AddWatchersKind, AccountIDs: accountIDs}
+}
+
+// Delete deletes the issues, the issues with sub-tasks are only deleted when deleteSubTasks is set.
+func Delete(deleteSubTasks bool) *Operation {
+	return &Operation{Kind: DeleteKind, DeleteSubTasks: deleteSubTasks}
+}
+
+func (o *Operation) validate(e *Executor) error {
+
+	if o == nil {
+		return ErrNoOperationError
+	}
+
+	switch o.Kind {
+	case EditKind:
+
+		if o.Payload == nil {
+			return fmt.Errorf("%w: %v requires a payload", ErrInvalidOperationError, o.Kind)
+		}
+
+	case TransitionKind:
+
+		if o.Status == "" {
+			return fmt.Errorf("%w: %v requires a status", ErrInvalidOperationError, o.Kind)
+		}
+
+	case AssignKind:
+
+		if o.AccountID == "" {
+			return fmt.Errorf("%w: %v requires an account ID", ErrInvalidOperationError, o.Kind)
+		}
+
+	case AddLabelsKind, RemoveLabelsKind:
+
+		if len(o.Labels) == 0 {
+			return fmt.Errorf("%w: %v requires the labels", ErrInvalidOperationError, o.Kind)
+		}
+
+	case AddWatchersKind:
+
+		if e.watcher == nil {
+			return ErrNoWatcherConnectorError
+		}
+
+	case DeleteKind:
+
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidOperationError, o.Kind)
+	}
+
+	return nil
+}
+
+// apply applies the operation to the issue, the read-only lookups are also done on dry-run so the
+// issues that would fail are reported.
+func (o *Operation) apply(ctx context.Context, e *Executor, limiter *batch.Limiter, key string) (int, error) {
+
+	retries := 0
+
+	call := func(fn func() (*model.ResponseScheme, error)) error {
+		attempts, err := e.call(ctx, limiter, fn)
+		retries += attempts
+		return err
+	}
+
+	switch o.Kind {
+	case EditKind:
+
+		if e.options.DryRun {
+			return 0, nil
+		}
+
+		return retries, call(func() (*model.ResponseScheme, error) {
+			return e.issue.Update(ctx, key, o.Notify, o.Payload, o.CustomFields, o.Operations)
+		})
+
+	case TransitionKind:
+
+		issue := &transitionIssue{IssueADFConnector: e.issue, call: call, dryRun: e.options.DryRun}
+
+		transitioner, err := workflow.NewTransitioner(issue, e.options.Registry, &workflow.Options{Workflow: e.options.Workflow})
+		if err != nil {
+			return retries, err
+		}
+
+		_, err = transitioner.TransitionTo(ctx, key, o.Status, o.Values)
+		if errors.Is(err, workflow.ErrUnreachableStatusError) {
+			return retries, fmt.Errorf("%w: %v", ErrNoTransitionError, o.Status)
+		}
+
+		return retries, err
+
+	case AssignKind:
+
+		if e.options.DryRun {
+			return 0, nil
+		}
+
+		return retries, call(func() (*model.ResponseScheme, error) {
+			return e.issue.Assign(ctx, key, o.AccountID)
+		})
+
+	case AddLabelsKind, RemoveLabelsKind:
+
+		if e.options.DryRun {
+			return 0, nil
+		}
+
+		verb := "add"
+		if o.Kind == RemoveLabelsKind {
+			verb = "remove"
+		}
+
+		mapping := make(map[string]string, len(o.Labels))
+		for _, label := range o.Labels {
+			mapping[label] = verb
+		}
+
+		operations := &model.UpdateOperations{}
+		if err := operations.AddArrayOperation("labels", mapping); err != nil {
+			return 0, err
+		}
+
+		return retries, call(func() (*model.ResponseScheme, error) {
+			return e.issue.Update(ctx, key, o.Notify, &model.IssueScheme{}, nil, operations)
+		})
+
+	case AddWatchersKind:
+
+		if e.options.DryRun {
+			return 0, nil
+		}
+
+		if len(o.AccountIDs) == 0 {
+			return retries, call(func() (*model.ResponseScheme, error) {
+				return e.watcher.Add(ctx, key)
+			})
+		}
+
+		for _, accountID := range o.AccountIDs {
+
+			accountID := accountID
+			err := call(func() (*model.ResponseScheme, error) {
+				return e.watcher.AddUser(ctx, key, accountID)
+			})
+			if err != nil {
+				return retries, fmt.Errorf("%v: %w", accountID, err)
+			}
+		}
+
+		return retries, nil
+
+	case DeleteKind:
+
+		if e.options.DryRun {
+			return 0, nil
+		}
+
+		return retries, call(func() (*model.ResponseScheme, error) {
+			return e.issue.Delete(ctx, key, o.DeleteSubTasks)
+		})
+	}
+
+	return 0, fmt.Errorf("%w: unknown kind %q", ErrInvalidOperationError, o.Kind)
+}
+
+// transitionIssue sends the calls of the transitioner through the limiter and the retries of the
+// executor, the transitions aren't performed on dry-run.
+type transitionIssue struct {
+	jira.IssueADFConnector
+
+	call   func(fn func() (*model.ResponseScheme, error)) error
+	dryRun bool
+}
+
+func (t *transitionIssue) Get(ctx context.Context, issueKeyOrID string, fields, expand []string) (*model.IssueScheme, *model.ResponseScheme, error) {
+
+	var (
+		issue    *model.IssueScheme
+		response *model.ResponseScheme
+	)
+
+	err := t.call(func() (*model.ResponseScheme, error) {
+		var err error
+		issue, response, err = t.IssueADFConnector.Get(ctx, issueKeyOrID, fields, expand)
+		return response, err
+	})
+
+	return issue, response, err
+}
+
+func (t *transitionIssue) TransitionsWithFields(ctx context.Context, issueKeyOrID string) (*model.IssueTransitionsScheme, *model.ResponseScheme, error) {
+
+	var (
+		transitions *model.IssueTransitionsScheme
+		response    *model.ResponseScheme
+	)
+
+	err := t.call(func() (*model.ResponseScheme, error) {
+		var err error
+		transitions, response, err = t.IssueADFConnector.TransitionsWithFields(ctx, issueKeyOrID)
+		return response, err
+	})
+
+	return transitions, response, err
+}
+
+func (t *transitionIssue) Move(ctx context.Context, issueKeyOrID, transitionID string, options *model.IssueMoveOptionsV3) (*model.ResponseScheme, error) {
+
+	if t.dryRun {
+		return nil, nil
+	}
+
+	var response *model.ResponseScheme
+
+	err := t.call(func() (*model.ResponseScheme, error) {
+		var err error
+		response, err = t.IssueADFConnector.Move(ctx, issueKeyOrID, transitionID, options)
+		return response, err
+	})
+
+	return response, err
+}
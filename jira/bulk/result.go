@@ -0,0 +1,123 @@
+package bulk
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Status is the outcome of the operation on an issue.
+type Status string
+
+const (
+	// SucceededStatus is set when the operation was applied.
+	SucceededStatus Status = "succeeded"
+
+	// FailedStatus is set when the operation couldn't be applied, the error is set on the result.
+	FailedStatus Status = "failed"
+
+	// PlannedStatus is set on dry-run, when the operation would be applied.
+	PlannedStatus Status = "planned"
+
+	// SkippedStatus is set when the operation already succeeded on a previous run being resumed.
+	SkippedStatus Status = "skipped"
+)
+
+// Result is the outcome of the operation on an issue, the results are written one per line on the
+// Options.Results writer so an interrupted run can be resumed from them.
+type Result struct {
+
+	// Index is the position of the issue on the keys or the issues created.
+	Index int `json:"index"`
+
+	// Key is the issue key, for creates it's the key of the issue created.
+	Key string `json:"key,omitempty"`
+
+	Operation Kind   `json:"operation"`
+	Status    Status `json:"status"`
+	Error     string `json:"error,omitempty"`
+
+	// Retries is the number of requests retried after being rate limited.
+	Retries int `json:"retries,omitempty"`
+}
+
+// done reports whether the result doesn't need to be retried when a run is resumed.
+func (r *Result) done() bool {
+	return r.Status == SucceededStatus || r.Status == SkippedStatus
+}
+
+// Progress is reported after each issue is processed.
+type Progress struct {
+	Total     int
+	Done      int
+	Succeeded int
+	Failed    int
+
+	// Result is the result of the issue just processed.
+	Result *Result
+}
+
+// ReadResults reads the results written on the Options.Results writer by a previous run, the
+// lines that can't be decoded, e.g: the last line of an interrupted run, are ignored.
+func ReadResults(r io.Reader) ([]*Result, error) {
+
+	var results []*Result
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		result := new(Result)
+		if err := json.Unmarshal([]byte(line), result); err != nil {
+			continue
+		}
+
+		results = append(results, result)
+	}
+
+	return results, scanner.Err()
+}
+
+// WriteResults writes the results one per line, the format read by ReadResults.
+func WriteResults(w io.Writer, results []*Result) error {
+
+	encoder := json.NewEncoder(w)
+	for _, result := range results {
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// completed indexes the results of a previous run that are done by operation and key, or
+// operation and index for the creates.
+type completed map[string]bool
+
+func newCompleted(results []*Result) completed {
+
+	index := completed{}
+	for _, result := range results {
+		if result != nil && result.done() {
+			index[completedKey(result.Operation, result.Key, result.Index)] = true
+		}
+	}
+
+	return index
+}
+
+func completedKey(operation Kind, key string, index int) string {
+
+	if operation == CreateKind {
+		return fmt.Sprintf("%v/%v", operation, index)
+	}
+
+	return fmt.Sprintf("%v/%v", operation, key)
+}
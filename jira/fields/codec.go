@@ -0,0 +1,462 @@
+package fields
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+var (
+	ErrValueTypeError  = errors.New("fields: the value doesn't match the field type")
+	ErrEmptyValueError = errors.New("fields: the field has no value")
+	ErrNoFieldsError   = errors.New("fields: the issue has no fields")
+)
+
+// The layouts of the date and date-time values returned by Jira.
+const (
+	DateLayout     = "2006-01-02"
+	DateTimeLayout = "2006-01-02T15:04:05.000-0700"
+)
+
+// Encoder sets the values of the fields by name on the custom fields of the IssueADFService and
+// IssueRichTextService Create and Update methods.
+type Encoder struct {
+	snapshot *snapshot
+	fields   *model.CustomFields
+}
+
+// NewEncoder returns an encoder of the fields of the registry.
+func (r *Registry) NewEncoder(ctx context.Context) (*Encoder, error) {
+
+	snapshot, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Encoder{snapshot: snapshot, fields: &model.CustomFields{}}, nil
+}
+
+// CustomFields returns the custom fields with the values set.
+func (e *Encoder) CustomFields() *model.CustomFields {
+	return e.fields
+}
+
+// Set sets the value of the field, the Go type of the value depends on the field type:
+//
+//   - text, url, select, radiobutton, user and group: string, the users are account IDs.
+//   - number: int, int64 or float64.
+//   - date and datetime: time.Time.
+//   - multiselect, checkbox, users, groups and labels: []string.
+//   - cascading: []string with the parent and the child option.
+//   - version: string and versions: []string, the version names.
+//   - sprint: int, the sprint ID.
+//   - project and parent: string, the keys.
+//   - issuetype, priority and resolution: string and components: []string, the names.
+//
+// The values of the fields of unknown type are sent as they are.
+func (e *Encoder) Set(name string, value interface{}) error {
+
+	field, err := e.snapshot.resolve(name)
+	if err != nil {
+		return err
+	}
+
+	return e.set(field, field.Type, value)
+}
+
+func (e *Encoder) set(field *Field, fieldType Type, value interface{}) error {
+
+	mismatch := fmt.Errorf("%w: %v is %v, got %T", ErrValueTypeError, field.Name, fieldType, value)
+
+	switch fieldType {
+	case TypeProject, TypeParent:
+
+		key, ok := value.(string)
+		if !ok {
+			return mismatch
+		}
+
+		e.raw(field.ID, map[string]interface{}{"key": key})
+		return nil
+
+	case TypeText, TypeURL, TypeSelect, TypeRadioButton, TypeUser, TypeGroup, TypeVersion, TypeIssueType, TypePriority, TypeResolution:
+
+		text, ok := value.(string)
+		if !ok {
+			return mismatch
+		}
+
+		switch fieldType {
+		case TypeText:
+			return e.fields.Text(field.ID, text)
+		case TypeURL:
+			return e.fields.URL(field.ID, text)
+		case TypeSelect:
+			return e.fields.Select(field.ID, text)
+		case TypeRadioButton:
+			return e.fields.RadioButton(field.ID, text)
+		case TypeUser:
+			return e.fields.User(field.ID, text)
+		case TypeGroup:
+			return e.fields.Group(field.ID, text)
+		}
+
+		e.raw(field.ID, map[string]interface{}{"name": text})
+		return nil
+
+	case TypeMultiSelect, TypeCheckBox, TypeUsers, TypeGroups, TypeLabels, TypeVersions, TypeComponents, TypeCascading:
+
+		values, ok := value.([]string)
+		if !ok {
+			return mismatch
+		}
+
+		switch fieldType {
+		case TypeMultiSelect:
+			return e.fields.MultiSelect(field.ID, values)
+		case TypeCheckBox:
+			return e.fields.CheckBox(field.ID, values)
+		case TypeUsers:
+			return e.fields.Users(field.ID, values)
+		case TypeGroups:
+			return e.fields.Groups(field.ID, values)
+		case TypeLabels:
+			e.raw(field.ID, values)
+			return nil
+		case TypeCascading:
+
+			if len(values) != 2 {
+				return mismatch
+			}
+
+			return e.fields.Cascading(field.ID, values[0], values[1])
+		}
+
+		references := make([]map[string]interface{}, len(values))
+		for index, name := range values {
+			references[index] = map[string]interface{}{"name": name}
+		}
+
+		e.raw(field.ID, references)
+		return nil
+
+	case TypeNumber:
+
+		number, ok := toFloat(value)
+		if !ok {
+			return mismatch
+		}
+
+		return e.fields.Number(field.ID, number)
+
+	case TypeDate, TypeDateTime:
+
+		date, ok := value.(time.Time)
+		if !ok {
+			return mismatch
+		}
+
+		if fieldType == TypeDate {
+			return e.fields.Date(field.ID, date)
+		}
+
+		return e.fields.DateTime(field.ID, date)
+
+	case TypeSprint:
+
+		id, ok := value.(int)
+		if !ok {
+			return mismatch
+		}
+
+		e.raw(field.ID, id)
+		return nil
+	}
+
+	e.raw(field.ID, value)
+	return nil
+}
+
+func (e *Encoder) raw(id string, value interface{}) {
+	e.fields.Fields = append(e.fields.Fields, map[string]interface{}{"fields": map[string]interface{}{id: value}})
+}
+
+func toFloat(value interface{}) (float64, bool) {
+
+	switch value := value.(type) {
+	case int:
+		return float64(value), true
+	case int32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case float32:
+		return float64(value), true
+	case float64:
+		return value, true
+	}
+
+	return 0, false
+}
+
+// Values are the field values of an issue decoded once, e.g: from the response of IssueADFService.Get.
+type Values struct {
+	ID  string
+	Key string
+
+	snapshot *snapshot
+	fields   map[string]json.RawMessage
+}
+
+// Decode decodes the fields of the issue, the values are read by field name.
+func (r *Registry) Decode(ctx context.Context, data []byte) (*Values, error) {
+
+	snapshot, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var issue struct {
+		ID     string                     `json:"id"`
+		Key    string                     `json:"key"`
+		Fields map[string]json.RawMessage `json:"fields"`
+	}
+
+	if err := json.Unmarshal(data, &issue); err != nil {
+		return nil, err
+	}
+
+	if issue.Fields == nil {
+		return nil, ErrNoFieldsError
+	}
+
+	return &Values{ID: issue.ID, Key: issue.Key, snapshot: snapshot, fields: issue.Fields}, nil
+}
+
+// Raw returns the JSON value of the field.
+func (v *Values) Raw(name string) (*Field, json.RawMessage, error) {
+
+	field, err := v.snapshot.resolve(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raw, ok := v.fields[field.ID]
+	if !ok || bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return field, nil, fmt.Errorf("%w: %v", ErrEmptyValueError, field.Name)
+	}
+
+	return field, raw, nil
+}
+
+// Get returns the value of the field decoded by field type:
+//
+//   - text and url: string.
+//   - number: float64.
+//   - date and datetime: time.Time.
+//   - select and radiobutton: *model.CustomFieldContextOptionScheme.
+//   - multiselect and checkbox: []*model.CustomFieldContextOptionScheme.
+//   - cascading: *model.CascadingSelectScheme.
+//   - user: *model.UserDetailScheme and users: []*model.UserDetailScheme.
+//   - group: *model.GroupDetailScheme and groups: []*model.GroupDetailScheme.
+//   - labels: []string.
+//   - version: *model.VersionDetailScheme and versions: []*model.VersionDetailScheme.
+//   - sprint: []*model.SprintDetailScheme.
+//   - project: *model.ProjectScheme and parent: *model.ParentScheme.
+//   - issuetype: *model.IssueTypeScheme, priority: *model.PriorityScheme and resolution: *model.ResolutionScheme.
+//   - components: []*model.ComponentScheme.
+//
+// The values of the fields of unknown type are returned as json.RawMessage.
+func (v *Values) Get(name string) (interface{}, error) {
+
+	field, raw, err := v.Raw(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+
+	switch field.Type {
+	case TypeText, TypeURL:
+		value = new(string)
+	case TypeNumber:
+		value = new(float64)
+	case TypeDate, TypeDateTime:
+		return parseTime(field, raw)
+	case TypeSelect, TypeRadioButton:
+		value = new(model.CustomFieldContextOptionScheme)
+	case TypeMultiSelect, TypeCheckBox:
+		value = new([]*model.CustomFieldContextOptionScheme)
+	case TypeCascading:
+		value = new(model.CascadingSelectScheme)
+	case TypeUser:
+		value = new(model.UserDetailScheme)
+	case TypeUsers:
+		value = new([]*model.UserDetailScheme)
+	case TypeGroup:
+		value = new(model.GroupDetailScheme)
+	case TypeGroups:
+		value = new([]*model.GroupDetailScheme)
+	case TypeLabels:
+		value = new([]string)
+	case TypeVersion:
+		value = new(model.VersionDetailScheme)
+	case TypeVersions:
+		value = new([]*model.VersionDetailScheme)
+	case TypeSprint:
+		value = new([]*model.SprintDetailScheme)
+	case TypeProject:
+		value = new(model.ProjectScheme)
+	case TypeIssueType:
+		value = new(model.IssueTypeScheme)
+	case TypePriority:
+		value = new(model.PriorityScheme)
+	case TypeResolution:
+		value = new(model.ResolutionScheme)
+	case TypeParent:
+		value = new(model.ParentScheme)
+	case TypeComponents:
+		value = new([]*model.ComponentScheme)
+	default:
+		return raw, nil
+	}
+
+	if err := decode(field, raw, value); err != nil {
+		return nil, err
+	}
+
+	// The structs are returned as pointers and the other values as they are.
+	if element := reflect.ValueOf(value).Elem(); element.Kind() != reflect.Struct {
+		return element.Interface(), nil
+	}
+
+	return value, nil
+}
+
+// String returns the value of a text or url field.
+func (v *Values) String(name string) (string, error) {
+
+	var value string
+	if err := v.decode(name, &value); err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
+
+// Number returns the value of a number field.
+func (v *Values) Number(name string) (float64, error) {
+
+	var value float64
+	if err := v.decode(name, &value); err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// Time returns the value of a date or datetime field.
+func (v *Values) Time(name string) (time.Time, error) {
+
+	field, raw, err := v.Raw(name)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return parseTime(field, raw)
+}
+
+// Option returns the value of a select or radiobutton field.
+func (v *Values) Option(name string) (*model.CustomFieldContextOptionScheme, error) {
+
+	value := new(model.CustomFieldContextOptionScheme)
+	return value, v.decode(name, value)
+}
+
+// Options returns the value of a multiselect or checkbox field.
+func (v *Values) Options(name string) ([]*model.CustomFieldContextOptionScheme, error) {
+
+	var value []*model.CustomFieldContextOptionScheme
+	if err := v.decode(name, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// Cascading returns the value of a cascading field.
+func (v *Values) Cascading(name string) (*model.CascadingSelectScheme, error) {
+
+	value := new(model.CascadingSelectScheme)
+	return value, v.decode(name, value)
+}
+
+// User returns the value of a user field.
+func (v *Values) User(name string) (*model.UserDetailScheme, error) {
+
+	value := new(model.UserDetailScheme)
+	return value, v.decode(name, value)
+}
+
+// Users returns the value of a users field.
+func (v *Values) Users(name string) ([]*model.UserDetailScheme, error) {
+
+	var value []*model.UserDetailScheme
+	if err := v.decode(name, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// Strings returns the value of a labels field.
+func (v *Values) Strings(name string) ([]string, error) {
+
+	var value []string
+	if err := v.decode(name, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (v *Values) decode(name string, value interface{}) error {
+
+	field, raw, err := v.Raw(name)
+	if err != nil {
+		return err
+	}
+
+	return decode(field, raw, value)
+}
+
+func decode(field *Field, raw json.RawMessage, value interface{}) error {
+
+	if err := json.Unmarshal(raw, value); err != nil {
+		return fmt.Errorf("%w: %v is %v, got %s", ErrValueTypeError, field.Name, field.Type, raw)
+	}
+
+	return nil
+}
+
+func parseTime(field *Field, raw json.RawMessage) (time.Time, error) {
+
+	var text string
+	if err := decode(field, raw, &text); err != nil {
+		return time.Time{}, err
+	}
+
+	for _, layout := range []string{DateTimeLayout, DateLayout, time.RFC3339} {
+		if value, err := time.Parse(layout, text); err == nil {
+			return value, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %v is %v, got %s", ErrValueTypeError, field.Name, field.Type, raw)
+}
@@ -0,0 +1,319 @@
+package fields
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/jira"
+	"github.com/stretchr/testify/assert"
+)
+
+type fieldFake struct {
+	jira.FieldConnector
+	fields []*model.IssueFieldScheme
+	calls  int
+
+	// loading and release block Gets until the release channel is closed, when they're set.
+	loading chan struct{}
+	release chan struct{}
+}
+
+func (f *fieldFake) Gets(ctx context.Context) ([]*model.IssueFieldScheme, *model.ResponseScheme, error) {
+
+	f.calls++
+
+	if f.release != nil {
+		close(f.loading)
+		<-f.release
+	}
+
+	return f.fields, nil, nil
+}
+
+func custom(id, name, key string) *model.IssueFieldScheme {
+	return &model.IssueFieldScheme{
+		ID:     id,
+		Key:    id,
+		Name:   name,
+		Custom: true,
+		Schema: &model.IssueFieldSchemaScheme{Custom: "com.atlassian.jira.plugin.system.customfieldtypes:" + key},
+	}
+}
+
+func newFake() *fieldFake {
+	return &fieldFake{fields: []*model.IssueFieldScheme{
+		{ID: "summary", Key: "summary", Name: "Summary", Schema: &model.IssueFieldSchemaScheme{Type: "string", System: "summary"}},
+		{ID: "labels", Key: "labels", Name: "Labels", Schema: &model.IssueFieldSchemaScheme{Type: "array", Items: "string", System: "labels"}},
+		{ID: "duedate", Key: "duedate", Name: "Due date", Schema: &model.IssueFieldSchemaScheme{Type: "date", System: "duedate"}},
+		{ID: "project", Key: "project", Name: "Project", Schema: &model.IssueFieldSchemaScheme{Type: "project", System: "project"}},
+		{ID: "issuetype", Key: "issuetype", Name: "Issue Type", Schema: &model.IssueFieldSchemaScheme{Type: "issuetype", System: "issuetype"}},
+		{ID: "description", Key: "description", Name: "Description", Schema: &model.IssueFieldSchemaScheme{Type: "string", System: "description"}},
+		{ID: "assignee", Key: "assignee", Name: "Assignee", Schema: &model.IssueFieldSchemaScheme{Type: "user", System: "assignee"}},
+		{ID: "fixVersions", Key: "fixVersions", Name: "Fix versions", Schema: &model.IssueFieldSchemaScheme{Type: "array", Items: "version", System: "fixVersions"}},
+		custom("customfield_10010", "Story Points", "float"),
+		custom("customfield_10011", "Team", "select"),
+		custom("customfield_10012", "Region", "cascadingselect"),
+		custom("customfield_10013", "Reviewers", "multiuserpicker"),
+		custom("customfield_10014", "Launch", "datetime"),
+		custom("customfield_10015", "Owner", "userpicker"),
+		custom("customfield_10016", "Owner", "grouppicker"),
+		{ID: "customfield_10020", Name: "Sprint", Custom: true, Schema: &model.IssueFieldSchemaScheme{
+			Type: "array", Items: "json", Custom: "com.pyxis.greenhopper.jira:gh-sprint"}},
+		{ID: "customfield_10021", Name: "Legacy Sprint", Custom: true, Schema: &model.IssueFieldSchemaScheme{Custom: "com.example:sprint"}},
+		{ID: "customfield_10030", Name: "Rating", Custom: true, Schema: &model.IssueFieldSchemaScheme{Custom: "com.example:stars"}},
+	}}
+}
+
+func TestRegistry_Resolve(t *testing.T) {
+
+	fake := newFake()
+
+	registry, err := NewRegistry(fake, nil)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		field   string
+		want    string
+		typ     Type
+		wantErr error
+		err     string
+	}{
+		{name: "when the field is resolved by name", field: "story points", want: "customfield_10010", typ: TypeNumber},
+		{name: "when the field is resolved by ID", field: "customfield_10011", want: "customfield_10011", typ: TypeSelect},
+		{name: "when the field is resolved by JQL reference", field: "cf[10012]", want: "customfield_10012", typ: TypeCascading},
+		{name: "when the field is a system field", field: "Labels", want: "labels", typ: TypeLabels},
+		{name: "when the field is a sprint field", field: "Sprint", want: "customfield_10020", typ: TypeSprint},
+		{name: "when the field type is unknown", field: "Rating", want: "customfield_10030", typ: TypeUnknown},
+		{
+			name:    "when the field name is ambiguous",
+			field:   "Owner",
+			wantErr: ErrAmbiguousFieldError,
+			err:     "fields: ambiguous field name: Owner matches customfield_10015, customfield_10016",
+		},
+		{
+			name:    "when the field doesn't exist",
+			field:   "Severity",
+			wantErr: ErrUnknownFieldError,
+			err:     "fields: unknown field: Severity",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			field, err := registry.Resolve(context.Background(), testCase.field)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				assert.EqualError(t, err, testCase.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, field.ID)
+			assert.Equal(t, testCase.typ, field.Type)
+		})
+	}
+
+	// The fields are loaded once until invalidated.
+	assert.Equal(t, 1, fake.calls)
+
+	registry.Invalidate()
+
+	_, err = registry.Resolve(context.Background(), "Team")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fake.calls)
+}
+
+func TestRegistry_TTL(t *testing.T) {
+
+	fake := newFake()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	registry, err := NewRegistry(fake, &Options{TTL: time.Hour, Now: func() time.Time { return now }})
+	assert.NoError(t, err)
+
+	_, err = registry.Fields(context.Background())
+	assert.NoError(t, err)
+
+	now = now.Add(30 * time.Minute)
+	_, err = registry.Fields(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fake.calls)
+
+	now = now.Add(time.Hour)
+	_, err = registry.Fields(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fake.calls)
+}
+
+func TestRegistry_InvalidateWhileLoading(t *testing.T) {
+
+	fake := newFake()
+	fake.loading, fake.release = make(chan struct{}), make(chan struct{})
+
+	registry, err := NewRegistry(fake, nil)
+	assert.NoError(t, err)
+
+	loaded := make(chan error)
+	go func() {
+		_, err := registry.Fields(context.Background())
+		loaded <- err
+	}()
+
+	// The cache isn't locked by the load, and the fields loaded before the invalidation aren't cached.
+	<-fake.loading
+	registry.Invalidate()
+	close(fake.release)
+
+	assert.NoError(t, <-loaded)
+
+	fake.release = nil
+	_, err = registry.Fields(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fake.calls)
+}
+
+func TestSites(t *testing.T) {
+
+	first, second := newFake(), newFake()
+	sites := NewSites(nil)
+
+	registry, err := sites.Registry("https://first.atlassian.net", first)
+	assert.NoError(t, err)
+
+	cached, err := sites.Registry("https://first.atlassian.net", second)
+	assert.NoError(t, err)
+	assert.Same(t, registry, cached)
+
+	other, err := sites.Registry("https://second.atlassian.net", second)
+	assert.NoError(t, err)
+	assert.NotSame(t, registry, other)
+
+	_, err = sites.Registry("", first)
+	assert.ErrorIs(t, err, ErrNoSiteError)
+
+	_, _ = registry.Fields(context.Background())
+	sites.Invalidate("https://first.atlassian.net")
+	_, _ = registry.Fields(context.Background())
+	assert.Equal(t, 2, first.calls)
+}
+
+func TestEncoder_Set(t *testing.T) {
+
+	registry, err := NewRegistry(newFake(), nil)
+	assert.NoError(t, err)
+
+	encoder, err := registry.NewEncoder(context.Background())
+	assert.NoError(t, err)
+
+	assert.NoError(t, encoder.Set("Story Points", 5))
+	assert.NoError(t, encoder.Set("Team", "Platform"))
+	assert.NoError(t, encoder.Set("Region", []string{"Europe", "Spain"}))
+	assert.NoError(t, encoder.Set("Reviewers", []string{"account-1"}))
+	assert.NoError(t, encoder.Set("Labels", []string{"backend"}))
+	assert.NoError(t, encoder.Set("Due date", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+	assert.NoError(t, encoder.Set("Sprint", 7))
+	assert.NoError(t, encoder.Set("Rating", map[string]int{"stars": 4}))
+
+	err = encoder.Set("Team", 1)
+	assert.ErrorIs(t, err, ErrValueTypeError)
+	assert.EqualError(t, err, "fields: the value doesn't match the field type: Team is select, got int")
+
+	payload, err := (&model.IssueScheme{Fields: &model.IssueFieldsScheme{Summary: "Launch"}}).MergeCustomFields(encoder.CustomFields())
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(payload)
+	assert.NoError(t, err)
+
+	assert.JSONEq(t, `{"fields":{
+		"summary":"Launch",
+		"customfield_10010":5,
+		"customfield_10011":{"value":"Platform"},
+		"customfield_10012":{"value":"Europe","child":{"value":"Spain"}},
+		"customfield_10013":[{"accountId":"account-1"}],
+		"labels":["backend"],
+		"duedate":"2024-03-01",
+		"customfield_10020":7,
+		"customfield_10030":{"stars":4}
+	}}`, string(data))
+}
+
+func TestValues(t *testing.T) {
+
+	registry, err := NewRegistry(newFake(), nil)
+	assert.NoError(t, err)
+
+	values, err := registry.Decode(context.Background(), []byte(`{"id":"10001","key":"KP-1","fields":{
+		"summary":"Launch",
+		"labels":["backend","api"],
+		"duedate":"2024-03-01",
+		"customfield_10010":5.5,
+		"customfield_10011":{"id":"1","value":"Platform"},
+		"customfield_10012":{"id":"2","value":"Europe","child":{"id":"3","value":"Spain"}},
+		"customfield_10013":[{"accountId":"account-1"}],
+		"customfield_10014":"2024-03-01T10:30:00.000+0100",
+		"customfield_10020":[{"id":7,"name":"Sprint 7"}],
+		"customfield_10030":{"stars":4},
+		"customfield_10015":null
+	}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "KP-1", values.Key)
+
+	summary, err := values.String("Summary")
+	assert.NoError(t, err)
+	assert.Equal(t, "Launch", summary)
+
+	points, err := values.Number("cf[10010]")
+	assert.NoError(t, err)
+	assert.Equal(t, 5.5, points)
+
+	team, err := values.Option("Team")
+	assert.NoError(t, err)
+	assert.Equal(t, "Platform", team.Value)
+
+	region, err := values.Cascading("Region")
+	assert.NoError(t, err)
+	assert.Equal(t, "Spain", region.Child.Value)
+
+	reviewers, err := values.Users("Reviewers")
+	assert.NoError(t, err)
+	assert.Equal(t, "account-1", reviewers[0].AccountID)
+
+	launch, err := values.Time("Launch")
+	assert.NoError(t, err)
+	assert.True(t, launch.Equal(time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)))
+
+	labels, err := values.Get("Labels")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"backend", "api"}, labels)
+
+	due, err := values.Get("Due date")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), due)
+
+	sprints, err := values.Get("Sprint")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sprint 7", sprints.([]*model.SprintDetailScheme)[0].Name)
+
+	option, err := values.Get("Team")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", option.(*model.CustomFieldContextOptionScheme).ID)
+
+	rating, err := values.Get("Rating")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"stars":4}`, string(rating.(json.RawMessage)))
+
+	_, err = values.User("customfield_10015")
+	assert.ErrorIs(t, err, ErrEmptyValueError)
+
+	_, err = values.Number("Team")
+	assert.ErrorIs(t, err, ErrValueTypeError)
+
+	_, err = registry.Decode(context.Background(), []byte(`{"key":"KP-1"}`))
+	assert.ErrorIs(t, err, ErrNoFieldsError)
+}
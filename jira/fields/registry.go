@@ -0,0 +1,306 @@
+// Package fields resolves the Jira fields by name and encodes and decodes their values by type,
+// so the custom fields can be set and read without hardcoding their customfield_NNNNN IDs, e.g:
+//
+//	registry, err := fields.NewRegistry(instance.Issue.Field, nil)
+//
+//	encoder, err := registry.NewEncoder(ctx)
+//	err = encoder.Set("Story Points", 5)
+//	_, _, err = instance.Issue.Create(ctx, payload, encoder.CustomFields())
+//
+//	values, err := registry.Decode(ctx, response.Bytes.Bytes())
+//	points, err := values.Number("Story Points")
+//
+// The issues modeled as structs are encoded and decoded with the IssueCodec.
+package fields
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/jira"
+)
+
+var (
+	ErrNoFieldConnectorError = errors.New("fields: no field connector set")
+	ErrNoSiteError           = errors.New("fields: no site set")
+	ErrUnknownFieldError     = errors.New("fields: unknown field")
+	ErrAmbiguousFieldError   = errors.New("fields: ambiguous field name")
+)
+
+type Options struct {
+
+	// TTL reloads the fields once expired, the fields are only reloaded when invalidated by default.
+	TTL time.Duration
+
+	// Now times the loads of the fields for the TTL, time.Now by default.
+	Now func() time.Time
+}
+
+// Field is a system or custom field of a site.
+type Field struct {
+	ID     string
+	Key    string
+	Name   string
+	Custom bool
+	Type   Type
+	Schema *model.IssueFieldSchemaScheme
+}
+
+// Registry resolves the fields of a site by ID, key, JQL reference or name, the fields are loaded
+// once with FieldConnector.Gets and cached until invalidated.
+type Registry struct {
+	connector jira.FieldConnector
+	options   *Options
+
+	// fetch serializes the loads of the fields, mu guards the cached fields only, so the cached
+	// reads don't wait on the loads.
+	fetch      sync.Mutex
+	mu         sync.Mutex
+	snapshot   *snapshot
+	loadedAt   time.Time
+	generation int
+}
+
+// NewRegistry returns the registry of the site of the connector.
+func NewRegistry(connector jira.FieldConnector, options *Options) (*Registry, error) {
+
+	if connector == nil {
+		return nil, ErrNoFieldConnectorError
+	}
+
+	if options == nil {
+		options = &Options{}
+	}
+
+	if options.Now == nil {
+		options.Now = time.Now
+	}
+
+	return &Registry{connector: connector, options: options}, nil
+}
+
+// Invalidate discards the cached fields, e.g: after a custom field is created or renamed.
+func (r *Registry) Invalidate() {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.snapshot = nil
+	r.generation++
+}
+
+// load returns the cached fields, loading them when they're not cached or the TTL expired.
+func (r *Registry) load(ctx context.Context) (*snapshot, error) {
+
+	if cached, _ := r.cached(); cached != nil {
+		return cached, nil
+	}
+
+	r.fetch.Lock()
+	defer r.fetch.Unlock()
+
+	// The fields may have been loaded while waiting for the previous load.
+	cached, generation := r.cached()
+	if cached != nil {
+		return cached, nil
+	}
+
+	fields, _, err := r.connector.Gets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := newSnapshot(fields)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The fields loaded before an Invalidate call are returned, but they aren't cached.
+	if r.generation == generation {
+		r.snapshot = loaded
+		r.loadedAt = r.options.Now()
+	}
+
+	return loaded, nil
+}
+
+// cached returns the cached fields, nil when they're not cached or the TTL expired, and the
+// generation of the cache, incremented by Invalidate.
+func (r *Registry) cached() (*snapshot, int) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.snapshot != nil && (r.options.TTL == 0 || r.options.Now().Sub(r.loadedAt) < r.options.TTL) {
+		return r.snapshot, r.generation
+	}
+
+	return nil, r.generation
+}
+
+// Fields returns the fields of the site sorted by ID.
+func (r *Registry) Fields(ctx context.Context) ([]*Field, error) {
+
+	snapshot, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]*Field, 0, len(snapshot.byID))
+	for _, field := range snapshot.byID {
+		fields = append(fields, field)
+	}
+
+	sort.Slice(fields, func(i, j int) bool { return fields[i].ID < fields[j].ID })
+	return fields, nil
+}
+
+// Resolve returns the field by ID, key, JQL reference, e.g: cf[10010], or name. The names are
+// case-insensitive and must identify a single field.
+func (r *Registry) Resolve(ctx context.Context, field string) (*Field, error) {
+
+	snapshot, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.resolve(field)
+}
+
+type snapshot struct {
+	byID   map[string]*Field
+	byName map[string][]*Field
+}
+
+func newSnapshot(fields []*model.IssueFieldScheme) *snapshot {
+
+	snapshot := &snapshot{byID: map[string]*Field{}, byName: map[string][]*Field{}}
+
+	for _, scheme := range fields {
+
+		field := &Field{
+			ID:     scheme.ID,
+			Key:    scheme.Key,
+			Name:   scheme.Name,
+			Custom: scheme.Custom,
+			Type:   InferType(scheme.Schema),
+			Schema: scheme.Schema,
+		}
+
+		snapshot.byID[field.ID] = field
+
+		name := strings.ToLower(field.Name)
+		snapshot.byName[name] = append(snapshot.byName[name], field)
+	}
+
+	return snapshot
+}
+
+var reference = regexp.MustCompile(`^cf\[(\d+)\]$`)
+
+func (s *snapshot) resolve(name string) (*Field, error) {
+
+	if field, ok := s.byID[name]; ok {
+		return field, nil
+	}
+
+	if matches := reference.FindStringSubmatch(name); matches != nil {
+
+		if field, ok := s.byID["customfield_"+matches[1]]; ok {
+			return field, nil
+		}
+	}
+
+	candidates := s.byName[strings.ToLower(name)]
+
+	switch len(candidates) {
+	case 0:
+
+		for _, field := range s.byID {
+			if field.Key == name {
+				return field, nil
+			}
+		}
+
+		return nil, fmt.Errorf("%w: %v", ErrUnknownFieldError, name)
+
+	case 1:
+		return candidates[0], nil
+	}
+
+	ids := make([]string, len(candidates))
+	for index, candidate := range candidates {
+		ids[index] = candidate.ID
+	}
+
+	sort.Strings(ids)
+	return nil, fmt.Errorf("%w: %v matches %v", ErrAmbiguousFieldError, name, strings.Join(ids, ", "))
+}
+
+// Sites caches a registry per site, e.g: the sites of the OAuth 2.0 accessible resources.
+type Sites struct {
+	options *Options
+
+	mu         sync.Mutex
+	registries map[string]*Registry
+}
+
+// NewSites returns the cache of registries, the options are shared by the registries.
+func NewSites(options *Options) *Sites {
+	return &Sites{options: options, registries: map[string]*Registry{}}
+}
+
+// Registry returns the registry of the site, it's created with the connector of the site on first use.
+func (s *Sites) Registry(site string, connector jira.FieldConnector) (*Registry, error) {
+
+	if site == "" {
+		return nil, ErrNoSiteError
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if registry, ok := s.registries[site]; ok {
+		return registry, nil
+	}
+
+	options := &Options{}
+	if s.options != nil {
+		*options = *s.options
+	}
+
+	registry, err := NewRegistry(connector, options)
+	if err != nil {
+		return nil, err
+	}
+
+	s.registries[site] = registry
+	return registry, nil
+}
+
+// Invalidate discards the cached fields of the sites, all the sites when none is provided.
+func (s *Sites) Invalidate(sites ...string) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(sites) == 0 {
+		for _, registry := range s.registries {
+			registry.Invalidate()
+		}
+		return
+	}
+
+	for _, site := range sites {
+		if registry, ok := s.registries[site]; ok {
+			registry.Invalidate()
+		}
+	}
+}
@@ -0,0 +1,612 @@
+package fields
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+var (
+	ErrNoRegistryError    = errors.New("fields: no registry set")
+	ErrInvalidTagError    = errors.New("fields: invalid jira tag")
+	ErrInvalidTargetError = errors.New("fields: the value must be a non-nil pointer to a struct")
+)
+
+// The pseudo fields of the issue ID and key, they're only decoded.
+const (
+	idField  = "id"
+	keyField = "key"
+)
+
+type CodecOptions struct {
+
+	// PlainText sends the rich text fields, e.g: description, as plain text instead of ADF documents,
+	// e.g: to the IssueRichTextService of the v2 API.
+	PlainText bool
+}
+
+// IssueCodec encodes and decodes the issues modeled as structs, the struct fields are mapped with the
+// jira tag: the field ID, key or name followed by the options, e.g:
+//
+//	type Story struct {
+//		Key         string   `jira:"key"`
+//		Summary     string   `jira:"summary"`
+//		Description *string  `jira:"description"`
+//		Points      *float64 `jira:"Story Points"`
+//		Sprint      *int     `jira:"customfield_10020,sprint"`
+//		Labels      []string `jira:"labels,add,omitempty"`
+//	}
+//
+// The options are the field type, overriding the type inferred, omitempty, to skip the empty values,
+// and add, to add the values to the field on update instead of replacing them. The nil pointers are
+// never encoded, so they can be used for the fields left unset. The empty strings and slices without
+// omitempty are sent as null and as an empty array, which clear the fields on update.
+type IssueCodec struct {
+	registry *Registry
+	options  *CodecOptions
+}
+
+// NewIssueCodec returns the codec of the issues of the registry site.
+func NewIssueCodec(registry *Registry, options *CodecOptions) (*IssueCodec, error) {
+
+	if registry == nil {
+		return nil, ErrNoRegistryError
+	}
+
+	if options == nil {
+		options = &CodecOptions{}
+	}
+
+	return &IssueCodec{registry: registry, options: options}, nil
+}
+
+type tag struct {
+	name      string
+	fieldType Type
+	omitEmpty bool
+	add       bool
+}
+
+func parseTag(value string) (*tag, error) {
+
+	parts := strings.Split(value, ",")
+	parsed := &tag{name: strings.TrimSpace(parts[0])}
+
+	if parsed.name == "" {
+		return nil, fmt.Errorf("%w: %q has no field", ErrInvalidTagError, value)
+	}
+
+	for _, option := range parts[1:] {
+
+		switch option = strings.TrimSpace(option); option {
+		case "omitempty":
+			parsed.omitEmpty = true
+		case "add":
+			parsed.add = true
+		default:
+
+			if !knownTypes[Type(option)] {
+				return nil, fmt.Errorf("%w: %q has an unknown option %v", ErrInvalidTagError, value, option)
+			}
+
+			parsed.fieldType = Type(option)
+		}
+	}
+
+	return parsed, nil
+}
+
+var knownTypes = map[Type]bool{
+	TypeText: true, TypeURL: true, TypeNumber: true, TypeDate: true, TypeDateTime: true, TypeSelect: true,
+	TypeRadioButton: true, TypeMultiSelect: true, TypeCheckBox: true, TypeCascading: true, TypeUser: true,
+	TypeUsers: true, TypeGroup: true, TypeGroups: true, TypeLabels: true, TypeVersion: true, TypeVersions: true,
+	TypeSprint: true, TypeProject: true, TypeIssueType: true, TypePriority: true, TypeResolution: true,
+	TypeParent: true, TypeComponents: true,
+}
+
+type taggedField struct {
+	tag   *tag
+	value reflect.Value
+}
+
+// taggedFields returns the tagged fields of the struct, including the fields of the embedded structs.
+func taggedFields(value reflect.Value) ([]*taggedField, error) {
+
+	var fields []*taggedField
+
+	for index := 0; index < value.NumField(); index++ {
+
+		structField := value.Type().Field(index)
+		tagValue, ok := structField.Tag.Lookup("jira")
+
+		if !ok && structField.Anonymous && structField.Type.Kind() == reflect.Struct {
+
+			embedded, err := taggedFields(value.Field(index))
+			if err != nil {
+				return nil, err
+			}
+
+			fields = append(fields, embedded...)
+			continue
+		}
+
+		if !ok || tagValue == "-" || structField.PkgPath != "" {
+			continue
+		}
+
+		parsed, err := parseTag(tagValue)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, &taggedField{tag: parsed, value: value.Field(index)})
+	}
+
+	return fields, nil
+}
+
+func structValue(v interface{}, settable bool) (reflect.Value, error) {
+
+	value := reflect.ValueOf(v)
+
+	if value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	} else if settable {
+		return reflect.Value{}, ErrInvalidTargetError
+	}
+
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, ErrInvalidTargetError
+	}
+
+	return value, nil
+}
+
+// Marshal returns the payload and the custom fields of IssueADFService.Create. The payload is empty,
+// every field, the system fields such as summary and project included, is set on the custom fields,
+// which Create merges into the payload.
+func (c *IssueCodec) Marshal(ctx context.Context, v interface{}) (*model.IssueScheme, *model.CustomFields, error) {
+
+	payload, customFields, _, err := c.marshal(ctx, v, false)
+	return payload, customFields, err
+}
+
+// MarshalUpdate returns the payload, the custom fields and the operations of IssueADFService.Update,
+// the fields tagged with the add option are added with operations. As with Marshal, the payload is
+// empty and the fields are set on the custom fields.
+func (c *IssueCodec) MarshalUpdate(ctx context.Context, v interface{}) (*model.IssueScheme, *model.CustomFields, *model.UpdateOperations, error) {
+	return c.marshal(ctx, v, true)
+}
+
+func (c *IssueCodec) marshal(ctx context.Context, v interface{}, update bool) (*model.IssueScheme, *model.CustomFields, *model.UpdateOperations, error) {
+
+	value, err := structValue(v, false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	tagged, err := taggedFields(value)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	encoder, err := c.registry.NewEncoder(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	operations := &model.UpdateOperations{}
+
+	for _, item := range tagged {
+
+		if item.tag.name == idField || item.tag.name == keyField {
+			continue
+		}
+
+		fieldValue := item.value
+		if fieldValue.Kind() == reflect.Ptr || fieldValue.Kind() == reflect.Interface {
+
+			if fieldValue.IsNil() {
+				continue
+			}
+
+			fieldValue = fieldValue.Elem()
+		}
+
+		if item.tag.omitEmpty && isEmpty(fieldValue) {
+			continue
+		}
+
+		field, err := encoder.snapshot.resolve(item.tag.name)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		fieldType := field.Type
+		if item.tag.fieldType != "" {
+			fieldType = item.tag.fieldType
+		}
+
+		if update && item.tag.add {
+
+			if isEmpty(fieldValue) {
+				continue
+			}
+
+			if err := addOperations(operations, field, fieldValue); err != nil {
+				return nil, nil, nil, err
+			}
+
+			continue
+		}
+
+		if empty, ok := clearing(fieldValue); ok {
+			encoder.raw(field.ID, empty)
+			continue
+		}
+
+		if err := c.encode(encoder, field, fieldType, fieldValue); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	customFields := encoder.CustomFields()
+	if len(customFields.Fields) == 0 {
+		customFields = nil
+	}
+
+	if len(operations.Fields) == 0 {
+		operations = nil
+	}
+
+	return &model.IssueScheme{}, customFields, operations, nil
+}
+
+func isEmpty(value reflect.Value) bool {
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String:
+		return value.Len() == 0
+	}
+
+	return value.IsZero()
+}
+
+// clearing returns the value clearing the field of an empty value: an empty array for the slices and
+// null for the strings, maps and zero times. The zero numbers are values, so they're encoded.
+func clearing(value reflect.Value) (interface{}, bool) {
+
+	if value.Type() == reflect.TypeOf(time.Time{}) {
+		return nil, value.IsZero()
+	}
+
+	switch value.Kind() {
+	case reflect.Slice:
+		return []interface{}{}, value.Len() == 0
+	case reflect.String, reflect.Map:
+		return nil, value.Len() == 0
+	}
+
+	return nil, false
+}
+
+func addOperations(operations *model.UpdateOperations, field *Field, value reflect.Value) error {
+
+	if value.Kind() != reflect.Slice || value.Type().Elem().Kind() != reflect.String {
+		return fmt.Errorf("%w: %v is added, got %v", ErrValueTypeError, field.Name, value.Type())
+	}
+
+	mapping := make(map[string]string, value.Len())
+	for index := 0; index < value.Len(); index++ {
+		mapping[value.Index(index).String()] = "add"
+	}
+
+	return operations.AddArrayOperation(field.ID, mapping)
+}
+
+// encode sets the value of the field, the strings of the rich text fields are converted to ADF, the
+// model values are sent as they are and the other values are set by type.
+func (c *IssueCodec) encode(encoder *Encoder, field *Field, fieldType Type, value reflect.Value) error {
+
+	if value.Type() == reflect.TypeOf(time.Time{}) {
+		return encoder.set(field, fieldType, value.Interface())
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+
+		if isRichText(field) && !c.options.PlainText {
+			encoder.raw(field.ID, TextToADF(value.String()))
+			return nil
+		}
+
+		if isRichText(field) {
+			encoder.raw(field.ID, value.String())
+			return nil
+		}
+
+		return encoder.set(field, fieldType, value.String())
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return encoder.set(field, fieldType, int(value.Int()))
+
+	case reflect.Float32, reflect.Float64:
+		return encoder.set(field, fieldType, value.Float())
+
+	case reflect.Slice:
+
+		if value.Type().Elem().Kind() == reflect.String {
+
+			values := make([]string, value.Len())
+			for index := range values {
+				values[index] = value.Index(index).String()
+			}
+
+			return encoder.set(field, fieldType, values)
+		}
+	}
+
+	encoder.raw(field.ID, value.Interface())
+	return nil
+}
+
+// isRichText reports whether the field values are ADF documents on the v3 API.
+func isRichText(field *Field) bool {
+
+	if field.Schema == nil {
+		return false
+	}
+
+	return field.Schema.System == "description" || field.Schema.System == "environment" ||
+		strings.HasSuffix(field.Schema.Custom, ":textarea")
+}
+
+// Unmarshal fills the struct pointed by v with the fields of the issue, e.g: the bytes of the response
+// of IssueADFService.Get. The fields without value are left untouched. The strings are filled with the
+// text of the ADF documents and the option value, account ID, key or name of the references.
+func (c *IssueCodec) Unmarshal(ctx context.Context, data []byte, v interface{}) error {
+
+	value, err := structValue(v, true)
+	if err != nil {
+		return err
+	}
+
+	tagged, err := taggedFields(value)
+	if err != nil {
+		return err
+	}
+
+	values, err := c.registry.Decode(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range tagged {
+
+		var raw json.RawMessage
+
+		switch item.tag.name {
+		case idField:
+			raw, _ = json.Marshal(values.ID)
+		case keyField:
+			raw, _ = json.Marshal(values.Key)
+		default:
+
+			field, fieldRaw, err := values.Raw(item.tag.name)
+			if errors.Is(err, ErrEmptyValueError) {
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if err := decodeValue(fieldRaw, item.value); err != nil {
+				return fmt.Errorf("%w: %v is %v, got %s", ErrValueTypeError, field.Name, item.value.Type(), fieldRaw)
+			}
+
+			continue
+		}
+
+		if err := decodeValue(raw, item.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalIssue fills the struct pointed by v with the fields of the issue, the custom fields are
+// taken from the raw values kept by model.IssueFieldsScheme when the issue was decoded.
+func (c *IssueCodec) UnmarshalIssue(ctx context.Context, issue *model.IssueScheme, v interface{}) error {
+
+	if issue == nil {
+		return ErrNoFieldsError
+	}
+
+	fields := make(map[string]json.RawMessage)
+
+	if issue.Fields != nil {
+
+		data, err := json.Marshal(issue.Fields)
+		if err != nil {
+			return err
+		}
+
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+
+		for key, value := range issue.Fields.CustomFields {
+			fields[key] = value
+		}
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"id": issue.ID, "key": issue.Key, "fields": fields})
+	if err != nil {
+		return err
+	}
+
+	return c.Unmarshal(ctx, data, v)
+}
+
+func decodeValue(raw json.RawMessage, target reflect.Value) error {
+
+	if target.Kind() == reflect.Ptr {
+
+		element := reflect.New(target.Type().Elem())
+		if err := decodeValue(raw, element.Elem()); err != nil {
+			return err
+		}
+
+		target.Set(element)
+		return nil
+	}
+
+	if target.Type() == reflect.TypeOf(time.Time{}) {
+
+		value, err := parseTime(&Field{}, raw)
+		if err != nil {
+			return err
+		}
+
+		target.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+
+		text, err := referenceText(raw)
+		if err != nil {
+			return err
+		}
+
+		target.SetString(text)
+		return nil
+
+	case reflect.Slice:
+
+		if target.Type().Elem().Kind() != reflect.String {
+			break
+		}
+
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return err
+		}
+
+		texts := reflect.MakeSlice(target.Type(), len(items), len(items))
+		for index, item := range items {
+
+			text, err := referenceText(item)
+			if err != nil {
+				return err
+			}
+
+			texts.Index(index).SetString(text)
+		}
+
+		target.Set(texts)
+		return nil
+	}
+
+	return json.Unmarshal(raw, target.Addr().Interface())
+}
+
+// referenceText returns the text of a value: the string, the text of an ADF document or the option
+// value, account ID, key, name or ID of a reference.
+func referenceText(raw json.RawMessage) (string, error) {
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text, nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return strings.TrimSpace(string(raw)), nil
+	}
+
+	if string(object["type"]) == `"doc"` {
+
+		document := new(model.CommentNodeScheme)
+		if err := json.Unmarshal(raw, document); err != nil {
+			return "", err
+		}
+
+		return ADFToText(document), nil
+	}
+
+	for _, key := range []string{"value", "accountId", "key", "name", "id"} {
+
+		if value, ok := object[key]; ok {
+			return referenceText(value)
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s has no reference", ErrValueTypeError, raw)
+}
+
+// TextToADF returns the ADF document of the text, a paragraph per line.
+func TextToADF(text string) *model.CommentNodeScheme {
+
+	document := &model.CommentNodeScheme{Version: 1, Type: "doc"}
+
+	for _, line := range strings.Split(text, "\n") {
+
+		paragraph := &model.CommentNodeScheme{Type: "paragraph"}
+		if line != "" {
+			paragraph.Content = []*model.CommentNodeScheme{{Type: "text", Text: line}}
+		}
+
+		document.Content = append(document.Content, paragraph)
+	}
+
+	return document
+}
+
+// ADFToText returns the text of the ADF document, the blocks are separated by new lines.
+func ADFToText(document *model.CommentNodeScheme) string {
+
+	var lines []string
+	var line strings.Builder
+
+	var walk func(node *model.CommentNodeScheme)
+	walk = func(node *model.CommentNodeScheme) {
+
+		switch node.Type {
+		case "text":
+			line.WriteString(node.Text)
+			return
+		case "hardBreak":
+			lines = append(lines, line.String())
+			line.Reset()
+			return
+		}
+
+		for _, child := range node.Content {
+			walk(child)
+		}
+
+		switch node.Type {
+		case "paragraph", "heading", "codeBlock":
+			lines = append(lines, line.String())
+			line.Reset()
+		}
+	}
+
+	walk(document)
+
+	if line.Len() != 0 {
+		lines = append(lines, line.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
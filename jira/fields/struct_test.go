@@ -0,0 +1,219 @@
+package fields
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type points float64
+
+type story struct {
+	Key         string                  `jira:"key"`
+	Project     string                  `jira:"project"`
+	Type        string                  `jira:"Issue Type"`
+	Summary     string                  `jira:"summary"`
+	Description *string                 `jira:"description"`
+	Points      *points                 `jira:"Story Points"`
+	Sprint      *int                    `jira:"customfield_10021,sprint"`
+	Team        string                  `jira:"Team,omitempty"`
+	Labels      []string                `jira:"labels,add,omitempty"`
+	Reviewers   []string                `jira:"Reviewers,omitempty"`
+	Assignee    *string                 `jira:"assignee"`
+	FixVersions []string                `jira:"fixVersions,omitempty"`
+	Owner       *model.UserDetailScheme `jira:"customfield_10015"`
+	Ignored     string                  `jira:"-"`
+}
+
+func TestIssueCodec_Marshal(t *testing.T) {
+
+	registry, err := NewRegistry(newFake(), nil)
+	assert.NoError(t, err)
+
+	codec, err := NewIssueCodec(registry, nil)
+	assert.NoError(t, err)
+
+	description := "First line\nSecond line"
+	estimate := points(3)
+	sprint := 7
+
+	issue := &story{
+		Project:     "KP",
+		Type:        "Story",
+		Summary:     "Launch",
+		Description: &description,
+		Points:      &estimate,
+		Sprint:      &sprint,
+		Labels:      []string{"backend"},
+		FixVersions: []string{"1.0"},
+		Owner:       &model.UserDetailScheme{AccountID: "account-2"},
+	}
+
+	payload, customFields, err := codec.Marshal(context.Background(), issue)
+	assert.NoError(t, err)
+
+	merged, err := payload.MergeCustomFields(customFields)
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(merged)
+	assert.NoError(t, err)
+
+	assert.JSONEq(t, `{"fields":{
+		"project":{"key":"KP"},
+		"issuetype":{"name":"Story"},
+		"summary":"Launch",
+		"description":{"version":1,"type":"doc","content":[
+			{"type":"paragraph","content":[{"type":"text","text":"First line"}]},
+			{"type":"paragraph","content":[{"type":"text","text":"Second line"}]}
+		]},
+		"customfield_10010":3,
+		"customfield_10021":7,
+		"labels":["backend"],
+		"fixVersions":[{"name":"1.0"}],
+		"customfield_10015":{"accountId":"account-2"}
+	}}`, string(data))
+
+	// The fields tagged with the add option are added on update and the nil pointers are left unset.
+	payload, customFields, operations, err := codec.MarshalUpdate(context.Background(), &story{
+		Project: "KP", Type: "Story", Summary: "Launch v2", Labels: []string{"frontend"},
+	})
+	assert.NoError(t, err)
+
+	merged, err = payload.MergeCustomFields(customFields)
+	assert.NoError(t, err)
+
+	data, err = json.Marshal(merged)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"fields":{"project":{"key":"KP"},"issuetype":{"name":"Story"},"summary":"Launch v2"}}`, string(data))
+
+	merged, err = payload.MergeOperations(operations)
+	assert.NoError(t, err)
+
+	data, err = json.Marshal(merged)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"update":{"labels":[{"add":"frontend"}]}}`, string(data))
+
+	// The empty values without omitempty clear the fields on update.
+	_, customFields, _, err = codec.MarshalUpdate(context.Background(), &struct {
+		Summary     string   `jira:"summary"`
+		Description *string  `jira:"description"`
+		FixVersions []string `jira:"fixVersions"`
+		Labels      []string `jira:"labels,add"`
+	}{Description: new(string)})
+	assert.NoError(t, err)
+
+	merged, err = payload.MergeCustomFields(customFields)
+	assert.NoError(t, err)
+
+	data, err = json.Marshal(merged)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"fields":{"summary":null,"description":null,"fixVersions":[]}}`, string(data))
+
+	// The rich text fields are sent as text to the v2 API.
+	codec, err = NewIssueCodec(registry, &CodecOptions{PlainText: true})
+	assert.NoError(t, err)
+
+	_, customFields, err = codec.Marshal(context.Background(), &struct {
+		Description string `jira:"description"`
+	}{Description: description})
+	assert.NoError(t, err)
+	assert.Equal(t, description, customFields.Fields[0]["fields"].(map[string]interface{})["description"])
+}
+
+func TestIssueCodec_MarshalErrors(t *testing.T) {
+
+	registry, err := NewRegistry(newFake(), nil)
+	assert.NoError(t, err)
+
+	codec, err := NewIssueCodec(registry, nil)
+	assert.NoError(t, err)
+
+	_, _, err = codec.Marshal(context.Background(), &struct {
+		Points float64 `jira:"Story Points,stars"`
+	}{})
+	assert.EqualError(t, err, `fields: invalid jira tag: "Story Points,stars" has an unknown option stars`)
+
+	_, _, err = codec.Marshal(context.Background(), &struct {
+		Severity string `jira:"Severity"`
+	}{})
+	assert.ErrorIs(t, err, ErrUnknownFieldError)
+
+	_, _, err = codec.Marshal(context.Background(), "KP-1")
+	assert.ErrorIs(t, err, ErrInvalidTargetError)
+
+	_, err = NewIssueCodec(nil, nil)
+	assert.ErrorIs(t, err, ErrNoRegistryError)
+}
+
+func TestIssueCodec_Unmarshal(t *testing.T) {
+
+	registry, err := NewRegistry(newFake(), nil)
+	assert.NoError(t, err)
+
+	codec, err := NewIssueCodec(registry, nil)
+	assert.NoError(t, err)
+
+	data := []byte(`{"id":"10001","key":"KP-1","fields":{
+		"project":{"id":"10000","key":"KP","name":"Kanban"},
+		"issuetype":{"id":"10002","name":"Story"},
+		"summary":"Launch",
+		"description":{"version":1,"type":"doc","content":[
+			{"type":"paragraph","content":[{"type":"text","text":"First "},{"type":"text","text":"line"}]},
+			{"type":"paragraph","content":[{"type":"text","text":"Second line"}]}
+		]},
+		"customfield_10010":5,
+		"customfield_10011":{"id":"1","value":"Platform"},
+		"labels":["backend"],
+		"customfield_10013":[{"accountId":"account-1","displayName":"Carlos"}],
+		"assignee":null,
+		"fixVersions":[{"id":"1","name":"1.0"}],
+		"customfield_10015":{"accountId":"account-2"}
+	}}`)
+
+	var got story
+	assert.NoError(t, codec.Unmarshal(context.Background(), data, &got))
+
+	description, estimate := "First line\nSecond line", points(5)
+	assert.Equal(t, story{
+		Key:         "KP-1",
+		Project:     "KP",
+		Type:        "Story",
+		Summary:     "Launch",
+		Description: &description,
+		Points:      &estimate,
+		Team:        "Platform",
+		Labels:      []string{"backend"},
+		Reviewers:   []string{"account-1"},
+		FixVersions: []string{"1.0"},
+		Owner:       &model.UserDetailScheme{AccountID: "account-2"},
+	}, got)
+
+	// The issue scheme keeps the custom fields of the response.
+	var issue model.IssueScheme
+	assert.NoError(t, json.Unmarshal(data, &issue))
+
+	var fromIssue story
+	assert.NoError(t, codec.UnmarshalIssue(context.Background(), &issue, &fromIssue))
+	assert.Equal(t, got, fromIssue)
+
+	var system story
+	assert.NoError(t, codec.UnmarshalIssue(context.Background(), &model.IssueScheme{
+		Key:    "KP-2",
+		Fields: &model.IssueFieldsScheme{Summary: "Other", Assignee: &model.UserScheme{AccountID: "account-3"}},
+	}, &system))
+
+	assert.Equal(t, "KP-2", system.Key)
+	assert.Equal(t, "account-3", *system.Assignee)
+
+	assert.ErrorIs(t, codec.UnmarshalIssue(context.Background(), nil, &system), ErrNoFieldsError)
+	assert.ErrorIs(t, codec.Unmarshal(context.Background(), data, got), ErrInvalidTargetError)
+}
+
+func TestADF(t *testing.T) {
+
+	text := "First line\n\nThird line"
+	assert.Equal(t, text, ADFToText(TextToADF(text)))
+}
@@ -0,0 +1,131 @@
+package fields
+
+import (
+	"strings"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// Type is the type of the values of a field, it's inferred from the field schema.
+type Type string
+
+const (
+	TypeUnknown     Type = "unknown"
+	TypeText        Type = "text"
+	TypeURL         Type = "url"
+	TypeNumber      Type = "number"
+	TypeDate        Type = "date"
+	TypeDateTime    Type = "datetime"
+	TypeSelect      Type = "select"
+	TypeRadioButton Type = "radiobutton"
+	TypeMultiSelect Type = "multiselect"
+	TypeCheckBox    Type = "checkbox"
+	TypeCascading   Type = "cascading"
+	TypeUser        Type = "user"
+	TypeUsers       Type = "users"
+	TypeGroup       Type = "group"
+	TypeGroups      Type = "groups"
+	TypeLabels      Type = "labels"
+	TypeVersion     Type = "version"
+	TypeVersions    Type = "versions"
+	TypeSprint      Type = "sprint"
+	TypeProject     Type = "project"
+	TypeIssueType   Type = "issuetype"
+	TypePriority    Type = "priority"
+	TypeResolution  Type = "resolution"
+	TypeParent      Type = "parent"
+	TypeComponents  Type = "components"
+)
+
+// customTypes maps the schema.custom keys of the custom fields to their types.
+var customTypes = map[string]Type{
+	"com.atlassian.jira.plugin.system.customfieldtypes:textfield":        TypeText,
+	"com.atlassian.jira.plugin.system.customfieldtypes:textarea":         TypeText,
+	"com.atlassian.jira.plugin.system.customfieldtypes:readonlyfield":    TypeText,
+	"com.atlassian.jira.plugin.system.customfieldtypes:url":              TypeURL,
+	"com.atlassian.jira.plugin.system.customfieldtypes:float":            TypeNumber,
+	"com.atlassian.jira.plugin.system.customfieldtypes:datepicker":       TypeDate,
+	"com.atlassian.jira.plugin.system.customfieldtypes:datetime":         TypeDateTime,
+	"com.atlassian.jira.plugin.system.customfieldtypes:select":           TypeSelect,
+	"com.atlassian.jira.plugin.system.customfieldtypes:radiobuttons":     TypeRadioButton,
+	"com.atlassian.jira.plugin.system.customfieldtypes:multiselect":      TypeMultiSelect,
+	"com.atlassian.jira.plugin.system.customfieldtypes:multicheckboxes":  TypeCheckBox,
+	"com.atlassian.jira.plugin.system.customfieldtypes:cascadingselect":  TypeCascading,
+	"com.atlassian.jira.plugin.system.customfieldtypes:userpicker":       TypeUser,
+	"com.atlassian.jira.plugin.system.customfieldtypes:multiuserpicker":  TypeUsers,
+	"com.atlassian.jira.plugin.system.customfieldtypes:grouppicker":      TypeGroup,
+	"com.atlassian.jira.plugin.system.customfieldtypes:multigrouppicker": TypeGroups,
+	"com.atlassian.jira.plugin.system.customfieldtypes:labels":           TypeLabels,
+	"com.atlassian.jira.plugin.system.customfieldtypes:version":          TypeVersion,
+	"com.atlassian.jira.plugin.system.customfieldtypes:multiversion":     TypeVersions,
+	"com.pyxis.greenhopper.jira:gh-sprint":                               TypeSprint,
+}
+
+// InferType returns the type of the field, the custom fields are inferred from schema.custom and the
+// system fields from schema.type and schema.items.
+func InferType(schema *model.IssueFieldSchemaScheme) Type {
+
+	if schema == nil {
+		return TypeUnknown
+	}
+
+	if schema.Custom != "" {
+
+		if fieldType, ok := customTypes[schema.Custom]; ok {
+			return fieldType
+		}
+	}
+
+	switch strings.ToLower(schema.Type) {
+	case "string":
+		return TypeText
+	case "number":
+		return TypeNumber
+	case "date":
+		return TypeDate
+	case "datetime":
+		return TypeDateTime
+	case "option":
+		return TypeSelect
+	case "option-with-child":
+		return TypeCascading
+	case "user":
+		return TypeUser
+	case "group":
+		return TypeGroup
+	case "version":
+		return TypeVersion
+	case "project":
+		return TypeProject
+	case "issuetype":
+		return TypeIssueType
+	case "priority":
+		return TypePriority
+	case "resolution":
+		return TypeResolution
+	case "issuelink":
+
+		if schema.System == "parent" {
+			return TypeParent
+		}
+
+	case "array":
+
+		switch strings.ToLower(schema.Items) {
+		case "string":
+			return TypeLabels
+		case "option":
+			return TypeMultiSelect
+		case "user":
+			return TypeUsers
+		case "group":
+			return TypeGroups
+		case "version":
+			return TypeVersions
+		case "component":
+			return TypeComponents
+		}
+	}
+
+	return TypeUnknown
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/ctreminiom/go-atlassian/service"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 type IssueServices struct {
@@ -145,15 +146,23 @@ func sendNotification(ctx context.Context, client service.Connector, version, is
 	return client.Call(request, nil)
 }
 
-func getTransitions(ctx context.Context, client service.Connector, version, issueKeyOrId string) (*model.IssueTransitionsScheme, *model.ResponseScheme, error) {
+func getTransitions(ctx context.Context, client service.Connector, version, issueKeyOrId string, expand []string) (*model.IssueTransitionsScheme, *model.ResponseScheme, error) {
 
 	if issueKeyOrId == "" {
 		return nil, nil, model.ErrNoIssueKeyOrIDError
 	}
 
-	endpoint := fmt.Sprintf("rest/api/%v/issue/%v/transitions", version, issueKeyOrId)
+	var endpoint strings.Builder
+	endpoint.WriteString(fmt.Sprintf("rest/api/%v/issue/%v/transitions", version, issueKeyOrId))
 
-	request, err := client.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if len(expand) != 0 {
+		params := url.Values{}
+		params.Add("expand", strings.Join(expand, ","))
+
+		endpoint.WriteString(fmt.Sprintf("?%v", params.Encode()))
+	}
+
+	request, err := client.NewRequest(ctx, http.MethodGet, endpoint.String(), "", nil)
 	if err != nil {
 		return nil, nil, err
 	}
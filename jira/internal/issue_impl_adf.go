@@ -84,6 +84,16 @@ func (i *IssueADFService) Transitions(ctx context.Context, issueKeyOrId string)
 	return i.internalClient.Transitions(ctx, issueKeyOrId)
 }
 
+// TransitionsWithFields returns the transitions that can be performed by the user on an issue, with the fields of
+// their screens expanded. The fields are used to know the values required by each transition.
+//
+// GET /rest/api/{2-3}/issue/{issueIdOrKey}/transitions?expand=transitions.fields
+//
+// https://docs.go-atlassian.io/jira-software-cloud/issues#get-transitions
+func (i *IssueADFService) TransitionsWithFields(ctx context.Context, issueKeyOrId string) (*model.IssueTransitionsScheme, *model.ResponseScheme, error) {
+	return i.internalClient.TransitionsWithFields(ctx, issueKeyOrId)
+}
+
 // Create creates an issue or, where the option to create subtasks is enabled in Jira, a subtask.
 //
 // POST /rest/api/{2-3}/issue
@@ -163,7 +173,11 @@ func (i *internalIssueADFServiceImpl) Notify(ctx context.Context, issueKeyOrId s
 }
 
 func (i *internalIssueADFServiceImpl) Transitions(ctx context.Context, issueKeyOrId string) (*model.IssueTransitionsScheme, *model.ResponseScheme, error) {
-	return getTransitions(ctx, i.c, i.version, issueKeyOrId)
+	return getTransitions(ctx, i.c, i.version, issueKeyOrId, nil)
+}
+
+func (i *internalIssueADFServiceImpl) TransitionsWithFields(ctx context.Context, issueKeyOrId string) (*model.IssueTransitionsScheme, *model.ResponseScheme, error) {
+	return getTransitions(ctx, i.c, i.version, issueKeyOrId, []string{"transitions.fields"})
 }
 
 func (i *internalIssueADFServiceImpl) Create(ctx context.Context, payload *model.IssueScheme, customFields *model.CustomFields) (*model.IssueResponseScheme, *model.ResponseScheme, error) {
@@ -356,7 +370,6 @@ func (i *internalIssueADFServiceImpl) Move(ctx context.Context, issueKeyOrId, tr
 	}
 
 	payloadUpdated := make(map[string]interface{})
-	payloadUpdated["transition"] = map[string]interface{}{"id": transitionId}
 
 	// Process logic only if the transition options are provided
 	if options != nil {
@@ -408,6 +421,9 @@ func (i *internalIssueADFServiceImpl) Move(ctx context.Context, issueKeyOrId, tr
 
 	}
 
+	// The transition is set once the fields are merged, the merges return new payloads.
+	payloadUpdated["transition"] = map[string]interface{}{"id": transitionId}
+
 	endpoint := fmt.Sprintf("rest/api/%v/issue/%v/transitions", i.version, issueKeyOrId)
 
 	request, err := i.c.NewRequest(ctx, http.MethodPost, endpoint, "", payloadUpdated)
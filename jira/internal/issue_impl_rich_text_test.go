@@ -518,6 +518,125 @@ func Test_internalRichTextServiceImpl_Transitions(t *testing.T) {
 	}
 }
 
+func Test_internalRichTextServiceImpl_TransitionsWithFields(t *testing.T) {
+
+	type fields struct {
+		c       service.Connector
+		version string
+	}
+
+	type args struct {
+		ctx          context.Context
+		issueKeyOrId string
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name:   "when the api version is v2",
+			fields: fields{version: "2"},
+			args: args{
+				ctx:          context.Background(),
+				issueKeyOrId: "DUMMY-1",
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/2/issue/DUMMY-1/transitions?expand=transitions.fields",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.IssueTransitionsScheme{}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name:   "when the issue issue key or id is not provided",
+			fields: fields{version: "2"},
+			args: args{
+				ctx:          context.Background(),
+				issueKeyOrId: "",
+			},
+			on: func(fields *fields) {
+				fields.c = mocks.NewConnector(t)
+			},
+			wantErr: true,
+			Err:     model.ErrNoIssueKeyOrIDError,
+		},
+
+		{
+			name:   "when the request method cannot be created",
+			fields: fields{version: "2"},
+			args: args{
+				ctx:          context.Background(),
+				issueKeyOrId: "DUMMY-1",
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/2/issue/DUMMY-1/transitions?expand=transitions.fields",
+					"",
+					nil).
+					Return(&http.Request{}, errors.New("error, unable to create the http request"))
+
+				fields.c = client
+			},
+			wantErr: true,
+			Err:     errors.New("error, unable to create the http request"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			issueService, _, err := NewIssueService(testCase.fields.c, testCase.fields.version, nil)
+			assert.NoError(t, err)
+
+			gotResult, gotResponse, err := issueService.TransitionsWithFields(testCase.args.ctx, testCase.args.issueKeyOrId)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+			}
+
+		})
+	}
+}
+
 func Test_internalRichTextServiceImpl_Create(t *testing.T) {
 
 	payloadMocked := &model.IssueSchemeV2{
@@ -1026,6 +1145,7 @@ func Test_internalRichTextServiceImpl_Move(t *testing.T) {
 	}
 
 	expectedPayloadWithCustomFieldsAndOperations := map[string]interface{}{
+		"transition": map[string]interface{}{"id": "10001"},
 
 		"fields": map[string]interface{}{
 			"customfield_10042": 1000.2222,
@@ -1042,6 +1162,7 @@ func Test_internalRichTextServiceImpl_Move(t *testing.T) {
 				"remove": "triaged"}}}}
 
 	expectedPayloadWithCustomfields := map[string]interface{}{
+		"transition": map[string]interface{}{"id": "10001"},
 		"fields": map[string]interface{}{
 			"customfield_10042": 1000.2222,
 			"customfield_10052": []map[string]interface{}{map[string]interface{}{
@@ -1053,6 +1174,7 @@ func Test_internalRichTextServiceImpl_Move(t *testing.T) {
 			"summary":   "New summary test"}}
 
 	expectedPayloadWithOperations := map[string]interface{}{
+		"transition": map[string]interface{}{"id": "10001"},
 		"fields": map[string]interface{}{
 			"issuetype": map[string]interface{}{"name": "Story"},
 			"project":   map[string]interface{}{"id": "10000"},
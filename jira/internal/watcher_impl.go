@@ -45,6 +45,15 @@ func (w *WatcherService) Add(ctx context.Context, issueKeyOrId string) (*model.R
 	return w.internalClient.Add(ctx, issueKeyOrId)
 }
 
+// AddUser adds the user of the account ID as a watcher of an issue.
+//
+// POST /rest/api/{2-3}/issue/{issueIdOrKey}/watchers
+//
+// https://docs.go-atlassian.io/jira-software-cloud/issues/watcher#add-watcher
+func (w *WatcherService) AddUser(ctx context.Context, issueKeyOrId, accountId string) (*model.ResponseScheme, error) {
+	return w.internalClient.AddUser(ctx, issueKeyOrId, accountId)
+}
+
 // Delete deletes a user as a watcher of an issue.
 //
 // DELETE /rest/api/{2-3}/issue/{issueIdOrKey}/watchers
@@ -97,6 +106,27 @@ func (i *internalWatcherImpl) Add(ctx context.Context, issueKeyOrId string) (*mo
 	return i.c.Call(request, nil)
 }
 
+func (i *internalWatcherImpl) AddUser(ctx context.Context, issueKeyOrId, accountId string) (*model.ResponseScheme, error) {
+
+	if issueKeyOrId == "" {
+		return nil, model.ErrNoIssueKeyOrIDError
+	}
+
+	if accountId == "" {
+		return nil, model.ErrNoAccountIDError
+	}
+
+	endpoint := fmt.Sprintf("rest/api/%v/issue/%v/watchers", i.version, issueKeyOrId)
+
+	// The body is the account ID as a JSON string.
+	request, err := i.c.NewRequest(ctx, http.MethodPost, endpoint, "", accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.c.Call(request, nil)
+}
+
 func (i *internalWatcherImpl) Delete(ctx context.Context, issueKeyOrId, accountId string) (*model.ResponseScheme, error) {
 
 	if issueKeyOrId == "" {
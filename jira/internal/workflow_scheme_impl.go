@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
 	"github.com/ctreminiom/go-atlassian/service"
@@ -76,6 +77,19 @@ func (w *WorkflowSchemeService) Delete(ctx context.Context, schemeId int) (*mode
 	return w.internalClient.Delete(ctx, schemeId)
 }
 
+// Publish publishes the draft of a workflow scheme.
+//
+// The issues whose status doesn't exist on the workflows of the draft are moved to the statuses mapped.
+//
+// The task is returned when the issues are migrated asynchronously, otherwise the draft is already published.
+//
+// POST /rest/api/{2-3}/workflowscheme/{id}/draft/publish
+//
+// https://docs.go-atlassian.io/jira-software-cloud/workflow/scheme#publish-draft-workflow-scheme
+func (w *WorkflowSchemeService) Publish(ctx context.Context, schemeId int, payload *model.WorkflowSchemePublishPayloadScheme, validateOnly bool) (*model.TaskScheme, *model.ResponseScheme, error) {
+	return w.internalClient.Publish(ctx, schemeId, payload, validateOnly)
+}
+
 // Associations returns a list of the workflow schemes associated with a list of projects.
 //
 // Each returned workflow scheme includes a list of the requested projects associated with it.
@@ -220,6 +234,51 @@ func (i *internalWorkflowSchemeImpl) Delete(ctx context.Context, schemeId int) (
 	return i.c.Call(request, nil)
 }
 
+func (i *internalWorkflowSchemeImpl) Publish(ctx context.Context, schemeId int, payload *model.WorkflowSchemePublishPayloadScheme, validateOnly bool) (*model.TaskScheme, *model.ResponseScheme, error) {
+
+	if schemeId == 0 {
+		return nil, nil, model.ErrNoWorkflowSchemeIDError
+	}
+
+	var endpoint strings.Builder
+	endpoint.WriteString(fmt.Sprintf("rest/api/%v/workflowscheme/%v/draft/publish", i.version, schemeId))
+
+	if validateOnly {
+
+		params := url.Values{}
+		params.Add("validateOnly", "true")
+
+		endpoint.WriteString(fmt.Sprintf("?%v", params.Encode()))
+	}
+
+	if payload == nil {
+		payload = &model.WorkflowSchemePublishPayloadScheme{}
+	}
+
+	request, err := i.c.NewRequest(ctx, http.MethodPost, endpoint.String(), "", payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The site answers without content when the draft is published synchronously, and redirects
+	// to the task when the issues are migrated.
+	response, err := i.c.Call(request, nil)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response == nil || response.Bytes.Len() == 0 {
+		return nil, response, nil
+	}
+
+	task := new(model.TaskScheme)
+	if err = json.Unmarshal(response.Bytes.Bytes(), task); err != nil {
+		return nil, response, err
+	}
+
+	return task, response, nil
+}
+
 func (i *internalWorkflowSchemeImpl) Associations(ctx context.Context, projectIds []int) (*model.WorkflowSchemeAssociationPageScheme, *model.ResponseScheme, error) {
 
 	if len(projectIds) == 0 {
@@ -893,6 +893,162 @@ func Test_internalWorkflowSchemeImpl_Update(t *testing.T) {
 	}
 }
 
+func Test_internalWorkflowSchemeImpl_Publish(t *testing.T) {
+
+	payloadMocked := &model.WorkflowSchemePublishPayloadScheme{
+		StatusMappings: []*model.WorkflowSchemeStatusMappingScheme{
+			{IssueTypeID: "10001", StatusID: "3", NewStatusID: "1"},
+		},
+	}
+
+	type fields struct {
+		c       service.Connector
+		version string
+	}
+
+	type args struct {
+		ctx          context.Context
+		schemeId     int
+		payload      *model.WorkflowSchemePublishPayloadScheme
+		validateOnly bool
+	}
+
+	testCases := []struct {
+		name     string
+		fields   fields
+		args     args
+		on       func(*fields)
+		wantTask *model.TaskScheme
+		wantErr  bool
+		Err      error
+	}{
+		{
+			name:   "when the issues are migrated by a task",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:      context.Background(),
+				schemeId: 10002,
+				payload:  payloadMocked,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/api/3/workflowscheme/10002/draft/publish",
+					"", payloadMocked).
+					Return(&http.Request{}, nil)
+
+				response := &model.ResponseScheme{}
+				response.Bytes.WriteString(`{"id":"10641","status":"RUNNING"}`)
+
+				client.On("Call",
+					&http.Request{},
+					nil).
+					Return(response, nil)
+
+				fields.c = client
+			},
+			wantTask: &model.TaskScheme{ID: "10641", Status: "RUNNING"},
+		},
+
+		{
+			name:   "when the draft is published synchronously",
+			fields: fields{version: "2"},
+			args: args{
+				ctx:          context.Background(),
+				schemeId:     10002,
+				validateOnly: true,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/api/2/workflowscheme/10002/draft/publish?validateOnly=true",
+					"", &model.WorkflowSchemePublishPayloadScheme{}).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					nil).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name:   "when the workflow scheme id is not provided",
+			fields: fields{version: "3"},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+			Err:     model.ErrNoWorkflowSchemeIDError,
+		},
+
+		{
+			name:   "when the http request cannot be created",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:      context.Background(),
+				schemeId: 10002,
+				payload:  payloadMocked,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/api/3/workflowscheme/10002/draft/publish",
+					"", payloadMocked).
+					Return(&http.Request{}, errors.New("error, unable to create the http request"))
+
+				fields.c = client
+			},
+			wantErr: true,
+			Err:     errors.New("error, unable to create the http request"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			newService := NewWorkflowSchemeService(testCase.fields.c, testCase.fields.version, nil)
+
+			gotResult, gotResponse, err := newService.Publish(testCase.args.ctx, testCase.args.schemeId, testCase.args.payload,
+				testCase.args.validateOnly)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.Equal(t, testCase.wantTask, gotResult)
+			}
+
+		})
+	}
+}
+
 func Test_internalWorkflowSchemeImpl_Create(t *testing.T) {
 
 	payloadMocked := &model.WorkflowSchemePayloadScheme{
@@ -0,0 +1,373 @@
+// Package jql builds, parses and rewrites Jira Query Language queries locally, the values and
+// fields are escaped, so the queries can be sent to the search and filter services safely, e.g:
+//
+//	query := jql.Where(jql.Field("project").Eq("KP")).
+//		And(jql.Field("assignee").In(jql.MembersOf("jira-users"))).
+//		OrderBy("created", jql.Descending)
+package jql
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Node is a node of the AST of a query.
+type Node interface {
+	String() string
+}
+
+// Clause is a node of the WHERE part of a query.
+type Clause interface {
+	Node
+	clause()
+}
+
+// Operand is the value of a term, e.g: a value, a list or a function.
+type Operand interface {
+	Node
+	operand()
+}
+
+// Operator is the operator of a term.
+type Operator string
+
+const (
+	OperatorEquals            Operator = "="
+	OperatorNotEquals         Operator = "!="
+	OperatorGreaterThan       Operator = ">"
+	OperatorGreaterThanEquals Operator = ">="
+	OperatorLessThan          Operator = "<"
+	OperatorLessThanEquals    Operator = "<="
+	OperatorContains          Operator = "~"
+	OperatorDoesNotContain    Operator = "!~"
+	OperatorIn                Operator = "IN"
+	OperatorNotIn             Operator = "NOT IN"
+	OperatorIs                Operator = "IS"
+	OperatorIsNot             Operator = "IS NOT"
+	OperatorWas               Operator = "WAS"
+	OperatorWasNot            Operator = "WAS NOT"
+	OperatorWasIn             Operator = "WAS IN"
+	OperatorWasNotIn          Operator = "WAS NOT IN"
+	OperatorChanged           Operator = "CHANGED"
+)
+
+// Direction is the direction of an ORDER BY field.
+type Direction string
+
+const (
+	Ascending  Direction = "ASC"
+	Descending Direction = "DESC"
+)
+
+// Query is the root of the AST, both parts are optional.
+type Query struct {
+	Where Clause
+	Order []*OrderBy
+}
+
+func (q *Query) String() string {
+
+	var parts []string
+
+	if q.Where != nil {
+		parts = append(parts, q.Where.String())
+	}
+
+	if len(q.Order) != 0 {
+
+		fields := make([]string, len(q.Order))
+		for index, order := range q.Order {
+			fields[index] = order.String()
+		}
+
+		parts = append(parts, "ORDER BY "+strings.Join(fields, ", "))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// OrderBy is a field of the ORDER BY part, the direction is optional.
+type OrderBy struct {
+	Field     Field
+	Direction Direction
+}
+
+func (o *OrderBy) String() string {
+
+	if o.Direction == "" {
+		return o.Field.String()
+	}
+
+	return o.Field.String() + " " + string(o.Direction)
+}
+
+// AndClause matches the issues matched by all its clauses.
+type AndClause struct {
+	Clauses []Clause
+}
+
+func (*AndClause) clause() {}
+
+func (a *AndClause) String() string {
+
+	parts := make([]string, len(a.Clauses))
+	for index, clause := range a.Clauses {
+
+		if _, ok := clause.(*OrClause); ok {
+			parts[index] = "(" + clause.String() + ")"
+			continue
+		}
+
+		parts[index] = clause.String()
+	}
+
+	return strings.Join(parts, " AND ")
+}
+
+// OrClause matches the issues matched by any of its clauses.
+type OrClause struct {
+	Clauses []Clause
+}
+
+func (*OrClause) clause() {}
+
+func (o *OrClause) String() string {
+
+	parts := make([]string, len(o.Clauses))
+	for index, clause := range o.Clauses {
+		parts[index] = clause.String()
+	}
+
+	return strings.Join(parts, " OR ")
+}
+
+// NotClause matches the issues not matched by its clause.
+type NotClause struct {
+	Clause Clause
+}
+
+func (*NotClause) clause() {}
+
+func (n *NotClause) String() string {
+
+	switch n.Clause.(type) {
+	case *AndClause, *OrClause:
+		return "NOT (" + n.Clause.String() + ")"
+	}
+
+	return "NOT " + n.Clause.String()
+}
+
+// TermClause compares a field with an operand, the operand of the CHANGED operator is nil. The
+// predicates apply to the history operators, e.g: WAS "In Progress" BY currentUser().
+type TermClause struct {
+	Field      Field
+	Operator   Operator
+	Operand    Operand
+	Predicates []*Predicate
+}
+
+func (*TermClause) clause() {}
+
+func (t *TermClause) String() string {
+
+	parts := []string{t.Field.String(), string(t.Operator)}
+	if t.Operand != nil {
+		parts = append(parts, t.Operand.String())
+	}
+
+	for _, predicate := range t.Predicates {
+		parts = append(parts, predicate.String())
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Predicate is a history predicate of a term, e.g: AFTER, BEFORE, DURING, ON, BY, FROM or TO.
+type Predicate struct {
+	Name    string
+	Operand Operand
+}
+
+func (p *Predicate) String() string {
+	return p.Name + " " + p.Operand.String()
+}
+
+// Value is a literal value, e.g: a key, a name, a number or a date.
+type Value struct {
+	Text string
+}
+
+func (*Value) operand() {}
+
+func (v *Value) String() string {
+	return Quote(v.Text)
+}
+
+// List is a list of operands, e.g: the operand of the IN operator.
+type List struct {
+	Operands []Operand
+}
+
+func (*List) operand() {}
+
+func (l *List) String() string {
+
+	parts := make([]string, len(l.Operands))
+	for index, operand := range l.Operands {
+		parts[index] = operand.String()
+	}
+
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// Function is a function call, e.g: membersOf("jira-users").
+type Function struct {
+	Name      string
+	Arguments []string
+}
+
+func (*Function) operand() {}
+
+func (f *Function) String() string {
+
+	arguments := make([]string, len(f.Arguments))
+	for index, argument := range f.Arguments {
+		arguments[index] = Quote(argument)
+	}
+
+	return f.Name + "(" + strings.Join(arguments, ", ") + ")"
+}
+
+// Keyword is the EMPTY or NULL operand.
+type Keyword struct {
+	Name string
+}
+
+func (*Keyword) operand() {}
+
+func (k *Keyword) String() string {
+	return k.Name
+}
+
+// Empty is the EMPTY operand, e.g: the operand of the IS and IS NOT operators.
+var Empty = &Keyword{Name: "EMPTY"}
+
+var (
+	unquotedValue = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	unquotedField = regexp.MustCompile(`^(cf\[\d+\]|[A-Za-z_][A-Za-z0-9_.]*)$`)
+)
+
+// Quote returns the value as a JQL literal, the value is quoted and escaped when it has reserved
+// characters or it's a reserved word.
+func Quote(value string) string {
+
+	if unquotedValue.MatchString(value) && !IsReserved(value) {
+		return value
+	}
+
+	return quote(value)
+}
+
+func quote(value string) string {
+
+	var builder strings.Builder
+	builder.WriteByte('"')
+
+	for _, r := range value {
+		switch r {
+		case '"', '\\':
+			builder.WriteByte('\\')
+			builder.WriteRune(r)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\t':
+			builder.WriteString(`\t`)
+		default:
+			builder.WriteRune(r)
+		}
+	}
+
+	builder.WriteByte('"')
+	return builder.String()
+}
+
+// IsReserved reports whether the word is a JQL reserved word, the reserved words must be quoted.
+func IsReserved(word string) bool {
+	return reserved[strings.ToLower(word)]
+}
+
+var reserved = map[string]bool{}
+
+func init() {
+
+	words := "a an abort access add after alias all alter and any are as asc audit avg before begin between boolean break " +
+		"by byte catch cf changed char character check checkpoint collate collation column commit connect continue count " +
+		"create current date decimal declare decrement default defaults define delete delimiter desc difference distinct " +
+		"divide do double drop during else empty encoding end equals escape exclusive exec execute exists explain false " +
+		"fetch file field first float for from function go goto grant greater group having identified if immediate in " +
+		"increment index initial inner inout input insert int integer intersect intersection into is isempty isnull join " +
+		"last left less like limit lock long max min minus mode modify modulo more multiply next noaudit not notin nowait " +
+		"null number object of on option or order outer output power previous prior privileges public raise raw remainder " +
+		"rename resource return returns revoke right row rowid rownum rows select session set share size sqrt start strict " +
+		"string subtract sum synonym table then to trans transaction trigger true uid union unique update user validate " +
+		"values view was when whenever where while with"
+
+	for _, word := range strings.Fields(words) {
+		reserved[word] = true
+	}
+}
+
+// Inspect traverses the AST depth-first calling fn for each node, the children of a node are skipped
+// when fn returns false. The nodes can be modified in place, e.g: to rewrite the project keys.
+func Inspect(node Node, fn func(Node) bool) {
+
+	if node == nil || !fn(node) {
+		return
+	}
+
+	switch node := node.(type) {
+	case *Query:
+
+		if node.Where != nil {
+			Inspect(node.Where, fn)
+		}
+
+		for _, order := range node.Order {
+			Inspect(order, fn)
+		}
+
+	case *AndClause:
+		for _, clause := range node.Clauses {
+			Inspect(clause, fn)
+		}
+
+	case *OrClause:
+		for _, clause := range node.Clauses {
+			Inspect(clause, fn)
+		}
+
+	case *NotClause:
+		Inspect(node.Clause, fn)
+
+	case *TermClause:
+
+		if node.Operand != nil {
+			Inspect(node.Operand, fn)
+		}
+
+		for _, predicate := range node.Predicates {
+			Inspect(predicate, fn)
+		}
+
+	case *Predicate:
+		Inspect(node.Operand, fn)
+
+	case *List:
+		for _, operand := range node.Operands {
+			Inspect(operand, fn)
+		}
+	}
+}
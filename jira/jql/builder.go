@@ -0,0 +1,336 @@
+package jql
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DateTimeFormat is the format of the time.Time values.
+const DateTimeFormat = "2006/01/02 15:04"
+
+// Field is the name of a field, e.g: status, "Story Points" or cf[10010].
+type Field string
+
+// CustomField returns the reference of a custom field by its ID, e.g: cf[10010].
+func CustomField(id int) Field {
+	return Field("cf[" + strconv.Itoa(id) + "]")
+}
+
+func (f Field) String() string {
+
+	if unquotedField.MatchString(string(f)) && !IsReserved(string(f)) {
+		return string(f)
+	}
+
+	return quote(string(f))
+}
+
+// Eq returns the term field = value, the values are strings, numbers, time.Time or operands.
+func (f Field) Eq(value interface{}) *TermClause {
+	return f.term(OperatorEquals, value)
+}
+
+// NotEq returns the term field != value.
+func (f Field) NotEq(value interface{}) *TermClause {
+	return f.term(OperatorNotEquals, value)
+}
+
+// Gt returns the term field > value.
+func (f Field) Gt(value interface{}) *TermClause {
+	return f.term(OperatorGreaterThan, value)
+}
+
+// Gte returns the term field >= value.
+func (f Field) Gte(value interface{}) *TermClause {
+	return f.term(OperatorGreaterThanEquals, value)
+}
+
+// Lt returns the term field < value.
+func (f Field) Lt(value interface{}) *TermClause {
+	return f.term(OperatorLessThan, value)
+}
+
+// Lte returns the term field <= value.
+func (f Field) Lte(value interface{}) *TermClause {
+	return f.term(OperatorLessThanEquals, value)
+}
+
+// Contains returns the text search term field ~ value.
+func (f Field) Contains(value interface{}) *TermClause {
+	return f.term(OperatorContains, value)
+}
+
+// DoesNotContain returns the text search term field !~ value.
+func (f Field) DoesNotContain(value interface{}) *TermClause {
+	return f.term(OperatorDoesNotContain, value)
+}
+
+// In returns the term field IN (values), a single function or list is used as the operand.
+func (f Field) In(values ...interface{}) *TermClause {
+	return &TermClause{Field: f, Operator: OperatorIn, Operand: list(values)}
+}
+
+// NotIn returns the term field NOT IN (values).
+func (f Field) NotIn(values ...interface{}) *TermClause {
+	return &TermClause{Field: f, Operator: OperatorNotIn, Operand: list(values)}
+}
+
+// IsEmpty returns the term field IS EMPTY.
+func (f Field) IsEmpty() *TermClause {
+	return &TermClause{Field: f, Operator: OperatorIs, Operand: &Keyword{Name: "EMPTY"}}
+}
+
+// IsNotEmpty returns the term field IS NOT EMPTY.
+func (f Field) IsNotEmpty() *TermClause {
+	return &TermClause{Field: f, Operator: OperatorIsNot, Operand: &Keyword{Name: "EMPTY"}}
+}
+
+// Was returns the history term field WAS value.
+func (f Field) Was(value interface{}) *TermClause {
+	return f.term(OperatorWas, value)
+}
+
+// WasNot returns the history term field WAS NOT value.
+func (f Field) WasNot(value interface{}) *TermClause {
+	return f.term(OperatorWasNot, value)
+}
+
+// WasIn returns the history term field WAS IN (values).
+func (f Field) WasIn(values ...interface{}) *TermClause {
+	return &TermClause{Field: f, Operator: OperatorWasIn, Operand: list(values)}
+}
+
+// WasNotIn returns the history term field WAS NOT IN (values).
+func (f Field) WasNotIn(values ...interface{}) *TermClause {
+	return &TermClause{Field: f, Operator: OperatorWasNotIn, Operand: list(values)}
+}
+
+// Changed returns the history term field CHANGED.
+func (f Field) Changed() *TermClause {
+	return &TermClause{Field: f, Operator: OperatorChanged}
+}
+
+func (f Field) term(operator Operator, value interface{}) *TermClause {
+	return &TermClause{Field: f, Operator: operator, Operand: NewOperand(value)}
+}
+
+// After appends the AFTER predicate to the history term.
+func (t *TermClause) After(value interface{}) *TermClause {
+	return t.predicate("AFTER", NewOperand(value))
+}
+
+// Before appends the BEFORE predicate to the history term.
+func (t *TermClause) Before(value interface{}) *TermClause {
+	return t.predicate("BEFORE", NewOperand(value))
+}
+
+// During appends the DURING (from, to) predicate to the history term.
+func (t *TermClause) During(from, to interface{}) *TermClause {
+	return t.predicate("DURING", &List{Operands: []Operand{NewOperand(from), NewOperand(to)}})
+}
+
+// On appends the ON predicate to the history term.
+func (t *TermClause) On(value interface{}) *TermClause {
+	return t.predicate("ON", NewOperand(value))
+}
+
+// By appends the BY predicate to the history term, e.g: the account ID that changed the field.
+func (t *TermClause) By(value interface{}) *TermClause {
+	return t.predicate("BY", NewOperand(value))
+}
+
+// From appends the FROM predicate to the CHANGED term.
+func (t *TermClause) From(value interface{}) *TermClause {
+	return t.predicate("FROM", NewOperand(value))
+}
+
+// To appends the TO predicate to the CHANGED term.
+func (t *TermClause) To(value interface{}) *TermClause {
+	return t.predicate("TO", NewOperand(value))
+}
+
+func (t *TermClause) predicate(name string, operand Operand) *TermClause {
+	t.Predicates = append(t.Predicates, &Predicate{Name: name, Operand: operand})
+	return t
+}
+
+// NewOperand returns the operand of a value, the operands are returned as they are, the time.Time
+// values are formatted with DateTimeFormat and the other values are formatted as strings.
+func NewOperand(value interface{}) Operand {
+
+	switch value := value.(type) {
+	case Operand:
+		return value
+	case string:
+		return &Value{Text: value}
+	case []string:
+		operands := make([]Operand, len(value))
+		for index, text := range value {
+			operands[index] = &Value{Text: text}
+		}
+		return &List{Operands: operands}
+	case time.Time:
+		return &Value{Text: value.Format(DateTimeFormat)}
+	}
+
+	return &Value{Text: fmt.Sprint(value)}
+}
+
+func list(values []interface{}) Operand {
+
+	if len(values) == 1 {
+		switch value := values[0].(type) {
+		case *Function, *List:
+			return value.(Operand)
+		case []string:
+			return NewOperand(value)
+		}
+	}
+
+	operands := make([]Operand, len(values))
+	for index, value := range values {
+		operands[index] = NewOperand(value)
+	}
+
+	return &List{Operands: operands}
+}
+
+// And returns the clause matching all the clauses, the nil clauses are skipped and the nested AND
+// clauses are flattened.
+func And(clauses ...Clause) Clause {
+	return combine(clauses, func(clause Clause) []Clause {
+		if and, ok := clause.(*AndClause); ok {
+			return and.Clauses
+		}
+		return nil
+	}, func(clauses []Clause) Clause {
+		return &AndClause{Clauses: clauses}
+	})
+}
+
+// Or returns the clause matching any of the clauses, the nil clauses are skipped and the nested OR
+// clauses are flattened.
+func Or(clauses ...Clause) Clause {
+	return combine(clauses, func(clause Clause) []Clause {
+		if or, ok := clause.(*OrClause); ok {
+			return or.Clauses
+		}
+		return nil
+	}, func(clauses []Clause) Clause {
+		return &OrClause{Clauses: clauses}
+	})
+}
+
+// Not returns the clause matching the issues not matched by the clause.
+func Not(clause Clause) Clause {
+	return &NotClause{Clause: clause}
+}
+
+func combine(clauses []Clause, nested func(Clause) []Clause, build func([]Clause) Clause) Clause {
+
+	var flattened []Clause
+	for _, clause := range clauses {
+
+		if clause == nil {
+			continue
+		}
+
+		if children := nested(clause); children != nil {
+			flattened = append(flattened, children...)
+			continue
+		}
+
+		flattened = append(flattened, clause)
+	}
+
+	switch len(flattened) {
+	case 0:
+		return nil
+	case 1:
+		return flattened[0]
+	}
+
+	return build(flattened)
+}
+
+// Where returns the query matching the clause.
+func Where(clause Clause) *Query {
+	return &Query{Where: clause}
+}
+
+// And narrows the query with the clauses.
+func (q *Query) And(clauses ...Clause) *Query {
+	q.Where = And(append([]Clause{q.Where}, clauses...)...)
+	return q
+}
+
+// Or widens the query with the clauses.
+func (q *Query) Or(clauses ...Clause) *Query {
+	q.Where = Or(append([]Clause{q.Where}, clauses...)...)
+	return q
+}
+
+// OrderBy appends a field to the ORDER BY part of the query, the direction is optional.
+func (q *Query) OrderBy(field Field, direction Direction) *Query {
+	q.Order = append(q.Order, &OrderBy{Field: field, Direction: direction})
+	return q
+}
+
+// Func returns the call of a function, e.g: Func("issueHistory").
+func Func(name string, arguments ...string) *Function {
+	return &Function{Name: name, Arguments: arguments}
+}
+
+// CurrentUser returns the currentUser() function.
+func CurrentUser() *Function { return Func("currentUser") }
+
+// MembersOf returns the membersOf(group) function.
+func MembersOf(group string) *Function { return Func("membersOf", group) }
+
+// Now returns the now() function.
+func Now() *Function { return Func("now") }
+
+// StartOfDay returns the startOfDay([increment]) function, e.g: StartOfDay("-1d").
+func StartOfDay(increment ...string) *Function { return Func("startOfDay", increment...) }
+
+// EndOfDay returns the endOfDay([increment]) function.
+func EndOfDay(increment ...string) *Function { return Func("endOfDay", increment...) }
+
+// StartOfWeek returns the startOfWeek([increment]) function.
+func StartOfWeek(increment ...string) *Function { return Func("startOfWeek", increment...) }
+
+// EndOfWeek returns the endOfWeek([increment]) function.
+func EndOfWeek(increment ...string) *Function { return Func("endOfWeek", increment...) }
+
+// StartOfMonth returns the startOfMonth([increment]) function.
+func StartOfMonth(increment ...string) *Function { return Func("startOfMonth", increment...) }
+
+// EndOfMonth returns the endOfMonth([increment]) function.
+func EndOfMonth(increment ...string) *Function { return Func("endOfMonth", increment...) }
+
+// StartOfYear returns the startOfYear([increment]) function.
+func StartOfYear(increment ...string) *Function { return Func("startOfYear", increment...) }
+
+// EndOfYear returns the endOfYear([increment]) function.
+func EndOfYear(increment ...string) *Function { return Func("endOfYear", increment...) }
+
+// OpenSprints returns the openSprints() function.
+func OpenSprints() *Function { return Func("openSprints") }
+
+// ClosedSprints returns the closedSprints() function.
+func ClosedSprints() *Function { return Func("closedSprints") }
+
+// FutureSprints returns the futureSprints() function.
+func FutureSprints() *Function { return Func("futureSprints") }
+
+// LinkedIssues returns the linkedIssues(issueKey, [linkType]) function.
+func LinkedIssues(issueKey string, linkType ...string) *Function {
+	return Func("linkedIssues", append([]string{issueKey}, linkType...)...)
+}
+
+// ReleasedVersions returns the releasedVersions([project]) function.
+func ReleasedVersions(project ...string) *Function { return Func("releasedVersions", project...) }
+
+// UnreleasedVersions returns the unreleasedVersions([project]) function.
+func UnreleasedVersions(project ...string) *Function { return Func("unreleasedVersions", project...) }
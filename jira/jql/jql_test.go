@@ -0,0 +1,178 @@
+package jql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+
+	testCases := []struct {
+		name  string
+		query *Query
+		want  string
+	}{
+		{
+			name: "when the query has terms, functions and an order",
+			query: Where(Field("project").Eq("KP")).
+				And(Field("status").In("To Do", "In Progress")).
+				And(Field("assignee").In(MembersOf("jira-users"))).
+				And(CustomField(10010).IsNotEmpty()).
+				OrderBy("created", Descending).
+				OrderBy("key", ""),
+			want: `project = KP AND status IN ("To Do", "In Progress") AND assignee IN membersOf(jira-users) AND ` +
+				`cf[10010] IS NOT EMPTY ORDER BY created DESC, key`,
+		},
+		{
+			name: "when the values are injected",
+			query: Where(Field("summary").Contains(`x" OR project = SECRET`)).
+				And(Field("Story Points").Gte(5)),
+			want: `summary ~ "x\" OR project = SECRET" AND "Story Points" >= 5`,
+		},
+		{
+			name: "when the values are reserved words",
+			query: Where(Field("labels").In("and", "empty", "order")).
+				And(Field("fixVersion").In(UnreleasedVersions("KP"))),
+			want: `labels IN ("and", "empty", "order") AND fixVersion IN unreleasedVersions(KP)`,
+		},
+		{
+			name: "when the query has history terms",
+			query: Where(Or(
+				Field("status").Was("In Progress").By(CurrentUser()).During(StartOfWeek("-1w"), EndOfWeek("-1w")),
+				Field("assignee").Changed().From("5b10ac8d82e05b22cc7d4ef5").To(Empty).After(time.Date(2024, 1, 2, 15, 4, 0, 0, time.UTC)),
+			)).And(Not(Field("resolution").WasIn("Done", "Fixed"))),
+			want: `(status WAS "In Progress" BY currentUser() DURING (startOfWeek(-1w), endOfWeek(-1w)) OR ` +
+				`assignee CHANGED FROM 5b10ac8d82e05b22cc7d4ef5 TO EMPTY AFTER "2024/01/02 15:04") AND NOT resolution WAS IN (Done, Fixed)`,
+		},
+		{
+			name:  "when the query only has an order",
+			query: (&Query{}).OrderBy("rank", Ascending),
+			want:  "ORDER BY rank ASC",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			assert.Equal(t, testCase.want, testCase.query.String())
+
+			// The queries built round-trip through the parser.
+			parsed, err := Parse(testCase.want)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, parsed.String())
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+
+	testCases := []struct {
+		name    string
+		query   string
+		want    string
+		wantErr error
+		err     string
+	}{
+		{
+			name:  "when the query uses the alternative syntax",
+			query: `project="KP" && (status!=Done || assignee is empty) and !labels in ('a b',c) order by Created desc`,
+			want:  `project = KP AND (status != Done OR assignee IS EMPTY) AND NOT labels IN ("a b", c) ORDER BY Created DESC`,
+		},
+		{
+			name:  "when the query has nested groups and negations",
+			query: `NOT (x = 1 AND (b = 2 OR c = 3)) OR ((d = 4 AND e = 5) AND f = 6)`,
+			want:  `NOT (x = 1 AND (b = 2 OR c = 3)) OR d = 4 AND e = 5 AND f = 6`,
+		},
+		{
+			name:  "when the query has escaped values and custom fields",
+			query: `cf[10010] ~ "say \"hi\"\n" AND "Epic Link" = KP-1 AND text ~ foo\ bar AND created >= -1d`,
+			want:  `cf[10010] ~ "say \"hi\"\n" AND "Epic Link" = KP-1 AND text ~ "foo bar" AND created >= -1d`,
+		},
+		{
+			name:  "when the query has history operators",
+			query: `status was not in (Open, "To Do") before "2024/01/01" and priority changed by (x, y) on startOfDay()`,
+			want:  `status WAS NOT IN (Open, "To Do") BEFORE "2024/01/01" AND priority CHANGED BY (x, y) ON startOfDay()`,
+		},
+		{
+			name:  "when the query is empty",
+			query: "  ",
+			want:  "",
+		},
+		{
+			name:    "when the string is unterminated",
+			query:   `summary ~ "open`,
+			wantErr: ErrSyntaxError,
+			err:     "jql: syntax error: unterminated string at position 10",
+		},
+		{
+			name:    "when the operator is missing",
+			query:   `project KP`,
+			wantErr: ErrSyntaxError,
+			err:     `jql: syntax error: expected operator, found "KP" at position 8`,
+		},
+		{
+			name:    "when the group is not closed",
+			query:   `(project = KP`,
+			wantErr: ErrSyntaxError,
+			err:     `jql: syntax error: expected ), found end of query at position 13`,
+		},
+		{
+			name:    "when the clauses are not joined",
+			query:   `project = KP status = Done`,
+			wantErr: ErrSyntaxError,
+			err:     `jql: syntax error: expected AND, OR or ORDER BY, found "status" at position 13`,
+		},
+		{
+			name:    "when the list is not closed",
+			query:   `status in (Open`,
+			wantErr: ErrSyntaxError,
+			err:     `jql: syntax error: expected , or ), found end of query at position 15`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			got, err := Parse(testCase.query)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				assert.EqualError(t, err, testCase.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, got.String())
+
+			reparsed, err := Parse(got.String())
+			assert.NoError(t, err)
+			assert.Equal(t, got, reparsed)
+		})
+	}
+}
+
+func TestInspect(t *testing.T) {
+
+	query := MustParse(`project in (KP, OLD) AND (project = OLD OR assignee = currentUser()) ORDER BY project`)
+
+	Inspect(query, func(node Node) bool {
+
+		term, ok := node.(*TermClause)
+		if !ok || term.Field != "project" {
+			return true
+		}
+
+		Inspect(term.Operand, func(node Node) bool {
+			if value, ok := node.(*Value); ok && value.Text == "OLD" {
+				value.Text = "NEW"
+			}
+			return true
+		})
+
+		return false
+	})
+
+	assert.Equal(t, `project IN (KP, NEW) AND (project = NEW OR assignee = currentUser()) ORDER BY project`, query.String())
+}
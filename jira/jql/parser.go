@@ -0,0 +1,526 @@
+package jql
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrSyntaxError = errors.New("jql: syntax error")
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+)
+
+type token struct {
+	kind     tokenKind
+	text     string
+	position int
+}
+
+func (t token) String() string {
+
+	if t.kind == tokenEOF {
+		return "end of query"
+	}
+
+	return strconv.Quote(t.text)
+}
+
+// is reports whether the token is the keyword, the keywords are case-insensitive.
+func (t token) is(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+const special = `()=!<>~,"'&|`
+
+var compound = map[string]bool{"!=": true, "!~": true, ">=": true, "<=": true}
+
+// lex splits the query into tokens, the && and || operators are returned as the AND and OR keywords.
+func lex(query string) ([]token, error) {
+
+	var (
+		tokens []token
+		runes  = []rune(query)
+	)
+
+	for position := 0; position < len(runes); {
+
+		r := runes[position]
+
+		switch {
+		case unicode.IsSpace(r):
+			position++
+
+		case r == '(':
+			tokens = append(tokens, token{tokenLeftParen, "(", position})
+			position++
+
+		case r == ')':
+			tokens = append(tokens, token{tokenRightParen, ")", position})
+			position++
+
+		case r == ',':
+			tokens = append(tokens, token{tokenComma, ",", position})
+			position++
+
+		case r == '&' || r == '|':
+
+			keyword := map[rune]string{'&': "AND", '|': "OR"}[r]
+			tokens = append(tokens, token{tokenWord, keyword, position})
+
+			position++
+			if position < len(runes) && runes[position] == r {
+				position++
+			}
+
+		case strings.ContainsRune("=!<>~", r):
+
+			operator := string(r)
+			if position+1 < len(runes) && compound[operator+string(runes[position+1])] {
+				operator += string(runes[position+1])
+			}
+
+			if operator == "!" {
+				tokens = append(tokens, token{tokenWord, "NOT", position})
+			} else {
+				tokens = append(tokens, token{tokenOperator, operator, position})
+			}
+
+			position += len([]rune(operator))
+
+		case r == '"' || r == '\'':
+
+			text, next, err := unquote(runes, position)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, token{tokenString, text, position})
+			position = next
+
+		default:
+
+			var builder strings.Builder
+			start := position
+
+			for position < len(runes) && !unicode.IsSpace(runes[position]) && !strings.ContainsRune(special, runes[position]) {
+
+				if runes[position] == '\\' {
+
+					if position+1 == len(runes) {
+						return nil, fmt.Errorf("%w: unterminated escape sequence at position %v", ErrSyntaxError, position)
+					}
+
+					position++
+				}
+
+				builder.WriteRune(runes[position])
+				position++
+			}
+
+			tokens = append(tokens, token{tokenWord, builder.String(), start})
+		}
+	}
+
+	return append(tokens, token{tokenEOF, "", len(runes)}), nil
+}
+
+// unquote returns the text of the string starting at the position and the position after it.
+func unquote(runes []rune, position int) (string, int, error) {
+
+	var (
+		builder strings.Builder
+		quote   = runes[position]
+		start   = position
+	)
+
+	for position++; position < len(runes); position++ {
+
+		r := runes[position]
+
+		if r == quote {
+			return builder.String(), position + 1, nil
+		}
+
+		if r != '\\' {
+			builder.WriteRune(r)
+			continue
+		}
+
+		position++
+		if position == len(runes) {
+			break
+		}
+
+		switch runes[position] {
+		case 'n':
+			builder.WriteRune('\n')
+		case 'r':
+			builder.WriteRune('\r')
+		case 't':
+			builder.WriteRune('\t')
+		case 'u':
+
+			if position+4 >= len(runes) {
+				return "", 0, fmt.Errorf("%w: invalid unicode escape sequence at position %v", ErrSyntaxError, position-1)
+			}
+
+			code, err := strconv.ParseUint(string(runes[position+1:position+5]), 16, 32)
+			if err != nil {
+				return "", 0, fmt.Errorf("%w: invalid unicode escape sequence at position %v", ErrSyntaxError, position-1)
+			}
+
+			builder.WriteRune(rune(code))
+			position += 4
+
+		default:
+			builder.WriteRune(runes[position])
+		}
+	}
+
+	return "", 0, fmt.Errorf("%w: unterminated string at position %v", ErrSyntaxError, start)
+}
+
+// Parse parses the query into its AST, the query can be empty or only have the ORDER BY part.
+func Parse(query string) (*Query, error) {
+
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	result := &Query{}
+
+	if !p.peek().is("ORDER") && p.peek().kind != tokenEOF {
+
+		if result.Where, err = p.or(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peek().is("ORDER") {
+
+		p.next()
+		if current := p.next(); !current.is("BY") {
+			return nil, unexpected(current, "BY")
+		}
+
+		if result.Order, err = p.orderBy(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peek().kind != tokenEOF {
+		return nil, unexpected(p.peek(), "AND, OR or ORDER BY")
+	}
+
+	return result, nil
+}
+
+// MustParse is like Parse but panics if the query can't be parsed, e.g: to parse constant queries.
+func MustParse(query string) *Query {
+
+	result, err := Parse(query)
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+type parser struct {
+	tokens   []token
+	position int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.position]
+}
+
+func (p *parser) next() token {
+
+	current := p.tokens[p.position]
+	if current.kind != tokenEOF {
+		p.position++
+	}
+
+	return current
+}
+
+func unexpected(found token, expected string) error {
+	return fmt.Errorf("%w: expected %v, found %v at position %v", ErrSyntaxError, expected, found, found.position)
+}
+
+func (p *parser) or() (Clause, error) {
+
+	clauses, err := p.sequence("OR", p.and)
+	if err != nil {
+		return nil, err
+	}
+
+	return Or(clauses...), nil
+}
+
+func (p *parser) and() (Clause, error) {
+
+	clauses, err := p.sequence("AND", p.not)
+	if err != nil {
+		return nil, err
+	}
+
+	return And(clauses...), nil
+}
+
+func (p *parser) sequence(keyword string, parse func() (Clause, error)) ([]Clause, error) {
+
+	var clauses []Clause
+	for {
+
+		clause, err := parse()
+		if err != nil {
+			return nil, err
+		}
+
+		clauses = append(clauses, clause)
+
+		if !p.peek().is(keyword) {
+			return clauses, nil
+		}
+
+		p.next()
+	}
+}
+
+func (p *parser) not() (Clause, error) {
+
+	switch current := p.peek(); {
+	case current.is("NOT"):
+
+		p.next()
+
+		clause, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+
+		return Not(clause), nil
+
+	case current.kind == tokenLeftParen:
+
+		p.next()
+
+		clause, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := p.next(); closing.kind != tokenRightParen {
+			return nil, unexpected(closing, ")")
+		}
+
+		return clause, nil
+	}
+
+	return p.term()
+}
+
+func (p *parser) field() (Field, error) {
+
+	current := p.next()
+	if current.kind != tokenWord && current.kind != tokenString {
+		return "", unexpected(current, "field")
+	}
+
+	return Field(current.text), nil
+}
+
+func (p *parser) term() (Clause, error) {
+
+	field, err := p.field()
+	if err != nil {
+		return nil, err
+	}
+
+	term := &TermClause{Field: field}
+
+	if term.Operator, err = p.operator(); err != nil {
+		return nil, err
+	}
+
+	if term.Operator != OperatorChanged {
+
+		if term.Operand, err = p.operand(); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+
+		current := p.peek()
+		if current.kind != tokenWord || !predicates[strings.ToUpper(current.text)] {
+			return term, nil
+		}
+
+		p.next()
+
+		operand, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+
+		term.Predicates = append(term.Predicates, &Predicate{Name: strings.ToUpper(current.text), Operand: operand})
+	}
+}
+
+var predicates = map[string]bool{"AFTER": true, "BEFORE": true, "DURING": true, "ON": true, "BY": true, "FROM": true, "TO": true}
+
+// operator parses the operator, the keyword operators are made of up to three words, e.g: WAS NOT IN.
+func (p *parser) operator() (Operator, error) {
+
+	current := p.next()
+	if current.kind == tokenOperator {
+		return Operator(current.text), nil
+	}
+
+	words := []string{strings.ToUpper(current.text)}
+
+	switch {
+	case current.is("IN"), current.is("CHANGED"):
+	case current.is("NOT"):
+
+		if next := p.next(); !next.is("IN") {
+			return "", unexpected(next, "IN")
+		}
+
+		words = append(words, "IN")
+
+	case current.is("IS"), current.is("WAS"):
+
+		if p.peek().is("NOT") {
+			words = append(words, strings.ToUpper(p.next().text))
+		}
+
+		if current.is("WAS") && p.peek().is("IN") {
+			words = append(words, strings.ToUpper(p.next().text))
+		}
+
+	default:
+		return "", unexpected(current, "operator")
+	}
+
+	return Operator(strings.Join(words, " ")), nil
+}
+
+func (p *parser) operand() (Operand, error) {
+
+	current := p.next()
+
+	switch current.kind {
+	case tokenString:
+		return &Value{Text: current.text}, nil
+
+	case tokenLeftParen:
+
+		operands := &List{}
+		for {
+
+			operand, err := p.operand()
+			if err != nil {
+				return nil, err
+			}
+
+			operands.Operands = append(operands.Operands, operand)
+
+			switch separator := p.next(); separator.kind {
+			case tokenComma:
+				continue
+			case tokenRightParen:
+				return operands, nil
+			default:
+				return nil, unexpected(separator, ", or )")
+			}
+		}
+
+	case tokenWord:
+
+		if current.is("EMPTY") || current.is("NULL") {
+			return &Keyword{Name: strings.ToUpper(current.text)}, nil
+		}
+
+		if p.peek().kind == tokenLeftParen {
+			return p.function(current.text)
+		}
+
+		return &Value{Text: current.text}, nil
+	}
+
+	return nil, unexpected(current, "value")
+}
+
+func (p *parser) function(name string) (Operand, error) {
+
+	p.next()
+	function := &Function{Name: name}
+
+	if p.peek().kind == tokenRightParen {
+		p.next()
+		return function, nil
+	}
+
+	for {
+
+		argument := p.next()
+		if argument.kind != tokenWord && argument.kind != tokenString {
+			return nil, unexpected(argument, "argument")
+		}
+
+		function.Arguments = append(function.Arguments, argument.text)
+
+		switch separator := p.next(); separator.kind {
+		case tokenComma:
+			continue
+		case tokenRightParen:
+			return function, nil
+		default:
+			return nil, unexpected(separator, ", or )")
+		}
+	}
+}
+
+func (p *parser) orderBy() ([]*OrderBy, error) {
+
+	var order []*OrderBy
+	for {
+
+		field, err := p.field()
+		if err != nil {
+			return nil, err
+		}
+
+		item := &OrderBy{Field: field}
+
+		if current := p.peek(); current.is("ASC") || current.is("DESC") {
+			item.Direction = Direction(strings.ToUpper(p.next().text))
+		}
+
+		order = append(order, item)
+
+		if p.peek().kind != tokenComma {
+			return order, nil
+		}
+
+		p.next()
+	}
+}
@@ -0,0 +1,192 @@
+package permissions
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/ctreminiom/go-atlassian/internal/sets"
+)
+
+// Access is the effective access granted on a project by its permission scheme.
+type Access struct {
+	Project    string
+	SchemeID   int
+	SchemeName string
+
+	// Grants are sorted by permission and holder.
+	Grants []*Grant
+}
+
+// Grant is a permission grant of the scheme expanded to account IDs.
+type Grant struct {
+	ID         int
+	Permission string
+	Holder     string
+
+	// Parameter is the name of the project role, the group name, the account ID, the application
+	// key or the custom field ID, depending on the holder.
+	Parameter string
+
+	// AccountIDs are the accounts granted the permission on every issue, sorted.
+	AccountIDs []string
+}
+
+// String returns the holder of the grant, e.g: projectRole:Developers.
+func (g *Grant) String() string {
+
+	if g.Parameter == "" {
+		return g.Holder
+	}
+
+	return g.Holder + ":" + g.Parameter
+}
+
+// Everyone reports whether the grant applies to any user, anonymous users included for the
+// anyone holder.
+func (g *Grant) Everyone() bool {
+	return g.Holder == AnyoneHolder || (g.Holder == ApplicationRoleHolder && g.Parameter == "")
+}
+
+// Conditional reports whether the grant depends on the issue, e.g: the assignee.
+func (g *Grant) Conditional() bool {
+
+	switch g.Holder {
+	case AssigneeHolder, ReporterHolder, UserCustomFieldHolder, GroupCustomFieldHolder:
+		return true
+	}
+
+	return false
+}
+
+// Holders are the users granted a permission.
+type Holders struct {
+	Permission string
+
+	// AccountIDs are the accounts granted the permission on every issue, sorted.
+	AccountIDs []string
+
+	// Everyone reports whether the permission is granted to any user.
+	Everyone bool
+
+	// Conditional are the grants that depend on the issue, e.g: the reporter.
+	Conditional []*Grant
+}
+
+// Who returns the users granted the permission, e.g: DELETE_ISSUES.
+func (a *Access) Who(permission string) *Holders {
+
+	holders := &Holders{Permission: permission}
+
+	var accountIDs []string
+	for _, grant := range a.Grants {
+
+		if grant.Permission != permission {
+			continue
+		}
+
+		switch {
+		case grant.Everyone():
+			holders.Everyone = true
+		case grant.Conditional():
+			holders.Conditional = append(holders.Conditional, grant)
+		default:
+			accountIDs = append(accountIDs, grant.AccountIDs...)
+		}
+	}
+
+	holders.AccountIDs = sets.Strings(accountIDs)
+	return holders
+}
+
+// Entitlement is a permission of a user and the grants it's granted by.
+type Entitlement struct {
+	Permission string
+	Grants     []*Grant
+
+	// Conditional reports whether the permission is granted by the grants that depend on the issue
+	// only, e.g: the user can edit the issues assigned to them.
+	Conditional bool
+}
+
+// What returns the permissions of the account sorted by key, the grants that depend on the issue
+// are included as any user can be the assignee or reporter of an issue.
+func (a *Access) What(accountID string) []*Entitlement {
+
+	entitlements := map[string]*Entitlement{}
+	for _, grant := range a.Grants {
+
+		granted := grant.Everyone() || grant.Conditional()
+		for _, value := range grant.AccountIDs {
+			if value == accountID {
+				granted = true
+				break
+			}
+		}
+
+		if !granted {
+			continue
+		}
+
+		entitlement, ok := entitlements[grant.Permission]
+		if !ok {
+			entitlement = &Entitlement{Permission: grant.Permission, Conditional: true}
+			entitlements[grant.Permission] = entitlement
+		}
+
+		entitlement.Grants = append(entitlement.Grants, grant)
+		entitlement.Conditional = entitlement.Conditional && grant.Conditional()
+	}
+
+	result := make([]*Entitlement, 0, len(entitlements))
+	for _, entitlement := range entitlements {
+		result = append(result, entitlement)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Permission < result[j].Permission })
+	return result
+}
+
+// Permissions returns the keys of the permissions granted by the scheme, sorted.
+func (a *Access) Permissions() []string {
+
+	keys := make([]string, 0, len(a.Grants))
+	for _, grant := range a.Grants {
+		keys = append(keys, grant.Permission)
+	}
+
+	return sets.Strings(keys)
+}
+
+// WriteCSV writes the access with a row per grant and account, the grants without accounts, e.g:
+// the assignee, have a single row without account.
+func (a *Access) WriteCSV(w io.Writer) error {
+
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{"project", "scheme", "permission", "holder", "parameter", "account_id", "everyone", "conditional"})
+	if err != nil {
+		return err
+	}
+
+	for _, grant := range a.Grants {
+
+		accountIDs := grant.AccountIDs
+		if len(accountIDs) == 0 {
+			accountIDs = []string{""}
+		}
+
+		for _, accountID := range accountIDs {
+
+			err = writer.Write([]string{a.Project, a.SchemeName, grant.Permission, grant.Holder, grant.Parameter, accountID,
+				strconv.FormatBool(grant.Everyone()), strconv.FormatBool(grant.Conditional())})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
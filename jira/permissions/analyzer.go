@@ -0,0 +1,309 @@
+// Package permissions computes the effective access granted by the permission schemes of Jira
+// projects: every grant is expanded to the account IDs it applies to, e.g: the actors of a project
+// role, including the members of the groups, or the lead of the project.
+//
+//	analyzer, err := permissions.NewAnalyzer(&permissions.Connectors{
+//		Project:                 instance.Project,
+//		ProjectPermissionScheme: instance.Project.Permission,
+//		ProjectRole:             instance.Project.Role,
+//		Grant:                   instance.Permission.Scheme.Grant,
+//		Group:                   instance.Group,
+//		ApplicationRole:         instance.Role,
+//	})
+//
+//	access, err := analyzer.Analyze(ctx, "KP")
+//
+//	holders := access.Who("DELETE_ISSUES")
+//	entitlements := access.What(accountID)
+//
+// The grants of the assignee, reporter and custom field holders depend on the issue, they're
+// reported as conditional instead of being expanded.
+package permissions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/ctreminiom/go-atlassian/internal/sets"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/jira"
+)
+
+const pageSize = 50
+
+// The types of the holders of the grants.
+const (
+	AnyoneHolder           = "anyone"
+	ApplicationRoleHolder  = "applicationRole"
+	AssigneeHolder         = "assignee"
+	GroupHolder            = "group"
+	GroupCustomFieldHolder = "groupCustomField"
+	ProjectLeadHolder      = "projectLead"
+	ProjectRoleHolder      = "projectRole"
+	ReporterHolder         = "reporter"
+	UserHolder             = "user"
+	UserCustomFieldHolder  = "userCustomField"
+)
+
+var (
+	ErrNoConnectorError = errors.New("permissions: no connector set")
+)
+
+// Connectors are the services the permission schemes, roles and groups are read with.
+type Connectors struct {
+	Project                 jira.ProjectConnector
+	ProjectPermissionScheme jira.ProjectPermissionSchemeConnector
+	ProjectRole             jira.ProjectRoleConnector
+	Grant                   jira.PermissionSchemeGrantConnector
+	Group                   jira.GroupConnector
+	ApplicationRole         jira.AppRoleConnector
+}
+
+// Analyzer expands the grants of the permission schemes. The members of the groups, the application
+// roles and the project roles are fetched once and cached, an analyzer isn't safe for concurrent use.
+type Analyzer struct {
+	c Connectors
+
+	members          map[string][]string
+	applicationRoles map[string]*model.ApplicationRoleScheme
+	roles            map[int]string
+}
+
+// NewAnalyzer returns an analyzer of the site of the connectors, all of them are required.
+func NewAnalyzer(connectors *Connectors) (*Analyzer, error) {
+
+	if connectors == nil {
+		return nil, ErrNoConnectorError
+	}
+
+	required := []struct {
+		name      string
+		connector interface{}
+	}{
+		{"Project", connectors.Project},
+		{"ProjectPermissionScheme", connectors.ProjectPermissionScheme},
+		{"ProjectRole", connectors.ProjectRole},
+		{"Grant", connectors.Grant},
+		{"Group", connectors.Group},
+		{"ApplicationRole", connectors.ApplicationRole},
+	}
+
+	for _, connector := range required {
+		if connector.connector == nil {
+			return nil, fmt.Errorf("%w: %v", ErrNoConnectorError, connector.name)
+		}
+	}
+
+	return &Analyzer{
+		c:                *connectors,
+		members:          map[string][]string{},
+		applicationRoles: map[string]*model.ApplicationRoleScheme{},
+	}, nil
+}
+
+// Analyze returns the grants of the permission scheme of the project expanded to account IDs.
+func (a *Analyzer) Analyze(ctx context.Context, projectKey string) (*Access, error) {
+
+	project, _, err := a.c.Project.Get(ctx, projectKey, []string{"lead"})
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, _, err := a.c.ProjectPermissionScheme.Get(ctx, project.Key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	grants, _, err := a.c.Grant.Gets(ctx, scheme.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	access := &Access{Project: project.Key, SchemeID: scheme.ID, SchemeName: scheme.Name}
+
+	// The actors of the roles are specific to the project.
+	actors := map[int]*model.ProjectRoleScheme{}
+
+	for _, value := range grants.Permissions {
+
+		if value.Holder == nil {
+			continue
+		}
+
+		grant := &Grant{ID: value.ID, Permission: value.Permission, Holder: value.Holder.Type, Parameter: value.Holder.Parameter}
+
+		var accountIDs []string
+		switch grant.Holder {
+		case UserHolder:
+			accountIDs = []string{grant.Parameter}
+
+		case ProjectLeadHolder:
+			if project.Lead != nil && project.Lead.AccountID != "" {
+				accountIDs = []string{project.Lead.AccountID}
+			}
+
+		case GroupHolder:
+			if accountIDs, err = a.groupMembers(ctx, grant.Parameter); err != nil {
+				return nil, err
+			}
+
+		case ApplicationRoleHolder:
+
+			// The grants without application role are granted to any user with product access.
+			if grant.Parameter == "" {
+				break
+			}
+
+			if accountIDs, err = a.applicationRoleMembers(ctx, grant.Parameter); err != nil {
+				return nil, err
+			}
+
+		case ProjectRoleHolder:
+
+			roleID, err := strconv.Atoi(grant.Parameter)
+			if err != nil {
+				return nil, fmt.Errorf("permissions: invalid project role ID %q: %w", grant.Parameter, err)
+			}
+
+			role, ok := actors[roleID]
+			if !ok {
+
+				if role, _, err = a.c.ProjectRole.Get(ctx, project.Key, roleID); err != nil {
+					return nil, err
+				}
+
+				actors[roleID] = role
+			}
+
+			grant.Parameter = role.Name
+			if accountIDs, err = a.roleMembers(ctx, role); err != nil {
+				return nil, err
+			}
+		}
+
+		grant.AccountIDs = sets.Strings(accountIDs)
+		access.Grants = append(access.Grants, grant)
+	}
+
+	sort.SliceStable(access.Grants, func(i, j int) bool {
+
+		if access.Grants[i].Permission != access.Grants[j].Permission {
+			return access.Grants[i].Permission < access.Grants[j].Permission
+		}
+
+		return access.Grants[i].String() < access.Grants[j].String()
+	})
+
+	return access, nil
+}
+
+// roleMembers returns the users of the role and the members of its groups.
+func (a *Analyzer) roleMembers(ctx context.Context, role *model.ProjectRoleScheme) ([]string, error) {
+
+	var accountIDs []string
+	for _, actor := range role.Actors {
+
+		switch {
+		case actor.ActorUser != nil:
+			accountIDs = append(accountIDs, actor.ActorUser.AccountID)
+
+		case actor.ActorGroup != nil:
+
+			members, err := a.groupMembers(ctx, actor.ActorGroup.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			accountIDs = append(accountIDs, members...)
+		}
+	}
+
+	return accountIDs, nil
+}
+
+// applicationRoleMembers returns the members of the groups of the application role.
+func (a *Analyzer) applicationRoleMembers(ctx context.Context, key string) ([]string, error) {
+
+	role, ok := a.applicationRoles[key]
+	if !ok {
+
+		var err error
+		if role, _, err = a.c.ApplicationRole.Get(ctx, key); err != nil {
+			return nil, err
+		}
+
+		a.applicationRoles[key] = role
+	}
+
+	var accountIDs []string
+	for _, group := range role.Groups {
+
+		members, err := a.groupMembers(ctx, group)
+		if err != nil {
+			return nil, err
+		}
+
+		accountIDs = append(accountIDs, members...)
+	}
+
+	return accountIDs, nil
+}
+
+// groupMembers returns the account IDs of the active members of the group.
+func (a *Analyzer) groupMembers(ctx context.Context, name string) ([]string, error) {
+
+	if members, ok := a.members[name]; ok {
+		return members, nil
+	}
+
+	var members []string
+	for startAt := 0; ; startAt += pageSize {
+
+		page, _, err := a.c.Group.Members(ctx, name, false, startAt, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("permissions: group %q: %w", name, err)
+		}
+
+		for _, member := range page.Values {
+			members = append(members, member.AccountID)
+		}
+
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+	}
+
+	a.members[name] = members
+	return members, nil
+}
+
+// roleName returns the name of the project role, the ID when it's unknown.
+func (a *Analyzer) roleName(ctx context.Context, id string) (string, error) {
+
+	if a.roles == nil {
+
+		roles, _, err := a.c.ProjectRole.Global(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		a.roles = map[int]string{}
+		for _, role := range roles {
+			a.roles[role.ID] = role.Name
+		}
+	}
+
+	roleID, err := strconv.Atoi(id)
+	if err != nil {
+		return id, nil
+	}
+
+	if name, ok := a.roles[roleID]; ok {
+		return name, nil
+	}
+
+	return id, nil
+}
@@ -0,0 +1,144 @@
+package permissions
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// Change is the kind of difference between two permission schemes.
+type Change string
+
+const (
+	// Granted is a grant of the other scheme missing on the scheme.
+	Granted Change = "granted"
+
+	// Revoked is a grant of the scheme missing on the other scheme.
+	Revoked Change = "revoked"
+)
+
+// Difference is a grant present on one of the schemes compared only.
+type Difference struct {
+	Change     Change
+	Permission string
+	Holder     string
+	Parameter  string
+}
+
+// Compare returns the grants that differ between the scheme and the other one, sorted by permission
+// and holder. The grants are matched by permission, holder and parameter, the IDs are ignored.
+func Compare(scheme, other []*model.PermissionGrantScheme) []*Difference {
+
+	current, desired := grantSet(scheme), grantSet(other)
+
+	var differences []*Difference
+	for key, grant := range desired {
+		if _, ok := current[key]; !ok {
+			differences = append(differences, &Difference{Change: Granted, Permission: grant.Permission, Holder: grant.Holder.Type,
+				Parameter: grant.Holder.Parameter})
+		}
+	}
+
+	for key, grant := range current {
+		if _, ok := desired[key]; !ok {
+			differences = append(differences, &Difference{Change: Revoked, Permission: grant.Permission, Holder: grant.Holder.Type,
+				Parameter: grant.Holder.Parameter})
+		}
+	}
+
+	sortDifferences(differences)
+	return differences
+}
+
+func sortDifferences(differences []*Difference) {
+
+	sort.Slice(differences, func(i, j int) bool {
+
+		if differences[i].Permission != differences[j].Permission {
+			return differences[i].Permission < differences[j].Permission
+		}
+
+		if differences[i].Holder != differences[j].Holder {
+			return differences[i].Holder < differences[j].Holder
+		}
+
+		if differences[i].Parameter != differences[j].Parameter {
+			return differences[i].Parameter < differences[j].Parameter
+		}
+
+		return differences[i].Change > differences[j].Change
+	})
+}
+
+// Diff returns the grants that differ between the permission schemes, e.g: the scheme of a project
+// and the one of a template project. The project roles are reported by name.
+func (a *Analyzer) Diff(ctx context.Context, schemeID, otherID int) ([]*Difference, error) {
+
+	scheme, _, err := a.c.Grant.Gets(ctx, schemeID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	other, _, err := a.c.Grant.Gets(ctx, otherID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	differences := Compare(scheme.Permissions, other.Permissions)
+	for _, difference := range differences {
+
+		if difference.Holder != ProjectRoleHolder {
+			continue
+		}
+
+		if difference.Parameter, err = a.roleName(ctx, difference.Parameter); err != nil {
+			return nil, err
+		}
+	}
+
+	sortDifferences(differences)
+	return differences, nil
+}
+
+// WriteDiff writes a line per difference, the grants only on the other scheme
+// are prefixed with + and the grants only on the scheme with -.
+func WriteDiff(w io.Writer, differences []*Difference) error {
+
+	buffer := bufio.NewWriter(w)
+
+	for _, difference := range differences {
+
+		prefix := "+"
+		if difference.Change == Revoked {
+			prefix = "-"
+		}
+
+		holder := difference.Holder
+		if difference.Parameter != "" {
+			holder += ":" + difference.Parameter
+		}
+
+		fmt.Fprintf(buffer, "%v %v %v\n", prefix, difference.Permission, holder)
+	}
+
+	return buffer.Flush()
+}
+
+func grantSet(grants []*model.PermissionGrantScheme) map[string]*model.PermissionGrantScheme {
+
+	set := make(map[string]*model.PermissionGrantScheme, len(grants))
+	for _, grant := range grants {
+
+		if grant.Holder == nil {
+			continue
+		}
+
+		set[grant.Permission+"\x00"+grant.Holder.Type+"\x00"+grant.Holder.Parameter] = grant
+	}
+
+	return set
+}
@@ -0,0 +1,264 @@
+package permissions
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/jira"
+	"github.com/stretchr/testify/assert"
+)
+
+// site is the state of a fake Jira site with the KP project, the reads are counted on calls.
+type site struct {
+	calls map[string]int
+
+	grants  map[int][]*model.PermissionGrantScheme
+	roles   map[int]*model.ProjectRoleScheme
+	members map[string][]string
+}
+
+func newSite() *site {
+
+	return &site{
+		calls: map[string]int{},
+		grants: map[int][]*model.PermissionGrantScheme{
+			600: {
+				{ID: 1, Permission: "BROWSE_PROJECTS", Holder: &model.PermissionGrantHolderScheme{Type: "applicationRole", Parameter: "jira-software"}},
+				{ID: 2, Permission: "DELETE_ISSUES", Holder: &model.PermissionGrantHolderScheme{Type: "projectRole", Parameter: "31"}},
+				{ID: 3, Permission: "DELETE_ISSUES", Holder: &model.PermissionGrantHolderScheme{Type: "projectLead"}},
+				{ID: 4, Permission: "DELETE_ISSUES", Holder: &model.PermissionGrantHolderScheme{Type: "reporter"}},
+				{ID: 5, Permission: "EDIT_ISSUES", Holder: &model.PermissionGrantHolderScheme{Type: "projectRole", Parameter: "30"}},
+				{ID: 6, Permission: "EDIT_ISSUES", Holder: &model.PermissionGrantHolderScheme{Type: "user", Parameter: "contractor"}},
+				{ID: 7, Permission: "EDIT_ISSUES", Holder: &model.PermissionGrantHolderScheme{Type: "assignee"}},
+				{ID: 8, Permission: "ADD_COMMENTS", Holder: &model.PermissionGrantHolderScheme{Type: "applicationRole"}},
+				{ID: 9, Permission: "ADMINISTER_PROJECTS", Holder: &model.PermissionGrantHolderScheme{Type: "group", Parameter: "jira-admins"}},
+			},
+			601: {
+				{ID: 1, Permission: "BROWSE_PROJECTS", Holder: &model.PermissionGrantHolderScheme{Type: "applicationRole", Parameter: "jira-software"}},
+				{ID: 2, Permission: "DELETE_ISSUES", Holder: &model.PermissionGrantHolderScheme{Type: "projectRole", Parameter: "30"}},
+				{ID: 3, Permission: "ADMINISTER_PROJECTS", Holder: &model.PermissionGrantHolderScheme{Type: "group", Parameter: "jira-admins"}},
+			},
+		},
+		roles: map[int]*model.ProjectRoleScheme{
+			30: {ID: 30, Name: "Developers", Actors: []*model.RoleActorScheme{
+				{ActorUser: &model.RoleActorUserScheme{AccountID: "dev-1"}},
+				{ActorGroup: &model.GroupScheme{Name: "developers"}},
+			}},
+			31: {ID: 31, Name: "Administrators", Actors: []*model.RoleActorScheme{
+				{ActorGroup: &model.GroupScheme{Name: "jira-admins"}},
+			}},
+		},
+		members: map[string][]string{
+			"developers":             {"dev-1", "dev-2", "dev-3"},
+			"jira-admins":            {"admin-1"},
+			"jira-software-users":    {"dev-1", "dev-2", "dev-3", "admin-1", "lead", "contractor"},
+			"jira-software-external": {"contractor"},
+		},
+	}
+}
+
+func (s *site) connectors() *Connectors {
+	return &Connectors{
+		Project:                 &projectFake{site: s},
+		ProjectPermissionScheme: &projectPermissionFake{site: s},
+		ProjectRole:             &roleFake{site: s},
+		Grant:                   &grantFake{site: s},
+		Group:                   &groupFake{site: s},
+		ApplicationRole:         &applicationRoleFake{site: s},
+	}
+}
+
+type projectFake struct {
+	jira.ProjectConnector
+	site *site
+}
+
+func (p *projectFake) Get(ctx context.Context, projectKeyOrID string, expand []string) (*model.ProjectScheme, *model.ResponseScheme, error) {
+
+	if projectKeyOrID != "KP" {
+		return nil, nil, model.ErrNotFound
+	}
+
+	return &model.ProjectScheme{ID: "10000", Key: "KP", Lead: &model.UserScheme{AccountID: "lead"}}, nil, nil
+}
+
+type projectPermissionFake struct {
+	jira.ProjectPermissionSchemeConnector
+	site *site
+}
+
+func (p *projectPermissionFake) Get(ctx context.Context, projectKeyOrID string, expand []string) (*model.PermissionSchemeScheme,
+	*model.ResponseScheme, error) {
+	return &model.PermissionSchemeScheme{ID: 600, Name: "KP Permissions"}, nil, nil
+}
+
+type roleFake struct {
+	jira.ProjectRoleConnector
+	site *site
+}
+
+func (r *roleFake) Get(ctx context.Context, projectKeyOrID string, roleID int) (*model.ProjectRoleScheme, *model.ResponseScheme, error) {
+	r.site.calls["role"]++
+	return r.site.roles[roleID], nil, nil
+}
+
+func (r *roleFake) Global(ctx context.Context) ([]*model.ProjectRoleScheme, *model.ResponseScheme, error) {
+	return []*model.ProjectRoleScheme{r.site.roles[30], r.site.roles[31]}, nil, nil
+}
+
+type grantFake struct {
+	jira.PermissionSchemeGrantConnector
+	site *site
+}
+
+func (g *grantFake) Gets(ctx context.Context, permissionSchemeID int, expand []string) (*model.PermissionSchemeGrantsScheme,
+	*model.ResponseScheme, error) {
+	return &model.PermissionSchemeGrantsScheme{Permissions: g.site.grants[permissionSchemeID]}, nil, nil
+}
+
+type groupFake struct {
+	jira.GroupConnector
+	site *site
+}
+
+func (g *groupFake) Members(ctx context.Context, groupName string, inactive bool, startAt, maxResults int) (*model.GroupMemberPageScheme,
+	*model.ResponseScheme, error) {
+
+	g.site.calls["group"]++
+
+	members, ok := g.site.members[groupName]
+	if !ok {
+		return nil, nil, model.ErrNotFound
+	}
+
+	page := &model.GroupMemberPageScheme{IsLast: true}
+	for index := startAt; index < len(members) && index < startAt+maxResults; index++ {
+		page.Values = append(page.Values, &model.GroupUserDetailScheme{AccountID: members[index]})
+	}
+
+	return page, nil, nil
+}
+
+type applicationRoleFake struct {
+	jira.AppRoleConnector
+	site *site
+}
+
+func (a *applicationRoleFake) Get(ctx context.Context, key string) (*model.ApplicationRoleScheme, *model.ResponseScheme, error) {
+	return &model.ApplicationRoleScheme{Key: key, Groups: []string{"jira-software-users", "jira-software-external"}}, nil, nil
+}
+
+func TestNewAnalyzer(t *testing.T) {
+
+	_, err := NewAnalyzer(nil)
+	assert.ErrorIs(t, err, ErrNoConnectorError)
+
+	connectors := newSite().connectors()
+	connectors.Grant = nil
+
+	_, err = NewAnalyzer(connectors)
+	assert.EqualError(t, err, "permissions: no connector set: Grant")
+}
+
+func TestAnalyzer_Analyze(t *testing.T) {
+
+	fake := newSite()
+
+	analyzer, err := NewAnalyzer(fake.connectors())
+	assert.NoError(t, err)
+
+	access, err := analyzer.Analyze(context.Background(), "KP")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "KP Permissions", access.SchemeName)
+	assert.Equal(t, []string{"ADD_COMMENTS", "ADMINISTER_PROJECTS", "BROWSE_PROJECTS", "DELETE_ISSUES", "EDIT_ISSUES"}, access.Permissions())
+
+	// The groups are listed once, jira-admins is granted directly and through the Administrators role.
+	assert.Equal(t, 4, fake.calls["group"])
+	assert.Equal(t, 2, fake.calls["role"])
+
+	t.Run("who", func(t *testing.T) {
+
+		holders := access.Who("DELETE_ISSUES")
+		assert.Equal(t, []string{"admin-1", "lead"}, holders.AccountIDs)
+		assert.False(t, holders.Everyone)
+		assert.Len(t, holders.Conditional, 1)
+		assert.Equal(t, "reporter", holders.Conditional[0].String())
+
+		holders = access.Who("EDIT_ISSUES")
+		assert.Equal(t, []string{"contractor", "dev-1", "dev-2", "dev-3"}, holders.AccountIDs)
+
+		holders = access.Who("BROWSE_PROJECTS")
+		assert.Equal(t, []string{"admin-1", "contractor", "dev-1", "dev-2", "dev-3", "lead"}, holders.AccountIDs)
+
+		holders = access.Who("ADD_COMMENTS")
+		assert.True(t, holders.Everyone)
+		assert.Empty(t, holders.AccountIDs)
+
+		assert.Empty(t, access.Who("ARCHIVE_ISSUES").AccountIDs)
+	})
+
+	t.Run("what", func(t *testing.T) {
+
+		var granted []string
+		for _, entitlement := range access.What("dev-2") {
+
+			var grants []string
+			for _, grant := range entitlement.Grants {
+				grants = append(grants, grant.String())
+			}
+
+			granted = append(granted, entitlement.Permission+" "+strings.Join(grants, ",")+" "+map[bool]string{true: "conditional", false: "granted"}[entitlement.Conditional])
+		}
+
+		assert.Equal(t, []string{
+			"ADD_COMMENTS applicationRole granted",
+			"BROWSE_PROJECTS applicationRole:jira-software granted",
+			"DELETE_ISSUES reporter conditional",
+			"EDIT_ISSUES assignee,projectRole:Developers granted",
+		}, granted)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+
+		buffer := new(bytes.Buffer)
+		assert.NoError(t, access.WriteCSV(buffer))
+
+		assert.Contains(t, buffer.String(), "project,scheme,permission,holder,parameter,account_id,everyone,conditional\n")
+		assert.Contains(t, buffer.String(), "KP,KP Permissions,DELETE_ISSUES,projectRole,Administrators,admin-1,false,false\n")
+		assert.Contains(t, buffer.String(), "KP,KP Permissions,DELETE_ISSUES,reporter,,,false,true\n")
+	})
+
+	t.Run("when the project doesn't exist", func(t *testing.T) {
+
+		_, err := analyzer.Analyze(context.Background(), "UNKNOWN")
+		assert.ErrorIs(t, err, model.ErrNotFound)
+	})
+}
+
+func TestAnalyzer_Diff(t *testing.T) {
+
+	analyzer, err := NewAnalyzer(newSite().connectors())
+	assert.NoError(t, err)
+
+	differences, err := analyzer.Diff(context.Background(), 600, 601)
+	assert.NoError(t, err)
+
+	buffer := new(bytes.Buffer)
+	assert.NoError(t, WriteDiff(buffer, differences))
+
+	assert.Equal(t, `- ADD_COMMENTS applicationRole
+- DELETE_ISSUES projectLead
+- DELETE_ISSUES projectRole:Administrators
++ DELETE_ISSUES projectRole:Developers
+- DELETE_ISSUES reporter
+- EDIT_ISSUES assignee
+- EDIT_ISSUES projectRole:Developers
+- EDIT_ISSUES user:contractor
+`, buffer.String())
+
+	assert.Empty(t, Compare(newSite().grants[600], newSite().grants[600]))
+}
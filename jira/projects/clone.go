@@ -0,0 +1,672 @@
+// Package projects clones company-managed Jira projects: the schemes, components, versions, role
+// actors, properties and features of a source project are shared with or copied to a new project,
+// e.g: to bootstrap the projects of a new team from a template project.
+//
+//	cloner, err := projects.NewCloner(&projects.Connectors{
+//		Schemes: schemes.Connectors{
+//			Project:                 instance.Project,
+//			ProjectPermissionScheme: instance.Project.Permission,
+//			ProjectRole:             instance.Project.Role,
+//			// ...
+//			NotificationScheme: instance.NotificationScheme,
+//		},
+//		Validator: instance.Project.Validator,
+//		Component: instance.Project.Component,
+//		Version:   instance.Project.Version,
+//		RoleActor: instance.Project.Role.Actor,
+//		Property:  instance.Project.Property,
+//		Feature:   instance.Project.Feature,
+//	})
+//
+//	result, err := cloner.Clone(ctx, "TMPL", "NEW", "New Project", accountID, &projects.Options{
+//		Schemes: map[schemes.Kind]projects.Mode{schemes.PermissionSchemeKind: projects.Copy},
+//	})
+//
+// The parts created are deleted when the clone fails midway.
+package projects
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/ctreminiom/go-atlassian/jira/schemes"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/jira"
+)
+
+var (
+	ErrNoConnectorError     = errors.New("projects: no connector set")
+	ErrInvalidKeyError      = errors.New("projects: invalid project key")
+	ErrInvalidModeError     = errors.New("projects: invalid mode")
+	ErrUnsupportedError     = errors.New("projects: team-managed projects can't be cloned")
+	ErrNoProjectOptionError = errors.New("projects: no project key, name or lead set")
+)
+
+// Mode is how a part of the source project is cloned.
+type Mode int
+
+const (
+	// Default shares the schemes and copies the other parts.
+	Default Mode = iota
+
+	// Share assigns the schemes of the source project to the new project. The components,
+	// versions, role actors, properties and features belong to a project and can't be shared.
+	Share
+
+	// Copy creates a copy of the part for the new project.
+	Copy
+
+	// Skip leaves the part of the new project as created by Jira, e.g: the default schemes.
+	Skip
+)
+
+// Connectors are the services the projects are read and written with.
+type Connectors struct {
+
+	// Schemes are the connectors of the schemes of the projects, the projects and project roles
+	// are read and written with them too.
+	Schemes schemes.Connectors
+
+	Validator jira.ProjectValidatorConnector
+	Component jira.ProjectComponentConnector
+	Version   jira.ProjectVersionConnector
+	RoleActor jira.ProjectRoleActorConnector
+	Property  jira.ProjectPropertyConnector
+	Feature   jira.ProjectFeatureConnector
+}
+
+// Options are the modes of each part of the source project.
+type Options struct {
+
+	// Schemes are the modes of the schemes keyed by kind, the schemes are shared by default. The
+	// screen schemes are referenced by the issue type screen schemes, they can be copied when the
+	// issue type screen scheme is copied too.
+	Schemes map[schemes.Kind]Mode
+
+	// SchemeName returns the name of a scheme copied, "<name> (<new project key>)" by default.
+	SchemeName func(name, projectKey string) string
+
+	Components Mode
+	Versions   Mode
+	Roles      Mode
+	Properties Mode
+	Features   Mode
+}
+
+// Result is the project created and the names of the schemes assigned to it.
+type Result struct {
+	Project *model.NewProjectCreatedScheme
+	Schemes map[schemes.Kind]string
+
+	// Copied are the names of the schemes created for the project keyed by kind.
+	Copied map[schemes.Kind][]string
+}
+
+// Cloner clones the projects of a site.
+type Cloner struct {
+	c Connectors
+}
+
+// NewCloner returns a cloner of the projects of the site of the connectors, all of them are required.
+func NewCloner(connectors *Connectors) (*Cloner, error) {
+
+	if connectors == nil {
+		return nil, ErrNoConnectorError
+	}
+
+	if _, err := schemes.NewManager(&connectors.Schemes); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoConnectorError, err)
+	}
+
+	required := []struct {
+		name      string
+		connector interface{}
+	}{
+		{"Validator", connectors.Validator},
+		{"Component", connectors.Component},
+		{"Version", connectors.Version},
+		{"RoleActor", connectors.RoleActor},
+		{"Property", connectors.Property},
+		{"Feature", connectors.Feature},
+	}
+
+	for _, connector := range required {
+		if connector.connector == nil {
+			return nil, fmt.Errorf("%w: %v", ErrNoConnectorError, connector.name)
+		}
+	}
+
+	return &Cloner{c: *connectors}, nil
+}
+
+// clone is the state of a clone in progress, the parts created are rolled back on failure.
+type clone struct {
+	*Cloner
+
+	manager *schemes.Manager
+	source  *model.ProjectScheme
+	key     string
+	created *model.NewProjectCreatedScheme
+	copied  map[schemes.Kind][]string
+}
+
+// Clone creates the project newKey from the project sourceKey, the new project gets the name and
+// lead set and the description, URL, category, assignee type and project type of the source project.
+//
+// Once the project is created, the copied schemes are created and assigned with the shared ones,
+// then the versions, components, role actors, properties and features are copied. When a step
+// fails, the project and the schemes created are deleted and the error of the step is returned.
+func (c *Cloner) Clone(ctx context.Context, sourceKey, newKey, name, lead string, options *Options) (*Result, error) {
+
+	if newKey == "" || name == "" || lead == "" {
+		return nil, ErrNoProjectOptionError
+	}
+
+	if options == nil {
+		options = &Options{}
+	}
+
+	opts := *options
+	if opts.SchemeName == nil {
+		opts.SchemeName = func(name, projectKey string) string { return fmt.Sprintf("%v (%v)", name, projectKey) }
+	}
+
+	for _, mode := range []Mode{opts.Components, opts.Versions, opts.Roles, opts.Properties, opts.Features} {
+		if mode == Share {
+			return nil, fmt.Errorf("%w: the parts of a project can't be shared", ErrInvalidModeError)
+		}
+	}
+
+	manager, err := schemes.NewManager(&c.c.Schemes)
+	if err != nil {
+		return nil, err
+	}
+
+	validation, _, err := c.c.Validator.Validate(ctx, newKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if validation.Errors.ProjectKey != "" {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeyError, validation.Errors.ProjectKey)
+	}
+
+	if len(validation.ErrorMessages) != 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeyError, validation.ErrorMessages[0])
+	}
+
+	source, _, err := c.c.Schemes.Project.Get(ctx, sourceKey, []string{"description", "lead", "url"})
+	if err != nil {
+		return nil, err
+	}
+
+	if source.Simplified || source.Style == "next-gen" {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedError, source.Key)
+	}
+
+	state := &clone{Cloner: c, manager: manager, source: source, key: newKey, copied: map[schemes.Kind][]string{}}
+
+	document, err := state.document(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &model.ProjectPayloadScheme{
+		Key:            newKey,
+		Name:           name,
+		LeadAccountID:  lead,
+		Description:    source.Description,
+		URL:            source.URL,
+		AssigneeType:   source.AssigneeType,
+		ProjectTypeKey: source.ProjectTypeKey,
+	}
+
+	if source.Category != nil {
+		if payload.CategoryID, err = strconv.Atoi(source.Category.ID); err != nil {
+			return nil, fmt.Errorf("projects: invalid project category ID %q: %w", source.Category.ID, err)
+		}
+	}
+
+	if state.created, _, err = c.c.Schemes.Project.Create(ctx, payload); err != nil {
+		return nil, err
+	}
+
+	steps := []struct {
+		mode Mode
+		step func(ctx context.Context) error
+	}{
+		{Default, func(ctx context.Context) error { return state.schemes(ctx, document) }},
+		{opts.Versions, state.versions},
+		{opts.Components, state.components},
+		{opts.Roles, state.roles},
+		{opts.Properties, state.properties},
+		{opts.Features, state.features},
+	}
+
+	for _, step := range steps {
+
+		if step.mode == Skip {
+			continue
+		}
+
+		if err := step.step(ctx); err != nil {
+			return nil, state.rollback(ctx, err)
+		}
+	}
+
+	result := &Result{Project: state.created, Schemes: map[schemes.Kind]string{}, Copied: state.copied}
+	for _, project := range document.Projects {
+
+		assigned := map[schemes.Kind]string{
+			schemes.FieldConfigurationSchemeKind: project.FieldConfigurationScheme,
+			schemes.IssueTypeSchemeKind:          project.IssueTypeScheme,
+			schemes.IssueTypeScreenSchemeKind:    project.IssueTypeScreenScheme,
+			schemes.WorkflowSchemeKind:           project.WorkflowScheme,
+			schemes.PermissionSchemeKind:         project.PermissionScheme,
+			schemes.NotificationSchemeKind:       project.NotificationScheme,
+		}
+
+		for kind, name := range assigned {
+			if name != "" {
+				result.Schemes[kind] = name
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// document returns the schemes to create for the new project and the schemes assigned to it. The
+// source schemes skipped aren't assigned, the copied ones are renamed and the references to them
+// updated, e.g: the screen schemes of a copied issue type screen scheme.
+func (c *clone) document(ctx context.Context, options *Options) (*schemes.Document, error) {
+
+	for kind, mode := range options.Schemes {
+
+		if kind == schemes.ProjectKind {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidModeError, kind)
+		}
+
+		if kind == schemes.ScreenSchemeKind && mode == Copy && options.Schemes[schemes.IssueTypeScreenSchemeKind] != Copy {
+			return nil, fmt.Errorf("%w: the screen schemes are copied with the issue type screen scheme", ErrInvalidModeError)
+		}
+	}
+
+	exported, err := c.manager.Export(ctx, c.source.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := func(kind schemes.Kind) Mode {
+
+		if options.Schemes[kind] == Default {
+			return Share
+		}
+
+		return options.Schemes[kind]
+	}
+
+	renamed := map[schemes.Kind]map[string]string{}
+	rename := func(kind schemes.Kind, name string) string {
+
+		if name == "" || name == schemes.DefaultMapping || mode(kind) != Copy {
+			return name
+		}
+
+		if renamed[kind] == nil {
+			renamed[kind] = map[string]string{}
+		}
+
+		if _, ok := renamed[kind][name]; !ok {
+			renamed[kind][name] = options.SchemeName(name, c.key)
+		}
+
+		return renamed[kind][name]
+	}
+
+	document := &schemes.Document{Version: exported.Version}
+
+	if mode(schemes.FieldConfigurationSchemeKind) == Copy {
+		for _, scheme := range exported.FieldConfigurationSchemes {
+			copied := *scheme
+			copied.Name = rename(schemes.FieldConfigurationSchemeKind, scheme.Name)
+			document.FieldConfigurationSchemes = append(document.FieldConfigurationSchemes, &copied)
+		}
+	}
+
+	if mode(schemes.ScreenSchemeKind) == Copy {
+		for _, scheme := range exported.ScreenSchemes {
+			copied := *scheme
+			copied.Name = rename(schemes.ScreenSchemeKind, scheme.Name)
+			document.ScreenSchemes = append(document.ScreenSchemes, &copied)
+		}
+	}
+
+	if mode(schemes.IssueTypeSchemeKind) == Copy {
+		for _, scheme := range exported.IssueTypeSchemes {
+			copied := *scheme
+			copied.Name = rename(schemes.IssueTypeSchemeKind, scheme.Name)
+			document.IssueTypeSchemes = append(document.IssueTypeSchemes, &copied)
+		}
+	}
+
+	if mode(schemes.IssueTypeScreenSchemeKind) == Copy {
+		for _, scheme := range exported.IssueTypeScreenSchemes {
+
+			copied := *scheme
+			copied.Name = rename(schemes.IssueTypeScreenSchemeKind, scheme.Name)
+			copied.Mappings = map[string]string{}
+
+			for issueType, screenScheme := range scheme.Mappings {
+				copied.Mappings[issueType] = rename(schemes.ScreenSchemeKind, screenScheme)
+			}
+
+			document.IssueTypeScreenSchemes = append(document.IssueTypeScreenSchemes, &copied)
+		}
+	}
+
+	if mode(schemes.WorkflowSchemeKind) == Copy {
+		for _, scheme := range exported.WorkflowSchemes {
+			copied := *scheme
+			copied.Name = rename(schemes.WorkflowSchemeKind, scheme.Name)
+			document.WorkflowSchemes = append(document.WorkflowSchemes, &copied)
+		}
+	}
+
+	if mode(schemes.PermissionSchemeKind) == Copy {
+		for _, scheme := range exported.PermissionSchemes {
+			copied := *scheme
+			copied.Name = rename(schemes.PermissionSchemeKind, scheme.Name)
+			document.PermissionSchemes = append(document.PermissionSchemes, &copied)
+		}
+	}
+
+	if mode(schemes.NotificationSchemeKind) == Copy {
+		for _, scheme := range exported.NotificationSchemes {
+			copied := *scheme
+			copied.Name = rename(schemes.NotificationSchemeKind, scheme.Name)
+			document.NotificationSchemes = append(document.NotificationSchemes, &copied)
+		}
+	}
+
+	for _, source := range exported.Projects {
+
+		project := &schemes.Project{Key: c.key}
+
+		assignments := []struct {
+			kind   schemes.Kind
+			source string
+			target *string
+		}{
+			{schemes.FieldConfigurationSchemeKind, source.FieldConfigurationScheme, &project.FieldConfigurationScheme},
+			{schemes.IssueTypeSchemeKind, source.IssueTypeScheme, &project.IssueTypeScheme},
+			{schemes.IssueTypeScreenSchemeKind, source.IssueTypeScreenScheme, &project.IssueTypeScreenScheme},
+			{schemes.WorkflowSchemeKind, source.WorkflowScheme, &project.WorkflowScheme},
+			{schemes.PermissionSchemeKind, source.PermissionScheme, &project.PermissionScheme},
+			{schemes.NotificationSchemeKind, source.NotificationScheme, &project.NotificationScheme},
+		}
+
+		for _, assignment := range assignments {
+			if mode(assignment.kind) != Skip {
+				*assignment.target = rename(assignment.kind, assignment.source)
+			}
+		}
+
+		document.Projects = append(document.Projects, project)
+	}
+
+	return document, nil
+}
+
+// schemes creates the copied schemes and assigns the schemes to the new project.
+func (c *clone) schemes(ctx context.Context, document *schemes.Document) error {
+
+	plan, err := schemes.Compare(&schemes.Document{}, document, nil)
+	if err != nil {
+		return err
+	}
+
+	results, err := c.manager.Apply(ctx, plan)
+	for _, result := range results {
+		if result.Change.Action == schemes.CreateAction && result.Err == nil {
+			c.copied[result.Change.Kind] = append(c.copied[result.Change.Kind], result.Change.Name)
+		}
+	}
+
+	if err != nil {
+		for _, result := range results {
+			if result.Err != nil {
+				return fmt.Errorf("%w: %v %q: %v", err, result.Change.Kind, result.Change.Name, result.Err)
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (c *clone) versions(ctx context.Context) error {
+
+	versions, _, err := c.c.Version.Gets(ctx, c.source.Key)
+	if err != nil {
+		return err
+	}
+
+	for _, version := range versions {
+
+		payload := &model.VersionPayloadScheme{
+			Name:        version.Name,
+			Description: version.Description,
+			Archived:    version.Archived,
+			Released:    version.Released,
+			ReleaseDate: version.ReleaseDate,
+			ProjectID:   c.created.ID,
+		}
+
+		if _, _, err := c.c.Version.Create(ctx, payload); err != nil {
+			return fmt.Errorf("projects: version %q: %w", version.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *clone) components(ctx context.Context) error {
+
+	components, _, err := c.c.Component.Gets(ctx, c.source.Key)
+	if err != nil {
+		return err
+	}
+
+	for _, component := range components {
+
+		payload := &model.ComponentPayloadScheme{
+			Name:         component.Name,
+			Description:  component.Description,
+			AssigneeType: component.AssigneeType,
+			Project:      c.created.Key,
+		}
+
+		if component.Lead != nil {
+			payload.LeadAccountID = component.Lead.AccountID
+		}
+
+		if _, _, err := c.c.Component.Create(ctx, payload); err != nil {
+			return fmt.Errorf("projects: component %q: %w", component.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// roles adds the actors of the roles of the source project missing on the new project, e.g: the
+// lead is added to the Administrators role by Jira.
+func (c *clone) roles(ctx context.Context) error {
+
+	roles, _, err := c.c.Schemes.ProjectRole.Gets(ctx, c.source.Key)
+	if err != nil {
+		return err
+	}
+
+	if roles == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(*roles))
+	for name := range *roles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+
+		id := (*roles)[name]
+
+		source, _, err := c.c.Schemes.ProjectRole.Get(ctx, c.source.Key, id)
+		if err != nil {
+			return err
+		}
+
+		current, _, err := c.c.Schemes.ProjectRole.Get(ctx, c.created.Key, id)
+		if err != nil {
+			return err
+		}
+
+		existing := map[string]bool{}
+		for _, actor := range current.Actors {
+			existing[actorOf(actor)] = true
+		}
+
+		var accountIDs, groups []string
+		for _, actor := range source.Actors {
+
+			if existing[actorOf(actor)] {
+				continue
+			}
+
+			switch {
+			case actor.ActorUser != nil && actor.ActorUser.AccountID != "":
+				accountIDs = append(accountIDs, actor.ActorUser.AccountID)
+			case actor.ActorGroup != nil && actor.ActorGroup.Name != "":
+				groups = append(groups, actor.ActorGroup.Name)
+			}
+		}
+
+		if len(accountIDs) == 0 && len(groups) == 0 {
+			continue
+		}
+
+		if _, _, err := c.c.RoleActor.Add(ctx, c.created.Key, id, accountIDs, groups); err != nil {
+			return fmt.Errorf("projects: role %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *clone) properties(ctx context.Context) error {
+
+	page, _, err := c.c.Property.Gets(ctx, c.source.Key)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range page.Keys {
+
+		property, _, err := c.c.Property.Get(ctx, c.source.Key, key.Key)
+		if err != nil {
+			return err
+		}
+
+		if _, err := c.c.Property.Set(ctx, c.created.Key, key.Key, property.Value); err != nil {
+			return fmt.Errorf("projects: property %q: %w", key.Key, err)
+		}
+	}
+
+	return nil
+}
+
+// features sets the state of the features of the new project that differ from the source project.
+func (c *clone) features(ctx context.Context) error {
+
+	source, _, err := c.c.Feature.Gets(ctx, c.source.Key)
+	if err != nil {
+		return err
+	}
+
+	current, _, err := c.c.Feature.Gets(ctx, c.created.Key)
+	if err != nil {
+		return err
+	}
+
+	states := map[string]string{}
+	for _, feature := range current.Features {
+		states[feature.Feature] = feature.State
+	}
+
+	for _, feature := range source.Features {
+
+		if feature.ToggleLocked || states[feature.Feature] == feature.State {
+			continue
+		}
+
+		if _, _, err := c.c.Feature.Set(ctx, c.created.Key, feature.Feature, feature.State); err != nil {
+			return fmt.Errorf("projects: feature %q: %w", feature.Feature, err)
+		}
+	}
+
+	return nil
+}
+
+// rollback deletes the project and then the schemes copied, the schemes assigned to a project
+// can't be deleted. The error returned wraps the error of the failed step.
+func (c *clone) rollback(ctx context.Context, cause error) error {
+
+	var failures []string
+	if _, err := c.c.Schemes.Project.Delete(ctx, c.created.Key, false); err != nil {
+		failures = append(failures, fmt.Sprintf("project %v: %v", c.created.Key, err))
+	}
+
+	kinds := []schemes.Kind{
+		schemes.NotificationSchemeKind,
+		schemes.PermissionSchemeKind,
+		schemes.WorkflowSchemeKind,
+		schemes.IssueTypeScreenSchemeKind,
+		schemes.IssueTypeSchemeKind,
+		schemes.ScreenSchemeKind,
+		schemes.FieldConfigurationSchemeKind,
+	}
+
+	for _, kind := range kinds {
+
+		for _, name := range c.copied[kind] {
+			if err := c.manager.Delete(ctx, kind, name); err != nil {
+				failures = append(failures, fmt.Sprintf("%v %q: %v", kind, name, err))
+			}
+		}
+	}
+
+	if len(failures) != 0 {
+		return fmt.Errorf("%w (rollback failed: %v)", cause, failures)
+	}
+
+	return cause
+}
+
+// actorOf returns the identity of a role actor.
+func actorOf(actor *model.RoleActorScheme) string {
+
+	switch {
+	case actor.ActorUser != nil:
+		return "user:" + actor.ActorUser.AccountID
+	case actor.ActorGroup != nil:
+		return "group:" + actor.ActorGroup.Name
+	}
+
+	return actor.Type + ":" + actor.Name
+}
@@ -0,0 +1,495 @@
+package projects
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/jira/schemes"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/jira"
+	"github.com/stretchr/testify/assert"
+)
+
+// site is the state of a fake Jira site with the TMPL project, the writes are recorded on calls.
+type site struct {
+	calls  []string
+	nextID int
+	failed map[string]bool
+
+	projects            map[string]*model.ProjectScheme
+	roles               []*model.ProjectRoleScheme
+	actors              map[string][]*model.RoleActorScheme
+	permissionSchemes   []*model.PermissionSchemeScheme
+	notificationSchemes []*model.NotificationSchemeScheme
+	features            map[string][]*model.ProjectFeatureScheme
+}
+
+func (s *site) record(format string, values ...interface{}) error {
+
+	call := fmt.Sprintf(format, values...)
+	s.calls = append(s.calls, call)
+
+	if s.failed[call] {
+		return model.ErrBadRequestError
+	}
+
+	return nil
+}
+
+func newSite() *site {
+
+	return &site{
+		nextID: 900,
+		failed: map[string]bool{},
+		projects: map[string]*model.ProjectScheme{
+			"TMPL": {
+				ID: "10000", Key: "TMPL", Description: "The template", ProjectTypeKey: "software", AssigneeType: "UNASSIGNED",
+				Category: &model.ProjectCategoryScheme{ID: "5"},
+			},
+		},
+		roles: []*model.ProjectRoleScheme{{ID: 30, Name: "Developers"}, {ID: 31, Name: "Administrators"}},
+		actors: map[string][]*model.RoleActorScheme{
+			"TMPL 30": {
+				{ActorUser: &model.RoleActorUserScheme{AccountID: "account-1"}},
+				{ActorGroup: &model.GroupScheme{Name: "developers"}},
+			},
+			"TMPL 31": {{ActorUser: &model.RoleActorUserScheme{AccountID: "lead"}}},
+			"NEW 31":  {{ActorUser: &model.RoleActorUserScheme{AccountID: "lead"}}},
+		},
+		permissionSchemes: []*model.PermissionSchemeScheme{
+			{ID: 600, Name: "Template Permissions", Permissions: []*model.PermissionGrantScheme{
+				{ID: 1, Permission: "BROWSE_PROJECTS", Holder: &model.PermissionGrantHolderScheme{Type: "projectRole", Parameter: "30"}},
+			}},
+		},
+		notificationSchemes: []*model.NotificationSchemeScheme{{ID: 700, Name: "Template Notifications"}},
+		features: map[string][]*model.ProjectFeatureScheme{
+			"TMPL": {{Feature: "jsw.classic.roadmap", State: "DISABLED"}, {Feature: "jsw.classic.deployments", State: "ENABLED", ToggleLocked: true}},
+			"NEW":  {{Feature: "jsw.classic.roadmap", State: "ENABLED"}, {Feature: "jsw.classic.deployments", State: "DISABLED"}},
+		},
+	}
+}
+
+func (s *site) connectors() *Connectors {
+	return &Connectors{
+		Schemes: schemes.Connectors{
+			Project:                  &projectFake{site: s},
+			ProjectPermissionScheme:  &projectPermissionFake{site: s},
+			ProjectRole:              &roleFake{site: s},
+			IssueType:                &issueTypeFake{},
+			Screen:                   &screenFake{},
+			FieldConfiguration:       &fieldConfigFake{},
+			FieldConfigurationScheme: &fieldConfigSchemeFake{},
+			ScreenScheme:             &screenSchemeFake{},
+			IssueTypeScheme:          &issueTypeSchemeFake{},
+			IssueTypeScreenScheme:    &issueTypeScreenSchemeFake{},
+			WorkflowScheme:           &workflowSchemeFake{},
+			PermissionScheme:         &permissionSchemeFake{site: s},
+			NotificationScheme:       &notificationSchemeFake{site: s},
+		},
+		Validator: &validatorFake{site: s},
+		Component: &componentFake{site: s},
+		Version:   &versionFake{site: s},
+		RoleActor: &roleActorFake{site: s},
+		Property:  &propertyFake{site: s},
+		Feature:   &featureFake{site: s},
+	}
+}
+
+type projectFake struct {
+	jira.ProjectConnector
+	site *site
+}
+
+func (p *projectFake) Create(ctx context.Context, payload *model.ProjectPayloadScheme) (*model.NewProjectCreatedScheme, *model.ResponseScheme, error) {
+
+	if err := p.site.record("project %v created: %v %v %v %v", payload.Key, payload.Name, payload.LeadAccountID, payload.ProjectTypeKey,
+		payload.CategoryID); err != nil {
+		return nil, nil, err
+	}
+
+	p.site.projects[payload.Key] = &model.ProjectScheme{ID: "10001", Key: payload.Key}
+	return &model.NewProjectCreatedScheme{ID: 10001, Key: payload.Key}, nil, nil
+}
+
+func (p *projectFake) Get(ctx context.Context, projectKeyOrID string, expand []string) (*model.ProjectScheme, *model.ResponseScheme, error) {
+
+	project, ok := p.site.projects[projectKeyOrID]
+	if !ok {
+		return nil, nil, model.ErrNotFound
+	}
+
+	return project, nil, nil
+}
+
+func (p *projectFake) Delete(ctx context.Context, projectKeyOrID string, enableUndo bool) (*model.ResponseScheme, error) {
+	return nil, p.site.record("project %v deleted", projectKeyOrID)
+}
+
+func (p *projectFake) NotificationScheme(ctx context.Context, projectKeyOrID string, expand []string) (*model.NotificationSchemeScheme,
+	*model.ResponseScheme, error) {
+	return p.site.notificationSchemes[0], nil, nil
+}
+
+func (p *projectFake) Update(ctx context.Context, projectKeyOrID string, payload *model.ProjectUpdateScheme) (*model.ProjectScheme,
+	*model.ResponseScheme, error) {
+	return nil, nil, p.site.record("project %v notification scheme %v", projectKeyOrID, payload.NotificationScheme)
+}
+
+type projectPermissionFake struct {
+	jira.ProjectPermissionSchemeConnector
+	site *site
+}
+
+func (p *projectPermissionFake) Get(ctx context.Context, projectKeyOrID string, expand []string) (*model.PermissionSchemeScheme,
+	*model.ResponseScheme, error) {
+	return p.site.permissionSchemes[0], nil, nil
+}
+
+func (p *projectPermissionFake) Assign(ctx context.Context, projectKeyOrID string, permissionSchemeID int) (*model.PermissionSchemeScheme,
+	*model.ResponseScheme, error) {
+	return nil, nil, p.site.record("project %v permission scheme %v", projectKeyOrID, permissionSchemeID)
+}
+
+type roleFake struct {
+	jira.ProjectRoleConnector
+	site *site
+}
+
+func (r *roleFake) Global(ctx context.Context) ([]*model.ProjectRoleScheme, *model.ResponseScheme, error) {
+	return r.site.roles, nil, nil
+}
+
+func (r *roleFake) Gets(ctx context.Context, projectKeyOrID string) (*map[string]int, *model.ResponseScheme, error) {
+
+	roles := map[string]int{}
+	for _, role := range r.site.roles {
+		roles[role.Name] = role.ID
+	}
+
+	return &roles, nil, nil
+}
+
+func (r *roleFake) Get(ctx context.Context, projectKeyOrID string, roleID int) (*model.ProjectRoleScheme, *model.ResponseScheme, error) {
+	return &model.ProjectRoleScheme{ID: roleID, Actors: r.site.actors[projectKeyOrID+" "+strconv.Itoa(roleID)]}, nil, nil
+}
+
+type issueTypeFake struct{ jira.TypeConnector }
+
+type screenFake struct{ jira.ScreenConnector }
+
+type fieldConfigFake struct{ jira.FieldConfigConnector }
+
+type screenSchemeFake struct{ jira.ScreenSchemeConnector }
+
+type issueTypeScreenSchemeFake struct{ jira.TypeScreenSchemeConnector }
+
+func (i *issueTypeScreenSchemeFake) Projects(ctx context.Context, projectIDs []int, startAt, maxResults int) (*model.IssueTypeProjectScreenSchemePageScheme,
+	*model.ResponseScheme, error) {
+	return &model.IssueTypeProjectScreenSchemePageScheme{IsLast: true}, nil, nil
+}
+
+type fieldConfigSchemeFake struct {
+	jira.FieldConfigSchemeConnector
+}
+
+func (f *fieldConfigSchemeFake) Project(ctx context.Context, projectIDs []int, startAt, maxResults int) (*model.FieldConfigurationSchemeProjectPageScheme,
+	*model.ResponseScheme, error) {
+	return &model.FieldConfigurationSchemeProjectPageScheme{IsLast: true}, nil, nil
+}
+
+type issueTypeSchemeFake struct{ jira.TypeSchemeConnector }
+
+func (i *issueTypeSchemeFake) Projects(ctx context.Context, projectIDs []int, startAt, maxResults int) (*model.ProjectIssueTypeSchemePageScheme,
+	*model.ResponseScheme, error) {
+	return &model.ProjectIssueTypeSchemePageScheme{IsLast: true}, nil, nil
+}
+
+type workflowSchemeFake struct{ jira.WorkflowSchemeConnector }
+
+func (w *workflowSchemeFake) Associations(ctx context.Context, projectIDs []int) (*model.WorkflowSchemeAssociationPageScheme, *model.ResponseScheme, error) {
+	return &model.WorkflowSchemeAssociationPageScheme{}, nil, nil
+}
+
+type permissionSchemeFake struct {
+	jira.PermissionSchemeConnector
+	site *site
+}
+
+func (p *permissionSchemeFake) Gets(ctx context.Context) (*model.PermissionSchemePageScheme, *model.ResponseScheme, error) {
+	return &model.PermissionSchemePageScheme{PermissionSchemes: p.site.permissionSchemes}, nil, nil
+}
+
+func (p *permissionSchemeFake) Get(ctx context.Context, permissionSchemeID int, expand []string) (*model.PermissionSchemeScheme,
+	*model.ResponseScheme, error) {
+
+	for _, scheme := range p.site.permissionSchemes {
+		if scheme.ID == permissionSchemeID {
+			return scheme, nil, nil
+		}
+	}
+
+	return nil, nil, model.ErrNotFound
+}
+
+func (p *permissionSchemeFake) Create(ctx context.Context, payload *model.PermissionSchemeScheme) (*model.PermissionSchemeScheme,
+	*model.ResponseScheme, error) {
+
+	p.site.nextID++
+
+	var grants []string
+	for _, grant := range payload.Permissions {
+		grants = append(grants, grant.Permission+" "+grant.Holder.Type+":"+grant.Holder.Parameter)
+	}
+
+	if err := p.site.record("permission scheme %v created: %v %v", p.site.nextID, payload.Name, grants); err != nil {
+		return nil, nil, err
+	}
+
+	return &model.PermissionSchemeScheme{ID: p.site.nextID}, nil, nil
+}
+
+func (p *permissionSchemeFake) Delete(ctx context.Context, permissionSchemeID int) (*model.ResponseScheme, error) {
+	return nil, p.site.record("permission scheme %v deleted", permissionSchemeID)
+}
+
+type notificationSchemeFake struct {
+	jira.NotificationSchemeConnector
+	site *site
+}
+
+func (n *notificationSchemeFake) Search(ctx context.Context, options *model.NotificationSchemeSearchOptions, startAt, maxResults int) (*model.NotificationSchemePageScheme,
+	*model.ResponseScheme, error) {
+	return &model.NotificationSchemePageScheme{Values: n.site.notificationSchemes, IsLast: true}, nil, nil
+}
+
+type validatorFake struct {
+	jira.ProjectValidatorConnector
+	site *site
+}
+
+func (v *validatorFake) Validate(ctx context.Context, key string) (*model.ProjectValidationMessageScheme, *model.ResponseScheme, error) {
+
+	validation := &model.ProjectValidationMessageScheme{}
+	if _, ok := v.site.projects[key]; ok {
+		validation.Errors.ProjectKey = fmt.Sprintf("Project '%v' uses this project key.", key)
+	}
+
+	return validation, nil, nil
+}
+
+type componentFake struct {
+	jira.ProjectComponentConnector
+	site *site
+}
+
+func (c *componentFake) Gets(ctx context.Context, projectIDOrKey string) ([]*model.ComponentScheme, *model.ResponseScheme, error) {
+	return []*model.ComponentScheme{{Name: "API", Lead: &model.UserScheme{AccountID: "account-1"}, AssigneeType: "COMPONENT_LEAD"}}, nil, nil
+}
+
+func (c *componentFake) Create(ctx context.Context, payload *model.ComponentPayloadScheme) (*model.ComponentScheme, *model.ResponseScheme, error) {
+	return nil, nil, c.site.record("component %v created: %v %v %v", payload.Project, payload.Name, payload.LeadAccountID, payload.AssigneeType)
+}
+
+type versionFake struct {
+	jira.ProjectVersionConnector
+	site *site
+}
+
+func (v *versionFake) Gets(ctx context.Context, projectKeyOrID string) ([]*model.VersionScheme, *model.ResponseScheme, error) {
+	return []*model.VersionScheme{{Name: "1.0", Released: true, ReleaseDate: "2026-01-31"}}, nil, nil
+}
+
+func (v *versionFake) Create(ctx context.Context, payload *model.VersionPayloadScheme) (*model.VersionScheme, *model.ResponseScheme, error) {
+	return nil, nil, v.site.record("version %v created: %v %v %v", payload.ProjectID, payload.Name, payload.Released, payload.ReleaseDate)
+}
+
+type roleActorFake struct {
+	jira.ProjectRoleActorConnector
+	site *site
+}
+
+func (r *roleActorFake) Add(ctx context.Context, projectKeyOrID string, roleID int, accountIDs, groups []string) (*model.ProjectRoleScheme,
+	*model.ResponseScheme, error) {
+	return nil, nil, r.site.record("project %v role %v actors added: %v %v", projectKeyOrID, roleID, accountIDs, groups)
+}
+
+type propertyFake struct {
+	jira.ProjectPropertyConnector
+	site *site
+}
+
+func (p *propertyFake) Gets(ctx context.Context, projectKeyOrID string) (*model.ProjectPropertyPageScheme, *model.ResponseScheme, error) {
+	return &model.ProjectPropertyPageScheme{Keys: []*model.ProjectPropertyScheme{{Key: "team"}}}, nil, nil
+}
+
+func (p *propertyFake) Get(ctx context.Context, projectKeyOrID, propertyKey string) (*model.EntityPropertyScheme, *model.ResponseScheme, error) {
+	return &model.EntityPropertyScheme{Key: propertyKey, Value: "platform"}, nil, nil
+}
+
+func (p *propertyFake) Set(ctx context.Context, projectKeyOrID, propertyKey string, payload interface{}) (*model.ResponseScheme, error) {
+	return nil, p.site.record("project %v property %v: %v", projectKeyOrID, propertyKey, payload)
+}
+
+type featureFake struct {
+	jira.ProjectFeatureConnector
+	site *site
+}
+
+func (f *featureFake) Gets(ctx context.Context, projectKeyOrID string) (*model.ProjectFeaturesScheme, *model.ResponseScheme, error) {
+	return &model.ProjectFeaturesScheme{Features: f.site.features[projectKeyOrID]}, nil, nil
+}
+
+func (f *featureFake) Set(ctx context.Context, projectKeyOrID, featureKey, state string) (*model.ProjectFeaturesScheme, *model.ResponseScheme, error) {
+	return nil, nil, f.site.record("project %v feature %v: %v", projectKeyOrID, featureKey, state)
+}
+
+func TestNewCloner(t *testing.T) {
+
+	_, err := NewCloner(nil)
+	assert.ErrorIs(t, err, ErrNoConnectorError)
+
+	connectors := newSite().connectors()
+	connectors.Feature = nil
+
+	_, err = NewCloner(connectors)
+	assert.EqualError(t, err, "projects: no connector set: Feature")
+
+	connectors = newSite().connectors()
+	connectors.Schemes.NotificationScheme = nil
+
+	_, err = NewCloner(connectors)
+	assert.EqualError(t, err, "projects: no connector set: schemes: no connector set: NotificationScheme")
+}
+
+func TestCloner_Clone(t *testing.T) {
+
+	t.Run("when the parts are shared and copied", func(t *testing.T) {
+
+		fake := newSite()
+
+		cloner, err := NewCloner(fake.connectors())
+		assert.NoError(t, err)
+
+		result, err := cloner.Clone(context.Background(), "TMPL", "NEW", "New Project", "lead", &Options{
+			Schemes: map[schemes.Kind]Mode{schemes.PermissionSchemeKind: Copy},
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, &model.NewProjectCreatedScheme{ID: 10001, Key: "NEW"}, result.Project)
+		assert.Equal(t, map[schemes.Kind]string{
+			schemes.PermissionSchemeKind:   "Template Permissions (NEW)",
+			schemes.NotificationSchemeKind: "Template Notifications",
+		}, result.Schemes)
+		assert.Equal(t, map[schemes.Kind][]string{schemes.PermissionSchemeKind: {"Template Permissions (NEW)"}}, result.Copied)
+
+		assert.Equal(t, []string{
+			"project NEW created: New Project lead software 5",
+			"permission scheme 901 created: Template Permissions (NEW) [BROWSE_PROJECTS projectRole:30]",
+			"project NEW permission scheme 901",
+			"project NEW notification scheme 700",
+			"version 10001 created: 1.0 true 2026-01-31",
+			"component NEW created: API account-1 COMPONENT_LEAD",
+			"project NEW role 30 actors added: [account-1] [developers]",
+			"project NEW property team: platform",
+			"project NEW feature jsw.classic.roadmap: DISABLED",
+		}, fake.calls)
+	})
+
+	t.Run("when the parts are skipped", func(t *testing.T) {
+
+		fake := newSite()
+
+		cloner, err := NewCloner(fake.connectors())
+		assert.NoError(t, err)
+
+		options := &Options{
+			Schemes:    map[schemes.Kind]Mode{schemes.NotificationSchemeKind: Skip, schemes.PermissionSchemeKind: Skip},
+			Components: Skip,
+			Versions:   Skip,
+			Roles:      Skip,
+			Properties: Skip,
+			Features:   Skip,
+		}
+
+		result, err := cloner.Clone(context.Background(), "TMPL", "NEW", "New Project", "lead", options)
+		assert.NoError(t, err)
+		assert.Empty(t, result.Schemes)
+		assert.Equal(t, []string{"project NEW created: New Project lead software 5"}, fake.calls)
+	})
+
+	t.Run("when a step fails, the project and the schemes copied are deleted", func(t *testing.T) {
+
+		fake := newSite()
+		fake.failed["component NEW created: API account-1 COMPONENT_LEAD"] = true
+
+		cloner, err := NewCloner(fake.connectors())
+		assert.NoError(t, err)
+
+		_, err = cloner.Clone(context.Background(), "TMPL", "NEW", "New Project", "lead", &Options{
+			Schemes:    map[schemes.Kind]Mode{schemes.PermissionSchemeKind: Copy},
+			SchemeName: func(name, projectKey string) string { return projectKey + " Permissions" },
+		})
+		assert.ErrorIs(t, err, model.ErrBadRequestError)
+		assert.EqualError(t, err, `projects: component "API": `+model.ErrBadRequestError.Error())
+
+		assert.Equal(t, []string{
+			"project NEW deleted",
+			"permission scheme 901 deleted",
+		}, fake.calls[len(fake.calls)-2:])
+	})
+
+	t.Run("when the schemes fail, the project is deleted", func(t *testing.T) {
+
+		fake := newSite()
+		fake.failed["project NEW notification scheme 700"] = true
+
+		cloner, err := NewCloner(fake.connectors())
+		assert.NoError(t, err)
+
+		_, err = cloner.Clone(context.Background(), "TMPL", "NEW", "New Project", "lead", nil)
+		assert.ErrorIs(t, err, schemes.ErrApplyError)
+		assert.Equal(t, "project NEW deleted", fake.calls[len(fake.calls)-1])
+	})
+
+	t.Run("when the rollback fails", func(t *testing.T) {
+
+		fake := newSite()
+		fake.failed["project NEW feature jsw.classic.roadmap: DISABLED"] = true
+		fake.failed["project NEW deleted"] = true
+
+		cloner, err := NewCloner(fake.connectors())
+		assert.NoError(t, err)
+
+		_, err = cloner.Clone(context.Background(), "TMPL", "NEW", "New Project", "lead", nil)
+		assert.ErrorIs(t, err, model.ErrBadRequestError)
+		assert.Contains(t, err.Error(), "rollback failed: [project NEW: ")
+	})
+
+	t.Run("when the project key is used", func(t *testing.T) {
+
+		cloner, err := NewCloner(newSite().connectors())
+		assert.NoError(t, err)
+
+		_, err = cloner.Clone(context.Background(), "TMPL", "TMPL", "Template", "lead", nil)
+		assert.EqualError(t, err, "projects: invalid project key: Project 'TMPL' uses this project key.")
+	})
+
+	t.Run("when the modes are invalid", func(t *testing.T) {
+
+		cloner, err := NewCloner(newSite().connectors())
+		assert.NoError(t, err)
+
+		_, err = cloner.Clone(context.Background(), "TMPL", "NEW", "New Project", "lead", &Options{Components: Share})
+		assert.ErrorIs(t, err, ErrInvalidModeError)
+
+		_, err = cloner.Clone(context.Background(), "TMPL", "NEW", "New Project", "lead", &Options{
+			Schemes: map[schemes.Kind]Mode{schemes.ScreenSchemeKind: Copy},
+		})
+		assert.ErrorIs(t, err, ErrInvalidModeError)
+
+		_, err = cloner.Clone(context.Background(), "TMPL", "", "New Project", "lead", nil)
+		assert.ErrorIs(t, err, ErrNoProjectOptionError)
+	})
+}
@@ -0,0 +1,789 @@
+package schemes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// Result is a scheme change applied to the site, Err is an ErrPendingError while a task of the site
+// still applies it.
+type Result struct {
+	Change *Change
+	Err    error
+}
+
+// Apply applies the changes of a plan computed by Drift or Compare on the site of the manager, in
+// dependency order. The names are resolved on the site, including the schemes created by the plan.
+//
+// The changes are applied even if some of them fail, the error of each change is returned on its
+// result, the changes that depend on a failed one usually fail too. The changes still applied by a
+// task of the site when Apply returns, e.g: the draft of an active workflow scheme published while the
+// issues are migrated, are returned with an ErrPendingError, Drift reports them until the task completes.
+func (m *Manager) Apply(ctx context.Context, plan *Plan) ([]*Result, error) {
+
+	if plan == nil {
+		return nil, nil
+	}
+
+	var (
+		results []*Result
+		failed  int
+	)
+
+	for _, kind := range kinds {
+		for _, change := range plan.Changes {
+
+			if change.Kind != kind {
+				continue
+			}
+
+			err := m.apply(ctx, change)
+			if err != nil {
+				failed++
+			}
+
+			results = append(results, &Result{Change: change, Err: err})
+		}
+	}
+
+	if failed != 0 {
+		return results, fmt.Errorf("%w: %v of %v", ErrApplyError, failed, len(results))
+	}
+
+	return results, nil
+}
+
+func (m *Manager) apply(ctx context.Context, change *Change) error {
+
+	var id string
+	if change.Action == UpdateAction {
+
+		var err error
+		if id, err = m.idOf(ctx, string(change.Kind), change.Name); err != nil {
+			return err
+		}
+	}
+
+	switch desired := change.desired.(type) {
+	case *FieldConfigurationScheme:
+		return m.applyFieldConfigurationScheme(ctx, id, desired)
+	case *ScreenScheme:
+		return m.applyScreenScheme(ctx, id, desired)
+	case *IssueTypeScheme:
+		return m.applyIssueTypeScheme(ctx, id, desired)
+	case *IssueTypeScreenScheme:
+		return m.applyIssueTypeScreenScheme(ctx, id, desired)
+	case *WorkflowScheme:
+		return m.applyWorkflowScheme(ctx, id, desired)
+	case *PermissionScheme:
+		return m.applyPermissionScheme(ctx, id, desired)
+	case *NotificationScheme:
+		return m.applyNotificationScheme(ctx, id, desired)
+	case *Project:
+		return m.assign(ctx, change, desired)
+	}
+
+	return nil
+}
+
+// applyFieldConfigurationScheme creates the scheme when the ID is empty, otherwise it updates it
+// and unlinks the issue types not mapped anymore.
+func (m *Manager) applyFieldConfigurationScheme(ctx context.Context, id string, desired *FieldConfigurationScheme) error {
+
+	created := id == ""
+	if created {
+
+		scheme, _, err := m.c.FieldConfigurationScheme.Create(ctx, desired.Name, desired.Description)
+		if err != nil {
+			return err
+		}
+
+		id = scheme.ID
+		m.register(string(FieldConfigurationSchemeKind), &entry{id: id, name: desired.Name, description: desired.Description, value: scheme})
+	}
+
+	schemeID, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("schemes: invalid field configuration scheme ID %q: %w", id, err)
+	}
+
+	var current *FieldConfigurationScheme
+	if !created {
+
+		found, err := m.entry(ctx, string(FieldConfigurationSchemeKind), id)
+		if err != nil {
+			return err
+		}
+
+		if current, err = m.fieldConfigurationScheme(ctx, found); err != nil {
+			return err
+		}
+
+		if current.Description != desired.Description {
+			if _, err := m.c.FieldConfigurationScheme.Update(ctx, schemeID, desired.Name, desired.Description); err != nil {
+				return err
+			}
+		}
+	}
+
+	payload := &model.FieldConfigurationToIssueTypeMappingPayloadScheme{}
+	for issueType, configuration := range desired.Mappings {
+
+		issueTypeID, err := m.idOf(ctx, issueTypeResource, issueType)
+		if err != nil {
+			return err
+		}
+
+		configurationID, err := m.idOf(ctx, fieldConfigurationResource, configuration)
+		if err != nil {
+			return err
+		}
+
+		payload.Mappings = append(payload.Mappings, &model.FieldConfigurationToIssueTypeMappingScheme{
+			IssueTypeID:          issueTypeID,
+			FieldConfigurationID: configurationID,
+		})
+	}
+
+	if len(payload.Mappings) != 0 {
+		if _, err := m.c.FieldConfigurationScheme.Link(ctx, schemeID, payload); err != nil {
+			return err
+		}
+	}
+
+	if current == nil {
+		return nil
+	}
+
+	var unlinked []string
+	for issueType := range current.Mappings {
+
+		if _, ok := desired.Mappings[issueType]; ok || issueType == DefaultMapping {
+			continue
+		}
+
+		issueTypeID, err := m.idOf(ctx, issueTypeResource, issueType)
+		if err != nil {
+			return err
+		}
+
+		unlinked = append(unlinked, issueTypeID)
+	}
+
+	if len(unlinked) != 0 {
+		if _, err := m.c.FieldConfigurationScheme.Unlink(ctx, schemeID, unlinked); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) applyScreenScheme(ctx context.Context, id string, desired *ScreenScheme) error {
+
+	payload := &model.ScreenSchemePayloadScheme{Name: desired.Name, Description: desired.Description, Screens: &model.ScreenTypesScheme{}}
+
+	for operation, name := range desired.Screens {
+
+		screenID, err := m.idOf(ctx, screenResource, name)
+		if err != nil {
+			return err
+		}
+
+		value, err := strconv.Atoi(screenID)
+		if err != nil {
+			return fmt.Errorf("schemes: invalid screen ID %q: %w", screenID, err)
+		}
+
+		switch operation {
+		case "default":
+			payload.Screens.Default = value
+		case "create":
+			payload.Screens.Create = value
+		case "edit":
+			payload.Screens.Edit = value
+		case "view":
+			payload.Screens.View = value
+		default:
+			return fmt.Errorf("schemes: unknown screen operation %q", operation)
+		}
+	}
+
+	if id != "" {
+		_, err := m.c.ScreenScheme.Update(ctx, id, payload)
+		return err
+	}
+
+	created, _, err := m.c.ScreenScheme.Create(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	m.register(string(ScreenSchemeKind), &entry{
+		id:          strconv.Itoa(created.ID),
+		name:        desired.Name,
+		description: desired.Description,
+		value:       &model.ScreenSchemeScheme{ID: created.ID, Name: desired.Name, Description: desired.Description, Screens: payload.Screens},
+	})
+
+	return nil
+}
+
+func (m *Manager) applyIssueTypeScheme(ctx context.Context, id string, desired *IssueTypeScheme) error {
+
+	payload := &model.IssueTypeSchemePayloadScheme{Name: desired.Name, Description: desired.Description}
+
+	if desired.DefaultIssueType != "" {
+
+		var err error
+		if payload.DefaultIssueTypeID, err = m.idOf(ctx, issueTypeResource, desired.DefaultIssueType); err != nil {
+			return err
+		}
+	}
+
+	wanted := map[string]bool{}
+	for _, issueType := range desired.IssueTypes {
+
+		issueTypeID, err := m.idOf(ctx, issueTypeResource, issueType)
+		if err != nil {
+			return err
+		}
+
+		wanted[issueTypeID] = true
+		payload.IssueTypeIds = append(payload.IssueTypeIds, issueTypeID)
+	}
+
+	if id == "" {
+
+		created, _, err := m.c.IssueTypeScheme.Create(ctx, payload)
+		if err != nil {
+			return err
+		}
+
+		m.register(string(IssueTypeSchemeKind), &entry{
+			id:          created.IssueTypeSchemeID,
+			name:        desired.Name,
+			description: desired.Description,
+			value:       &model.IssueTypeSchemeScheme{ID: created.IssueTypeSchemeID, Name: desired.Name, DefaultIssueTypeID: payload.DefaultIssueTypeID},
+		})
+
+		return nil
+	}
+
+	schemeID, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("schemes: invalid issue type scheme ID %q: %w", id, err)
+	}
+
+	current := map[string]bool{}
+	err = paginate(func(startAt int) (int, bool, error) {
+
+		page, _, err := m.c.IssueTypeScheme.Items(ctx, []int{schemeID}, startAt, pageSize)
+		if err != nil {
+			return 0, false, err
+		}
+
+		for _, item := range page.Values {
+			current[item.IssueTypeID] = true
+		}
+
+		return len(page.Values), page.IsLast, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The issue types are appended before updating the default one, it must be on the scheme.
+	var appended []int
+	for _, issueTypeID := range payload.IssueTypeIds {
+
+		if current[issueTypeID] {
+			continue
+		}
+
+		value, err := strconv.Atoi(issueTypeID)
+		if err != nil {
+			return fmt.Errorf("schemes: invalid issue type ID %q: %w", issueTypeID, err)
+		}
+
+		appended = append(appended, value)
+	}
+
+	if len(appended) != 0 {
+		if _, err := m.c.IssueTypeScheme.Append(ctx, schemeID, appended); err != nil {
+			return err
+		}
+	}
+
+	if _, err := m.c.IssueTypeScheme.Update(ctx, schemeID, &model.IssueTypeSchemePayloadScheme{
+		DefaultIssueTypeID: payload.DefaultIssueTypeID,
+		Name:               payload.Name,
+		Description:        payload.Description,
+	}); err != nil {
+		return err
+	}
+
+	for issueTypeID := range current {
+
+		if wanted[issueTypeID] {
+			continue
+		}
+
+		value, err := strconv.Atoi(issueTypeID)
+		if err != nil {
+			return fmt.Errorf("schemes: invalid issue type ID %q: %w", issueTypeID, err)
+		}
+
+		if _, err := m.c.IssueTypeScheme.Remove(ctx, schemeID, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) applyIssueTypeScreenScheme(ctx context.Context, id string, desired *IssueTypeScreenScheme) error {
+
+	mappings := map[string]string{}
+	for issueType, screenScheme := range desired.Mappings {
+
+		issueTypeID, err := m.idOf(ctx, issueTypeResource, issueType)
+		if err != nil {
+			return err
+		}
+
+		if mappings[issueTypeID], err = m.idOf(ctx, string(ScreenSchemeKind), screenScheme); err != nil {
+			return err
+		}
+	}
+
+	if id == "" {
+
+		payload := &model.IssueTypeScreenSchemePayloadScheme{Name: desired.Name, Description: desired.Description}
+		for _, issueTypeID := range sortedKeys(mappings) {
+			payload.IssueTypeMappings = append(payload.IssueTypeMappings, &model.IssueTypeScreenSchemeMappingPayloadScheme{
+				IssueTypeID:    issueTypeID,
+				ScreenSchemeID: mappings[issueTypeID],
+			})
+		}
+
+		created, _, err := m.c.IssueTypeScreenScheme.Create(ctx, payload)
+		if err != nil {
+			return err
+		}
+
+		m.register(string(IssueTypeScreenSchemeKind), &entry{id: created.ID, name: desired.Name, description: desired.Description})
+		return nil
+	}
+
+	if _, err := m.c.IssueTypeScreenScheme.Update(ctx, id, desired.Name, desired.Description); err != nil {
+		return err
+	}
+
+	schemeID, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("schemes: invalid issue type screen scheme ID %q: %w", id, err)
+	}
+
+	current := map[string]string{}
+	err = paginate(func(startAt int) (int, bool, error) {
+
+		page, _, err := m.c.IssueTypeScreenScheme.Mapping(ctx, []int{schemeID}, startAt, pageSize)
+		if err != nil {
+			return 0, false, err
+		}
+
+		for _, item := range page.Values {
+			current[item.IssueTypeID] = item.ScreenSchemeID
+		}
+
+		return len(page.Values), page.IsLast, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if screenSchemeID, ok := mappings[DefaultMapping]; ok && current[DefaultMapping] != screenSchemeID {
+		if _, err := m.c.IssueTypeScreenScheme.UpdateDefault(ctx, id, screenSchemeID); err != nil {
+			return err
+		}
+	}
+
+	// The mappings changed are removed and appended again.
+	var removed []string
+	for _, issueTypeID := range sortedKeys(current) {
+		if screenSchemeID, ok := mappings[issueTypeID]; issueTypeID != DefaultMapping && (!ok || screenSchemeID != current[issueTypeID]) {
+			removed = append(removed, issueTypeID)
+		}
+	}
+
+	if len(removed) != 0 {
+		if _, err := m.c.IssueTypeScreenScheme.Remove(ctx, id, removed); err != nil {
+			return err
+		}
+	}
+
+	appended := &model.IssueTypeScreenSchemePayloadScheme{}
+	for _, issueTypeID := range sortedKeys(mappings) {
+
+		if issueTypeID == DefaultMapping || current[issueTypeID] == mappings[issueTypeID] {
+			continue
+		}
+
+		appended.IssueTypeMappings = append(appended.IssueTypeMappings, &model.IssueTypeScreenSchemeMappingPayloadScheme{
+			IssueTypeID:    issueTypeID,
+			ScreenSchemeID: mappings[issueTypeID],
+		})
+	}
+
+	if len(appended.IssueTypeMappings) != 0 {
+		if _, err := m.c.IssueTypeScreenScheme.Append(ctx, id, appended); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) applyWorkflowScheme(ctx context.Context, id string, desired *WorkflowScheme) error {
+
+	mappings := map[string]string{}
+	for issueType, workflow := range desired.Mappings {
+
+		issueTypeID, err := m.idOf(ctx, issueTypeResource, issueType)
+		if err != nil {
+			return err
+		}
+
+		mappings[issueTypeID] = workflow
+	}
+
+	payload := &model.WorkflowSchemePayloadScheme{
+		DefaultWorkflow:     desired.DefaultWorkflow,
+		Name:                desired.Name,
+		Description:         desired.Description,
+		IssueTypeMappings:   mappings,
+		UpdateDraftIfNeeded: true,
+	}
+
+	if id != "" {
+
+		schemeID, err := strconv.Atoi(id)
+		if err != nil {
+			return fmt.Errorf("schemes: invalid workflow scheme ID %q: %w", id, err)
+		}
+
+		scheme, _, err := m.c.WorkflowScheme.Update(ctx, schemeID, payload)
+		if err != nil {
+			return err
+		}
+
+		// The schemes used by projects are updated on a draft, the draft is published so the projects use it.
+		if scheme == nil || !scheme.Draft {
+			return nil
+		}
+
+		task, _, err := m.c.WorkflowScheme.Publish(ctx, schemeID, nil, false)
+		if err != nil {
+			return fmt.Errorf("schemes: the draft of the workflow scheme %q can't be published: %w", desired.Name, err)
+		}
+
+		if task != nil && task.Status != "COMPLETE" {
+			return fmt.Errorf("%w: the draft of the workflow scheme %q is published by the task %v, %v", ErrPendingError,
+				desired.Name, task.ID, strings.ToLower(task.Status))
+		}
+
+		return nil
+	}
+
+	created, _, err := m.c.WorkflowScheme.Create(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	m.register(string(WorkflowSchemeKind), &entry{id: strconv.Itoa(created.ID), name: desired.Name, description: desired.Description, value: created})
+	return nil
+}
+
+// applyPermissionScheme creates or updates the scheme, the grants of the scheme are replaced by the desired ones.
+func (m *Manager) applyPermissionScheme(ctx context.Context, id string, desired *PermissionScheme) error {
+
+	payload := &model.PermissionSchemeScheme{Name: desired.Name, Description: desired.Description}
+	for _, grant := range desired.Grants {
+
+		holder := &model.PermissionGrantHolderScheme{Type: grant.Holder, Parameter: grant.Parameter}
+		if grant.Holder == "projectRole" {
+
+			var err error
+			if holder.Parameter, err = m.idOf(ctx, roleResource, grant.Parameter); err != nil {
+				return err
+			}
+		}
+
+		payload.Permissions = append(payload.Permissions, &model.PermissionGrantScheme{Holder: holder, Permission: grant.Permission})
+	}
+
+	if id != "" {
+
+		schemeID, err := strconv.Atoi(id)
+		if err != nil {
+			return fmt.Errorf("schemes: invalid permission scheme ID %q: %w", id, err)
+		}
+
+		_, _, err = m.c.PermissionScheme.Update(ctx, schemeID, payload)
+		return err
+	}
+
+	created, _, err := m.c.PermissionScheme.Create(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	m.register(string(PermissionSchemeKind), &entry{id: strconv.Itoa(created.ID), name: desired.Name, description: desired.Description, value: created})
+	return nil
+}
+
+// applyNotificationScheme creates or updates the scheme, the notifications not desired are removed
+// and the missing ones appended.
+func (m *Manager) applyNotificationScheme(ctx context.Context, id string, desired *NotificationScheme) error {
+
+	events, err := m.notificationEvents(ctx, desired.Events)
+	if err != nil {
+		return err
+	}
+
+	if id == "" {
+
+		created, _, err := m.c.NotificationScheme.Create(ctx, &model.NotificationSchemePayloadScheme{
+			Name:        desired.Name,
+			Description: desired.Description,
+			Events:      events,
+		})
+		if err != nil {
+			return err
+		}
+
+		m.register(string(NotificationSchemeKind), &entry{id: created.Id, name: desired.Name, description: desired.Description})
+		return nil
+	}
+
+	found, err := m.entry(ctx, string(NotificationSchemeKind), id)
+	if err != nil {
+		return err
+	}
+
+	current, err := m.notificationScheme(ctx, found)
+	if err != nil {
+		return err
+	}
+
+	if current.Description != desired.Description {
+
+		payload := &model.NotificationSchemePayloadScheme{Name: desired.Name, Description: desired.Description}
+		if _, err := m.c.NotificationScheme.Update(ctx, id, payload); err != nil {
+			return err
+		}
+	}
+
+	wanted := map[string]bool{}
+	for _, event := range desired.Events {
+		for _, notification := range event.Notifications {
+			wanted[event.ID+" "+notification.String()] = true
+		}
+	}
+
+	existing := map[string]bool{}
+	for _, event := range current.Events {
+		for _, notification := range event.Notifications {
+
+			existing[event.ID+" "+notification.String()] = true
+
+			if wanted[event.ID+" "+notification.String()] {
+				continue
+			}
+
+			if _, err := m.c.NotificationScheme.Remove(ctx, id, strconv.Itoa(notification.id)); err != nil {
+				return err
+			}
+		}
+	}
+
+	missing := &model.NotificationSchemeEventsPayloadScheme{}
+	for index, event := range desired.Events {
+
+		appended := &model.NotificationSchemePayloadEventScheme{Event: events[index].Event}
+		for position, notification := range event.Notifications {
+			if !existing[event.ID+" "+notification.String()] {
+				appended.Notifications = append(appended.Notifications, events[index].Notifications[position])
+			}
+		}
+
+		if len(appended.Notifications) != 0 {
+			missing.NotificationSchemeEvents = append(missing.NotificationSchemeEvents, appended)
+		}
+	}
+
+	if len(missing.NotificationSchemeEvents) != 0 {
+		if _, err := m.c.NotificationScheme.Append(ctx, id, missing); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// notificationEvents returns the events of the notification scheme payloads, in the same order.
+func (m *Manager) notificationEvents(ctx context.Context, events []*Event) ([]*model.NotificationSchemePayloadEventScheme, error) {
+
+	var payload []*model.NotificationSchemePayloadEventScheme
+	for _, event := range events {
+
+		converted := &model.NotificationSchemePayloadEventScheme{Event: &model.NotificationSchemeEventTypeScheme{ID: event.ID}}
+		for _, notification := range event.Notifications {
+
+			recipient := &model.NotificationSchemeEventNotificationScheme{NotificationType: notification.Type, Parameter: notification.Parameter}
+			if notification.Type == "ProjectRole" {
+
+				var err error
+				if recipient.Parameter, err = m.idOf(ctx, roleResource, notification.Parameter); err != nil {
+					return nil, err
+				}
+			}
+
+			converted.Notifications = append(converted.Notifications, recipient)
+		}
+
+		payload = append(payload, converted)
+	}
+
+	return payload, nil
+}
+
+// assign assigns the schemes that differ to the project.
+func (m *Manager) assign(ctx context.Context, change *Change, desired *Project) error {
+
+	project, _, err := m.c.Project.Get(ctx, desired.Key, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, difference := range change.Differences {
+
+		kind := Kind(difference.Attribute)
+
+		id, err := m.idOf(ctx, string(kind), difference.Desired)
+		if err != nil {
+			return err
+		}
+
+		switch kind {
+		case FieldConfigurationSchemeKind:
+			_, err = m.c.FieldConfigurationScheme.Assign(ctx, &model.FieldConfigurationSchemeAssignPayload{
+				FieldConfigurationSchemeID: id,
+				ProjectID:                  project.ID,
+			})
+		case IssueTypeSchemeKind:
+			_, err = m.c.IssueTypeScheme.Assign(ctx, id, project.ID)
+		case IssueTypeScreenSchemeKind:
+			_, err = m.c.IssueTypeScreenScheme.Assign(ctx, id, project.ID)
+		case WorkflowSchemeKind:
+			_, err = m.c.WorkflowScheme.Assign(ctx, id, project.ID)
+		case PermissionSchemeKind:
+			err = m.assignByID(id, func(schemeID int) error {
+				_, _, err := m.c.ProjectPermissionScheme.Assign(ctx, desired.Key, schemeID)
+				return err
+			})
+		case NotificationSchemeKind:
+			err = m.assignByID(id, func(schemeID int) error {
+				_, _, err := m.c.Project.Update(ctx, desired.Key, &model.ProjectUpdateScheme{NotificationScheme: schemeID})
+				return err
+			})
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete deletes the scheme of the kind by name, e.g: to roll back the schemes created by a plan.
+// The schemes assigned to a project can't be deleted.
+func (m *Manager) Delete(ctx context.Context, kind Kind, name string) error {
+
+	id, err := m.idOf(ctx, string(kind), name)
+	if err != nil {
+		return err
+	}
+
+	var schemeID int
+
+	switch kind {
+	case FieldConfigurationSchemeKind, IssueTypeSchemeKind, WorkflowSchemeKind, PermissionSchemeKind:
+
+		schemeID, err = strconv.Atoi(id)
+		if err != nil {
+			return fmt.Errorf("schemes: invalid scheme ID %q: %w", id, err)
+		}
+	}
+
+	switch kind {
+	case ScreenSchemeKind:
+		_, err = m.c.ScreenScheme.Delete(ctx, id)
+	case IssueTypeScreenSchemeKind:
+		_, err = m.c.IssueTypeScreenScheme.Delete(ctx, id)
+	case NotificationSchemeKind:
+		_, err = m.c.NotificationScheme.Delete(ctx, id)
+	case FieldConfigurationSchemeKind:
+		_, err = m.c.FieldConfigurationScheme.Delete(ctx, schemeID)
+	case IssueTypeSchemeKind:
+		_, err = m.c.IssueTypeScheme.Delete(ctx, schemeID)
+	case WorkflowSchemeKind:
+		_, err = m.c.WorkflowScheme.Delete(ctx, schemeID)
+	case PermissionSchemeKind:
+		_, err = m.c.PermissionScheme.Delete(ctx, schemeID)
+	default:
+		return fmt.Errorf("%w: %v %q", ErrUnknownNameError, kind, name)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	index, err := m.resource(ctx, string(kind))
+	if err != nil {
+		return err
+	}
+
+	delete(index.byName, strings.ToLower(name))
+	delete(index.byID, id)
+
+	return nil
+}
+
+func (m *Manager) assignByID(id string, assign func(schemeID int) error) error {
+
+	schemeID, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("schemes: invalid scheme ID %q: %w", id, err)
+	}
+
+	return assign(schemeID)
+}
+
+func sortedKeys(values map[string]string) []string {
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
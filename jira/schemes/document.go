@@ -0,0 +1,426 @@
+package schemes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const documentVersion = 1
+
+// DefaultMapping is the key of the mappings applied to the issue types not mapped explicitly.
+const DefaultMapping = "default"
+
+// Kind is the type of scheme of a document.
+type Kind string
+
+const (
+	FieldConfigurationSchemeKind Kind = "field configuration scheme"
+	ScreenSchemeKind             Kind = "screen scheme"
+	IssueTypeSchemeKind          Kind = "issue type scheme"
+	IssueTypeScreenSchemeKind    Kind = "issue type screen scheme"
+	WorkflowSchemeKind           Kind = "workflow scheme"
+	PermissionSchemeKind         Kind = "permission scheme"
+	NotificationSchemeKind       Kind = "notification scheme"
+	ProjectKind                  Kind = "project"
+)
+
+// kinds are the kinds of the document in dependency order, e.g: the issue type screen schemes
+// reference the screen schemes and the projects reference all the schemes.
+var kinds = []Kind{
+	FieldConfigurationSchemeKind,
+	ScreenSchemeKind,
+	IssueTypeSchemeKind,
+	IssueTypeScreenSchemeKind,
+	WorkflowSchemeKind,
+	PermissionSchemeKind,
+	NotificationSchemeKind,
+	ProjectKind,
+}
+
+// Document is the declarative configuration of the schemes of a set of projects.
+//
+// The schemes, issue types, screens, field configurations and project roles are referenced by name,
+// so a document exported from a site, e.g: staging, can be compared and applied to another one.
+type Document struct {
+	Version int `json:"version" yaml:"version"`
+
+	FieldConfigurationSchemes []*FieldConfigurationScheme `json:"fieldConfigurationSchemes,omitempty" yaml:"fieldConfigurationSchemes,omitempty"`
+	ScreenSchemes             []*ScreenScheme             `json:"screenSchemes,omitempty" yaml:"screenSchemes,omitempty"`
+	IssueTypeSchemes          []*IssueTypeScheme          `json:"issueTypeSchemes,omitempty" yaml:"issueTypeSchemes,omitempty"`
+	IssueTypeScreenSchemes    []*IssueTypeScreenScheme    `json:"issueTypeScreenSchemes,omitempty" yaml:"issueTypeScreenSchemes,omitempty"`
+	WorkflowSchemes           []*WorkflowScheme           `json:"workflowSchemes,omitempty" yaml:"workflowSchemes,omitempty"`
+	PermissionSchemes         []*PermissionScheme         `json:"permissionSchemes,omitempty" yaml:"permissionSchemes,omitempty"`
+	NotificationSchemes       []*NotificationScheme       `json:"notificationSchemes,omitempty" yaml:"notificationSchemes,omitempty"`
+	Projects                  []*Project                  `json:"projects,omitempty" yaml:"projects,omitempty"`
+}
+
+// FieldConfigurationScheme maps the issue types to the field configurations.
+type FieldConfigurationScheme struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// Mappings are the field configuration names keyed by issue type name or DefaultMapping.
+	Mappings map[string]string `json:"mappings,omitempty" yaml:"mappings,omitempty"`
+}
+
+// ScreenScheme maps the issue operations to the screens.
+type ScreenScheme struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// Screens are the screen names keyed by operation: default, create, edit and view.
+	Screens map[string]string `json:"screens,omitempty" yaml:"screens,omitempty"`
+}
+
+// IssueTypeScheme is the list of issue types available on the projects.
+type IssueTypeScheme struct {
+	Name             string   `json:"name" yaml:"name"`
+	Description      string   `json:"description,omitempty" yaml:"description,omitempty"`
+	DefaultIssueType string   `json:"defaultIssueType,omitempty" yaml:"defaultIssueType,omitempty"`
+	IssueTypes       []string `json:"issueTypes,omitempty" yaml:"issueTypes,omitempty"`
+}
+
+// IssueTypeScreenScheme maps the issue types to the screen schemes.
+type IssueTypeScreenScheme struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// Mappings are the screen scheme names keyed by issue type name or DefaultMapping.
+	Mappings map[string]string `json:"mappings,omitempty" yaml:"mappings,omitempty"`
+}
+
+// WorkflowScheme maps the issue types to the workflows.
+type WorkflowScheme struct {
+	Name            string `json:"name" yaml:"name"`
+	Description     string `json:"description,omitempty" yaml:"description,omitempty"`
+	DefaultWorkflow string `json:"defaultWorkflow,omitempty" yaml:"defaultWorkflow,omitempty"`
+
+	// Mappings are the workflow names keyed by issue type name.
+	Mappings map[string]string `json:"mappings,omitempty" yaml:"mappings,omitempty"`
+}
+
+// PermissionScheme is the list of permissions granted on the projects.
+type PermissionScheme struct {
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Grants      []*Grant `json:"grants,omitempty" yaml:"grants,omitempty"`
+}
+
+// Grant is a permission granted to a holder, e.g: BROWSE_PROJECTS to the projectRole Developers.
+//
+// The parameter of the projectRole holders is the role name, the parameter of the other holders is
+// sent as it is, e.g: the group name or the account ID.
+type Grant struct {
+	Permission string `json:"permission" yaml:"permission"`
+	Holder     string `json:"holder" yaml:"holder"`
+	Parameter  string `json:"parameter,omitempty" yaml:"parameter,omitempty"`
+}
+
+// NotificationScheme is the list of recipients notified on each issue event.
+type NotificationScheme struct {
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Events      []*Event `json:"events,omitempty" yaml:"events,omitempty"`
+}
+
+// Event is the list of recipients notified on an issue event. The events are matched by ID, the
+// IDs of the system events are the same on every site, the name is informative.
+type Event struct {
+	ID            string          `json:"id" yaml:"id"`
+	Name          string          `json:"name,omitempty" yaml:"name,omitempty"`
+	Notifications []*Notification `json:"notifications,omitempty" yaml:"notifications,omitempty"`
+}
+
+// Notification is a recipient of an event, the parameter of the ProjectRole recipients is the role name.
+type Notification struct {
+	Type      string `json:"type" yaml:"type"`
+	Parameter string `json:"parameter,omitempty" yaml:"parameter,omitempty"`
+
+	id int
+}
+
+// Project is the schemes assigned to a project. The schemes not set are left untouched.
+type Project struct {
+	Key                      string `json:"key" yaml:"key"`
+	FieldConfigurationScheme string `json:"fieldConfigurationScheme,omitempty" yaml:"fieldConfigurationScheme,omitempty"`
+	IssueTypeScheme          string `json:"issueTypeScheme,omitempty" yaml:"issueTypeScheme,omitempty"`
+	IssueTypeScreenScheme    string `json:"issueTypeScreenScheme,omitempty" yaml:"issueTypeScreenScheme,omitempty"`
+	WorkflowScheme           string `json:"workflowScheme,omitempty" yaml:"workflowScheme,omitempty"`
+	PermissionScheme         string `json:"permissionScheme,omitempty" yaml:"permissionScheme,omitempty"`
+	NotificationScheme       string `json:"notificationScheme,omitempty" yaml:"notificationScheme,omitempty"`
+}
+
+// ReadDocument reads a document written as YAML or JSON.
+func ReadDocument(r io.Reader) (*Document, error) {
+
+	document := new(Document)
+	if err := yaml.NewDecoder(r).Decode(document); err != nil {
+		return nil, err
+	}
+
+	document.sort()
+	return document, nil
+}
+
+// WriteYAML writes the document as YAML.
+func (d *Document) WriteYAML(w io.Writer) error {
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(d); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// WriteJSON writes the document as an indented JSON document.
+func (d *Document) WriteJSON(w io.Writer) error {
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(d)
+}
+
+// item is a scheme or project of the document.
+type item interface {
+	name() string
+	attributes() []*attribute
+}
+
+// identity matches the items by case-insensitive name.
+func identity(value item) string {
+	return strings.ToLower(value.name())
+}
+
+// attribute is a comparable attribute of an item, the lists and the mappings are flattened to a sorted string.
+type attribute struct {
+	name  string
+	value string
+}
+
+// items returns the items of the document by kind.
+func (d *Document) items() map[Kind][]item {
+
+	items := map[Kind][]item{}
+
+	for _, scheme := range d.FieldConfigurationSchemes {
+		items[FieldConfigurationSchemeKind] = append(items[FieldConfigurationSchemeKind], scheme)
+	}
+
+	for _, scheme := range d.ScreenSchemes {
+		items[ScreenSchemeKind] = append(items[ScreenSchemeKind], scheme)
+	}
+
+	for _, scheme := range d.IssueTypeSchemes {
+		items[IssueTypeSchemeKind] = append(items[IssueTypeSchemeKind], scheme)
+	}
+
+	for _, scheme := range d.IssueTypeScreenSchemes {
+		items[IssueTypeScreenSchemeKind] = append(items[IssueTypeScreenSchemeKind], scheme)
+	}
+
+	for _, scheme := range d.WorkflowSchemes {
+		items[WorkflowSchemeKind] = append(items[WorkflowSchemeKind], scheme)
+	}
+
+	for _, scheme := range d.PermissionSchemes {
+		items[PermissionSchemeKind] = append(items[PermissionSchemeKind], scheme)
+	}
+
+	for _, scheme := range d.NotificationSchemes {
+		items[NotificationSchemeKind] = append(items[NotificationSchemeKind], scheme)
+	}
+
+	for _, project := range d.Projects {
+		items[ProjectKind] = append(items[ProjectKind], project)
+	}
+
+	return items
+}
+
+func (d *Document) sort() {
+
+	sort.SliceStable(d.FieldConfigurationSchemes, func(i, j int) bool {
+		return d.FieldConfigurationSchemes[i].Name < d.FieldConfigurationSchemes[j].Name
+	})
+
+	sort.SliceStable(d.ScreenSchemes, func(i, j int) bool { return d.ScreenSchemes[i].Name < d.ScreenSchemes[j].Name })
+
+	sort.SliceStable(d.IssueTypeSchemes, func(i, j int) bool { return d.IssueTypeSchemes[i].Name < d.IssueTypeSchemes[j].Name })
+	for _, scheme := range d.IssueTypeSchemes {
+		sort.Strings(scheme.IssueTypes)
+	}
+
+	sort.SliceStable(d.IssueTypeScreenSchemes, func(i, j int) bool {
+		return d.IssueTypeScreenSchemes[i].Name < d.IssueTypeScreenSchemes[j].Name
+	})
+
+	sort.SliceStable(d.WorkflowSchemes, func(i, j int) bool { return d.WorkflowSchemes[i].Name < d.WorkflowSchemes[j].Name })
+
+	sort.SliceStable(d.PermissionSchemes, func(i, j int) bool { return d.PermissionSchemes[i].Name < d.PermissionSchemes[j].Name })
+	for _, scheme := range d.PermissionSchemes {
+
+		sort.SliceStable(scheme.Grants, func(i, j int) bool {
+			return scheme.Grants[i].String() < scheme.Grants[j].String()
+		})
+	}
+
+	sort.SliceStable(d.NotificationSchemes, func(i, j int) bool {
+		return d.NotificationSchemes[i].Name < d.NotificationSchemes[j].Name
+	})
+
+	for _, scheme := range d.NotificationSchemes {
+
+		sort.SliceStable(scheme.Events, func(i, j int) bool { return eventOrder(scheme.Events[i]) < eventOrder(scheme.Events[j]) })
+		for _, event := range scheme.Events {
+
+			sort.SliceStable(event.Notifications, func(i, j int) bool {
+				return event.Notifications[i].String() < event.Notifications[j].String()
+			})
+		}
+	}
+
+	sort.SliceStable(d.Projects, func(i, j int) bool { return d.Projects[i].Key < d.Projects[j].Key })
+}
+
+// eventOrder sorts the events numerically by ID, e.g: 2 before 10.
+func eventOrder(event *Event) string {
+	return fmt.Sprintf("%20v", event.ID)
+}
+
+// String returns the grant as "PERMISSION holder:parameter".
+func (g *Grant) String() string {
+
+	if g.Parameter == "" {
+		return g.Permission + " " + g.Holder
+	}
+
+	return g.Permission + " " + g.Holder + ":" + g.Parameter
+}
+
+// String returns the notification as "type:parameter".
+func (n *Notification) String() string {
+
+	if n.Parameter == "" {
+		return n.Type
+	}
+
+	return n.Type + ":" + n.Parameter
+}
+
+func (s *FieldConfigurationScheme) name() string { return s.Name }
+
+func (s *FieldConfigurationScheme) attributes() []*attribute {
+	return []*attribute{{"description", s.Description}, {"mappings", mapping(s.Mappings)}}
+}
+
+func (s *ScreenScheme) name() string { return s.Name }
+
+func (s *ScreenScheme) attributes() []*attribute {
+	return []*attribute{{"description", s.Description}, {"screens", mapping(s.Screens)}}
+}
+
+func (s *IssueTypeScheme) name() string { return s.Name }
+
+func (s *IssueTypeScheme) attributes() []*attribute {
+	return []*attribute{
+		{"description", s.Description},
+		{"defaultIssueType", s.DefaultIssueType},
+		{"issueTypes", list(s.IssueTypes)},
+	}
+}
+
+func (s *IssueTypeScreenScheme) name() string { return s.Name }
+
+func (s *IssueTypeScreenScheme) attributes() []*attribute {
+	return []*attribute{{"description", s.Description}, {"mappings", mapping(s.Mappings)}}
+}
+
+func (s *WorkflowScheme) name() string { return s.Name }
+
+func (s *WorkflowScheme) attributes() []*attribute {
+	return []*attribute{
+		{"description", s.Description},
+		{"defaultWorkflow", s.DefaultWorkflow},
+		{"mappings", mapping(s.Mappings)},
+	}
+}
+
+func (s *PermissionScheme) name() string { return s.Name }
+
+func (s *PermissionScheme) attributes() []*attribute {
+
+	grants := make([]string, 0, len(s.Grants))
+	for _, grant := range s.Grants {
+		grants = append(grants, grant.String())
+	}
+
+	return []*attribute{{"description", s.Description}, {"grants", list(grants)}}
+}
+
+func (s *NotificationScheme) name() string { return s.Name }
+
+func (s *NotificationScheme) attributes() []*attribute {
+
+	var events []string
+	for _, event := range s.Events {
+		for _, notification := range event.Notifications {
+			events = append(events, event.ID+" "+notification.String())
+		}
+	}
+
+	return []*attribute{{"description", s.Description}, {"events", list(events)}}
+}
+
+func (p *Project) name() string { return p.Key }
+
+// attributes returns the schemes of the project, the schemes not set are omitted and left untouched.
+func (p *Project) attributes() []*attribute {
+
+	var attributes []*attribute
+	for _, assignment := range p.assignments() {
+		if assignment.value != "" {
+			attributes = append(attributes, assignment)
+		}
+	}
+
+	return attributes
+}
+
+// assignments returns the names of the schemes of the project keyed by kind.
+func (p *Project) assignments() []*attribute {
+	return []*attribute{
+		{string(FieldConfigurationSchemeKind), p.FieldConfigurationScheme},
+		{string(IssueTypeSchemeKind), p.IssueTypeScheme},
+		{string(IssueTypeScreenSchemeKind), p.IssueTypeScreenScheme},
+		{string(WorkflowSchemeKind), p.WorkflowScheme},
+		{string(PermissionSchemeKind), p.PermissionScheme},
+		{string(NotificationSchemeKind), p.NotificationScheme},
+	}
+}
+
+func mapping(values map[string]string) string {
+
+	pairs := make([]string, 0, len(values))
+	for key, value := range values {
+		pairs = append(pairs, key+": "+value)
+	}
+
+	return list(pairs)
+}
+
+func list(values []string) string {
+
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+
+	return strings.Join(sorted, ", ")
+}
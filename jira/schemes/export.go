@@ -0,0 +1,502 @@
+package schemes
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// Export returns the schemes assigned to the projects as a document. The projects using the default
+// field configuration scheme or workflow scheme of the site have no scheme of that kind set.
+func (m *Manager) Export(ctx context.Context, projectKeys ...string) (*Document, error) {
+
+	document := &Document{Version: documentVersion}
+	exported := map[Kind]map[string]bool{}
+
+	for _, key := range projectKeys {
+
+		project, err := m.project(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		document.Projects = append(document.Projects, project)
+
+		for _, assignment := range project.assignments() {
+
+			kind := Kind(assignment.name)
+			if assignment.value == "" {
+				continue
+			}
+
+			id, err := m.idOf(ctx, string(kind), assignment.value)
+			if err != nil {
+				return nil, err
+			}
+
+			if exported[kind][id] {
+				continue
+			}
+
+			if exported[kind] == nil {
+				exported[kind] = map[string]bool{}
+			}
+
+			exported[kind][id] = true
+
+			if err := m.export(ctx, document, kind, id); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// The screen schemes are referenced by the issue type screen schemes, not by the projects.
+	for _, scheme := range document.IssueTypeScreenSchemes {
+		for _, name := range scheme.Mappings {
+
+			id, err := m.idOf(ctx, string(ScreenSchemeKind), name)
+			if err != nil {
+				return nil, err
+			}
+
+			if exported[ScreenSchemeKind][id] {
+				continue
+			}
+
+			if exported[ScreenSchemeKind] == nil {
+				exported[ScreenSchemeKind] = map[string]bool{}
+			}
+
+			exported[ScreenSchemeKind][id] = true
+
+			if err := m.export(ctx, document, ScreenSchemeKind, id); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	document.sort()
+	return document, nil
+}
+
+// ExportSchemes returns the schemes of the kind as a document, e.g: to promote a scheme shared by
+// many projects without assigning it.
+func (m *Manager) ExportSchemes(ctx context.Context, kind Kind, names ...string) (*Document, error) {
+
+	document := &Document{Version: documentVersion}
+
+	for _, name := range names {
+
+		id, err := m.idOf(ctx, string(kind), name)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := m.export(ctx, document, kind, id); err != nil {
+			return nil, err
+		}
+	}
+
+	document.sort()
+	return document, nil
+}
+
+// export adds the scheme of the kind to the document.
+func (m *Manager) export(ctx context.Context, document *Document, kind Kind, id string) error {
+
+	found, err := m.entry(ctx, string(kind), id)
+	if err != nil {
+		return err
+	}
+
+	if found == nil {
+		return fmt.Errorf("%w: %v %v", ErrUnknownNameError, kind, id)
+	}
+
+	switch kind {
+	case FieldConfigurationSchemeKind:
+
+		scheme, err := m.fieldConfigurationScheme(ctx, found)
+		if err != nil {
+			return err
+		}
+
+		document.FieldConfigurationSchemes = append(document.FieldConfigurationSchemes, scheme)
+
+	case ScreenSchemeKind:
+
+		scheme, err := m.screenScheme(ctx, found)
+		if err != nil {
+			return err
+		}
+
+		document.ScreenSchemes = append(document.ScreenSchemes, scheme)
+
+	case IssueTypeSchemeKind:
+
+		scheme, err := m.issueTypeScheme(ctx, found)
+		if err != nil {
+			return err
+		}
+
+		document.IssueTypeSchemes = append(document.IssueTypeSchemes, scheme)
+
+	case IssueTypeScreenSchemeKind:
+
+		scheme, err := m.issueTypeScreenScheme(ctx, found)
+		if err != nil {
+			return err
+		}
+
+		document.IssueTypeScreenSchemes = append(document.IssueTypeScreenSchemes, scheme)
+
+	case WorkflowSchemeKind:
+
+		scheme, err := m.workflowScheme(ctx, found)
+		if err != nil {
+			return err
+		}
+
+		document.WorkflowSchemes = append(document.WorkflowSchemes, scheme)
+
+	case PermissionSchemeKind:
+
+		scheme, err := m.permissionScheme(ctx, found)
+		if err != nil {
+			return err
+		}
+
+		document.PermissionSchemes = append(document.PermissionSchemes, scheme)
+
+	case NotificationSchemeKind:
+
+		scheme, err := m.notificationScheme(ctx, found)
+		if err != nil {
+			return err
+		}
+
+		document.NotificationSchemes = append(document.NotificationSchemes, scheme)
+	}
+
+	return nil
+}
+
+// project returns the names of the schemes assigned to the project.
+func (m *Manager) project(ctx context.Context, key string) (*Project, error) {
+
+	details, _, err := m.c.Project.Get(ctx, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := strconv.Atoi(details.ID)
+	if err != nil {
+		return nil, fmt.Errorf("schemes: invalid project ID %q: %w", details.ID, err)
+	}
+
+	project := &Project{Key: details.Key}
+
+	fieldConfigurationSchemes, _, err := m.c.FieldConfigurationScheme.Project(ctx, []int{id}, 0, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, value := range fieldConfigurationSchemes.Values {
+		if value.FieldConfigurationScheme != nil {
+			project.FieldConfigurationScheme = value.FieldConfigurationScheme.Name
+		}
+	}
+
+	issueTypeSchemes, _, err := m.c.IssueTypeScheme.Projects(ctx, []int{id}, 0, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, value := range issueTypeSchemes.Values {
+		if value.IssueTypeScheme != nil {
+			project.IssueTypeScheme = value.IssueTypeScheme.Name
+		}
+	}
+
+	issueTypeScreenSchemes, _, err := m.c.IssueTypeScreenScheme.Projects(ctx, []int{id}, 0, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, value := range issueTypeScreenSchemes.Values {
+		if value.IssueTypeScreenScheme != nil {
+			project.IssueTypeScreenScheme = value.IssueTypeScreenScheme.Name
+		}
+	}
+
+	associations, _, err := m.c.WorkflowScheme.Associations(ctx, []int{id})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, value := range associations.Values {
+
+		// The default workflow scheme has no ID and can't be assigned.
+		if value.WorkflowScheme != nil && value.WorkflowScheme.ID != 0 {
+			project.WorkflowScheme = value.WorkflowScheme.Name
+		}
+	}
+
+	permissionScheme, _, err := m.c.ProjectPermissionScheme.Get(ctx, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	project.PermissionScheme = permissionScheme.Name
+
+	notificationScheme, _, err := m.c.Project.NotificationScheme(ctx, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	project.NotificationScheme = notificationScheme.Name
+
+	return project, nil
+}
+
+func (m *Manager) fieldConfigurationScheme(ctx context.Context, found *entry) (*FieldConfigurationScheme, error) {
+
+	id, err := strconv.Atoi(found.id)
+	if err != nil {
+		return nil, fmt.Errorf("schemes: invalid field configuration scheme ID %q: %w", found.id, err)
+	}
+
+	scheme := &FieldConfigurationScheme{Name: found.name, Description: found.description, Mappings: map[string]string{}}
+
+	err = paginate(func(startAt int) (int, bool, error) {
+
+		page, _, err := m.c.FieldConfigurationScheme.Mapping(ctx, []int{id}, startAt, pageSize)
+		if err != nil {
+			return 0, false, err
+		}
+
+		for _, mapping := range page.Values {
+
+			issueType, err := m.nameOf(ctx, issueTypeResource, mapping.IssueTypeID)
+			if err != nil {
+				return 0, false, err
+			}
+
+			configuration, err := m.nameOf(ctx, fieldConfigurationResource, mapping.FieldConfigurationID)
+			if err != nil {
+				return 0, false, err
+			}
+
+			scheme.Mappings[issueType] = configuration
+		}
+
+		return len(page.Values), page.IsLast, nil
+	})
+
+	return scheme, err
+}
+
+func (m *Manager) screenScheme(ctx context.Context, found *entry) (*ScreenScheme, error) {
+
+	scheme := &ScreenScheme{Name: found.name, Description: found.description, Screens: map[string]string{}}
+
+	value, ok := found.value.(*model.ScreenSchemeScheme)
+	if !ok || value.Screens == nil {
+		return scheme, nil
+	}
+
+	screens := map[string]int{
+		"default": value.Screens.Default,
+		"create":  value.Screens.Create,
+		"edit":    value.Screens.Edit,
+		"view":    value.Screens.View,
+	}
+
+	for operation, id := range screens {
+
+		if id == 0 {
+			continue
+		}
+
+		name, err := m.nameOf(ctx, screenResource, strconv.Itoa(id))
+		if err != nil {
+			return nil, err
+		}
+
+		scheme.Screens[operation] = name
+	}
+
+	return scheme, nil
+}
+
+func (m *Manager) issueTypeScheme(ctx context.Context, found *entry) (*IssueTypeScheme, error) {
+
+	id, err := strconv.Atoi(found.id)
+	if err != nil {
+		return nil, fmt.Errorf("schemes: invalid issue type scheme ID %q: %w", found.id, err)
+	}
+
+	scheme := &IssueTypeScheme{Name: found.name, Description: found.description}
+
+	if value, ok := found.value.(*model.IssueTypeSchemeScheme); ok && value.DefaultIssueTypeID != "" {
+
+		if scheme.DefaultIssueType, err = m.nameOf(ctx, issueTypeResource, value.DefaultIssueTypeID); err != nil {
+			return nil, err
+		}
+	}
+
+	err = paginate(func(startAt int) (int, bool, error) {
+
+		page, _, err := m.c.IssueTypeScheme.Items(ctx, []int{id}, startAt, pageSize)
+		if err != nil {
+			return 0, false, err
+		}
+
+		for _, item := range page.Values {
+
+			issueType, err := m.nameOf(ctx, issueTypeResource, item.IssueTypeID)
+			if err != nil {
+				return 0, false, err
+			}
+
+			scheme.IssueTypes = append(scheme.IssueTypes, issueType)
+		}
+
+		return len(page.Values), page.IsLast, nil
+	})
+
+	return scheme, err
+}
+
+func (m *Manager) issueTypeScreenScheme(ctx context.Context, found *entry) (*IssueTypeScreenScheme, error) {
+
+	id, err := strconv.Atoi(found.id)
+	if err != nil {
+		return nil, fmt.Errorf("schemes: invalid issue type screen scheme ID %q: %w", found.id, err)
+	}
+
+	scheme := &IssueTypeScreenScheme{Name: found.name, Description: found.description, Mappings: map[string]string{}}
+
+	err = paginate(func(startAt int) (int, bool, error) {
+
+		page, _, err := m.c.IssueTypeScreenScheme.Mapping(ctx, []int{id}, startAt, pageSize)
+		if err != nil {
+			return 0, false, err
+		}
+
+		for _, item := range page.Values {
+
+			issueType, err := m.nameOf(ctx, issueTypeResource, item.IssueTypeID)
+			if err != nil {
+				return 0, false, err
+			}
+
+			screenScheme, err := m.nameOf(ctx, string(ScreenSchemeKind), item.ScreenSchemeID)
+			if err != nil {
+				return 0, false, err
+			}
+
+			scheme.Mappings[issueType] = screenScheme
+		}
+
+		return len(page.Values), page.IsLast, nil
+	})
+
+	return scheme, err
+}
+
+func (m *Manager) workflowScheme(ctx context.Context, found *entry) (*WorkflowScheme, error) {
+
+	scheme := &WorkflowScheme{Name: found.name, Description: found.description, Mappings: map[string]string{}}
+
+	value, ok := found.value.(*model.WorkflowSchemeScheme)
+	if !ok {
+		return scheme, nil
+	}
+
+	scheme.DefaultWorkflow = value.DefaultWorkflow
+
+	for issueTypeID, workflow := range value.IssueTypeMappings {
+
+		issueType, err := m.nameOf(ctx, issueTypeResource, issueTypeID)
+		if err != nil {
+			return nil, err
+		}
+
+		scheme.Mappings[issueType] = workflow
+	}
+
+	return scheme, nil
+}
+
+func (m *Manager) permissionScheme(ctx context.Context, found *entry) (*PermissionScheme, error) {
+
+	id, err := strconv.Atoi(found.id)
+	if err != nil {
+		return nil, fmt.Errorf("schemes: invalid permission scheme ID %q: %w", found.id, err)
+	}
+
+	details, _, err := m.c.PermissionScheme.Get(ctx, id, []string{"permissions"})
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := &PermissionScheme{Name: found.name, Description: found.description}
+	for _, permission := range details.Permissions {
+
+		if permission.Holder == nil {
+			continue
+		}
+
+		grant := &Grant{Permission: permission.Permission, Holder: permission.Holder.Type, Parameter: permission.Holder.Parameter}
+
+		if grant.Holder == "projectRole" {
+			if grant.Parameter, err = m.nameOf(ctx, roleResource, grant.Parameter); err != nil {
+				return nil, err
+			}
+		}
+
+		scheme.Grants = append(scheme.Grants, grant)
+	}
+
+	return scheme, nil
+}
+
+func (m *Manager) notificationScheme(ctx context.Context, found *entry) (*NotificationScheme, error) {
+
+	scheme := &NotificationScheme{Name: found.name, Description: found.description}
+
+	value, ok := found.value.(*model.NotificationSchemeScheme)
+	if !ok {
+		return scheme, nil
+	}
+
+	for _, schemeEvent := range value.NotificationSchemeEvents {
+
+		if schemeEvent.Event == nil {
+			continue
+		}
+
+		event := &Event{ID: strconv.Itoa(schemeEvent.Event.ID), Name: schemeEvent.Event.Name}
+		for _, recipient := range schemeEvent.Notifications {
+
+			notification := &Notification{Type: recipient.NotificationType, Parameter: recipient.Parameter, id: recipient.ID}
+
+			if notification.Type == "ProjectRole" {
+
+				var err error
+				if notification.Parameter, err = m.nameOf(ctx, roleResource, notification.Parameter); err != nil {
+					return nil, err
+				}
+			}
+
+			event.Notifications = append(event.Notifications, notification)
+		}
+
+		scheme.Events = append(scheme.Events, event)
+	}
+
+	return scheme, nil
+}
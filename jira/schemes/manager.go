@@ -0,0 +1,405 @@
+// Package schemes exports the schemes of Jira projects to a declarative document referenced by name,
+// computes the drift between the document and a site or project, and applies the changes in
+// dependency order, e.g: to promote the configuration of a project from staging to production.
+//
+//	source, err := schemes.NewManager(&schemes.Connectors{
+//		Project:                 staging.Project,
+//		ProjectPermissionScheme: staging.Project.Permission,
+//		ProjectRole:             staging.Project.Role,
+//		IssueType:               staging.Issue.Type,
+//		// ...
+//		NotificationScheme: staging.NotificationScheme,
+//	})
+//
+//	document, err := source.Export(ctx, "KP")
+//
+//	// The manager of the production site is built the same way.
+//	plan, err := target.Drift(ctx, document, nil)
+//	results, err := target.Apply(ctx, plan)
+//
+// The field configuration schemes, screen schemes, issue type schemes, issue type screen schemes,
+// workflow schemes, permission schemes, notification schemes and the schemes assigned to the
+// projects are managed, the issue types, screens, field configurations, workflows and project
+// roles they reference must exist on the target site.
+package schemes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/jira"
+)
+
+const pageSize = 50
+
+// The resources referenced by name by the schemes, the schemes are referenced by Kind.
+const (
+	issueTypeResource          = "issue type"
+	screenResource             = "screen"
+	fieldConfigurationResource = "field configuration"
+	roleResource               = "project role"
+)
+
+var (
+	ErrNoConnectorError = errors.New("schemes: no connector set")
+	ErrNoDocumentError  = errors.New("schemes: no document set")
+	ErrUnknownNameError = errors.New("schemes: unknown name")
+	ErrDuplicatedError  = errors.New("schemes: duplicated name")
+	ErrApplyError       = errors.New("schemes: some changes failed")
+	ErrPendingError     = errors.New("schemes: change pending")
+)
+
+// Connectors are the services the schemes are read and written with.
+type Connectors struct {
+	Project                 jira.ProjectConnector
+	ProjectPermissionScheme jira.ProjectPermissionSchemeConnector
+	ProjectRole             jira.ProjectRoleConnector
+
+	IssueType          jira.TypeConnector
+	Screen             jira.ScreenConnector
+	FieldConfiguration jira.FieldConfigConnector
+
+	FieldConfigurationScheme jira.FieldConfigSchemeConnector
+	ScreenScheme             jira.ScreenSchemeConnector
+	IssueTypeScheme          jira.TypeSchemeConnector
+	IssueTypeScreenScheme    jira.TypeScreenSchemeConnector
+	WorkflowScheme           jira.WorkflowSchemeConnector
+	PermissionScheme         jira.PermissionSchemeConnector
+	NotificationScheme       jira.NotificationSchemeConnector
+}
+
+// Manager exports, compares and applies the schemes of a site. The names of the resources are
+// listed once and cached, a manager isn't safe for concurrent use.
+type Manager struct {
+	c       Connectors
+	catalog map[string]*names
+}
+
+// NewManager returns a manager of the schemes of the site of the connectors, all of them are required.
+func NewManager(connectors *Connectors) (*Manager, error) {
+
+	if connectors == nil {
+		return nil, ErrNoConnectorError
+	}
+
+	required := []struct {
+		name      string
+		connector interface{}
+	}{
+		{"Project", connectors.Project},
+		{"ProjectPermissionScheme", connectors.ProjectPermissionScheme},
+		{"ProjectRole", connectors.ProjectRole},
+		{"IssueType", connectors.IssueType},
+		{"Screen", connectors.Screen},
+		{"FieldConfiguration", connectors.FieldConfiguration},
+		{"FieldConfigurationScheme", connectors.FieldConfigurationScheme},
+		{"ScreenScheme", connectors.ScreenScheme},
+		{"IssueTypeScheme", connectors.IssueTypeScheme},
+		{"IssueTypeScreenScheme", connectors.IssueTypeScreenScheme},
+		{"WorkflowScheme", connectors.WorkflowScheme},
+		{"PermissionScheme", connectors.PermissionScheme},
+		{"NotificationScheme", connectors.NotificationScheme},
+	}
+
+	for _, connector := range required {
+		if connector.connector == nil {
+			return nil, fmt.Errorf("%w: %v", ErrNoConnectorError, connector.name)
+		}
+	}
+
+	return &Manager{c: *connectors, catalog: map[string]*names{}}, nil
+}
+
+// entry is a resource of the site, the value is the resource as returned by the connector.
+type entry struct {
+	id          string
+	name        string
+	description string
+	value       interface{}
+}
+
+// names indexes the resources of a kind by ID and case-insensitive name.
+type names struct {
+	byID   map[string]*entry
+	byName map[string]*entry
+}
+
+func (n *names) add(e *entry) {
+
+	n.byID[e.id] = e
+
+	// The first resource wins when the names are duplicated, e.g: the issue types of the team-managed projects.
+	if _, ok := n.byName[strings.ToLower(e.name)]; !ok {
+		n.byName[strings.ToLower(e.name)] = e
+	}
+}
+
+// resource returns the resources of a kind, they're listed on first use.
+func (m *Manager) resource(ctx context.Context, resource string) (*names, error) {
+
+	if loaded, ok := m.catalog[resource]; ok {
+		return loaded, nil
+	}
+
+	index := &names{byID: map[string]*entry{}, byName: map[string]*entry{}}
+	if err := m.list(ctx, resource, index.add); err != nil {
+		return nil, err
+	}
+
+	m.catalog[resource] = index
+	return index, nil
+}
+
+// entry returns the resource by ID, nil when it doesn't exist.
+func (m *Manager) entry(ctx context.Context, resource, id string) (*entry, error) {
+
+	index, err := m.resource(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return index.byID[id], nil
+}
+
+// nameOf returns the name of the resource, the ID when it's unknown.
+func (m *Manager) nameOf(ctx context.Context, resource, id string) (string, error) {
+
+	if id == DefaultMapping {
+		return id, nil
+	}
+
+	found, err := m.entry(ctx, resource, id)
+	if err != nil || found == nil {
+		return id, err
+	}
+
+	return found.name, nil
+}
+
+// idOf returns the ID of the resource by case-insensitive name.
+func (m *Manager) idOf(ctx context.Context, resource, name string) (string, error) {
+
+	if name == DefaultMapping {
+		return name, nil
+	}
+
+	index, err := m.resource(ctx, resource)
+	if err != nil {
+		return "", err
+	}
+
+	found, ok := index.byName[strings.ToLower(name)]
+	if !ok {
+		return "", fmt.Errorf("%w: %v %q", ErrUnknownNameError, resource, name)
+	}
+
+	return found.id, nil
+}
+
+// register adds a resource created to the catalog.
+func (m *Manager) register(resource string, e *entry) {
+
+	if index, ok := m.catalog[resource]; ok {
+		index.add(e)
+	}
+}
+
+// list calls add with every resource of the kind.
+func (m *Manager) list(ctx context.Context, resource string, add func(*entry)) error {
+
+	switch resource {
+	case issueTypeResource:
+
+		issueTypes, _, err := m.c.IssueType.Gets(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, issueType := range issueTypes {
+
+			// The issue types of the team-managed projects aren't available on the schemes.
+			if issueType.Scope != nil {
+				continue
+			}
+
+			add(&entry{id: issueType.ID, name: issueType.Name, value: issueType})
+		}
+
+	case screenResource:
+
+		return paginate(func(startAt int) (int, bool, error) {
+
+			page, _, err := m.c.Screen.Gets(ctx, nil, startAt, pageSize)
+			if err != nil {
+				return 0, false, err
+			}
+
+			for _, screen := range page.Values {
+				add(&entry{id: strconv.Itoa(screen.ID), name: screen.Name, value: screen})
+			}
+
+			return len(page.Values), page.IsLast, nil
+		})
+
+	case fieldConfigurationResource:
+
+		return paginate(func(startAt int) (int, bool, error) {
+
+			page, _, err := m.c.FieldConfiguration.Gets(ctx, nil, false, startAt, pageSize)
+			if err != nil {
+				return 0, false, err
+			}
+
+			for _, configuration := range page.Values {
+				add(&entry{id: strconv.Itoa(configuration.ID), name: configuration.Name, value: configuration})
+			}
+
+			return len(page.Values), page.IsLast, nil
+		})
+
+	case roleResource:
+
+		roles, _, err := m.c.ProjectRole.Global(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, role := range roles {
+			add(&entry{id: strconv.Itoa(role.ID), name: role.Name, value: role})
+		}
+
+	case string(FieldConfigurationSchemeKind):
+
+		return paginate(func(startAt int) (int, bool, error) {
+
+			page, _, err := m.c.FieldConfigurationScheme.Gets(ctx, nil, startAt, pageSize)
+			if err != nil {
+				return 0, false, err
+			}
+
+			for _, scheme := range page.Values {
+				add(&entry{id: scheme.ID, name: scheme.Name, description: scheme.Description, value: scheme})
+			}
+
+			return len(page.Values), page.IsLast, nil
+		})
+
+	case string(ScreenSchemeKind):
+
+		return paginate(func(startAt int) (int, bool, error) {
+
+			page, _, err := m.c.ScreenScheme.Gets(ctx, nil, startAt, pageSize)
+			if err != nil {
+				return 0, false, err
+			}
+
+			for _, scheme := range page.Values {
+				add(&entry{id: strconv.Itoa(scheme.ID), name: scheme.Name, description: scheme.Description, value: scheme})
+			}
+
+			return len(page.Values), page.IsLast, nil
+		})
+
+	case string(IssueTypeSchemeKind):
+
+		return paginate(func(startAt int) (int, bool, error) {
+
+			page, _, err := m.c.IssueTypeScheme.Gets(ctx, nil, startAt, pageSize)
+			if err != nil {
+				return 0, false, err
+			}
+
+			for _, scheme := range page.Values {
+				add(&entry{id: scheme.ID, name: scheme.Name, description: scheme.Description, value: scheme})
+			}
+
+			return len(page.Values), page.IsLast, nil
+		})
+
+	case string(IssueTypeScreenSchemeKind):
+
+		return paginate(func(startAt int) (int, bool, error) {
+
+			page, _, err := m.c.IssueTypeScreenScheme.Gets(ctx, nil, startAt, pageSize)
+			if err != nil {
+				return 0, false, err
+			}
+
+			for _, scheme := range page.Values {
+				add(&entry{id: scheme.ID, name: scheme.Name, description: scheme.Description, value: scheme})
+			}
+
+			return len(page.Values), page.IsLast, nil
+		})
+
+	case string(WorkflowSchemeKind):
+
+		return paginate(func(startAt int) (int, bool, error) {
+
+			page, _, err := m.c.WorkflowScheme.Gets(ctx, startAt, pageSize)
+			if err != nil {
+				return 0, false, err
+			}
+
+			for _, scheme := range page.Values {
+				add(&entry{id: strconv.Itoa(scheme.ID), name: scheme.Name, description: scheme.Description, value: scheme})
+			}
+
+			return len(page.Values), page.IsLast, nil
+		})
+
+	case string(PermissionSchemeKind):
+
+		page, _, err := m.c.PermissionScheme.Gets(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, scheme := range page.PermissionSchemes {
+			add(&entry{id: strconv.Itoa(scheme.ID), name: scheme.Name, description: scheme.Description, value: scheme})
+		}
+
+	case string(NotificationSchemeKind):
+
+		options := &model.NotificationSchemeSearchOptions{Expand: []string{"all"}}
+
+		return paginate(func(startAt int) (int, bool, error) {
+
+			page, _, err := m.c.NotificationScheme.Search(ctx, options, startAt, pageSize)
+			if err != nil {
+				return 0, false, err
+			}
+
+			for _, scheme := range page.Values {
+				add(&entry{id: strconv.Itoa(scheme.ID), name: scheme.Name, description: scheme.Description, value: scheme})
+			}
+
+			return len(page.Values), page.IsLast, nil
+		})
+	}
+
+	return nil
+}
+
+// paginate calls fetch with the offset of each page until the last one.
+func paginate(fetch func(startAt int) (count int, last bool, err error)) error {
+
+	startAt := 0
+	for {
+
+		count, last, err := fetch(startAt)
+		if err != nil {
+			return err
+		}
+
+		if last || count == 0 {
+			return nil
+		}
+
+		startAt += count
+	}
+}
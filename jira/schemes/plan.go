@@ -0,0 +1,261 @@
+package schemes
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+)
+
+// Action is whether a scheme is created or updated, or the schemes of a project are assigned.
+type Action string
+
+const (
+	CreateAction Action = "create"
+	UpdateAction Action = "update"
+	AssignAction Action = "assign"
+)
+
+type CompareOptions struct {
+
+	// Projects maps the keys of the projects of the desired document to the keys of the target
+	// projects, e.g: to apply the configuration of a template project to another one. The projects
+	// not mapped keep their key.
+	Projects map[string]string
+}
+
+// Plan is the list of changes required to turn the current schemes into the desired ones, in
+// dependency order. The schemes not included on the desired document are left untouched.
+type Plan struct {
+	Changes []*Change
+}
+
+// Change is a scheme created or updated, or the schemes assigned to a project.
+type Change struct {
+	Action      Action
+	Kind        Kind
+	Name        string
+	Differences []*Difference
+
+	desired item
+}
+
+// Difference is an attribute whose current value differs from the desired one, the attributes of
+// the projects are the kinds of the schemes assigned.
+type Difference struct {
+	Attribute string
+	Current   string
+	Desired   string
+}
+
+// IsEmpty reports whether there's no drift between the documents.
+func (p *Plan) IsEmpty() bool {
+	return len(p.Changes) == 0
+}
+
+// Compare returns the changes required to turn the current document into the desired one, e.g:
+// a document exported from the production site into the document exported from staging.
+func Compare(current, desired *Document, options *CompareOptions) (*Plan, error) {
+
+	if current == nil || desired == nil {
+		return nil, ErrNoDocumentError
+	}
+
+	if options == nil {
+		options = &CompareOptions{}
+	}
+
+	desired = retarget(desired, options.Projects)
+
+	currentItems, desiredItems := current.items(), desired.items()
+
+	plan := new(Plan)
+	for _, kind := range kinds {
+
+		currentIndex, err := index(kind, currentItems[kind])
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := index(kind, desiredItems[kind]); err != nil {
+			return nil, err
+		}
+
+		for _, wanted := range desiredItems[kind] {
+
+			name := wanted.name()
+
+			existing, ok := currentIndex[identity(wanted)]
+			if !ok {
+
+				action := CreateAction
+				if kind == ProjectKind {
+					action = AssignAction
+				}
+
+				plan.Changes = append(plan.Changes, &Change{
+					Action:      action,
+					Kind:        kind,
+					Name:        name,
+					Differences: differences(nil, wanted),
+					desired:     wanted,
+				})
+
+				continue
+			}
+
+			changed := differences(existing, wanted)
+			if len(changed) == 0 {
+				continue
+			}
+
+			action := UpdateAction
+			if kind == ProjectKind {
+				action = AssignAction
+			}
+
+			plan.Changes = append(plan.Changes, &Change{Action: action, Kind: kind, Name: name, Differences: changed, desired: wanted})
+		}
+	}
+
+	return plan, nil
+}
+
+// Drift exports the schemes of the desired document and the projects from the site of the manager,
+// by name, and compares them with the desired document. The schemes missing on the site are created.
+//
+// The plan returned can be applied on the site with Apply.
+func (m *Manager) Drift(ctx context.Context, desired *Document, options *CompareOptions) (*Plan, error) {
+
+	if desired == nil {
+		return nil, ErrNoDocumentError
+	}
+
+	if options == nil {
+		options = &CompareOptions{}
+	}
+
+	current := &Document{Version: documentVersion}
+	for kind, items := range desired.items() {
+
+		if kind == ProjectKind {
+			continue
+		}
+
+		index, err := m.resource(ctx, string(kind))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, wanted := range items {
+
+			found, ok := index.byName[identity(wanted)]
+			if !ok {
+				continue
+			}
+
+			if err := m.export(ctx, current, kind, found.id); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, project := range retarget(desired, options.Projects).Projects {
+
+		exported, err := m.project(ctx, project.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		current.Projects = append(current.Projects, exported)
+	}
+
+	current.sort()
+
+	return Compare(current, desired, options)
+}
+
+// WriteDiff writes a line per scheme created (+), scheme updated or project assigned (~), in the order
+// the changes are applied, each followed by its attributes changed.
+func (p *Plan) WriteDiff(w io.Writer) error {
+
+	buffer := bufio.NewWriter(w)
+
+	for _, change := range p.Changes {
+
+		switch change.Action {
+		case CreateAction:
+			fmt.Fprintf(buffer, "+ %v %q\n", change.Kind, change.Name)
+		default:
+			fmt.Fprintf(buffer, "~ %v %q\n", change.Kind, change.Name)
+		}
+
+		for _, difference := range change.Differences {
+			fmt.Fprintf(buffer, "    %v: %q -> %q\n", difference.Attribute, difference.Current, difference.Desired)
+		}
+	}
+
+	return buffer.Flush()
+}
+
+// differences returns the attributes of the desired item that differ from the current one, all of
+// them when there's no current item. The attributes not set on the desired projects are omitted.
+func differences(current, desired item) []*Difference {
+
+	values := map[string]string{}
+	if current != nil {
+		for _, attribute := range current.attributes() {
+			values[attribute.name] = attribute.value
+		}
+	}
+
+	var changed []*Difference
+	for _, attribute := range desired.attributes() {
+
+		if values[attribute.name] == attribute.value {
+			continue
+		}
+
+		changed = append(changed, &Difference{Attribute: attribute.name, Current: values[attribute.name], Desired: attribute.value})
+	}
+
+	return changed
+}
+
+// retarget returns a copy of the document with the keys of the projects mapped.
+func retarget(document *Document, keys map[string]string) *Document {
+
+	if len(keys) == 0 {
+		return document
+	}
+
+	copied := *document
+	copied.Projects = make([]*Project, 0, len(document.Projects))
+
+	for _, project := range document.Projects {
+
+		mapped := *project
+		if key, ok := keys[project.Key]; ok {
+			mapped.Key = key
+		}
+
+		copied.Projects = append(copied.Projects, &mapped)
+	}
+
+	return &copied
+}
+
+func index(kind Kind, items []item) (map[string]item, error) {
+
+	indexed := make(map[string]item, len(items))
+	for _, value := range items {
+
+		if _, ok := indexed[identity(value)]; ok {
+			return nil, fmt.Errorf("%w: %v %q", ErrDuplicatedError, kind, value.name())
+		}
+
+		indexed[identity(value)] = value
+	}
+
+	return indexed, nil
+}
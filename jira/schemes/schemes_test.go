@@ -0,0 +1,837 @@
+package schemes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/jira"
+	"github.com/stretchr/testify/assert"
+)
+
+// site is the state of a fake Jira site, the writes are recorded on calls.
+type site struct {
+	calls  []string
+	nextID int
+
+	project      *model.ProjectScheme
+	assignments  map[Kind]string
+	issueTypes   []*model.IssueTypeScheme
+	screens      []*model.ScreenScheme
+	fieldConfigs []*model.FieldConfigurationScheme
+	roles        []*model.ProjectRoleScheme
+
+	fieldConfigurationSchemes  []*model.FieldConfigurationSchemeScheme
+	fieldConfigurationMappings []*model.FieldConfigurationIssueTypeItemScheme
+	screenSchemes              []*model.ScreenSchemeScheme
+	issueTypeSchemes           []*model.IssueTypeSchemeScheme
+	issueTypeSchemeItems       []*model.IssueTypeSchemeMappingScheme
+	issueTypeScreenSchemes     []*model.IssueTypeScreenSchemeScheme
+	issueTypeScreenSchemeItems []*model.IssueTypeScreenSchemeItemScheme
+	workflowSchemes            []*model.WorkflowSchemeScheme
+	permissionSchemes          []*model.PermissionSchemeScheme
+	notificationSchemes        []*model.NotificationSchemeScheme
+
+	// publication is the task returned when the draft of a workflow scheme is published, none by default.
+	publication *model.TaskScheme
+}
+
+func (s *site) record(format string, values ...interface{}) {
+	s.calls = append(s.calls, fmt.Sprintf(format, values...))
+}
+
+func (s *site) id() int {
+	s.nextID++
+	return s.nextID
+}
+
+func newSite() *site {
+
+	return &site{
+		nextID:  900,
+		project: &model.ProjectScheme{ID: "10000", Key: "KP"},
+		assignments: map[Kind]string{
+			FieldConfigurationSchemeKind: "100",
+			IssueTypeSchemeKind:          "300",
+			IssueTypeScreenSchemeKind:    "400",
+			WorkflowSchemeKind:           "500",
+			PermissionSchemeKind:         "600",
+			NotificationSchemeKind:       "700",
+		},
+		issueTypes: []*model.IssueTypeScheme{
+			{ID: "1", Name: "Bug"},
+			{ID: "2", Name: "Task"},
+			{ID: "99", Name: "Bug", Scope: &model.IssueTypeScopeScheme{Type: "PROJECT"}},
+		},
+		screens:      []*model.ScreenScheme{{ID: 10, Name: "Default Screen"}, {ID: 11, Name: "Bug Screen"}},
+		fieldConfigs: []*model.FieldConfigurationScheme{{ID: 20, Name: "Default Field Configuration"}, {ID: 21, Name: "Bug Fields"}},
+		roles:        []*model.ProjectRoleScheme{{ID: 30, Name: "Developers"}, {ID: 31, Name: "Administrators"}},
+
+		fieldConfigurationSchemes: []*model.FieldConfigurationSchemeScheme{{ID: "100", Name: "KP Fields"}},
+		fieldConfigurationMappings: []*model.FieldConfigurationIssueTypeItemScheme{
+			{FieldConfigurationSchemeID: "100", IssueTypeID: "default", FieldConfigurationID: "20"},
+			{FieldConfigurationSchemeID: "100", IssueTypeID: "1", FieldConfigurationID: "21"},
+		},
+		screenSchemes: []*model.ScreenSchemeScheme{
+			{ID: 200, Name: "KP Screens", Screens: &model.ScreenTypesScheme{Default: 10}},
+			{ID: 201, Name: "Bug Screens", Screens: &model.ScreenTypesScheme{Default: 11, View: 10}},
+		},
+		issueTypeSchemes: []*model.IssueTypeSchemeScheme{{ID: "300", Name: "KP Types", DefaultIssueTypeID: "2"}},
+		issueTypeSchemeItems: []*model.IssueTypeSchemeMappingScheme{
+			{IssueTypeSchemeID: "300", IssueTypeID: "1"},
+			{IssueTypeSchemeID: "300", IssueTypeID: "2"},
+		},
+		issueTypeScreenSchemes: []*model.IssueTypeScreenSchemeScheme{{ID: "400", Name: "KP Screen Scheme"}},
+		issueTypeScreenSchemeItems: []*model.IssueTypeScreenSchemeItemScheme{
+			{IssueTypeScreenSchemeID: "400", IssueTypeID: "default", ScreenSchemeID: "200"},
+			{IssueTypeScreenSchemeID: "400", IssueTypeID: "1", ScreenSchemeID: "201"},
+		},
+		workflowSchemes: []*model.WorkflowSchemeScheme{
+			{ID: 500, Name: "KP Workflows", DefaultWorkflow: "jira", IssueTypeMappings: map[string]string{"1": "Bug Workflow"}},
+		},
+		permissionSchemes: []*model.PermissionSchemeScheme{
+			{ID: 600, Name: "KP Permissions", Permissions: []*model.PermissionGrantScheme{
+				{ID: 1, Permission: "DELETE_ISSUES", Holder: &model.PermissionGrantHolderScheme{Type: "group", Parameter: "jira-admins"}},
+				{ID: 2, Permission: "BROWSE_PROJECTS", Holder: &model.PermissionGrantHolderScheme{Type: "projectRole", Parameter: "30"}},
+			}},
+		},
+		notificationSchemes: []*model.NotificationSchemeScheme{
+			{ID: 700, Name: "KP Notifications", NotificationSchemeEvents: []*model.ProjectNotificationSchemeEventScheme{
+				{
+					Event: &model.NotificationEventScheme{ID: 1, Name: "Issue created"},
+					Notifications: []*model.EventNotificationScheme{
+						{ID: 7001, NotificationType: "ProjectRole", Parameter: "30"},
+						{ID: 7002, NotificationType: "CurrentAssignee"},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func (s *site) connectors() *Connectors {
+	return &Connectors{
+		Project:                  &projectFake{site: s},
+		ProjectPermissionScheme:  &projectPermissionFake{site: s},
+		ProjectRole:              &roleFake{site: s},
+		IssueType:                &issueTypeFake{site: s},
+		Screen:                   &screenFake{site: s},
+		FieldConfiguration:       &fieldConfigFake{site: s},
+		FieldConfigurationScheme: &fieldConfigSchemeFake{site: s},
+		ScreenScheme:             &screenSchemeFake{site: s},
+		IssueTypeScheme:          &issueTypeSchemeFake{site: s},
+		IssueTypeScreenScheme:    &issueTypeScreenSchemeFake{site: s},
+		WorkflowScheme:           &workflowSchemeFake{site: s},
+		PermissionScheme:         &permissionSchemeFake{site: s},
+		NotificationScheme:       &notificationSchemeFake{site: s},
+	}
+}
+
+type projectFake struct {
+	jira.ProjectConnector
+	site *site
+}
+
+func (p *projectFake) Get(ctx context.Context, projectKeyOrID string, expand []string) (*model.ProjectScheme, *model.ResponseScheme, error) {
+
+	if projectKeyOrID != p.site.project.Key {
+		return nil, nil, model.ErrNotFound
+	}
+
+	return p.site.project, nil, nil
+}
+
+func (p *projectFake) NotificationScheme(ctx context.Context, projectKeyOrID string, expand []string) (*model.NotificationSchemeScheme,
+	*model.ResponseScheme, error) {
+
+	for _, scheme := range p.site.notificationSchemes {
+		if strconv.Itoa(scheme.ID) == p.site.assignments[NotificationSchemeKind] {
+			return scheme, nil, nil
+		}
+	}
+
+	return nil, nil, model.ErrNotFound
+}
+
+func (p *projectFake) Update(ctx context.Context, projectKeyOrID string, payload *model.ProjectUpdateScheme) (*model.ProjectScheme,
+	*model.ResponseScheme, error) {
+	p.site.record("project %v notification scheme %v", projectKeyOrID, payload.NotificationScheme)
+	return p.site.project, nil, nil
+}
+
+type projectPermissionFake struct {
+	jira.ProjectPermissionSchemeConnector
+	site *site
+}
+
+func (p *projectPermissionFake) Get(ctx context.Context, projectKeyOrID string, expand []string) (*model.PermissionSchemeScheme,
+	*model.ResponseScheme, error) {
+
+	for _, scheme := range p.site.permissionSchemes {
+		if strconv.Itoa(scheme.ID) == p.site.assignments[PermissionSchemeKind] {
+			return scheme, nil, nil
+		}
+	}
+
+	return nil, nil, model.ErrNotFound
+}
+
+func (p *projectPermissionFake) Assign(ctx context.Context, projectKeyOrID string, permissionSchemeID int) (*model.PermissionSchemeScheme,
+	*model.ResponseScheme, error) {
+	p.site.record("project %v permission scheme %v", projectKeyOrID, permissionSchemeID)
+	return nil, nil, nil
+}
+
+type roleFake struct {
+	jira.ProjectRoleConnector
+	site *site
+}
+
+func (r *roleFake) Global(ctx context.Context) ([]*model.ProjectRoleScheme, *model.ResponseScheme, error) {
+	return r.site.roles, nil, nil
+}
+
+type issueTypeFake struct {
+	jira.TypeConnector
+	site *site
+}
+
+func (i *issueTypeFake) Gets(ctx context.Context) ([]*model.IssueTypeScheme, *model.ResponseScheme, error) {
+	return i.site.issueTypes, nil, nil
+}
+
+type screenFake struct {
+	jira.ScreenConnector
+	site *site
+}
+
+func (s *screenFake) Gets(ctx context.Context, options *model.ScreenParamsScheme, startAt, maxResults int) (*model.ScreenSearchPageScheme,
+	*model.ResponseScheme, error) {
+	return &model.ScreenSearchPageScheme{Values: s.site.screens, IsLast: true}, nil, nil
+}
+
+type fieldConfigFake struct {
+	jira.FieldConfigConnector
+	site *site
+}
+
+func (f *fieldConfigFake) Gets(ctx context.Context, ids []int, isDefault bool, startAt, maxResults int) (*model.FieldConfigurationPageScheme,
+	*model.ResponseScheme, error) {
+	return &model.FieldConfigurationPageScheme{Values: f.site.fieldConfigs, IsLast: true}, nil, nil
+}
+
+type fieldConfigSchemeFake struct {
+	jira.FieldConfigSchemeConnector
+	site *site
+}
+
+func (f *fieldConfigSchemeFake) Gets(ctx context.Context, ids []int, startAt, maxResults int) (*model.FieldConfigurationSchemePageScheme,
+	*model.ResponseScheme, error) {
+	return &model.FieldConfigurationSchemePageScheme{Values: f.site.fieldConfigurationSchemes, IsLast: true}, nil, nil
+}
+
+func (f *fieldConfigSchemeFake) Create(ctx context.Context, name, description string) (*model.FieldConfigurationSchemeScheme,
+	*model.ResponseScheme, error) {
+
+	id := f.site.id()
+	f.site.record("field configuration scheme %v created: %v", id, name)
+
+	return &model.FieldConfigurationSchemeScheme{ID: strconv.Itoa(id), Name: name, Description: description}, nil, nil
+}
+
+func (f *fieldConfigSchemeFake) Mapping(ctx context.Context, fieldConfigIDs []int, startAt, maxResults int) (*model.FieldConfigurationIssueTypeItemPageScheme,
+	*model.ResponseScheme, error) {
+
+	page := &model.FieldConfigurationIssueTypeItemPageScheme{IsLast: true}
+	for _, mapping := range f.site.fieldConfigurationMappings {
+		if mapping.FieldConfigurationSchemeID == strconv.Itoa(fieldConfigIDs[0]) {
+			page.Values = append(page.Values, mapping)
+		}
+	}
+
+	return page, nil, nil
+}
+
+func (f *fieldConfigSchemeFake) Project(ctx context.Context, projectIDs []int, startAt, maxResults int) (*model.FieldConfigurationSchemeProjectPageScheme,
+	*model.ResponseScheme, error) {
+
+	page := &model.FieldConfigurationSchemeProjectPageScheme{IsLast: true}
+	for _, scheme := range f.site.fieldConfigurationSchemes {
+		if scheme.ID == f.site.assignments[FieldConfigurationSchemeKind] {
+			page.Values = append(page.Values, &model.FieldConfigurationSchemeProjectScheme{ProjectIds: []string{f.site.project.ID}, FieldConfigurationScheme: scheme})
+		}
+	}
+
+	return page, nil, nil
+}
+
+func (f *fieldConfigSchemeFake) Assign(ctx context.Context, payload *model.FieldConfigurationSchemeAssignPayload) (*model.ResponseScheme, error) {
+	f.site.record("project %v field configuration scheme %v", payload.ProjectID, payload.FieldConfigurationSchemeID)
+	return nil, nil
+}
+
+func (f *fieldConfigSchemeFake) Link(ctx context.Context, schemeID int, payload *model.FieldConfigurationToIssueTypeMappingPayloadScheme) (*model.ResponseScheme, error) {
+
+	var mappings []string
+	for _, mapping := range payload.Mappings {
+		mappings = append(mappings, mapping.IssueTypeID+"="+mapping.FieldConfigurationID)
+	}
+
+	f.site.record("field configuration scheme %v linked: %v", schemeID, list(mappings))
+	return nil, nil
+}
+
+type screenSchemeFake struct {
+	jira.ScreenSchemeConnector
+	site *site
+}
+
+func (s *screenSchemeFake) Gets(ctx context.Context, options *model.ScreenSchemeParamsScheme, startAt, maxResults int) (*model.ScreenSchemePageScheme,
+	*model.ResponseScheme, error) {
+	return &model.ScreenSchemePageScheme{Values: s.site.screenSchemes, IsLast: true}, nil, nil
+}
+
+func (s *screenSchemeFake) Create(ctx context.Context, payload *model.ScreenSchemePayloadScheme) (*model.ScreenSchemeScheme,
+	*model.ResponseScheme, error) {
+
+	id := s.site.id()
+	s.site.record("screen scheme %v created: %v %+v", id, payload.Name, *payload.Screens)
+
+	return &model.ScreenSchemeScheme{ID: id}, nil, nil
+}
+
+func (s *screenSchemeFake) Update(ctx context.Context, screenSchemeID string, payload *model.ScreenSchemePayloadScheme) (*model.ResponseScheme, error) {
+	s.site.record("screen scheme %v updated: %+v", screenSchemeID, *payload.Screens)
+	return nil, nil
+}
+
+func (s *screenSchemeFake) Delete(ctx context.Context, screenSchemeID string) (*model.ResponseScheme, error) {
+	s.site.record("screen scheme %v deleted", screenSchemeID)
+	return nil, nil
+}
+
+type issueTypeSchemeFake struct {
+	jira.TypeSchemeConnector
+	site *site
+}
+
+func (i *issueTypeSchemeFake) Gets(ctx context.Context, issueTypeSchemeIDs []int, startAt, maxResults int) (*model.IssueTypeSchemePageScheme,
+	*model.ResponseScheme, error) {
+	return &model.IssueTypeSchemePageScheme{Values: i.site.issueTypeSchemes, IsLast: true}, nil, nil
+}
+
+func (i *issueTypeSchemeFake) Items(ctx context.Context, issueTypeSchemeIDs []int, startAt, maxResults int) (*model.IssueTypeSchemeItemPageScheme,
+	*model.ResponseScheme, error) {
+
+	page := &model.IssueTypeSchemeItemPageScheme{IsLast: true}
+	for _, item := range i.site.issueTypeSchemeItems {
+		if item.IssueTypeSchemeID == strconv.Itoa(issueTypeSchemeIDs[0]) {
+			page.Values = append(page.Values, item)
+		}
+	}
+
+	return page, nil, nil
+}
+
+func (i *issueTypeSchemeFake) Projects(ctx context.Context, projectIDs []int, startAt, maxResults int) (*model.ProjectIssueTypeSchemePageScheme,
+	*model.ResponseScheme, error) {
+
+	page := &model.ProjectIssueTypeSchemePageScheme{IsLast: true}
+	for _, scheme := range i.site.issueTypeSchemes {
+		if scheme.ID == i.site.assignments[IssueTypeSchemeKind] {
+			page.Values = append(page.Values, &model.IssueTypeSchemeProjectsScheme{IssueTypeScheme: scheme, ProjectIds: []string{i.site.project.ID}})
+		}
+	}
+
+	return page, nil, nil
+}
+
+func (i *issueTypeSchemeFake) Assign(ctx context.Context, issueTypeSchemeID, projectID string) (*model.ResponseScheme, error) {
+	i.site.record("project %v issue type scheme %v", projectID, issueTypeSchemeID)
+	return nil, nil
+}
+
+func (i *issueTypeSchemeFake) Update(ctx context.Context, issueTypeSchemeID int, payload *model.IssueTypeSchemePayloadScheme) (*model.ResponseScheme, error) {
+	i.site.record("issue type scheme %v updated: default %v", issueTypeSchemeID, payload.DefaultIssueTypeID)
+	return nil, nil
+}
+
+func (i *issueTypeSchemeFake) Append(ctx context.Context, issueTypeSchemeID int, issueTypeIDs []int) (*model.ResponseScheme, error) {
+	i.site.record("issue type scheme %v appended: %v", issueTypeSchemeID, issueTypeIDs)
+	return nil, nil
+}
+
+func (i *issueTypeSchemeFake) Remove(ctx context.Context, issueTypeSchemeID, issueTypeID int) (*model.ResponseScheme, error) {
+	i.site.record("issue type scheme %v removed: %v", issueTypeSchemeID, issueTypeID)
+	return nil, nil
+}
+
+type issueTypeScreenSchemeFake struct {
+	jira.TypeScreenSchemeConnector
+	site *site
+}
+
+func (i *issueTypeScreenSchemeFake) Gets(ctx context.Context, options *model.ScreenSchemeParamsScheme, startAt, maxResults int) (*model.IssueTypeScreenSchemePageScheme,
+	*model.ResponseScheme, error) {
+	return &model.IssueTypeScreenSchemePageScheme{Values: i.site.issueTypeScreenSchemes, IsLast: true}, nil, nil
+}
+
+func (i *issueTypeScreenSchemeFake) Create(ctx context.Context, payload *model.IssueTypeScreenSchemePayloadScheme) (*model.IssueTypeScreenScreenCreatedScheme,
+	*model.ResponseScheme, error) {
+
+	id := i.site.id()
+	i.site.record("issue type screen scheme %v created: %v", id, payload.Name)
+
+	return &model.IssueTypeScreenScreenCreatedScheme{ID: strconv.Itoa(id)}, nil, nil
+}
+
+func (i *issueTypeScreenSchemeFake) Assign(ctx context.Context, issueTypeScreenSchemeID, projectID string) (*model.ResponseScheme, error) {
+	i.site.record("project %v issue type screen scheme %v", projectID, issueTypeScreenSchemeID)
+	return nil, nil
+}
+
+func (i *issueTypeScreenSchemeFake) Projects(ctx context.Context, projectIDs []int, startAt, maxResults int) (*model.IssueTypeProjectScreenSchemePageScheme,
+	*model.ResponseScheme, error) {
+
+	page := &model.IssueTypeProjectScreenSchemePageScheme{IsLast: true}
+	for _, scheme := range i.site.issueTypeScreenSchemes {
+		if scheme.ID == i.site.assignments[IssueTypeScreenSchemeKind] {
+			page.Values = append(page.Values, &model.IssueTypeScreenSchemesProjectScheme{IssueTypeScreenScheme: scheme, ProjectIds: []string{i.site.project.ID}})
+		}
+	}
+
+	return page, nil, nil
+}
+
+func (i *issueTypeScreenSchemeFake) Mapping(ctx context.Context, issueTypeScreenSchemeIDs []int, startAt, maxResults int) (*model.IssueTypeScreenSchemeMappingScheme,
+	*model.ResponseScheme, error) {
+
+	page := &model.IssueTypeScreenSchemeMappingScheme{IsLast: true}
+	for _, item := range i.site.issueTypeScreenSchemeItems {
+		if item.IssueTypeScreenSchemeID == strconv.Itoa(issueTypeScreenSchemeIDs[0]) {
+			page.Values = append(page.Values, item)
+		}
+	}
+
+	return page, nil, nil
+}
+
+func (i *issueTypeScreenSchemeFake) Update(ctx context.Context, issueTypeScreenSchemeID, name, description string) (*model.ResponseScheme, error) {
+	i.site.record("issue type screen scheme %v updated: %v", issueTypeScreenSchemeID, name)
+	return nil, nil
+}
+
+func (i *issueTypeScreenSchemeFake) Append(ctx context.Context, issueTypeScreenSchemeID string, payload *model.IssueTypeScreenSchemePayloadScheme) (*model.ResponseScheme, error) {
+
+	var mappings []string
+	for _, mapping := range payload.IssueTypeMappings {
+		mappings = append(mappings, mapping.IssueTypeID+"="+mapping.ScreenSchemeID)
+	}
+
+	i.site.record("issue type screen scheme %v appended: %v", issueTypeScreenSchemeID, list(mappings))
+	return nil, nil
+}
+
+func (i *issueTypeScreenSchemeFake) UpdateDefault(ctx context.Context, issueTypeScreenSchemeID, screenSchemeID string) (*model.ResponseScheme, error) {
+	i.site.record("issue type screen scheme %v default: %v", issueTypeScreenSchemeID, screenSchemeID)
+	return nil, nil
+}
+
+func (i *issueTypeScreenSchemeFake) Remove(ctx context.Context, issueTypeScreenSchemeID string, issueTypeIDs []string) (*model.ResponseScheme, error) {
+	i.site.record("issue type screen scheme %v removed: %v", issueTypeScreenSchemeID, issueTypeIDs)
+	return nil, nil
+}
+
+type workflowSchemeFake struct {
+	jira.WorkflowSchemeConnector
+	site *site
+}
+
+func (w *workflowSchemeFake) Gets(ctx context.Context, startAt, maxResults int) (*model.WorkflowSchemePageScheme, *model.ResponseScheme, error) {
+	return &model.WorkflowSchemePageScheme{Values: w.site.workflowSchemes, IsLast: true}, nil, nil
+}
+
+func (w *workflowSchemeFake) Associations(ctx context.Context, projectIDs []int) (*model.WorkflowSchemeAssociationPageScheme, *model.ResponseScheme, error) {
+
+	page := &model.WorkflowSchemeAssociationPageScheme{}
+	for _, scheme := range w.site.workflowSchemes {
+		if strconv.Itoa(scheme.ID) == w.site.assignments[WorkflowSchemeKind] {
+			page.Values = append(page.Values, &model.WorkflowSchemeAssociationsScheme{ProjectIds: []string{w.site.project.ID}, WorkflowScheme: scheme})
+		}
+	}
+
+	return page, nil, nil
+}
+
+func (w *workflowSchemeFake) Update(ctx context.Context, schemeID int, payload *model.WorkflowSchemePayloadScheme) (*model.WorkflowSchemeScheme,
+	*model.ResponseScheme, error) {
+	w.site.record("workflow scheme %v updated: %v %v", schemeID, payload.DefaultWorkflow, payload.IssueTypeMappings)
+
+	// The schemes assigned to the project are active, their updates are saved on a draft.
+	active := strconv.Itoa(schemeID) == w.site.assignments[WorkflowSchemeKind]
+	return &model.WorkflowSchemeScheme{ID: schemeID, Draft: active && payload.UpdateDraftIfNeeded}, nil, nil
+}
+
+func (w *workflowSchemeFake) Publish(ctx context.Context, schemeID int, payload *model.WorkflowSchemePublishPayloadScheme,
+	validateOnly bool) (*model.TaskScheme, *model.ResponseScheme, error) {
+	w.site.record("workflow scheme %v draft published", schemeID)
+	return w.site.publication, nil, nil
+}
+
+func (w *workflowSchemeFake) Assign(ctx context.Context, schemeID, projectID string) (*model.ResponseScheme, error) {
+	w.site.record("project %v workflow scheme %v", projectID, schemeID)
+	return nil, nil
+}
+
+type permissionSchemeFake struct {
+	jira.PermissionSchemeConnector
+	site *site
+}
+
+func (p *permissionSchemeFake) Gets(ctx context.Context) (*model.PermissionSchemePageScheme, *model.ResponseScheme, error) {
+	return &model.PermissionSchemePageScheme{PermissionSchemes: p.site.permissionSchemes}, nil, nil
+}
+
+func (p *permissionSchemeFake) Get(ctx context.Context, permissionSchemeID int, expand []string) (*model.PermissionSchemeScheme,
+	*model.ResponseScheme, error) {
+
+	for _, scheme := range p.site.permissionSchemes {
+		if scheme.ID == permissionSchemeID {
+			return scheme, nil, nil
+		}
+	}
+
+	return nil, nil, model.ErrNotFound
+}
+
+func (p *permissionSchemeFake) Create(ctx context.Context, payload *model.PermissionSchemeScheme) (*model.PermissionSchemeScheme,
+	*model.ResponseScheme, error) {
+
+	id := p.site.id()
+	p.site.record("permission scheme %v created: %v", id, payload.Name)
+
+	return &model.PermissionSchemeScheme{ID: id}, nil, nil
+}
+
+func (p *permissionSchemeFake) Update(ctx context.Context, permissionSchemeID int, payload *model.PermissionSchemeScheme) (*model.PermissionSchemeScheme,
+	*model.ResponseScheme, error) {
+
+	var grants []string
+	for _, grant := range payload.Permissions {
+		grants = append(grants, grant.Permission+" "+grant.Holder.Type+":"+grant.Holder.Parameter)
+	}
+
+	p.site.record("permission scheme %v updated: %v", permissionSchemeID, list(grants))
+	return nil, nil, nil
+}
+
+func (p *permissionSchemeFake) Delete(ctx context.Context, permissionSchemeID int) (*model.ResponseScheme, error) {
+	p.site.record("permission scheme %v deleted", permissionSchemeID)
+	return nil, nil
+}
+
+type notificationSchemeFake struct {
+	jira.NotificationSchemeConnector
+	site *site
+}
+
+func (n *notificationSchemeFake) Search(ctx context.Context, options *model.NotificationSchemeSearchOptions, startAt, maxResults int) (*model.NotificationSchemePageScheme,
+	*model.ResponseScheme, error) {
+	return &model.NotificationSchemePageScheme{Values: n.site.notificationSchemes, IsLast: true}, nil, nil
+}
+
+func (n *notificationSchemeFake) Update(ctx context.Context, schemeID string, payload *model.NotificationSchemePayloadScheme) (*model.ResponseScheme, error) {
+	n.site.record("notification scheme %v updated: %v", schemeID, payload.Description)
+	return nil, nil
+}
+
+func (n *notificationSchemeFake) Append(ctx context.Context, schemeID string, payload *model.NotificationSchemeEventsPayloadScheme) (*model.ResponseScheme, error) {
+
+	var notifications []string
+	for _, event := range payload.NotificationSchemeEvents {
+		for _, notification := range event.Notifications {
+			notifications = append(notifications, event.Event.ID+" "+notification.NotificationType+":"+notification.Parameter)
+		}
+	}
+
+	n.site.record("notification scheme %v appended: %v", schemeID, list(notifications))
+	return nil, nil
+}
+
+func (n *notificationSchemeFake) Remove(ctx context.Context, schemeID, notificationID string) (*model.ResponseScheme, error) {
+	n.site.record("notification scheme %v removed: %v", schemeID, notificationID)
+	return nil, nil
+}
+
+func TestNewManager(t *testing.T) {
+
+	connectors := newSite().connectors()
+	connectors.NotificationScheme = nil
+
+	_, err := NewManager(connectors)
+	assert.EqualError(t, err, "schemes: no connector set: NotificationScheme")
+
+	_, err = NewManager(nil)
+	assert.ErrorIs(t, err, ErrNoConnectorError)
+}
+
+func TestExport(t *testing.T) {
+
+	manager, err := NewManager(newSite().connectors())
+	assert.NoError(t, err)
+
+	document, err := manager.Export(context.Background(), "KP")
+	assert.NoError(t, err)
+
+	buffer := new(bytes.Buffer)
+	assert.NoError(t, document.WriteYAML(buffer))
+
+	exported := buffer.String()
+	assert.Equal(t, `version: 1
+fieldConfigurationSchemes:
+  - name: KP Fields
+    mappings:
+      Bug: Bug Fields
+      default: Default Field Configuration
+screenSchemes:
+  - name: Bug Screens
+    screens:
+      default: Bug Screen
+      view: Default Screen
+  - name: KP Screens
+    screens:
+      default: Default Screen
+issueTypeSchemes:
+  - name: KP Types
+    defaultIssueType: Task
+    issueTypes:
+      - Bug
+      - Task
+issueTypeScreenSchemes:
+  - name: KP Screen Scheme
+    mappings:
+      Bug: Bug Screens
+      default: KP Screens
+workflowSchemes:
+  - name: KP Workflows
+    defaultWorkflow: jira
+    mappings:
+      Bug: Bug Workflow
+permissionSchemes:
+  - name: KP Permissions
+    grants:
+      - permission: BROWSE_PROJECTS
+        holder: projectRole
+        parameter: Developers
+      - permission: DELETE_ISSUES
+        holder: group
+        parameter: jira-admins
+notificationSchemes:
+  - name: KP Notifications
+    events:
+      - id: "1"
+        name: Issue created
+        notifications:
+          - type: CurrentAssignee
+          - type: ProjectRole
+            parameter: Developers
+projects:
+  - key: KP
+    fieldConfigurationScheme: KP Fields
+    issueTypeScheme: KP Types
+    issueTypeScreenScheme: KP Screen Scheme
+    workflowScheme: KP Workflows
+    permissionScheme: KP Permissions
+    notificationScheme: KP Notifications
+`, exported)
+
+	buffer.Reset()
+	assert.NoError(t, document.WriteJSON(buffer))
+
+	read, err := ReadDocument(buffer)
+	assert.NoError(t, err)
+
+	buffer.Reset()
+	assert.NoError(t, read.WriteYAML(buffer))
+	assert.Equal(t, exported, buffer.String())
+
+	schemes, err := manager.ExportSchemes(context.Background(), ScreenSchemeKind, "kp screens")
+	assert.NoError(t, err)
+	assert.Equal(t, []*ScreenScheme{{Name: "KP Screens", Screens: map[string]string{"default": "Default Screen"}}}, schemes.ScreenSchemes)
+
+	_, err = manager.ExportSchemes(context.Background(), ScreenSchemeKind, "Unknown")
+	assert.EqualError(t, err, `schemes: unknown name: screen scheme "Unknown"`)
+}
+
+func TestDriftAndApply(t *testing.T) {
+
+	source, err := NewManager(newSite().connectors())
+	assert.NoError(t, err)
+
+	desired, err := source.Export(context.Background(), "KP")
+	assert.NoError(t, err)
+
+	// The production site has no Bug Screens screen scheme, the PROD project uses other permission
+	// and notification schemes and the KP ones are outdated.
+	production := newSite()
+	production.project = &model.ProjectScheme{ID: "20000", Key: "PROD"}
+	production.screenSchemes = production.screenSchemes[:1]
+	production.issueTypeScreenSchemeItems = production.issueTypeScreenSchemeItems[:1]
+	production.issueTypeSchemeItems = production.issueTypeSchemeItems[1:]
+	production.permissionSchemes[0].Permissions = production.permissionSchemes[0].Permissions[1:]
+	production.permissionSchemes = append(production.permissionSchemes, &model.PermissionSchemeScheme{ID: 601, Name: "Default Permission Scheme"})
+	production.notificationSchemes[0].Description = "Outdated"
+	production.notificationSchemes[0].NotificationSchemeEvents[0].Notifications[1].NotificationType = "Reporter"
+	production.assignments[PermissionSchemeKind] = "601"
+
+	target, err := NewManager(production.connectors())
+	assert.NoError(t, err)
+
+	plan, err := target.Drift(context.Background(), desired, &CompareOptions{Projects: map[string]string{"KP": "PROD"}})
+	assert.NoError(t, err)
+
+	buffer := new(bytes.Buffer)
+	assert.NoError(t, plan.WriteDiff(buffer))
+	assert.Equal(t, `+ screen scheme "Bug Screens"
+    screens: "" -> "default: Bug Screen, view: Default Screen"
+~ issue type scheme "KP Types"
+    issueTypes: "Task" -> "Bug, Task"
+~ issue type screen scheme "KP Screen Scheme"
+    mappings: "default: KP Screens" -> "Bug: Bug Screens, default: KP Screens"
+~ permission scheme "KP Permissions"
+    grants: "BROWSE_PROJECTS projectRole:Developers" -> "BROWSE_PROJECTS projectRole:Developers, DELETE_ISSUES group:jira-admins"
+~ notification scheme "KP Notifications"
+    description: "Outdated" -> ""
+    events: "1 ProjectRole:Developers, 1 Reporter" -> "1 CurrentAssignee, 1 ProjectRole:Developers"
+~ project "PROD"
+    permission scheme: "Default Permission Scheme" -> "KP Permissions"
+`, buffer.String())
+
+	results, err := target.Apply(context.Background(), plan)
+	assert.NoError(t, err)
+	assert.Len(t, results, 6)
+
+	assert.Equal(t, []string{
+		"screen scheme 901 created: Bug Screens {Create:0 Default:11 View:10 Edit:0}",
+		"issue type scheme 300 appended: [1]",
+		"issue type scheme 300 updated: default 2",
+		"issue type screen scheme 400 updated: KP Screen Scheme",
+		"issue type screen scheme 400 appended: 1=901",
+		"permission scheme 600 updated: BROWSE_PROJECTS projectRole:30, DELETE_ISSUES group:jira-admins",
+		"notification scheme 700 updated: ",
+		"notification scheme 700 removed: 7002",
+		"notification scheme 700 appended: 1 CurrentAssignee:",
+		"project PROD permission scheme 600",
+	}, production.calls)
+
+	plan, err = source.Drift(context.Background(), desired, nil)
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+}
+
+func TestApply_WorkflowSchemeDraft(t *testing.T) {
+
+	desired := &Document{
+		WorkflowSchemes: []*WorkflowScheme{{Name: "KP Workflows", DefaultWorkflow: "Software Simplified", Mappings: map[string]string{"Bug": "Bug Workflow"}}},
+	}
+
+	production := newSite()
+
+	manager, err := NewManager(production.connectors())
+	assert.NoError(t, err)
+
+	plan, err := manager.Drift(context.Background(), desired, nil)
+	assert.NoError(t, err)
+
+	// The scheme is used by the project, the draft created by the update is published.
+	_, err = manager.Apply(context.Background(), plan)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"workflow scheme 500 updated: Software Simplified map[1:Bug Workflow]",
+		"workflow scheme 500 draft published",
+	}, production.calls)
+
+	t.Run("when the issues are migrated by a task", func(t *testing.T) {
+
+		production := newSite()
+		production.publication = &model.TaskScheme{ID: "10641", Status: "RUNNING"}
+
+		manager, err := NewManager(production.connectors())
+		assert.NoError(t, err)
+
+		results, err := manager.Apply(context.Background(), plan)
+		assert.ErrorIs(t, err, ErrApplyError)
+		assert.ErrorIs(t, results[0].Err, ErrPendingError)
+		assert.EqualError(t, results[0].Err, `schemes: change pending: the draft of the workflow scheme "KP Workflows" is published by the task 10641, running`)
+	})
+}
+
+func TestManager_Delete(t *testing.T) {
+
+	production := newSite()
+
+	manager, err := NewManager(production.connectors())
+	assert.NoError(t, err)
+
+	assert.NoError(t, manager.Delete(context.Background(), ScreenSchemeKind, "Bug Screens"))
+	assert.NoError(t, manager.Delete(context.Background(), PermissionSchemeKind, "kp permissions"))
+	assert.Equal(t, []string{
+		"screen scheme 201 deleted",
+		"permission scheme 600 deleted",
+	}, production.calls)
+
+	// The deleted schemes are removed from the catalog.
+	err = manager.Delete(context.Background(), PermissionSchemeKind, "KP Permissions")
+	assert.ErrorIs(t, err, ErrUnknownNameError)
+
+	err = manager.Delete(context.Background(), Kind("board"), "KP Board")
+	assert.ErrorIs(t, err, ErrUnknownNameError)
+}
+
+func TestCompare(t *testing.T) {
+
+	current := &Document{WorkflowSchemes: []*WorkflowScheme{{Name: "Software", DefaultWorkflow: "jira"}}}
+	desired := &Document{
+		WorkflowSchemes: []*WorkflowScheme{{Name: "software", DefaultWorkflow: "Software Simplified"}},
+		Projects:        []*Project{{Key: "KP", WorkflowScheme: "Software"}},
+	}
+
+	plan, err := Compare(current, desired, &CompareOptions{Projects: map[string]string{"KP": "NEW"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []*Difference{{Attribute: "defaultWorkflow", Current: "jira", Desired: "Software Simplified"}}, plan.Changes[0].Differences)
+	assert.Equal(t, UpdateAction, plan.Changes[0].Action)
+	assert.Equal(t, "NEW", plan.Changes[1].Name)
+	assert.Equal(t, []*Difference{{Attribute: "workflow scheme", Desired: "Software"}}, plan.Changes[1].Differences)
+	assert.Equal(t, "KP", desired.Projects[0].Key)
+
+	desired.WorkflowSchemes = append(desired.WorkflowSchemes, desired.WorkflowSchemes[0])
+	_, err = Compare(current, desired, nil)
+	assert.EqualError(t, err, `schemes: duplicated name: workflow scheme "software"`)
+
+	_, err = Compare(nil, desired, nil)
+	assert.ErrorIs(t, err, ErrNoDocumentError)
+}
+
+func TestReadDocument(t *testing.T) {
+
+	document, err := ReadDocument(strings.NewReader(`
+version: 1
+notificationSchemes:
+  - name: Default
+    events:
+      - id: "10"
+        notifications: [{type: Reporter}]
+      - id: "2"
+        notifications: [{type: Group, parameter: jira-users}, {type: CurrentAssignee}]
+`))
+	assert.NoError(t, err)
+
+	events := document.NotificationSchemes[0].Events
+	assert.Equal(t, "2", events[0].ID)
+	assert.Equal(t, "CurrentAssignee", events[0].Notifications[0].Type)
+	assert.Equal(t, "10", events[1].ID)
+}
@@ -55,6 +55,7 @@ func New(httpClient common.HttpClient, site string) (*Client, error) {
 		SLA:         internal.NewServiceLevelAgreementService(client, defaultServiceManagementVersion),
 		Feedback:    internal.NewFeedbackService(client, defaultServiceManagementVersion),
 		Type:        internal.NewTypeService(client, defaultServiceManagementVersion),
+		Submitter:   internal.NewRequestSubmitterService(client, defaultServiceManagementVersion),
 	}
 
 	requestService, err := internal.NewRequestService(client, defaultServiceManagementVersion, requestSubServices)
@@ -87,6 +88,8 @@ type Client struct {
 	Request       *internal.RequestService
 	ServiceDesk   *internal.ServiceDeskService
 	WorkSpace     *internal.WorkSpaceService
+
+	middlewares []common.Middleware
 }
 
 func (c *Client) NewRequest(ctx context.Context, method, urlStr, type_ string, body interface{}) (*http.Request, error) {
@@ -147,9 +150,16 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr, type_ string, b
 	return req, nil
 }
 
+// Use appends middlewares to the pipeline the requests are sent through, e.g: to log or trace them.
+//
+// The middlewares must be configured before the client is used.
+func (c *Client) Use(middlewares ...common.Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
 func (c *Client) Call(request *http.Request, structure interface{}) (*model.ResponseScheme, error) {
 
-	response, err := c.HTTP.Do(request)
+	response, err := common.Chain(c.HTTP, c.middlewares...)(request)
 	if err != nil {
 		return nil, err
 	}
@@ -5,10 +5,14 @@ import (
 	"fmt"
 	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
 	"github.com/ctreminiom/go-atlassian/service"
+	"github.com/ctreminiom/go-atlassian/service/confluence"
 	"github.com/ctreminiom/go-atlassian/service/sm"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"unicode"
 )
 
 func NewKnowledgebaseService(client service.Connector, version string) *KnowledgebaseService {
@@ -40,6 +44,46 @@ func (k *KnowledgebaseService) Gets(ctx context.Context, serviceDeskID int, quer
 	return k.internalClient.Gets(ctx, serviceDeskID, query, highlight, start, limit)
 }
 
+// Article returns a knowledge base article with its Confluence page.
+//
+// The page is fetched through the Confluence page connector provided, e.g: the Page service of the Confluence v2 client.
+//
+// GET /wiki/api/v2/pages/{id}
+func (k *KnowledgebaseService) Article(ctx context.Context, pages confluence.PageConnector, article *model.ArticleScheme, format string) (*model.KnowledgeBaseArticleScheme, *model.ResponseScheme, error) {
+	return k.internalClient.Article(ctx, pages, article, format)
+}
+
+// Suggest returns the articles of a service desk relevant for a customer request summary, ordered by relevance.
+//
+// The Confluence page of each article is included when the Confluence page connector is provided, the
+// error of the pages that can't be fetched is set on the PageErr of their suggestion.
+//
+// GET /rest/servicedeskapi/servicedesk/{serviceDeskId}/knowledgebase/article
+func (k *KnowledgebaseService) Suggest(ctx context.Context, pages confluence.PageConnector, serviceDeskID int, summary string, limit int) ([]*model.KnowledgeBaseSuggestionScheme, *model.ResponseScheme, error) {
+	return k.internalClient.Suggest(ctx, pages, serviceDeskID, summary, limit)
+}
+
+// ExperimentalLinkSpace links a Confluence space to a service desk as its knowledge base, the application link
+// identifies the Confluence site and it's only required when the site has several Confluence links.
+//
+// Experimental: the endpoint is the one used by the knowledge base settings of the project, it isn't part
+// of the published REST API, it isn't supported by Atlassian and may change or be removed without notice.
+//
+// POST /rest/servicedesk/knowledgebase/latest/servicedesk/{serviceDeskId}/linkedspace
+func (k *KnowledgebaseService) ExperimentalLinkSpace(ctx context.Context, serviceDeskID int, payload *model.KnowledgeBaseSpaceLinkScheme) (*model.ResponseScheme, error) {
+	return k.internalClient.ExperimentalLinkSpace(ctx, serviceDeskID, payload)
+}
+
+// ExperimentalUnlinkSpace unlinks the Confluence space linked to a service desk as its knowledge base.
+//
+// Experimental: the endpoint is the one used by the knowledge base settings of the project, it isn't part
+// of the published REST API, it isn't supported by Atlassian and may change or be removed without notice.
+//
+// DELETE /rest/servicedesk/knowledgebase/latest/servicedesk/{serviceDeskId}/linkedspace
+func (k *KnowledgebaseService) ExperimentalUnlinkSpace(ctx context.Context, serviceDeskID int) (*model.ResponseScheme, error) {
+	return k.internalClient.ExperimentalUnlinkSpace(ctx, serviceDeskID)
+}
+
 type internalKnowledgebaseImpl struct {
 	c       service.Connector
 	version string
@@ -104,3 +148,160 @@ func (i *internalKnowledgebaseImpl) Gets(ctx context.Context, serviceDeskID int,
 
 	return page, res, nil
 }
+
+func (i *internalKnowledgebaseImpl) Article(ctx context.Context, pages confluence.PageConnector, article *model.ArticleScheme, format string) (*model.KnowledgeBaseArticleScheme, *model.ResponseScheme, error) {
+
+	if pages == nil {
+		return nil, nil, model.ErrNoKBPageConnectorError
+	}
+
+	if article == nil {
+		return nil, nil, model.ErrNoKBArticleError
+	}
+
+	if article.Source == nil || article.Source.PageID == "" {
+		return nil, nil, model.ErrNoKBArticlePageError
+	}
+
+	pageID, err := strconv.Atoi(article.Source.PageID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page, res, err := pages.Get(ctx, pageID, format, false, 0)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &model.KnowledgeBaseArticleScheme{Article: article, Page: page}, res, nil
+}
+
+func (i *internalKnowledgebaseImpl) Suggest(ctx context.Context, pages confluence.PageConnector, serviceDeskID int, summary string, limit int) ([]*model.KnowledgeBaseSuggestionScheme, *model.ResponseScheme, error) {
+
+	keywords := articleKeywords(summary)
+	if len(keywords) == 0 {
+		return nil, nil, model.ErrNoKBQueryError
+	}
+
+	articles, res, err := i.Gets(ctx, serviceDeskID, strings.Join(keywords, " "), false, 0, limit)
+	if err != nil {
+		return nil, res, err
+	}
+
+	var suggestions []*model.KnowledgeBaseSuggestionScheme
+	for _, article := range articles.Values {
+
+		suggestion := &model.KnowledgeBaseSuggestionScheme{
+			Article: article,
+			Score:   articleScore(article, keywords),
+		}
+
+		if pages != nil && article.Source != nil && article.Source.PageID != "" {
+
+			// The pages that can't be fetched, e.g: removed or restricted, don't discard the other suggestions.
+			content, _, err := i.Article(ctx, pages, article, "")
+			if err != nil {
+				suggestion.PageErr = err
+			} else {
+				suggestion.Page = content.Page
+			}
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	// The articles are already sorted by the search relevance, the stable sort keeps that
+	// order for the articles with the same score.
+	sort.SliceStable(suggestions, func(x, y int) bool {
+		return suggestions[x].Score > suggestions[y].Score
+	})
+
+	return suggestions, res, nil
+}
+
+func (i *internalKnowledgebaseImpl) ExperimentalLinkSpace(ctx context.Context, serviceDeskID int, payload *model.KnowledgeBaseSpaceLinkScheme) (*model.ResponseScheme, error) {
+
+	if serviceDeskID == 0 {
+		return nil, model.ErrNoServiceDeskIDError
+	}
+
+	if payload == nil || payload.SpaceKey == "" {
+		return nil, model.ErrNoKBSpaceKeyError
+	}
+
+	endpoint := fmt.Sprintf("rest/servicedesk/knowledgebase/latest/servicedesk/%v/linkedspace", serviceDeskID)
+
+	req, err := i.c.NewRequest(ctx, http.MethodPost, endpoint, "", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.c.Call(req, nil)
+}
+
+func (i *internalKnowledgebaseImpl) ExperimentalUnlinkSpace(ctx context.Context, serviceDeskID int) (*model.ResponseScheme, error) {
+
+	if serviceDeskID == 0 {
+		return nil, model.ErrNoServiceDeskIDError
+	}
+
+	endpoint := fmt.Sprintf("rest/servicedesk/knowledgebase/latest/servicedesk/%v/linkedspace", serviceDeskID)
+
+	req, err := i.c.NewRequest(ctx, http.MethodDelete, endpoint, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.c.Call(req, nil)
+}
+
+var articleStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "not": true, "can": true, "cannot": true,
+	"how": true, "why": true, "what": true, "when": true, "from": true, "this": true, "that": true,
+	"are": true, "was": true, "has": true, "have": true, "does": true, "doesn": true, "after": true,
+	"into": true, "please": true, "help": true, "need": true, "issue": true, "problem": true,
+}
+
+// articleKeywords returns the lower-cased words of a summary, without stop words and duplicates.
+func articleKeywords(summary string) []string {
+
+	words := strings.FieldsFunc(strings.ToLower(summary), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	seen := make(map[string]bool)
+
+	var keywords []string
+	for _, word := range words {
+
+		if len(word) < 3 || articleStopWords[word] || seen[word] {
+			continue
+		}
+
+		seen[word] = true
+		keywords = append(keywords, word)
+	}
+
+	return keywords
+}
+
+// articleScore returns the share of keywords found on the article, the keywords found
+// on the title weight twice the keywords found on the excerpt.
+func articleScore(article *model.ArticleScheme, keywords []string) float64 {
+
+	title, excerpt := strings.ToLower(article.Title), strings.ToLower(article.Excerpt)
+
+	var score float64
+	for _, keyword := range keywords {
+
+		if strings.Contains(title, keyword) {
+			score += 2
+		}
+
+		if strings.Contains(excerpt, keyword) {
+			score++
+		}
+	}
+
+	return score / float64(3*len(keywords))
+}
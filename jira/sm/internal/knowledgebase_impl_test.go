@@ -5,8 +5,10 @@ import (
 	"errors"
 	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
 	"github.com/ctreminiom/go-atlassian/service"
+	"github.com/ctreminiom/go-atlassian/service/confluence"
 	"github.com/ctreminiom/go-atlassian/service/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"net/http"
 	"testing"
 )
@@ -330,3 +332,521 @@ func Test_internalKnowledgebaseImpl_Gets(t *testing.T) {
 		})
 	}
 }
+
+type pageConnectorFake struct {
+	confluence.PageConnector
+	pages map[int]*model.PageScheme
+}
+
+func (p *pageConnectorFake) Get(ctx context.Context, pageID int, format string, draft bool, version int) (*model.PageScheme, *model.ResponseScheme, error) {
+
+	page, ok := p.pages[pageID]
+	if !ok {
+		return nil, &model.ResponseScheme{Code: http.StatusNotFound}, model.ErrNotFound
+	}
+
+	return page, &model.ResponseScheme{Code: http.StatusOK}, nil
+}
+
+func Test_internalKnowledgebaseImpl_Article(t *testing.T) {
+
+	pages := &pageConnectorFake{pages: map[int]*model.PageScheme{10001: {ID: "10001", Title: "Reset your VPN token"}}}
+
+	type args struct {
+		ctx     context.Context
+		pages   confluence.PageConnector
+		article *model.ArticleScheme
+	}
+
+	testCases := []struct {
+		name    string
+		args    args
+		wantErr bool
+		Err     error
+	}{
+		{
+			name: "when the parameters are correct",
+			args: args{
+				ctx:     context.Background(),
+				pages:   pages,
+				article: &model.ArticleScheme{Title: "Reset your VPN token", Source: &model.ArticleSourceScheme{PageID: "10001"}},
+			},
+		},
+
+		{
+			name: "when the page does not exist",
+			args: args{
+				ctx:     context.Background(),
+				pages:   pages,
+				article: &model.ArticleScheme{Source: &model.ArticleSourceScheme{PageID: "10002"}},
+			},
+			wantErr: true,
+			Err:     model.ErrNotFound,
+		},
+
+		{
+			name: "when the article has no confluence page",
+			args: args{
+				ctx:     context.Background(),
+				pages:   pages,
+				article: &model.ArticleScheme{Source: &model.ArticleSourceScheme{Type: "confluence"}},
+			},
+			wantErr: true,
+			Err:     model.ErrNoKBArticlePageError,
+		},
+
+		{
+			name: "when the page connector is not provided",
+			args: args{
+				ctx:     context.Background(),
+				article: &model.ArticleScheme{},
+			},
+			wantErr: true,
+			Err:     model.ErrNoKBPageConnectorError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			newService := NewKnowledgebaseService(nil, "latest")
+
+			gotResult, gotResponse, err := newService.Article(testCase.args.ctx, testCase.args.pages, testCase.args.article, "storage")
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.Equal(t, "10001", gotResult.Page.ID)
+				assert.Equal(t, testCase.args.article, gotResult.Article)
+			}
+		})
+	}
+}
+
+func Test_internalKnowledgebaseImpl_Suggest(t *testing.T) {
+
+	type fields struct {
+		c service.Connector
+	}
+
+	type args struct {
+		ctx           context.Context
+		pages         confluence.PageConnector
+		serviceDeskID int
+		summary       string
+		limit         int
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		want    []string
+		wantErr bool
+		Err     error
+	}{
+		{
+			name: "when the parameters are correct",
+			args: args{
+				ctx: context.Background(),
+				pages: &pageConnectorFake{pages: map[int]*model.PageScheme{
+					10001: {ID: "10001"},
+					10002: {ID: "10002"},
+				}},
+				serviceDeskID: 1,
+				summary:       "The VPN token cannot be reset!",
+				limit:         10,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/servicedeskapi/servicedesk/1/knowledgebase/article?highlight=false&limit=10&query=vpn+token+reset&start=0",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.ArticlePageScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.ArticlePageScheme).Values = []*model.ArticleScheme{
+							{Title: "Request a VPN access", Source: &model.ArticleSourceScheme{PageID: "10002"}},
+							{Title: "Reset your VPN token", Excerpt: "The token can be reset",
+								Source: &model.ArticleSourceScheme{PageID: "10001"}},
+						}
+					}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+			want: []string{"10001", "10002"},
+		},
+
+		{
+			name: "when a page of the articles cannot be fetched",
+			args: args{
+				ctx:           context.Background(),
+				pages:         &pageConnectorFake{pages: map[int]*model.PageScheme{10001: {ID: "10001"}}},
+				serviceDeskID: 1,
+				summary:       "The VPN token cannot be reset!",
+				limit:         10,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/servicedeskapi/servicedesk/1/knowledgebase/article?highlight=false&limit=10&query=vpn+token+reset&start=0",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.ArticlePageScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.ArticlePageScheme).Values = []*model.ArticleScheme{
+							{Title: "Request a VPN access", Source: &model.ArticleSourceScheme{PageID: "10002"}},
+							{Title: "Reset your VPN token", Excerpt: "The token can be reset",
+								Source: &model.ArticleSourceScheme{PageID: "10001"}},
+						}
+					}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+			want: []string{"10001", model.ErrNotFound.Error()},
+		},
+
+		{
+			name: "when the summary has no keywords",
+			args: args{
+				ctx:           context.Background(),
+				serviceDeskID: 1,
+				summary:       "Please help!",
+			},
+			wantErr: true,
+			Err:     model.ErrNoKBQueryError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			newService := NewKnowledgebaseService(testCase.fields.c, "latest")
+
+			gotResult, _, err := newService.Suggest(testCase.args.ctx, testCase.args.pages, testCase.args.serviceDeskID,
+				testCase.args.summary, testCase.args.limit)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+			} else {
+
+				assert.NoError(t, err)
+
+				var pageIDs []string
+				for _, suggestion := range gotResult {
+
+					if suggestion.PageErr != nil {
+						pageIDs = append(pageIDs, suggestion.PageErr.Error())
+						continue
+					}
+
+					pageIDs = append(pageIDs, suggestion.Page.ID)
+				}
+
+				assert.Equal(t, testCase.want, pageIDs)
+			}
+		})
+	}
+}
+
+func Test_internalKnowledgebaseImpl_LinkSpace(t *testing.T) {
+
+	payloadMocked := &model.KnowledgeBaseSpaceLinkScheme{
+		ApplicationLinkID: "a2a1f5d5-0a3e-3b6b-9c55-3a9a8d1c6f4e",
+		SpaceKey:          "KB",
+	}
+
+	type fields struct {
+		c service.Connector
+	}
+
+	type args struct {
+		ctx           context.Context
+		serviceDeskID int
+		payload       *model.KnowledgeBaseSpaceLinkScheme
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name: "when the parameters are correct",
+			args: args{
+				ctx:           context.Background(),
+				serviceDeskID: 10001,
+				payload:       payloadMocked,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/servicedesk/knowledgebase/latest/servicedesk/10001/linkedspace",
+					"",
+					payloadMocked).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					nil).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name: "when the http call cannot be executed",
+			args: args{
+				ctx:           context.Background(),
+				serviceDeskID: 10001,
+				payload:       payloadMocked,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/servicedesk/knowledgebase/latest/servicedesk/10001/linkedspace",
+					"",
+					payloadMocked).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					nil).
+					Return(&model.ResponseScheme{}, errors.New("client: no http response found"))
+
+				fields.c = client
+			},
+			Err:     errors.New("client: no http response found"),
+			wantErr: true,
+		},
+
+		{
+			name: "when the request cannot be created",
+			args: args{
+				ctx:           context.Background(),
+				serviceDeskID: 10001,
+				payload:       payloadMocked,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/servicedesk/knowledgebase/latest/servicedesk/10001/linkedspace",
+					"",
+					payloadMocked).
+					Return(&http.Request{}, errors.New("client: no http request created"))
+
+				fields.c = client
+			},
+			Err:     errors.New("client: no http request created"),
+			wantErr: true,
+		},
+
+		{
+			name: "when the service desk id is not provided",
+			args: args{
+				ctx:     context.Background(),
+				payload: payloadMocked,
+			},
+			wantErr: true,
+			Err:     model.ErrNoServiceDeskIDError,
+		},
+
+		{
+			name: "when the space key is not provided",
+			args: args{
+				ctx:           context.Background(),
+				serviceDeskID: 10001,
+				payload:       &model.KnowledgeBaseSpaceLinkScheme{},
+			},
+			wantErr: true,
+			Err:     model.ErrNoKBSpaceKeyError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			baseService := NewKnowledgebaseService(testCase.fields.c, "latest")
+
+			gotResponse, err := baseService.ExperimentalLinkSpace(testCase.args.ctx, testCase.args.serviceDeskID, testCase.args.payload)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+			}
+		})
+	}
+}
+
+func Test_internalKnowledgebaseImpl_UnlinkSpace(t *testing.T) {
+
+	type fields struct {
+		c service.Connector
+	}
+
+	type args struct {
+		ctx           context.Context
+		serviceDeskID int
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name: "when the parameters are correct",
+			args: args{
+				ctx:           context.Background(),
+				serviceDeskID: 10001,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodDelete,
+					"rest/servicedesk/knowledgebase/latest/servicedesk/10001/linkedspace",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					nil).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name: "when the request cannot be created",
+			args: args{
+				ctx:           context.Background(),
+				serviceDeskID: 10001,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodDelete,
+					"rest/servicedesk/knowledgebase/latest/servicedesk/10001/linkedspace",
+					"",
+					nil).
+					Return(&http.Request{}, errors.New("client: no http request created"))
+
+				fields.c = client
+			},
+			Err:     errors.New("client: no http request created"),
+			wantErr: true,
+		},
+
+		{
+			name: "when the service desk id is not provided",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+			Err:     model.ErrNoServiceDeskIDError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			baseService := NewKnowledgebaseService(testCase.fields.c, "latest")
+
+			gotResponse, err := baseService.ExperimentalUnlinkSpace(testCase.args.ctx, testCase.args.serviceDeskID)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ type ServiceRequestSubServices struct {
 	SLA         *ServiceLevelAgreementService
 	Feedback    *FeedbackService
 	Type        *TypeService
+	Submitter   *RequestSubmitterService
 }
 
 func NewRequestService(client service.Connector, version string, subServices *ServiceRequestSubServices) (*RequestService, error) {
@@ -40,6 +41,7 @@ func NewRequestService(client service.Connector, version string, subServices *Se
 		requestService.SLA = subServices.SLA
 		requestService.Feedback = subServices.Feedback
 		requestService.Type = subServices.Type
+		requestService.Submitter = subServices.Submitter
 
 	}
 
@@ -55,6 +57,7 @@ type RequestService struct {
 	SLA            *ServiceLevelAgreementService
 	Feedback       *FeedbackService
 	Type           *TypeService
+	Submitter      *RequestSubmitterService
 }
 
 // Create creates a customer request in a service desk.
@@ -0,0 +1,187 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service"
+	"github.com/ctreminiom/go-atlassian/service/sm"
+	"strconv"
+)
+
+// attachmentFieldID is the request type field the temporary files are sent on when the request is created.
+const attachmentFieldID = "attachment"
+
+func NewRequestSubmitterService(client service.Connector, version string) *RequestSubmitterService {
+
+	return &RequestSubmitterService{
+		internalClient: &internalRequestSubmitterImpl{
+			requestType: &internalTypeImpl{c: client, version: version},
+			serviceDesk: &internalServiceDeskImpl{c: client, version: version},
+			request:     &internalServiceRequestImpl{c: client, version: version},
+			attachment:  &internalServiceRequestAttachmentImpl{c: client, version: version},
+		},
+	}
+}
+
+type RequestSubmitterService struct {
+	internalClient sm.RequestSubmitterConnector
+}
+
+// Submit creates a customer request with its attachments.
+//
+// The payload is validated against the request type fields and the attachments are uploaded as temporary files.
+//
+// When the request type has an attachment field, the temporary files are sent on it when the customer request
+// is created, otherwise they're attached to the customer request once it's created.
+//
+// The result is returned even if one of the steps fails, describing the steps already completed.
+//
+// GET /rest/servicedeskapi/servicedesk/{serviceDeskId}/requesttype/{requestTypeId}/field
+//
+// POST /rest/servicedeskapi/servicedesk/{serviceDeskId}/attachTemporaryFile
+//
+// POST /rest/servicedeskapi/request
+//
+// POST /rest/servicedeskapi/request/{issueIdOrKey}/attachment
+func (r *RequestSubmitterService) Submit(ctx context.Context, payload *model.CreateCustomerRequestPayloadScheme, options *model.RequestSubmissionOptionsScheme) (*model.RequestSubmissionResultScheme, *model.ResponseScheme, error) {
+	return r.internalClient.Submit(ctx, payload, options)
+}
+
+type internalRequestSubmitterImpl struct {
+	requestType sm.TypeConnector
+	serviceDesk sm.ServiceDeskConnector
+	request     sm.RequestConnector
+	attachment  sm.AttachmentConnector
+}
+
+func (i *internalRequestSubmitterImpl) Submit(ctx context.Context, payload *model.CreateCustomerRequestPayloadScheme, options *model.RequestSubmissionOptionsScheme) (*model.RequestSubmissionResultScheme, *model.ResponseScheme, error) {
+
+	if payload == nil {
+		return nil, nil, model.ErrNoCustomRequestFieldsError
+	}
+
+	if options == nil {
+		options = &model.RequestSubmissionOptionsScheme{}
+	}
+
+	serviceDeskID, err := strconv.Atoi(payload.ServiceDeskID)
+	if err != nil || serviceDeskID == 0 {
+		return nil, nil, model.ErrNoServiceDeskIDError
+	}
+
+	requestTypeID, err := strconv.Atoi(payload.RequestTypeID)
+	if err != nil || requestTypeID == 0 {
+		return nil, nil, model.ErrNoRequestTypeIDError
+	}
+
+	for _, attachment := range options.Attachments {
+
+		if attachment.FileName == "" {
+			return nil, nil, model.ErrNoFileNameError
+		}
+
+		if attachment.Reader == nil {
+			return nil, nil, model.ErrNoFileReaderError
+		}
+	}
+
+	result := &model.RequestSubmissionResultScheme{Stage: model.RequestSubmissionValidationStage}
+
+	var attachmentField bool
+	if !options.SkipValidation {
+
+		fields, response, err := i.requestType.Fields(ctx, serviceDeskID, requestTypeID)
+		if err != nil {
+			return result, response, submissionError(result, err)
+		}
+
+		values := payload.RequestFieldValues
+		for _, field := range fields.RequestTypeFields {
+			attachmentField = attachmentField || field.FieldID == attachmentFieldID
+		}
+
+		// The attachments are validated as the value of the attachment field, they're sent on it.
+		if attachmentField && len(options.Attachments) != 0 {
+
+			values = make(map[string]interface{}, len(payload.RequestFieldValues)+1)
+			for key, value := range payload.RequestFieldValues {
+				values[key] = value
+			}
+
+			fileNames := make([]string, 0, len(options.Attachments))
+			for _, attachment := range options.Attachments {
+				fileNames = append(fileNames, attachment.FileName)
+			}
+
+			values[attachmentFieldID] = fileNames
+		}
+
+		if err = fields.Validate(values); err != nil {
+			return result, nil, submissionError(result, err)
+		}
+	}
+
+	result.Stage = model.RequestSubmissionUploadStage
+
+	for _, attachment := range options.Attachments {
+
+		files, response, err := i.serviceDesk.Attach(ctx, serviceDeskID, attachment.FileName, attachment.Reader)
+		if err != nil {
+			return result, response, submissionError(result, err)
+		}
+
+		for _, file := range files.TemporaryAttachments {
+			result.TemporaryAttachmentIDs = append(result.TemporaryAttachmentIDs, file.TemporaryAttachmentID)
+		}
+	}
+
+	result.Stage = model.RequestSubmissionCreationStage
+
+	if attachmentField && len(result.TemporaryAttachmentIDs) != 0 {
+
+		// The payload of the caller isn't modified, the temporary files are set on a copy.
+		values := make(map[string]interface{}, len(payload.RequestFieldValues)+1)
+		for key, value := range payload.RequestFieldValues {
+			values[key] = value
+		}
+
+		values[attachmentFieldID] = result.TemporaryAttachmentIDs
+
+		withAttachments := *payload
+		withAttachments.RequestFieldValues = values
+		payload = &withAttachments
+	}
+
+	request, response, err := i.request.Create(ctx, payload)
+	if err != nil {
+		return result, response, submissionError(result, err)
+	}
+
+	result.Request = request
+
+	if attachmentField || len(result.TemporaryAttachmentIDs) == 0 {
+		return result, response, nil
+	}
+
+	result.Stage = model.RequestSubmissionAttachmentStage
+
+	attachments, response, err := i.attachment.Create(ctx, request.IssueKey, result.TemporaryAttachmentIDs, options.Public)
+	if err != nil {
+		return result, response, submissionError(result, err)
+	}
+
+	result.Attachments = attachments
+
+	return result, response, nil
+}
+
+func submissionError(result *model.RequestSubmissionResultScheme, err error) error {
+
+	if result.Request != nil {
+		return fmt.Errorf("sm: request %v created, but the submission failed at the %v stage: %w",
+			result.Request.IssueKey, result.Stage, err)
+	}
+
+	return fmt.Errorf("sm: request submission failed at the %v stage: %w", result.Stage, err)
+}
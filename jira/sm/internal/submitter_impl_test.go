@@ -0,0 +1,429 @@
+package internal
+
+import (
+	"context"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service"
+	"github.com/ctreminiom/go-atlassian/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func Test_internalRequestSubmitterImpl_Submit(t *testing.T) {
+
+	payloadMocked := &model.CreateCustomerRequestPayloadScheme{
+		RequestTypeID: "2",
+		ServiceDeskID: "1",
+		RequestFieldValues: map[string]interface{}{
+			"summary":           "Laptop screen broken",
+			"customfield_10010": map[string]interface{}{"id": "10001"},
+		},
+	}
+
+	type fields struct {
+		c service.Connector
+	}
+
+	type args struct {
+		ctx     context.Context
+		payload *model.CreateCustomerRequestPayloadScheme
+		options *model.RequestSubmissionOptionsScheme
+	}
+
+	testCases := []struct {
+		name      string
+		fields    fields
+		args      args
+		on        func(*fields)
+		wantStage string
+		wantErr   bool
+		Err       error
+	}{
+		{
+			name: "when the parameters are correct",
+			args: args{
+				ctx:     context.Background(),
+				payload: payloadMocked,
+				options: &model.RequestSubmissionOptionsScheme{
+					Attachments: []*model.RequestSubmissionFileScheme{
+						{FileName: "screen.png", Reader: strings.NewReader("image")},
+					},
+					Public: true,
+				},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/servicedeskapi/servicedesk/1/requesttype/2/field",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.RequestTypeFieldsScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.RequestTypeFieldsScheme).RequestTypeFields = []*model.RequestTypeFieldScheme{
+							{FieldID: "summary", Name: "Summary", Required: true},
+							{FieldID: "customfield_10010", Name: "Impact", ValidValues: []*model.RequestTypeFieldValueScheme{
+								{Value: "10001", Label: "High"},
+								{Value: "10002", Label: "Low"},
+							}},
+						}
+					}).
+					Return(&model.ResponseScheme{}, nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/servicedeskapi/servicedesk/1/attachTemporaryFile",
+					mock.AnythingOfType("string"),
+					mock.Anything).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.ServiceDeskTemporaryFileScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.ServiceDeskTemporaryFileScheme).TemporaryAttachments = []*model.TemporaryAttachmentScheme{
+							{TemporaryAttachmentID: "temp-id", FileName: "screen.png"},
+						}
+					}).
+					Return(&model.ResponseScheme{}, nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/servicedeskapi/request",
+					"",
+					payloadMocked).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.CustomerRequestScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.CustomerRequestScheme).IssueKey = "DESK-1"
+					}).
+					Return(&model.ResponseScheme{}, nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/servicedeskapi/request/DESK-1/attachment",
+					"",
+					map[string]interface{}{"temporaryAttachmentIds": []string{"temp-id"}, "public": true}).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.RequestAttachmentCreationScheme{}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+			wantStage: model.RequestSubmissionAttachmentStage,
+		},
+
+		{
+			name: "when the request type has an attachment field",
+			args: args{
+				ctx:     context.Background(),
+				payload: payloadMocked,
+				options: &model.RequestSubmissionOptionsScheme{
+					Attachments: []*model.RequestSubmissionFileScheme{
+						{FileName: "screen.png", Reader: strings.NewReader("image")},
+					},
+				},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/servicedeskapi/servicedesk/1/requesttype/2/field",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.RequestTypeFieldsScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.RequestTypeFieldsScheme).RequestTypeFields = []*model.RequestTypeFieldScheme{
+							{FieldID: "summary", Name: "Summary", Required: true},
+							{FieldID: "attachment", Name: "Attachment", Required: true},
+							{FieldID: "customfield_10010", Name: "Impact", ValidValues: []*model.RequestTypeFieldValueScheme{
+								{Value: "10001", Label: "High"},
+								{Value: "10002", Label: "Low"},
+							}},
+						}
+					}).
+					Return(&model.ResponseScheme{}, nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/servicedeskapi/servicedesk/1/attachTemporaryFile",
+					mock.AnythingOfType("string"),
+					mock.Anything).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.ServiceDeskTemporaryFileScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.ServiceDeskTemporaryFileScheme).TemporaryAttachments = []*model.TemporaryAttachmentScheme{
+							{TemporaryAttachmentID: "temp-id", FileName: "screen.png"},
+						}
+					}).
+					Return(&model.ResponseScheme{}, nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/servicedeskapi/request",
+					"",
+					&model.CreateCustomerRequestPayloadScheme{
+						RequestTypeID: "2",
+						ServiceDeskID: "1",
+						RequestFieldValues: map[string]interface{}{
+							"summary":           "Laptop screen broken",
+							"customfield_10010": map[string]interface{}{"id": "10001"},
+							"attachment":        []string{"temp-id"},
+						},
+					}).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.CustomerRequestScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.CustomerRequestScheme).IssueKey = "DESK-1"
+					}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+			wantStage: model.RequestSubmissionCreationStage,
+		},
+
+		{
+			name: "when the attachment field is required and the attachments are not provided",
+			args: args{
+				ctx:     context.Background(),
+				payload: payloadMocked,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/servicedeskapi/servicedesk/1/requesttype/2/field",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.RequestTypeFieldsScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.RequestTypeFieldsScheme).RequestTypeFields = []*model.RequestTypeFieldScheme{
+							{FieldID: "summary", Name: "Summary", Required: true},
+							{FieldID: "attachment", Name: "Attachment", Required: true},
+							{FieldID: "customfield_10010", Name: "Impact", ValidValues: []*model.RequestTypeFieldValueScheme{
+								{Value: "10001", Label: "High"},
+								{Value: "10002", Label: "Low"},
+							}},
+						}
+					}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+			wantStage: model.RequestSubmissionValidationStage,
+			wantErr:   true,
+			Err:       model.ErrInvalidRequestFieldValuesError,
+		},
+
+		{
+			name: "when the attachments cannot be linked to the request",
+			args: args{
+				ctx:     context.Background(),
+				payload: payloadMocked,
+				options: &model.RequestSubmissionOptionsScheme{
+					Attachments: []*model.RequestSubmissionFileScheme{
+						{FileName: "screen.png", Reader: strings.NewReader("image")},
+					},
+					SkipValidation: true,
+				},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/servicedeskapi/servicedesk/1/attachTemporaryFile",
+					mock.AnythingOfType("string"),
+					mock.Anything).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.ServiceDeskTemporaryFileScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.ServiceDeskTemporaryFileScheme).TemporaryAttachments = []*model.TemporaryAttachmentScheme{
+							{TemporaryAttachmentID: "temp-id", FileName: "screen.png"},
+						}
+					}).
+					Return(&model.ResponseScheme{}, nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/servicedeskapi/request",
+					"",
+					payloadMocked).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.CustomerRequestScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.CustomerRequestScheme).IssueKey = "DESK-1"
+					}).
+					Return(&model.ResponseScheme{}, nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/servicedeskapi/request/DESK-1/attachment",
+					"",
+					map[string]interface{}{"temporaryAttachmentIds": []string{"temp-id"}, "public": false}).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.RequestAttachmentCreationScheme{}).
+					Return(&model.ResponseScheme{}, model.ErrInternalError)
+
+				fields.c = client
+			},
+			wantStage: model.RequestSubmissionAttachmentStage,
+			wantErr:   true,
+			Err:       model.ErrInternalError,
+		},
+
+		{
+			name: "when the payload does not match the request type fields",
+			args: args{
+				ctx: context.Background(),
+				payload: &model.CreateCustomerRequestPayloadScheme{
+					RequestTypeID: "2",
+					ServiceDeskID: "1",
+					RequestFieldValues: map[string]interface{}{
+						"customfield_10010": "Medium",
+					},
+				},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewConnector(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/servicedeskapi/servicedesk/1/requesttype/2/field",
+					"",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.RequestTypeFieldsScheme{}).
+					Run(func(arguments mock.Arguments) {
+						arguments.Get(1).(*model.RequestTypeFieldsScheme).RequestTypeFields = []*model.RequestTypeFieldScheme{
+							{FieldID: "summary", Name: "Summary", Required: true},
+							{FieldID: "customfield_10010", Name: "Impact", ValidValues: []*model.RequestTypeFieldValueScheme{
+								{Value: "10001", Label: "High"},
+								{Value: "10002", Label: "Low"},
+							}},
+						}
+					}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+			wantStage: model.RequestSubmissionValidationStage,
+			wantErr:   true,
+			Err:       model.ErrInvalidRequestFieldValuesError,
+		},
+
+		{
+			name: "when the service desk id is not provided",
+			args: args{
+				ctx:     context.Background(),
+				payload: &model.CreateCustomerRequestPayloadScheme{RequestTypeID: "2"},
+			},
+			wantErr: true,
+			Err:     model.ErrNoServiceDeskIDError,
+		},
+
+		{
+			name: "when the attachment reader is not provided",
+			args: args{
+				ctx:     context.Background(),
+				payload: payloadMocked,
+				options: &model.RequestSubmissionOptionsScheme{
+					Attachments: []*model.RequestSubmissionFileScheme{{FileName: "screen.png"}},
+				},
+			},
+			wantErr: true,
+			Err:     model.ErrNoFileReaderError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			newService := NewRequestSubmitterService(testCase.fields.c, "latest")
+
+			gotResult, _, err := newService.Submit(testCase.args.ctx, testCase.args.payload, testCase.args.options)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.ErrorIs(t, err, testCase.Err)
+			} else {
+
+				assert.NoError(t, err)
+				assert.Equal(t, "DESK-1", gotResult.Request.IssueKey)
+				assert.Equal(t, []string{"temp-id"}, gotResult.TemporaryAttachmentIDs)
+			}
+
+			if testCase.wantStage != "" {
+				assert.Equal(t, testCase.wantStage, gotResult.Stage)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ctreminiom/go-atlassian/service/common"
 	"github.com/ctreminiom/go-atlassian/service/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"io"
 	"net/http"
 	"net/url"
@@ -549,3 +550,46 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Use(t *testing.T) {
+
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request: &http.Request{
+			Method: http.MethodGet,
+			URL:    &url.URL{},
+		},
+	}
+
+	client := mocks.NewHttpClient(t)
+	client.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Header.Get("X-Middleware") == "first,second"
+	})).Return(response, nil)
+
+	instance, err := New(client, "https://ctreminiom.atlassian.net")
+	assert.NoError(t, err)
+
+	header := func(name string) common.Middleware {
+		return func(next common.RoundTrip) common.RoundTrip {
+			return func(request *http.Request) (*http.Response, error) {
+
+				values := []string{name}
+				if previous := request.Header.Get("X-Middleware"); previous != "" {
+					values = append([]string{previous}, values...)
+				}
+
+				request.Header.Set("X-Middleware", strings.Join(values, ","))
+				return next(request)
+			}
+		}
+	}
+
+	instance.Use(header("first"), header("second"))
+
+	request, err := instance.NewRequest(context.Background(), http.MethodGet, "rest/api/3/myself", "", nil)
+	assert.NoError(t, err)
+
+	_, err = instance.Call(request, nil)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,88 @@
+package workflow
+
+// Report is the structural analysis of a workflow.
+type Report struct {
+
+	// Unreachable are the statuses that no issue can reach from the status the issues are created on.
+	Unreachable []*Status
+
+	// DeadEnds are the statuses without transitions to other statuses, e.g: the done statuses
+	// without a reopen transition.
+	DeadEnds []*Status
+
+	// WithoutScreen are the transitions, other than the initial one, performed without a screen.
+	WithoutScreen []*Transition
+}
+
+// Empty reports whether the analysis found nothing to review.
+func (r *Report) Empty() bool {
+	return len(r.Unreachable) == 0 && len(r.DeadEnds) == 0 && len(r.WithoutScreen) == 0
+}
+
+// Analyze reports the unreachable statuses, the dead-end statuses and the transitions without screen.
+// The statuses are reached from the target of the initial transitions, all the statuses are
+// reachable when the workflow has no initial transition.
+func (g *Graph) Analyze() *Report {
+
+	report := &Report{}
+
+	var initial []string
+	for _, transition := range g.Transitions {
+		if transition.Initial() {
+			initial = append(initial, transition.To)
+		}
+	}
+
+	if len(initial) != 0 {
+
+		reached := g.reachable(initial)
+		for _, status := range g.Statuses {
+			if !reached[status.ID] {
+				report.Unreachable = append(report.Unreachable, status)
+			}
+		}
+	}
+
+	for _, status := range g.Statuses {
+		if len(g.Outgoing(status.ID)) == 0 {
+			report.DeadEnds = append(report.DeadEnds, status)
+		}
+	}
+
+	for _, transition := range g.Transitions {
+		if !transition.Initial() && transition.Screen == "" {
+			report.WithoutScreen = append(report.WithoutScreen, transition)
+		}
+	}
+
+	return report
+}
+
+// reachable returns the IDs of the statuses reachable from the statuses provided, including them.
+func (g *Graph) reachable(from []string) map[string]bool {
+
+	reached := map[string]bool{}
+
+	queue := append([]string(nil), from...)
+	for _, status := range from {
+		reached[status] = true
+	}
+
+	for len(queue) != 0 {
+
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, transition := range g.Outgoing(current) {
+
+			if reached[transition.To] {
+				continue
+			}
+
+			reached[transition.To] = true
+			queue = append(queue, transition.To)
+		}
+	}
+
+	return reached
+}
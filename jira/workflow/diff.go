@@ -0,0 +1,223 @@
+package workflow
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Diff is the structural difference between two workflows, e.g: the active workflow and its draft.
+// The statuses and the transitions are matched by ID.
+type Diff struct {
+	AddedStatuses   []*Status
+	RemovedStatuses []*Status
+	RenamedStatuses []*StatusChange
+
+	AddedTransitions   []*Transition
+	RemovedTransitions []*Transition
+	ChangedTransitions []*TransitionChange
+
+	before, after *Graph
+}
+
+// StatusChange is a status renamed.
+type StatusChange struct {
+	Before *Status
+	After  *Status
+}
+
+// TransitionChange is a transition changed, Changes are the attributes changed: name, from, to,
+// type, screen and rules.
+type TransitionChange struct {
+	Before  *Transition
+	After   *Transition
+	Changes []string
+}
+
+// Compare returns the changes that turn the workflow before into the workflow after.
+func Compare(before, after *Graph) *Diff {
+
+	if before == nil {
+		before = &Graph{}
+	}
+
+	if after == nil {
+		after = &Graph{}
+	}
+
+	diff := &Diff{before: before, after: after}
+
+	beforeStatuses := map[string]*Status{}
+	for _, status := range before.Statuses {
+		beforeStatuses[status.ID] = status
+	}
+
+	afterStatuses := map[string]*Status{}
+	for _, status := range after.Statuses {
+
+		afterStatuses[status.ID] = status
+
+		previous, ok := beforeStatuses[status.ID]
+		switch {
+		case !ok:
+			diff.AddedStatuses = append(diff.AddedStatuses, status)
+		case previous.Name != status.Name:
+			diff.RenamedStatuses = append(diff.RenamedStatuses, &StatusChange{Before: previous, After: status})
+		}
+	}
+
+	for _, status := range before.Statuses {
+		if _, ok := afterStatuses[status.ID]; !ok {
+			diff.RemovedStatuses = append(diff.RemovedStatuses, status)
+		}
+	}
+
+	beforeTransitions := map[string]*Transition{}
+	for _, transition := range before.Transitions {
+		beforeTransitions[transitionKey(transition)] = transition
+	}
+
+	afterTransitions := map[string]*Transition{}
+	for _, transition := range after.Transitions {
+
+		afterTransitions[transitionKey(transition)] = transition
+
+		previous, ok := beforeTransitions[transitionKey(transition)]
+		if !ok {
+			diff.AddedTransitions = append(diff.AddedTransitions, transition)
+			continue
+		}
+
+		if changes := changes(previous, transition); len(changes) != 0 {
+			diff.ChangedTransitions = append(diff.ChangedTransitions, &TransitionChange{Before: previous, After: transition, Changes: changes})
+		}
+	}
+
+	for _, transition := range before.Transitions {
+		if _, ok := afterTransitions[transitionKey(transition)]; !ok {
+			diff.RemovedTransitions = append(diff.RemovedTransitions, transition)
+		}
+	}
+
+	return diff
+}
+
+// transitionKey matches the transitions by ID, or by name when they have no ID.
+func transitionKey(transition *Transition) string {
+
+	if transition.ID != "" {
+		return "id:" + transition.ID
+	}
+
+	return "name:" + transition.Name
+}
+
+func changes(before, after *Transition) []string {
+
+	var changes []string
+
+	if before.Name != after.Name {
+		changes = append(changes, "name")
+	}
+
+	if !reflect.DeepEqual(sorted(before.From), sorted(after.From)) {
+		changes = append(changes, "from")
+	}
+
+	if before.To != after.To {
+		changes = append(changes, "to")
+	}
+
+	if before.Type != after.Type {
+		changes = append(changes, "type")
+	}
+
+	if before.Screen != after.Screen {
+		changes = append(changes, "screen")
+	}
+
+	if !reflect.DeepEqual(before.Rules, after.Rules) {
+		changes = append(changes, "rules")
+	}
+
+	return changes
+}
+
+func sorted(values []string) []string {
+
+	copied := append([]string{}, values...)
+	sort.Strings(copied)
+
+	return copied
+}
+
+// Empty reports whether both workflows have the same structure.
+func (d *Diff) Empty() bool {
+	return len(d.AddedStatuses) == 0 && len(d.RemovedStatuses) == 0 && len(d.RenamedStatuses) == 0 &&
+		len(d.AddedTransitions) == 0 && len(d.RemovedTransitions) == 0 && len(d.ChangedTransitions) == 0
+}
+
+// String returns the changes one per line, prefixed by "+" when added, "-" when removed and "~" when changed.
+func (d *Diff) String() string {
+
+	var b strings.Builder
+
+	for _, status := range d.AddedStatuses {
+		fmt.Fprintf(&b, "+ status %v (%v)\n", status.Name, status.ID)
+	}
+
+	for _, status := range d.RemovedStatuses {
+		fmt.Fprintf(&b, "- status %v (%v)\n", status.Name, status.ID)
+	}
+
+	for _, change := range d.RenamedStatuses {
+		fmt.Fprintf(&b, "~ status %v renamed: %v -> %v\n", change.After.ID, change.Before.Name, change.After.Name)
+	}
+
+	for _, transition := range d.AddedTransitions {
+		fmt.Fprintf(&b, "+ transition %v\n", describe(d.after, transition))
+	}
+
+	for _, transition := range d.RemovedTransitions {
+		fmt.Fprintf(&b, "- transition %v\n", describe(d.before, transition))
+	}
+
+	for _, change := range d.ChangedTransitions {
+		fmt.Fprintf(&b, "~ transition %v changed %v: %v => %v\n", change.After.Name, strings.Join(change.Changes, ", "),
+			describe(d.before, change.Before), describe(d.after, change.After))
+	}
+
+	return b.String()
+}
+
+// describe returns the transition as "Name (ID): From, From -> To [screen ID]".
+func describe(graph *Graph, transition *Transition) string {
+
+	name := func(id string) string {
+		if status, err := graph.Status(id); err == nil {
+			return status.Name
+		}
+		return id
+	}
+
+	var origins []string
+	for _, from := range graph.origins(transition) {
+
+		switch {
+		case from != "":
+			origins = append(origins, name(from))
+		case transition.Initial():
+			origins = append(origins, "create")
+		default:
+			origins = append(origins, "any")
+		}
+	}
+
+	screen := "no screen"
+	if transition.Screen != "" {
+		screen = "screen " + transition.Screen
+	}
+
+	return fmt.Sprintf("%v (%v): %v -> %v [%v]", transition.Name, transition.ID, strings.Join(origins, ", "), name(transition.To), screen)
+}
@@ -0,0 +1,137 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DOT returns the workflow as a Graphviz digraph. The initial transitions start from a point and
+// the global transitions from an "Any status" node, the transitions without screen are dashed.
+func (g *Graph) DOT() string {
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "digraph %v {\n", dotQuote(g.Name))
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box, style=rounded];\n")
+
+	for _, status := range g.Statuses {
+		fmt.Fprintf(&b, "\t%v [label=%v];\n", dotQuote(status.ID), dotQuote(status.Name))
+	}
+
+	initial, global := g.pseudoStatuses()
+	if initial {
+		b.WriteString("\t\"initial\" [shape=point];\n")
+	}
+
+	if global {
+		b.WriteString("\t\"any\" [label=\"Any status\", shape=plaintext];\n")
+	}
+
+	for _, transition := range g.Transitions {
+
+		attributes := "label=" + dotQuote(transition.Name)
+		if transition.Screen == "" && !transition.Initial() {
+			attributes += ", style=dashed"
+		}
+
+		for _, from := range g.origins(transition) {
+
+			source := dotQuote(from)
+			if from == "" {
+				source = `"any"`
+				if transition.Initial() {
+					source = `"initial"`
+				}
+			}
+
+			fmt.Fprintf(&b, "\t%v -> %v [%v];\n", source, dotQuote(transition.To), attributes)
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid returns the workflow as a Mermaid state diagram. The initial transitions start from the
+// start state and the global transitions from an "Any status" state.
+func (g *Graph) Mermaid() string {
+
+	var b strings.Builder
+
+	b.WriteString("stateDiagram-v2\n")
+
+	ids := make(map[string]string, len(g.Statuses))
+	for index, status := range g.Statuses {
+		ids[status.ID] = fmt.Sprintf("s%v", index)
+		fmt.Fprintf(&b, "    state \"%v\" as %v\n", mermaidEscape(status.Name), ids[status.ID])
+	}
+
+	if _, global := g.pseudoStatuses(); global {
+		b.WriteString("    state \"Any status\" as any\n")
+	}
+
+	state := func(id string) string {
+		if mermaid, ok := ids[id]; ok {
+			return mermaid
+		}
+		return "unknown_" + strings.Map(identifier, id)
+	}
+
+	for _, transition := range g.Transitions {
+
+		for _, from := range g.origins(transition) {
+
+			source := "any"
+			switch {
+			case from != "":
+				source = state(from)
+			case transition.Initial():
+				source = "[*]"
+			}
+
+			fmt.Fprintf(&b, "    %v --> %v: %v\n", source, state(transition.To), mermaidEscape(transition.Name))
+		}
+	}
+
+	return b.String()
+}
+
+// origins returns the statuses the transition starts from, an empty status for the initial and global transitions.
+func (g *Graph) origins(transition *Transition) []string {
+
+	if transition.Initial() || transition.Global() {
+		return []string{""}
+	}
+
+	return transition.From
+}
+
+// pseudoStatuses reports whether the workflow has initial and global transitions.
+func (g *Graph) pseudoStatuses() (initial, global bool) {
+
+	for _, transition := range g.Transitions {
+		initial = initial || transition.Initial()
+		global = global || (transition.Global() && !transition.Initial())
+	}
+
+	return initial, global
+}
+
+func dotQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
+
+// mermaidEscape escapes the characters that end the Mermaid labels with their entity codes.
+func mermaidEscape(value string) string {
+	return strings.NewReplacer(`"`, "#quot;", ":", "#58;", ";", "#59;", "\n", " ").Replace(value)
+}
+
+func identifier(r rune) rune {
+
+	if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+		return r
+	}
+
+	return '_'
+}
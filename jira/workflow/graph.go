@@ -0,0 +1,253 @@
+// Package workflow models the Jira workflows as graphs of statuses and transitions. The graphs are
+// analyzed, exported to Graphviz DOT and Mermaid and compared, e.g: a draft against the active workflow:
+//
+//	page, _, err := instance.Workflow.Gets(ctx, &models.WorkflowSearchOptions{
+//		WorkflowName: []string{"Software Workflow"},
+//		Expand:       []string{"transitions", "transitions.rules", "statuses"},
+//	}, 0, 50)
+//
+//	graphs, err := workflow.NewGraphs(page)
+//	report := graphs[0].Analyze()
+//	fmt.Println(graphs[0].Mermaid())
+//	fmt.Println(workflow.Compare(active, draft))
+//
+// The Transitioner moves the issues to a status by name, walking the shortest path of transitions
+// when the status isn't directly reachable, e.g:
+//
+//	transitioner, err := workflow.NewTransitioner(instance.Issue, registry, &workflow.Options{
+//		Workflow: workflow.WorkflowOf(instance.Workflow, instance.Workflow.Scheme, instance.Workflow.Scheme.IssueType),
+//	})
+//
+//	steps, err := transitioner.TransitionTo(ctx, "KP-1", "Done", map[string]interface{}{"Resolution": "Fixed"})
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+var (
+	ErrNoWorkflowError        = errors.New("workflow: no workflow set")
+	ErrUnknownStatusError     = errors.New("workflow: unknown status")
+	ErrUnreachableStatusError = errors.New("workflow: the status isn't reachable")
+)
+
+// Graph is a workflow as a graph, the statuses are the nodes and the transitions the edges.
+type Graph struct {
+	Name        string
+	Statuses    []*Status
+	Transitions []*Transition
+}
+
+// Status is a status of the workflow.
+type Status struct {
+	ID   string
+	Name string
+}
+
+// Transition is a transition of the workflow.
+type Transition struct {
+	ID   string
+	Name string
+
+	// From are the IDs of the statuses the transition starts from, none for the global and initial transitions.
+	From []string
+
+	// To is the ID of the status the transition ends on.
+	To string
+
+	// Type is either "initial", "global" or "directed".
+	Type string
+
+	// Screen is the ID of the screen of the transition, empty when the transition has no screen.
+	Screen string
+
+	// Rules are the conditions, validators and post functions, returned when the transitions.rules are expanded.
+	Rules *model.WorkflowTransitionRulesScheme
+}
+
+// Global reports whether the transition can be performed from any status.
+func (t *Transition) Global() bool {
+	return t.Type == "global" || (len(t.From) == 0 && t.Type != "initial")
+}
+
+// Initial reports whether the transition creates the issues.
+func (t *Transition) Initial() bool {
+	return t.Type == "initial"
+}
+
+// NewGraphs returns the graphs of the workflows of a page returned by WorkflowService.Gets.
+func NewGraphs(page *model.WorkflowPageScheme) ([]*Graph, error) {
+
+	if page == nil {
+		return nil, ErrNoWorkflowError
+	}
+
+	graphs := make([]*Graph, 0, len(page.Values))
+	for _, workflow := range page.Values {
+
+		if workflow == nil {
+			continue
+		}
+
+		graph, err := NewGraph(workflow)
+		if err != nil {
+			return nil, err
+		}
+
+		graphs = append(graphs, graph)
+	}
+
+	return graphs, nil
+}
+
+// NewGraph returns the graph of a workflow returned by WorkflowService.Gets, the workflow must be
+// requested with the transitions and statuses expanded.
+func NewGraph(workflow *model.WorkflowScheme) (*Graph, error) {
+
+	if workflow == nil {
+		return nil, ErrNoWorkflowError
+	}
+
+	graph := &Graph{}
+	if workflow.ID != nil {
+		graph.Name = workflow.ID.Name
+	}
+
+	for _, status := range workflow.Statuses {
+		if status != nil {
+			graph.Statuses = append(graph.Statuses, &Status{ID: status.ID, Name: status.Name})
+		}
+	}
+
+	for _, transition := range workflow.Transitions {
+
+		if transition == nil {
+			continue
+		}
+
+		edge := &Transition{
+			ID:    transition.ID,
+			Name:  transition.Name,
+			From:  transition.From,
+			To:    transition.To,
+			Type:  transition.Type,
+			Rules: transition.Rules,
+		}
+
+		if transition.Screen != nil {
+			edge.Screen = transition.Screen.ID
+		}
+
+		graph.Transitions = append(graph.Transitions, edge)
+	}
+
+	return graph, nil
+}
+
+// Status returns the status by ID or name, the names are case-insensitive.
+func (g *Graph) Status(status string) (*Status, error) {
+
+	for _, candidate := range g.Statuses {
+		if candidate.ID == status {
+			return candidate, nil
+		}
+	}
+
+	for _, candidate := range g.Statuses {
+		if strings.EqualFold(candidate.Name, status) {
+			return candidate, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %v", ErrUnknownStatusError, status)
+}
+
+// Outgoing returns the transitions that can be performed from the status, including the global ones
+// that end on another status.
+func (g *Graph) Outgoing(statusID string) []*Transition {
+
+	var transitions []*Transition
+	for _, transition := range g.Transitions {
+
+		if transition.Initial() || (transition.Global() && transition.To == statusID) {
+			continue
+		}
+
+		if transition.Global() {
+			transitions = append(transitions, transition)
+			continue
+		}
+
+		for _, from := range transition.From {
+			if from == statusID {
+				transitions = append(transitions, transition)
+				break
+			}
+		}
+	}
+
+	return transitions
+}
+
+// ShortestPath returns the fewest transitions that move an issue between the statuses, by ID or
+// name. The path is empty when both statuses are the same.
+func (g *Graph) ShortestPath(from, to string) ([]*Transition, error) {
+
+	source, err := g.Status(from)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := g.Status(to)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.ID == target.ID {
+		return nil, nil
+	}
+
+	// A breadth-first search, the transitions are visited in the workflow order so the path is stable.
+	type step struct {
+		transition *Transition
+		from       string
+	}
+
+	reached := map[string]*step{source.ID: nil}
+	queue := []string{source.ID}
+
+	for len(queue) != 0 {
+
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, transition := range g.Outgoing(current) {
+
+			if _, visited := reached[transition.To]; visited {
+				continue
+			}
+
+			reached[transition.To] = &step{transition: transition, from: current}
+			queue = append(queue, transition.To)
+		}
+
+		if _, ok := reached[target.ID]; ok {
+			break
+		}
+	}
+
+	if _, ok := reached[target.ID]; !ok {
+		return nil, fmt.Errorf("%w: %v from %v", ErrUnreachableStatusError, target.Name, source.Name)
+	}
+
+	var path []*Transition
+	for status := target.ID; status != source.ID; status = reached[status].from {
+		path = append([]*Transition{reached[status].transition}, path...)
+	}
+
+	return path, nil
+}
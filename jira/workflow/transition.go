@@ -0,0 +1,428 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ctreminiom/go-atlassian/jira/fields"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/jira"
+)
+
+const defaultMaxSteps = 10
+
+var (
+	ErrNoIssueConnectorError      = errors.New("workflow: no issue connector set")
+	ErrNoWorkflowConnectorError   = errors.New("workflow: no workflow or workflow scheme connector set")
+	ErrNoStatusError              = errors.New("workflow: no status set")
+	ErrRequiredFieldError         = errors.New("workflow: required fields missing")
+	ErrUnavailableTransitionError = errors.New("workflow: the transition isn't available")
+	ErrTooManyStepsError          = errors.New("workflow: the path exceeds the maximum steps")
+)
+
+// WorkflowFunc returns the workflow of the issue, with the transitions and statuses expanded.
+type WorkflowFunc func(ctx context.Context, issue *model.IssueScheme) (*model.WorkflowScheme, error)
+
+type Options struct {
+
+	// Workflow returns the workflow of the issues, it's required to reach the statuses that aren't
+	// directly reachable from the issue status, e.g: WorkflowOf.
+	Workflow WorkflowFunc
+
+	// MaxSteps is the maximum number of transitions walked to reach a status, 10 by default.
+	MaxSteps int
+}
+
+// Step is a transition performed on the issue.
+type Step struct {
+	TransitionID string
+	Name         string
+	From         string
+	To           string
+}
+
+// Transitioner moves the issues to a status by name, filling the fields required by the transition screens.
+type Transitioner struct {
+	issue    jira.IssueADFConnector
+	registry *fields.Registry
+	options  Options
+}
+
+// NewTransitioner returns a transitioner, the connector is usually the Issue service of the v3 client.
+//
+// The values of the fields are encoded by field type with the registry, the values are sent as they
+// are when the registry is nil.
+func NewTransitioner(issue jira.IssueADFConnector, registry *fields.Registry, options *Options) (*Transitioner, error) {
+
+	if issue == nil {
+		return nil, ErrNoIssueConnectorError
+	}
+
+	transitioner := &Transitioner{issue: issue, registry: registry}
+
+	if options != nil {
+		transitioner.options = *options
+	}
+
+	if transitioner.options.MaxSteps <= 0 {
+		transitioner.options.MaxSteps = defaultMaxSteps
+	}
+
+	return transitioner, nil
+}
+
+// TransitionTo moves the issue to the status, the names are case-insensitive. The transition is
+// performed directly when available, otherwise the shortest path of the issue workflow is walked
+// step by step. The issue is left untouched when it's already on the status.
+//
+// The values are keyed by field ID, key or name, and set on the transition screens that have the
+// field, the values of the fields not on the screens are ignored. The required fields without
+// default value must be provided.
+//
+// The steps performed are returned, also when a step fails and the issue is left on an intermediate status.
+func (t *Transitioner) TransitionTo(ctx context.Context, issueKeyOrID, status string, values map[string]interface{}) ([]*Step, error) {
+
+	if issueKeyOrID == "" {
+		return nil, model.ErrNoIssueKeyOrIDError
+	}
+
+	if status == "" {
+		return nil, ErrNoStatusError
+	}
+
+	issue, _, err := t.issue.Get(ctx, issueKeyOrID, []string{"status", "project", "issuetype"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	current := currentStatus(issue)
+	if current != nil && (strings.EqualFold(current.Name, status) || current.ID == status) {
+		return nil, nil
+	}
+
+	available, _, err := t.issue.TransitionsWithFields(ctx, issueKeyOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transition := lookup(available.Transitions, "", status); transition != nil {
+
+		step, err := t.perform(ctx, issueKeyOrID, current, transition, values)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*Step{step}, nil
+	}
+
+	if t.options.Workflow == nil {
+		return nil, fmt.Errorf("%w: %v, no workflow set to find a path", ErrUnreachableStatusError, status)
+	}
+
+	workflow, err := t.options.Workflow(ctx, issue)
+	if err != nil {
+		return nil, err
+	}
+
+	graph, err := NewGraph(workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	if current == nil {
+		return nil, fmt.Errorf("%w: the issue has no status", ErrUnknownStatusError)
+	}
+
+	path, err := graph.ShortestPath(current.ID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(path) > t.options.MaxSteps {
+		return nil, fmt.Errorf("%w: %v steps to %v", ErrTooManyStepsError, len(path), status)
+	}
+
+	var steps []*Step
+	for index, edge := range path {
+
+		// The transitions available on the first step are already fetched.
+		if index != 0 {
+
+			available, _, err = t.issue.TransitionsWithFields(ctx, issueKeyOrID)
+			if err != nil {
+				return steps, err
+			}
+		}
+
+		target, _ := graph.Status(edge.To)
+
+		transition := lookup(available.Transitions, edge.ID, target.ID)
+		if transition == nil {
+			return steps, fmt.Errorf("%w: %v to %v", ErrUnavailableTransitionError, edge.Name, target.Name)
+		}
+
+		step, err := t.perform(ctx, issueKeyOrID, current, transition, values)
+		if err != nil {
+			return steps, err
+		}
+
+		steps = append(steps, step)
+		current = transition.To
+	}
+
+	return steps, nil
+}
+
+// perform validates the required fields of the transition screen and performs the transition.
+func (t *Transitioner) perform(ctx context.Context, issueKeyOrID string, current *model.StatusScheme,
+	transition *model.IssueTransitionScheme, values map[string]interface{}) (*Step, error) {
+
+	customFields, err := t.encode(ctx, transition, values)
+	if err != nil {
+		return nil, err
+	}
+
+	var options *model.IssueMoveOptionsV3
+	if customFields != nil {
+		options = &model.IssueMoveOptionsV3{Fields: &model.IssueScheme{}, CustomFields: customFields}
+	}
+
+	if _, err := t.issue.Move(ctx, issueKeyOrID, transition.ID, options); err != nil {
+		return nil, err
+	}
+
+	step := &Step{TransitionID: transition.ID, Name: transition.Name}
+	if current != nil {
+		step.From = current.Name
+	}
+
+	if transition.To != nil {
+		step.To = transition.To.Name
+	}
+
+	return step, nil
+}
+
+// encode returns the values of the fields of the transition screen, nil when none is set.
+func (t *Transitioner) encode(ctx context.Context, transition *model.IssueTransitionScheme, values map[string]interface{}) (*model.CustomFields, error) {
+
+	var (
+		encoder *fields.Encoder
+		raw     = &model.CustomFields{}
+		missing []string
+		set     int
+	)
+
+	ids := make([]string, 0, len(transition.Fields))
+	for id := range transition.Fields {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	for _, id := range ids {
+
+		field := transition.Fields[id]
+
+		value, ok := valueOf(values, id, field)
+		if !ok {
+
+			if field.Required && !field.HasDefaultValue {
+				missing = append(missing, field.Name)
+			}
+
+			continue
+		}
+
+		set++
+
+		if t.registry == nil {
+			raw.Fields = append(raw.Fields, map[string]interface{}{"fields": map[string]interface{}{id: value}})
+			continue
+		}
+
+		if encoder == nil {
+
+			var err error
+			if encoder, err = t.registry.NewEncoder(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := encoder.Set(id, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(missing) != 0 {
+		return nil, fmt.Errorf("%w: %v requires %v", ErrRequiredFieldError, transition.Name, strings.Join(missing, ", "))
+	}
+
+	if set == 0 {
+		return nil, nil
+	}
+
+	if encoder != nil {
+		return encoder.CustomFields(), nil
+	}
+
+	return raw, nil
+}
+
+// valueOf returns the value of the field keyed by ID, key or case-insensitive name.
+func valueOf(values map[string]interface{}, id string, field *model.IssueTransitionFieldScheme) (interface{}, bool) {
+
+	if value, ok := values[id]; ok {
+		return value, true
+	}
+
+	if field == nil {
+		return nil, false
+	}
+
+	if value, ok := values[field.Key]; ok && field.Key != "" {
+		return value, true
+	}
+
+	for name, value := range values {
+		if strings.EqualFold(name, field.Name) {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+// lookup returns the transition by ID or, when not found, by the ID or name of the status it ends on.
+func lookup(transitions []*model.IssueTransitionScheme, id, status string) *model.IssueTransitionScheme {
+
+	for _, transition := range transitions {
+		if id != "" && transition.ID == id {
+			return transition
+		}
+	}
+
+	for _, transition := range transitions {
+		if transition.To != nil && (transition.To.ID == status || strings.EqualFold(transition.To.Name, status)) {
+			return transition
+		}
+	}
+
+	return nil
+}
+
+func currentStatus(issue *model.IssueScheme) *model.StatusScheme {
+
+	if issue == nil || issue.Fields == nil {
+		return nil
+	}
+
+	return issue.Fields.Status
+}
+
+// WorkflowOf returns the WorkflowFunc that resolves the workflow of the issues through the workflow
+// scheme of their project and their issue type, the workflows are cached per project and issue type.
+// The connectors are usually the Workflow, Workflow.Scheme and Workflow.Scheme.IssueType services.
+func WorkflowOf(workflows jira.WorkflowConnector, schemes jira.WorkflowSchemeConnector,
+	issueTypes jira.WorkflowSchemeIssueTypeConnector) WorkflowFunc {
+
+	var (
+		mu    sync.Mutex
+		cache = map[string]*model.WorkflowScheme{}
+	)
+
+	return func(ctx context.Context, issue *model.IssueScheme) (*model.WorkflowScheme, error) {
+
+		if workflows == nil || schemes == nil || issueTypes == nil {
+			return nil, ErrNoWorkflowConnectorError
+		}
+
+		if issue == nil || issue.Fields == nil || issue.Fields.Project == nil || issue.Fields.IssueType == nil {
+			return nil, fmt.Errorf("%w: the issue has no project or issue type", ErrNoWorkflowError)
+		}
+
+		key := issue.Fields.Project.ID + "/" + issue.Fields.IssueType.ID
+
+		mu.Lock()
+		workflow, ok := cache[key]
+		mu.Unlock()
+
+		if ok {
+			return workflow, nil
+		}
+
+		name, err := workflowName(ctx, schemes, issueTypes, issue.Fields.Project.ID, issue.Fields.IssueType.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		page, _, err := workflows.Gets(ctx, &model.WorkflowSearchOptions{
+			WorkflowName: []string{name},
+			Expand:       []string{"transitions", "statuses"},
+		}, 0, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(page.Values) == 0 {
+			return nil, fmt.Errorf("%w: %v not found", ErrNoWorkflowError, name)
+		}
+
+		mu.Lock()
+		cache[key] = page.Values[0]
+		mu.Unlock()
+
+		return page.Values[0], nil
+	}
+}
+
+// workflowName returns the name of the workflow of the issue type on the workflow scheme of the project.
+func workflowName(ctx context.Context, schemes jira.WorkflowSchemeConnector, issueTypes jira.WorkflowSchemeIssueTypeConnector,
+	projectID, issueTypeID string) (string, error) {
+
+	id, err := strconv.Atoi(projectID)
+	if err != nil {
+		return "", fmt.Errorf("workflow: invalid project ID %q: %w", projectID, err)
+	}
+
+	associations, _, err := schemes.Associations(ctx, []int{id})
+	if err != nil {
+		return "", err
+	}
+
+	if len(associations.Values) == 0 || associations.Values[0].WorkflowScheme == nil {
+		return "", fmt.Errorf("%w: the project %v has no workflow scheme", ErrNoWorkflowError, projectID)
+	}
+
+	scheme := associations.Values[0].WorkflowScheme
+
+	// The default workflow scheme has no ID, its issue types use the default workflow.
+	if scheme.ID == 0 {
+
+		if scheme.DefaultWorkflow == "" {
+			return "jira", nil
+		}
+
+		return scheme.DefaultWorkflow, nil
+	}
+
+	mapping, _, err := issueTypes.Get(ctx, scheme.ID, issueTypeID, false)
+	if err == nil && mapping.Workflow != "" {
+		return mapping.Workflow, nil
+	}
+
+	if scheme.DefaultWorkflow != "" {
+		return scheme.DefaultWorkflow, nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("%w: the issue type %v has no workflow", ErrNoWorkflowError, issueTypeID)
+}
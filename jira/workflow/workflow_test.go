@@ -0,0 +1,463 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/jira"
+	"github.com/stretchr/testify/assert"
+)
+
+// newWorkflow returns a workflow To Do -> In Progress -> In Review -> Done, with a global
+// transition to Cancelled and a Reopen transition from Done and Cancelled to To Do.
+func newWorkflow() *model.WorkflowScheme {
+	return &model.WorkflowScheme{
+		ID: &model.WorkflowPublishedIDScheme{Name: "Software Workflow"},
+		Statuses: []*model.WorkflowStatusScheme{
+			{ID: "1", Name: "To Do"},
+			{ID: "2", Name: "In Progress"},
+			{ID: "3", Name: "In Review"},
+			{ID: "4", Name: "Done"},
+			{ID: "5", Name: "Cancelled"},
+		},
+		Transitions: []*model.WorkflowTransitionScheme{
+			{ID: "1", Name: "Create", To: "1", Type: "initial"},
+			{ID: "11", Name: "Start", From: []string{"1"}, To: "2", Type: "directed"},
+			{ID: "21", Name: "Review", From: []string{"2"}, To: "3", Type: "directed"},
+			{ID: "31", Name: "Approve", From: []string{"3"}, To: "4", Type: "directed",
+				Screen: &model.WorkflowTransitionScreenScheme{ID: "10001"}},
+			{ID: "41", Name: "Cancel", To: "5", Type: "global"},
+			{ID: "51", Name: "Reopen", From: []string{"4", "5"}, To: "1", Type: "directed"},
+		},
+	}
+}
+
+type issueFake struct {
+	jira.IssueADFConnector
+
+	workflow *Graph
+	status   string
+	moves    []map[string]interface{}
+}
+
+func (f *issueFake) Get(ctx context.Context, issueKeyOrId string, fields, expand []string) (*model.IssueScheme, *model.ResponseScheme, error) {
+
+	status, _ := f.workflow.Status(f.status)
+
+	return &model.IssueScheme{Key: issueKeyOrId, Fields: &model.IssueFieldsScheme{
+		Status:    &model.StatusScheme{ID: status.ID, Name: status.Name},
+		Project:   &model.ProjectScheme{ID: "10000"},
+		IssueType: &model.IssueTypeScheme{ID: "10001"},
+	}}, nil, nil
+}
+
+// TransitionsWithFields returns the transitions of the workflow from the issue status, the
+// Approve transition requires the resolution.
+func (f *issueFake) TransitionsWithFields(ctx context.Context, issueKeyOrId string) (*model.IssueTransitionsScheme, *model.ResponseScheme, error) {
+
+	transitions := &model.IssueTransitionsScheme{}
+	for _, edge := range f.workflow.Outgoing(f.status) {
+
+		to, _ := f.workflow.Status(edge.To)
+
+		transition := &model.IssueTransitionScheme{ID: edge.ID, Name: edge.Name, To: &model.StatusScheme{ID: to.ID, Name: to.Name}}
+		if edge.Screen != "" {
+			transition.Fields = map[string]*model.IssueTransitionFieldScheme{
+				"resolution":        {Required: true, Name: "Resolution", Key: "resolution"},
+				"customfield_10010": {Name: "Story Points", Key: "customfield_10010"},
+				"comment":           {Name: "Comment", Key: "comment"},
+			}
+		}
+
+		transitions.Transitions = append(transitions.Transitions, transition)
+	}
+
+	return transitions, nil, nil
+}
+
+func (f *issueFake) Move(ctx context.Context, issueKeyOrId, transitionId string, options *model.IssueMoveOptionsV3) (*model.ResponseScheme, error) {
+
+	for _, edge := range f.workflow.Outgoing(f.status) {
+
+		if edge.ID != transitionId {
+			continue
+		}
+
+		move := map[string]interface{}{"transition": transitionId}
+		if options != nil {
+
+			payload, err := options.Fields.MergeCustomFields(options.CustomFields)
+			if err != nil {
+				return nil, err
+			}
+
+			move["fields"] = payload["fields"]
+		}
+
+		f.moves = append(f.moves, move)
+		f.status = edge.To
+
+		return nil, nil
+	}
+
+	return nil, model.ErrBadRequestError
+}
+
+func newIssueFake(status string) *issueFake {
+	graph, _ := NewGraph(newWorkflow())
+	current, _ := graph.Status(status)
+
+	return &issueFake{workflow: graph, status: current.ID}
+}
+
+func TestGraph_ShortestPath(t *testing.T) {
+
+	graph, err := NewGraph(newWorkflow())
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		from    string
+		to      string
+		want    []string
+		wantErr error
+		err     string
+	}{
+		{name: "when the status is directly reachable", from: "To Do", to: "in progress", want: []string{"Start"}},
+		{name: "when the status is reachable in many steps", from: "To Do", to: "Done", want: []string{"Start", "Review", "Approve"}},
+		{name: "when the status is reachable through a global transition", from: "In Progress", to: "To Do", want: []string{"Cancel", "Reopen"}},
+		{name: "when the statuses are the same", from: "4", to: "Done"},
+		{
+			name:    "when the status doesn't exist",
+			from:    "To Do",
+			to:      "Blocked",
+			wantErr: ErrUnknownStatusError,
+			err:     "workflow: unknown status: Blocked",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			path, err := graph.ShortestPath(testCase.from, testCase.to)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				assert.EqualError(t, err, testCase.err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			var names []string
+			for _, transition := range path {
+				names = append(names, transition.Name)
+			}
+
+			assert.Equal(t, testCase.want, names)
+		})
+	}
+
+	unreachable := newWorkflow()
+	unreachable.Transitions = unreachable.Transitions[:4]
+
+	graph, err = NewGraph(unreachable)
+	assert.NoError(t, err)
+
+	_, err = graph.ShortestPath("Done", "To Do")
+	assert.ErrorIs(t, err, ErrUnreachableStatusError)
+	assert.EqualError(t, err, "workflow: the status isn't reachable: To Do from Done")
+}
+
+func TestTransitioner_TransitionTo(t *testing.T) {
+
+	workflow := func(ctx context.Context, issue *model.IssueScheme) (*model.WorkflowScheme, error) {
+		return newWorkflow(), nil
+	}
+
+	testCases := []struct {
+		name    string
+		status  string
+		target  string
+		values  map[string]interface{}
+		options *Options
+		steps   []string
+		moves   string
+		wantErr error
+		err     string
+	}{
+		{
+			name:   "when the status is directly reachable",
+			status: "To Do",
+			target: "in progress",
+			steps:  []string{"Start: To Do -> In Progress"},
+			moves:  `[{"transition":"11"}]`,
+		},
+		{
+			name:    "when the path is walked step by step",
+			status:  "To Do",
+			target:  "Done",
+			values:  map[string]interface{}{"Resolution": map[string]string{"name": "Fixed"}, "customfield_10010": 5, "Team": "Platform"},
+			options: &Options{Workflow: workflow},
+			steps:   []string{"Start: To Do -> In Progress", "Review: In Progress -> In Review", "Approve: In Review -> Done"},
+			moves: `[{"transition":"11"},{"transition":"21"},
+				{"transition":"31","fields":{"resolution":{"name":"Fixed"},"customfield_10010":5}}]`,
+		},
+		{
+			name:   "when the issue is already on the status",
+			status: "Done",
+			target: "done",
+			moves:  `null`,
+		},
+		{
+			name:    "when a required field is missing",
+			status:  "In Review",
+			target:  "Done",
+			moves:   `null`,
+			wantErr: ErrRequiredFieldError,
+			err:     "workflow: required fields missing: Approve requires Resolution",
+		},
+		{
+			name:    "when the status is not directly reachable and there's no workflow",
+			status:  "To Do",
+			target:  "Done",
+			moves:   `null`,
+			wantErr: ErrUnreachableStatusError,
+			err:     "workflow: the status isn't reachable: Done, no workflow set to find a path",
+		},
+		{
+			name:    "when the path exceeds the maximum steps",
+			status:  "To Do",
+			target:  "Done",
+			options: &Options{Workflow: workflow, MaxSteps: 2},
+			moves:   `null`,
+			wantErr: ErrTooManyStepsError,
+			err:     "workflow: the path exceeds the maximum steps: 3 steps to Done",
+		},
+		{
+			name:    "when a step fails the steps performed are returned",
+			status:  "To Do",
+			target:  "Done",
+			options: &Options{Workflow: workflow},
+			steps:   []string{"Start: To Do -> In Progress", "Review: In Progress -> In Review"},
+			moves:   `[{"transition":"11"},{"transition":"21"}]`,
+			wantErr: ErrRequiredFieldError,
+			err:     "workflow: required fields missing: Approve requires Resolution",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			fake := newIssueFake(testCase.status)
+
+			transitioner, err := NewTransitioner(fake, nil, testCase.options)
+			assert.NoError(t, err)
+
+			steps, err := transitioner.TransitionTo(context.Background(), "KP-1", testCase.target, testCase.values)
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				assert.EqualError(t, err, testCase.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			var got []string
+			for _, step := range steps {
+				got = append(got, step.Name+": "+step.From+" -> "+step.To)
+			}
+
+			assert.Equal(t, testCase.steps, got)
+
+			moves, err := json.Marshal(fake.moves)
+			assert.NoError(t, err)
+			assert.JSONEq(t, testCase.moves, string(moves))
+		})
+	}
+}
+
+type workflowFake struct {
+	jira.WorkflowConnector
+	calls int
+}
+
+type schemeFake struct {
+	jira.WorkflowSchemeConnector
+}
+
+type issueTypeFake struct {
+	jira.WorkflowSchemeIssueTypeConnector
+}
+
+func (f *workflowFake) Gets(ctx context.Context, options *model.WorkflowSearchOptions, startAt, maxResults int) (*model.WorkflowPageScheme, *model.ResponseScheme, error) {
+
+	f.calls++
+
+	if options.WorkflowName[0] != "Software Workflow" {
+		return &model.WorkflowPageScheme{}, nil, nil
+	}
+
+	return &model.WorkflowPageScheme{Values: []*model.WorkflowScheme{newWorkflow()}}, nil, nil
+}
+
+func (f *schemeFake) Associations(ctx context.Context, projectIds []int) (*model.WorkflowSchemeAssociationPageScheme, *model.ResponseScheme, error) {
+	return &model.WorkflowSchemeAssociationPageScheme{Values: []*model.WorkflowSchemeAssociationsScheme{
+		{ProjectIds: []string{"10000"}, WorkflowScheme: &model.WorkflowSchemeScheme{ID: 10100, DefaultWorkflow: "jira"}},
+	}}, nil, nil
+}
+
+func (f *issueTypeFake) Get(ctx context.Context, schemeID int, issueTypeID string, returnDraft bool) (*model.IssueTypeWorkflowMappingScheme, *model.ResponseScheme, error) {
+	return &model.IssueTypeWorkflowMappingScheme{IssueType: issueTypeID, Workflow: "Software Workflow"}, nil, nil
+}
+
+func TestWorkflowOf(t *testing.T) {
+
+	fake := &workflowFake{}
+	resolve := WorkflowOf(fake, &schemeFake{}, &issueTypeFake{})
+
+	issue := &model.IssueScheme{Fields: &model.IssueFieldsScheme{
+		Project:   &model.ProjectScheme{ID: "10000"},
+		IssueType: &model.IssueTypeScheme{ID: "10001"},
+	}}
+
+	workflow, err := resolve(context.Background(), issue)
+	assert.NoError(t, err)
+	assert.Equal(t, "Software Workflow", workflow.ID.Name)
+
+	_, err = resolve(context.Background(), issue)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fake.calls)
+
+	_, err = resolve(context.Background(), &model.IssueScheme{})
+	assert.ErrorIs(t, err, ErrNoWorkflowError)
+
+	_, err = WorkflowOf(nil, &schemeFake{}, &issueTypeFake{})(context.Background(), issue)
+	assert.ErrorIs(t, err, ErrNoWorkflowConnectorError)
+}
+
+func TestNewGraphs(t *testing.T) {
+
+	graphs, err := NewGraphs(&model.WorkflowPageScheme{Values: []*model.WorkflowScheme{newWorkflow(), nil}})
+	assert.NoError(t, err)
+	assert.Len(t, graphs, 1)
+	assert.Equal(t, "Software Workflow", graphs[0].Name)
+	assert.Len(t, graphs[0].Statuses, 5)
+	assert.Equal(t, "10001", graphs[0].Transitions[3].Screen)
+
+	_, err = NewGraphs(nil)
+	assert.ErrorIs(t, err, ErrNoWorkflowError)
+}
+
+func TestGraph_Analyze(t *testing.T) {
+
+	graph, err := NewGraph(newWorkflow())
+	assert.NoError(t, err)
+
+	report := graph.Analyze()
+	assert.Empty(t, report.Unreachable)
+	assert.Empty(t, report.DeadEnds)
+
+	var names []string
+	for _, transition := range report.WithoutScreen {
+		names = append(names, transition.Name)
+	}
+
+	assert.Equal(t, []string{"Start", "Review", "Cancel", "Reopen"}, names)
+
+	// Without the global and the reopen transitions, Cancelled is unreachable and Done is a dead end.
+	workflow := newWorkflow()
+	workflow.Transitions = workflow.Transitions[:4]
+
+	graph, err = NewGraph(workflow)
+	assert.NoError(t, err)
+
+	report = graph.Analyze()
+	assert.Equal(t, []*Status{{ID: "5", Name: "Cancelled"}}, report.Unreachable)
+	assert.Equal(t, []*Status{{ID: "4", Name: "Done"}, {ID: "5", Name: "Cancelled"}}, report.DeadEnds)
+	assert.False(t, report.Empty())
+}
+
+func TestGraph_DOT(t *testing.T) {
+
+	graph, err := NewGraph(newWorkflow())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `digraph "Software Workflow" {
+	rankdir=LR;
+	node [shape=box, style=rounded];
+	"1" [label="To Do"];
+	"2" [label="In Progress"];
+	"3" [label="In Review"];
+	"4" [label="Done"];
+	"5" [label="Cancelled"];
+	"initial" [shape=point];
+	"any" [label="Any status", shape=plaintext];
+	"initial" -> "1" [label="Create"];
+	"1" -> "2" [label="Start", style=dashed];
+	"2" -> "3" [label="Review", style=dashed];
+	"3" -> "4" [label="Approve"];
+	"any" -> "5" [label="Cancel", style=dashed];
+	"4" -> "1" [label="Reopen", style=dashed];
+	"5" -> "1" [label="Reopen", style=dashed];
+}
+`, graph.DOT())
+}
+
+func TestGraph_Mermaid(t *testing.T) {
+
+	workflow := newWorkflow()
+	workflow.Statuses[2].Name = `Review: "QA"`
+
+	graph, err := NewGraph(workflow)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `stateDiagram-v2
+    state "To Do" as s0
+    state "In Progress" as s1
+    state "Review#58; #quot;QA#quot;" as s2
+    state "Done" as s3
+    state "Cancelled" as s4
+    state "Any status" as any
+    [*] --> s0: Create
+    s0 --> s1: Start
+    s1 --> s2: Review
+    s2 --> s3: Approve
+    any --> s4: Cancel
+    s3 --> s0: Reopen
+    s4 --> s0: Reopen
+`, graph.Mermaid())
+}
+
+func TestCompare(t *testing.T) {
+
+	active, err := NewGraph(newWorkflow())
+	assert.NoError(t, err)
+
+	draft := newWorkflow()
+	draft.Statuses[2].Name = "Code Review"
+	draft.Statuses = append(draft.Statuses[:4], &model.WorkflowStatusScheme{ID: "6", Name: "Blocked"})
+	draft.Transitions[3].Screen = nil
+	draft.Transitions = append(draft.Transitions[:4], &model.WorkflowTransitionScheme{
+		ID: "61", Name: "Block", From: []string{"2"}, To: "6", Type: "directed"})
+
+	after, err := NewGraph(draft)
+	assert.NoError(t, err)
+
+	diff := Compare(active, after)
+	assert.False(t, diff.Empty())
+	assert.Len(t, diff.RemovedTransitions, 2)
+
+	assert.Equal(t, `+ status Blocked (6)
+- status Cancelled (5)
+~ status 3 renamed: In Review -> Code Review
++ transition Block (61): In Progress -> Blocked [no screen]
+- transition Cancel (41): any -> Cancelled [no screen]
+- transition Reopen (51): Done, Cancelled -> To Do [no screen]
+~ transition Approve changed screen: Approve (31): In Review -> Done [screen 10001] => Approve (31): Code Review -> Done [no screen]
+`, diff.String())
+
+	assert.True(t, Compare(active, active).Empty())
+}
@@ -0,0 +1,133 @@
+package worklogs
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Worklog is a worklog of the store with the details of the issue it's logged on.
+type Worklog struct {
+	ID        string `json:"id"`
+	IssueID   string `json:"issueId"`
+	IssueKey  string `json:"issueKey,omitempty"`
+	AccountID string `json:"accountId,omitempty"`
+
+	ProjectKey string   `json:"projectKey,omitempty"`
+	EpicKey    string   `json:"epicKey,omitempty"`
+	Labels     []string `json:"labels,omitempty"`
+
+	// Started keeps the offset of the worklog, the timesheets bucket it on the location of the query.
+	Started          time.Time `json:"started"`
+	Updated          time.Time `json:"updated,omitempty"`
+	TimeSpentSeconds int       `json:"timeSpentSeconds"`
+}
+
+// Checkpoint is the position of the sync on the change feeds, in Unix milliseconds.
+type Checkpoint struct {
+	Updated int `json:"updated"`
+	Deleted int `json:"deleted"`
+}
+
+// Store is the local copy of the worklogs, e.g: a table of a database.
+type Store interface {
+
+	// Checkpoint returns the checkpoint saved, nil or zero when the store has never been synced.
+	Checkpoint(ctx context.Context) (*Checkpoint, error)
+	SetCheckpoint(ctx context.Context, checkpoint *Checkpoint) error
+
+	// Put inserts or replaces the worklogs by ID.
+	Put(ctx context.Context, worklogs []*Worklog) error
+
+	// Delete deletes the worklogs by ID, the IDs unknown are ignored.
+	Delete(ctx context.Context, ids []string) error
+
+	// Worklogs returns the worklogs started within [from, to), the zero times leave the period open.
+	Worklogs(ctx context.Context, from, to time.Time) ([]*Worklog, error)
+}
+
+// NewMemoryStore returns a Store that keeps the worklogs in memory, e.g: for short-lived exports.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{worklogs: map[string]*Worklog{}, checkpoint: &Checkpoint{}}
+}
+
+// MemoryStore is a Store that keeps the worklogs in memory, it's safe for concurrent use.
+type MemoryStore struct {
+	mu         sync.Mutex
+	worklogs   map[string]*Worklog
+	checkpoint *Checkpoint
+}
+
+func (m *MemoryStore) Checkpoint(ctx context.Context) (*Checkpoint, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	checkpoint := *m.checkpoint
+	return &checkpoint, nil
+}
+
+func (m *MemoryStore) SetCheckpoint(ctx context.Context, checkpoint *Checkpoint) error {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	saved := *checkpoint
+	m.checkpoint = &saved
+	return nil
+}
+
+func (m *MemoryStore) Put(ctx context.Context, worklogs []*Worklog) error {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, worklog := range worklogs {
+		m.worklogs[worklog.ID] = worklog
+	}
+
+	return nil
+}
+
+func (m *MemoryStore) Delete(ctx context.Context, ids []string) error {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range ids {
+		delete(m.worklogs, id)
+	}
+
+	return nil
+}
+
+// Worklogs returns the worklogs sorted by start time and ID.
+func (m *MemoryStore) Worklogs(ctx context.Context, from, to time.Time) ([]*Worklog, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var worklogs []*Worklog
+	for _, worklog := range m.worklogs {
+		if within(worklog.Started, from, to) {
+			worklogs = append(worklogs, worklog)
+		}
+	}
+
+	sort.Slice(worklogs, func(i, j int) bool {
+
+		if !worklogs[i].Started.Equal(worklogs[j].Started) {
+			return worklogs[i].Started.Before(worklogs[j].Started)
+		}
+
+		return worklogs[i].ID < worklogs[j].ID
+	})
+
+	return worklogs, nil
+}
+
+// within reports whether the time is within [from, to), the zero times leave the period open.
+func within(value, from, to time.Time) bool {
+	return (from.IsZero() || !value.Before(from)) && (to.IsZero() || value.Before(to))
+}
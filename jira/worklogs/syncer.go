@@ -0,0 +1,390 @@
+// Package worklogs keeps a local copy of the worklogs of a Jira site up to date from the change
+// feeds of the site, and aggregates them into timesheets, e.g: to export the time spent per project
+// and week without downloading every issue.
+//
+//	store := worklogs.NewMemoryStore()
+//
+//	syncer, err := worklogs.NewSyncer(instance.Issue.Worklog, instance.Issue.Search, store, nil)
+//
+//	// Sync fetches the worklogs changed since the checkpoint of the store and applies the deletes.
+//	result, err := syncer.Sync(ctx)
+//
+//	timesheet, err := syncer.Timesheet(ctx, &worklogs.Query{
+//		From:     from,
+//		To:       to,
+//		Location: location,
+//		GroupBy:  []worklogs.Dimension{worklogs.UserDimension, worklogs.WeekDimension},
+//	})
+package worklogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/internal/sets"
+	"github.com/ctreminiom/go-atlassian/service/jira"
+)
+
+const (
+	// maxWorklogs is the maximum number of worklogs fetched per request.
+	maxWorklogs = 1000
+
+	// maxIssues is the maximum number of issues searched per query.
+	maxIssues = 100
+
+	// timeLayout is the layout of the start and update times of the worklogs.
+	timeLayout = "2006-01-02T15:04:05.000-0700"
+)
+
+var (
+	ErrNoWorklogConnectorError = errors.New("worklogs: no worklog connector set")
+	ErrNoSearchConnectorError  = errors.New("worklogs: no search connector set")
+	ErrNoStoreError            = errors.New("worklogs: no store set")
+	ErrNoQueryError            = errors.New("worklogs: no query set")
+)
+
+type Options struct {
+
+	// Since is the time the worklogs are synced from when the store has no checkpoint, e.g: the start
+	// of the fiscal year. All the worklogs are synced by default.
+	Since time.Time
+
+	// Progress is called after each page of changes is applied on the store.
+	Progress func(result *Result)
+}
+
+// Result is the outcome of a sync.
+type Result struct {
+	Updated int
+	Deleted int
+
+	// Checkpoint is the checkpoint of the store once the changes are applied.
+	Checkpoint *Checkpoint
+}
+
+// Syncer syncs the worklogs of a site into a store, the connectors are usually the Issue.Worklog
+// and Issue.Search services of the v3 client. A syncer isn't safe for concurrent use.
+type Syncer struct {
+	worklog jira.WorklogADFConnector
+	search  jira.SearchADFConnector
+	store   Store
+	options Options
+}
+
+// NewSyncer returns a syncer of the worklogs into the store, the connectors and the store are required.
+func NewSyncer(worklog jira.WorklogADFConnector, search jira.SearchADFConnector, store Store, options *Options) (*Syncer, error) {
+
+	if worklog == nil {
+		return nil, ErrNoWorklogConnectorError
+	}
+
+	if search == nil {
+		return nil, ErrNoSearchConnectorError
+	}
+
+	if store == nil {
+		return nil, ErrNoStoreError
+	}
+
+	syncer := &Syncer{worklog: worklog, search: search, store: store}
+
+	if options != nil {
+		syncer.options = *options
+	}
+
+	return syncer, nil
+}
+
+// Sync applies the worklogs updated and deleted since the checkpoint of the store. The checkpoint
+// is saved after each page of changes, so an interrupted sync resumes where it stopped.
+//
+// The updates are applied before the deletes, so a worklog updated and then deleted is removed.
+// The changes of the minute preceding the request aren't returned by the site, they're synced by
+// the next call.
+func (s *Syncer) Sync(ctx context.Context) (*Result, error) {
+
+	checkpoint, err := s.store.Checkpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkpoint == nil {
+		checkpoint = &Checkpoint{}
+	}
+
+	if checkpoint.Updated == 0 && checkpoint.Deleted == 0 && !s.options.Since.IsZero() {
+		since := int(s.options.Since.UnixNano() / int64(time.Millisecond))
+		checkpoint = &Checkpoint{Updated: since, Deleted: since}
+	}
+
+	result := &Result{Checkpoint: checkpoint}
+	issues := map[string]*issue{}
+
+	for {
+
+		page, _, err := s.worklog.Updated(ctx, checkpoint.Updated, nil)
+		if err != nil {
+			return result, err
+		}
+
+		ids := make([]int, 0, len(page.Values))
+		for _, value := range page.Values {
+			ids = append(ids, value.WorklogID)
+		}
+
+		for start := 0; start < len(ids); start += maxWorklogs {
+
+			end := start + maxWorklogs
+			if end > len(ids) {
+				end = len(ids)
+			}
+
+			saved, err := s.update(ctx, ids[start:end], issues)
+			if err != nil {
+				return result, err
+			}
+
+			result.Updated += saved
+		}
+
+		if page.Until > checkpoint.Updated {
+			checkpoint.Updated = page.Until
+		}
+
+		if err := s.checkpoint(ctx, result); err != nil {
+			return result, err
+		}
+
+		if page.LastPage || len(page.Values) == 0 {
+			break
+		}
+	}
+
+	for {
+
+		page, _, err := s.worklog.Deleted(ctx, checkpoint.Deleted)
+		if err != nil {
+			return result, err
+		}
+
+		ids := make([]string, 0, len(page.Values))
+		for _, value := range page.Values {
+			ids = append(ids, strconv.Itoa(value.WorklogID))
+		}
+
+		if len(ids) != 0 {
+
+			if err := s.store.Delete(ctx, ids); err != nil {
+				return result, err
+			}
+
+			result.Deleted += len(ids)
+		}
+
+		if page.Until > checkpoint.Deleted {
+			checkpoint.Deleted = page.Until
+		}
+
+		if err := s.checkpoint(ctx, result); err != nil {
+			return result, err
+		}
+
+		if page.LastPage || len(page.Values) == 0 {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// Timesheet aggregates the worklogs of the store started within the period of the query.
+func (s *Syncer) Timesheet(ctx context.Context, query *Query) (*Timesheet, error) {
+
+	if query == nil {
+		return nil, ErrNoQueryError
+	}
+
+	worklogs, err := s.store.Worklogs(ctx, query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return Aggregate(worklogs, query)
+}
+
+func (s *Syncer) checkpoint(ctx context.Context, result *Result) error {
+
+	if err := s.store.SetCheckpoint(ctx, result.Checkpoint); err != nil {
+		return err
+	}
+
+	if s.options.Progress != nil {
+		s.options.Progress(result)
+	}
+
+	return nil
+}
+
+// update fetches the worklogs and the issues they're logged on and saves them on the store. The
+// worklogs deleted since they were listed aren't returned by the site and are skipped.
+func (s *Syncer) update(ctx context.Context, ids []int, issues map[string]*issue) (int, error) {
+
+	values, _, err := s.worklog.Gets(ctx, ids, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var missing []string
+	for _, value := range values {
+		if _, ok := issues[value.IssueID]; !ok {
+			missing = append(missing, value.IssueID)
+		}
+	}
+
+	if err := s.issues(ctx, sets.Strings(missing), issues); err != nil {
+		return 0, err
+	}
+
+	worklogs := make([]*Worklog, 0, len(values))
+	for _, value := range values {
+
+		worklog := &Worklog{ID: value.ID, IssueID: value.IssueID, TimeSpentSeconds: value.TimeSpentSeconds}
+
+		if value.Author != nil {
+			worklog.AccountID = value.Author.AccountID
+		}
+
+		if worklog.Started, err = time.Parse(timeLayout, value.Started); err != nil {
+			return 0, fmt.Errorf("worklogs: invalid start of the worklog %v: %w", value.ID, err)
+		}
+
+		if value.Updated != "" {
+			if worklog.Updated, err = time.Parse(timeLayout, value.Updated); err != nil {
+				return 0, fmt.Errorf("worklogs: invalid update of the worklog %v: %w", value.ID, err)
+			}
+		}
+
+		if found, ok := issues[value.IssueID]; ok {
+			worklog.IssueKey, worklog.ProjectKey, worklog.EpicKey = found.key, found.project, found.epic
+			worklog.Labels = found.labels
+		}
+
+		worklogs = append(worklogs, worklog)
+	}
+
+	if len(worklogs) == 0 {
+		return 0, nil
+	}
+
+	return len(worklogs), s.store.Put(ctx, worklogs)
+}
+
+// issue is the issue a worklog is logged on.
+type issue struct {
+	key     string
+	project string
+	epic    string
+	labels  []string
+
+	// parent is the ID of the parent of the sub-tasks, their epic is the parent of their parent.
+	parent string
+}
+
+// issues searches the issues of the IDs and adds them to the index. The epic of the issues is their
+// parent, the epic of the sub-tasks is the parent of their parent issue.
+func (s *Syncer) issues(ctx context.Context, ids []string, index map[string]*issue) error {
+
+	if err := s.searchIssues(ctx, ids, index); err != nil {
+		return err
+	}
+
+	var parents []string
+	for _, id := range ids {
+
+		found, ok := index[id]
+		if !ok || found.parent == "" {
+			continue
+		}
+
+		if _, ok := index[found.parent]; !ok {
+			parents = append(parents, found.parent)
+		}
+	}
+
+	if err := s.searchIssues(ctx, sets.Strings(parents), index); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+
+		found, ok := index[id]
+		if !ok || found.parent == "" {
+			continue
+		}
+
+		if parent, ok := index[found.parent]; ok {
+			found.epic = parent.epic
+		}
+	}
+
+	return nil
+}
+
+func (s *Syncer) searchIssues(ctx context.Context, ids []string, index map[string]*issue) error {
+
+	fields := []string{"project", "labels", "parent", "issuetype"}
+
+	for start := 0; start < len(ids); start += maxIssues {
+
+		end := start + maxIssues
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		jql := fmt.Sprintf("id IN (%v)", strings.Join(ids[start:end], ","))
+
+		for startAt := 0; ; {
+
+			page, _, err := s.search.Post(ctx, jql, fields, nil, startAt, maxIssues, "")
+			if err != nil {
+				return err
+			}
+
+			for _, value := range page.Issues {
+
+				found := &issue{key: value.Key}
+
+				if value.Fields != nil {
+
+					if value.Fields.Project != nil {
+						found.project = value.Fields.Project.Key
+					}
+
+					found.labels = value.Fields.Labels
+
+					if value.Fields.Parent != nil {
+
+						if value.Fields.IssueType != nil && value.Fields.IssueType.Subtask {
+							found.parent = value.Fields.Parent.ID
+						} else {
+							found.epic = value.Fields.Parent.Key
+						}
+					}
+				}
+
+				index[value.ID] = found
+			}
+
+			startAt += len(page.Issues)
+			if len(page.Issues) == 0 || startAt >= page.Total {
+				break
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package worklogs
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/internal/sets"
+)
+
+var ErrInvalidDimensionError = errors.New("worklogs: invalid dimension")
+
+// Dimension is an attribute the time spent is aggregated by.
+type Dimension string
+
+const (
+	UserDimension    Dimension = "user"
+	ProjectDimension Dimension = "project"
+	EpicDimension    Dimension = "epic"
+
+	// LabelDimension aggregates the time spent by label of the issue, the worklogs of the issues
+	// with many labels count on each of them, so the rows may add up to more than the total.
+	LabelDimension Dimension = "label"
+
+	// DayDimension is the date the worklog started on the location of the query, e.g: 2026-01-31.
+	DayDimension Dimension = "day"
+
+	// WeekDimension is the ISO 8601 week the worklog started on the location of the query, e.g: 2026-W05.
+	WeekDimension Dimension = "week"
+)
+
+// Query selects the worklogs of a timesheet and the dimensions the time spent is aggregated by.
+type Query struct {
+
+	// From and To select the worklogs started within [From, To), the zero times leave the period open.
+	From time.Time
+	To   time.Time
+
+	// Location is the time zone of the days and weeks, UTC by default, e.g: a worklog started on
+	// Monday at 01:00 in Madrid was started on Sunday in UTC.
+	Location *time.Location
+
+	// GroupBy are the dimensions of the rows, in order. The time spent is totaled when empty.
+	GroupBy []Dimension
+}
+
+// Timesheet is the time spent aggregated by the dimensions of the query.
+type Timesheet struct {
+	Dimensions []Dimension
+
+	// Rows are sorted by the values of the dimensions.
+	Rows []*Row
+
+	// TotalSeconds is the time spent of the worklogs selected, each worklog counts once.
+	TotalSeconds int
+}
+
+// Row is the time spent for a combination of values of the dimensions.
+type Row struct {
+
+	// Values are the values of the dimensions of the timesheet, in order. The empty values are the
+	// worklogs without value, e.g: the issues without epic.
+	Values  []string
+	Seconds int
+}
+
+// Hours returns the time spent of the row in hours.
+func (r *Row) Hours() float64 {
+	return float64(r.Seconds) / 3600
+}
+
+// Aggregate returns the timesheet of the worklogs started within the period of the query.
+func Aggregate(worklogs []*Worklog, query *Query) (*Timesheet, error) {
+
+	if query == nil {
+		return nil, ErrNoQueryError
+	}
+
+	location := query.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	for _, dimension := range query.GroupBy {
+
+		switch dimension {
+		case UserDimension, ProjectDimension, EpicDimension, LabelDimension, DayDimension, WeekDimension:
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrInvalidDimensionError, dimension)
+		}
+	}
+
+	timesheet := &Timesheet{Dimensions: query.GroupBy}
+	rows := map[string]*Row{}
+
+	for _, worklog := range worklogs {
+
+		if !within(worklog.Started, query.From, query.To) {
+			continue
+		}
+
+		timesheet.TotalSeconds += worklog.TimeSpentSeconds
+
+		for _, values := range combinations(worklog, query.GroupBy, location) {
+
+			key := strings.Join(values, "\x00")
+
+			row, ok := rows[key]
+			if !ok {
+				row = &Row{Values: values}
+				rows[key] = row
+				timesheet.Rows = append(timesheet.Rows, row)
+			}
+
+			row.Seconds += worklog.TimeSpentSeconds
+		}
+	}
+
+	sort.Slice(timesheet.Rows, func(i, j int) bool {
+
+		for index := range timesheet.Rows[i].Values {
+			if timesheet.Rows[i].Values[index] != timesheet.Rows[j].Values[index] {
+				return timesheet.Rows[i].Values[index] < timesheet.Rows[j].Values[index]
+			}
+		}
+
+		return false
+	})
+
+	return timesheet, nil
+}
+
+// WriteCSV writes the timesheet with a column per dimension and the time spent in seconds and hours.
+func (t *Timesheet) WriteCSV(w io.Writer) error {
+
+	writer := csv.NewWriter(w)
+
+	header := make([]string, 0, len(t.Dimensions)+2)
+	for _, dimension := range t.Dimensions {
+		header = append(header, string(dimension))
+	}
+
+	if err := writer.Write(append(header, "seconds", "hours")); err != nil {
+		return err
+	}
+
+	for _, row := range t.Rows {
+
+		record := append(append([]string{}, row.Values...), strconv.Itoa(row.Seconds), strconv.FormatFloat(row.Hours(), 'f', 2, 64))
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// combinations returns the values of the dimensions of the worklog, a combination per label when
+// the worklog is aggregated by label.
+func combinations(worklog *Worklog, dimensions []Dimension, location *time.Location) [][]string {
+
+	started := worklog.Started.In(location)
+	combined := [][]string{{}}
+
+	for _, dimension := range dimensions {
+
+		var values []string
+		switch dimension {
+		case UserDimension:
+			values = []string{worklog.AccountID}
+		case ProjectDimension:
+			values = []string{worklog.ProjectKey}
+		case EpicDimension:
+			values = []string{worklog.EpicKey}
+		case DayDimension:
+			values = []string{started.Format("2006-01-02")}
+		case WeekDimension:
+			year, week := started.ISOWeek()
+			values = []string{fmt.Sprintf("%04d-W%02d", year, week)}
+		case LabelDimension:
+			values = sets.Strings(worklog.Labels)
+			if len(values) == 0 {
+				values = []string{""}
+			}
+		}
+
+		next := make([][]string, 0, len(combined)*len(values))
+		for _, prefix := range combined {
+			for _, value := range values {
+				next = append(next, append(append([]string{}, prefix...), value))
+			}
+		}
+
+		combined = next
+	}
+
+	return combined
+}
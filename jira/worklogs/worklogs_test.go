@@ -0,0 +1,308 @@
+package worklogs
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/jira"
+	"github.com/stretchr/testify/assert"
+)
+
+type worklogFake struct {
+	jira.WorklogADFConnector
+
+	updated  map[int]*model.ChangedWorklogPageScheme
+	deleted  map[int]*model.ChangedWorklogPageScheme
+	worklogs map[int]*model.IssueWorklogADFScheme
+	batches  []int
+}
+
+func (w *worklogFake) Updated(ctx context.Context, since int, expand []string) (*model.ChangedWorklogPageScheme, *model.ResponseScheme, error) {
+
+	if page, ok := w.updated[since]; ok {
+		return page, nil, nil
+	}
+
+	return &model.ChangedWorklogPageScheme{Since: since, Until: since, LastPage: true}, nil, nil
+}
+
+func (w *worklogFake) Deleted(ctx context.Context, since int) (*model.ChangedWorklogPageScheme, *model.ResponseScheme, error) {
+
+	if page, ok := w.deleted[since]; ok {
+		return page, nil, nil
+	}
+
+	return &model.ChangedWorklogPageScheme{Since: since, Until: since, LastPage: true}, nil, nil
+}
+
+func (w *worklogFake) Gets(ctx context.Context, worklogIDs []int, expand []string) ([]*model.IssueWorklogADFScheme, *model.ResponseScheme, error) {
+
+	w.batches = append(w.batches, len(worklogIDs))
+
+	var worklogs []*model.IssueWorklogADFScheme
+	for _, id := range worklogIDs {
+		if worklog, ok := w.worklogs[id]; ok {
+			worklogs = append(worklogs, worklog)
+		}
+	}
+
+	return worklogs, nil, nil
+}
+
+type searchFake struct {
+	jira.SearchADFConnector
+
+	issues  map[string]*model.IssueScheme
+	queries []string
+}
+
+func (s *searchFake) Post(ctx context.Context, jql string, fields, expands []string, startAt, maxResults int,
+	validate string) (*model.IssueSearchScheme, *model.ResponseScheme, error) {
+
+	s.queries = append(s.queries, jql)
+
+	page := &model.IssueSearchScheme{}
+	for _, id := range strings.Split(strings.TrimSuffix(strings.TrimPrefix(jql, "id IN ("), ")"), ",") {
+		if found, ok := s.issues[id]; ok {
+			page.Issues = append(page.Issues, found)
+		}
+	}
+
+	page.Total = len(page.Issues)
+	return page, nil, nil
+}
+
+func changes(until int, last bool, ids ...int) *model.ChangedWorklogPageScheme {
+
+	page := &model.ChangedWorklogPageScheme{Until: until, LastPage: last}
+	for _, id := range ids {
+		page.Values = append(page.Values, &model.ChangedWorklogScheme{WorklogID: id, UpdatedTime: until})
+	}
+
+	return page
+}
+
+func worklogOf(id int, issueID, accountID, started string, seconds int) *model.IssueWorklogADFScheme {
+	return &model.IssueWorklogADFScheme{
+		ID:               strconv.Itoa(id),
+		IssueID:          issueID,
+		Author:           &model.UserDetailScheme{AccountID: accountID},
+		Started:          started,
+		Updated:          started,
+		TimeSpentSeconds: seconds,
+	}
+}
+
+func newFakes() (*worklogFake, *searchFake) {
+
+	worklogs := &worklogFake{
+		updated: map[int]*model.ChangedWorklogPageScheme{
+			0:    changes(1000, false, 1, 2, 3),
+			1000: changes(2000, true, 4),
+		},
+		deleted: map[int]*model.ChangedWorklogPageScheme{
+			0: changes(3000, true, 4),
+		},
+		worklogs: map[int]*model.IssueWorklogADFScheme{
+			1: worklogOf(1, "100", "account-a", "2026-01-04T23:30:00.000+0000", 3600),
+			2: worklogOf(2, "101", "account-b", "2026-01-05T00:30:00.000+0100", 1800),
+			3: worklogOf(3, "102", "account-a", "2026-01-06T10:00:00.000+0000", 7200),
+			4: worklogOf(4, "102", "account-b", "2026-01-06T12:00:00.000+0000", 600),
+		},
+	}
+
+	search := &searchFake{
+		issues: map[string]*model.IssueScheme{
+			"100": {ID: "100", Key: "KP-1", Fields: &model.IssueFieldsScheme{
+				Project:   &model.ProjectScheme{Key: "KP"},
+				IssueType: &model.IssueTypeScheme{Name: "Story"},
+				Parent:    &model.ParentScheme{ID: "90", Key: "EP-1"},
+				Labels:    []string{"backend", "api"},
+			}},
+			"101": {ID: "101", Key: "KP-2", Fields: &model.IssueFieldsScheme{
+				Project:   &model.ProjectScheme{Key: "KP"},
+				IssueType: &model.IssueTypeScheme{Name: "Sub-task", Subtask: true},
+				Parent:    &model.ParentScheme{ID: "100", Key: "KP-1"},
+			}},
+			"102": {ID: "102", Key: "OPS-1", Fields: &model.IssueFieldsScheme{
+				Project:   &model.ProjectScheme{Key: "OPS"},
+				IssueType: &model.IssueTypeScheme{Name: "Task"},
+			}},
+		},
+	}
+
+	return worklogs, search
+}
+
+func TestNewSyncer(t *testing.T) {
+
+	worklogs, search := newFakes()
+
+	_, err := NewSyncer(nil, search, NewMemoryStore(), nil)
+	assert.ErrorIs(t, err, ErrNoWorklogConnectorError)
+
+	_, err = NewSyncer(worklogs, nil, NewMemoryStore(), nil)
+	assert.ErrorIs(t, err, ErrNoSearchConnectorError)
+
+	_, err = NewSyncer(worklogs, search, nil, nil)
+	assert.ErrorIs(t, err, ErrNoStoreError)
+}
+
+func TestSyncer_Sync(t *testing.T) {
+
+	worklogs, search := newFakes()
+	store := NewMemoryStore()
+
+	var progress []Checkpoint
+	syncer, err := NewSyncer(worklogs, search, store, &Options{
+		Progress: func(result *Result) { progress = append(progress, *result.Checkpoint) },
+	})
+	assert.NoError(t, err)
+
+	result, err := syncer.Sync(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, 4, result.Updated)
+	assert.Equal(t, 1, result.Deleted)
+	assert.Equal(t, &Checkpoint{Updated: 2000, Deleted: 3000}, result.Checkpoint)
+	assert.Equal(t, []Checkpoint{{Updated: 1000}, {Updated: 2000}, {Updated: 2000, Deleted: 3000}}, progress)
+
+	// The issues are searched once per sync, the parent of the sub-task was already found.
+	assert.Equal(t, []string{"id IN (100,101,102)"}, search.queries)
+
+	// The worklogs 1 and 2 started at the same time, the worklog 4 was deleted.
+	saved, err := store.Worklogs(context.Background(), time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Len(t, saved, 3)
+
+	assert.Equal(t, &Worklog{
+		ID:               "2",
+		IssueID:          "101",
+		IssueKey:         "KP-2",
+		AccountID:        "account-b",
+		ProjectKey:       "KP",
+		EpicKey:          "EP-1",
+		Started:          saved[1].Started,
+		Updated:          saved[1].Updated,
+		TimeSpentSeconds: 1800,
+	}, saved[1])
+	assert.True(t, saved[1].Started.Equal(time.Date(2026, 1, 4, 23, 30, 0, 0, time.UTC)))
+	assert.Equal(t, []string{"backend", "api"}, saved[0].Labels)
+
+	checkpoint, err := store.Checkpoint(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &Checkpoint{Updated: 2000, Deleted: 3000}, checkpoint)
+
+	t.Run("when there are no changes since the checkpoint", func(t *testing.T) {
+
+		result, err := syncer.Sync(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.Updated)
+		assert.Equal(t, 0, result.Deleted)
+		assert.Equal(t, &Checkpoint{Updated: 2000, Deleted: 3000}, result.Checkpoint)
+	})
+
+	t.Run("when the changes exceed the worklogs per request", func(t *testing.T) {
+
+		worklogs, search := newFakes()
+
+		var ids []int
+		for id := 1; id <= 2500; id++ {
+			ids = append(ids, id)
+		}
+
+		worklogs.updated = map[int]*model.ChangedWorklogPageScheme{500: changes(4000, true, ids...)}
+
+		syncer, err := NewSyncer(worklogs, search, NewMemoryStore(), &Options{Since: time.Unix(0, 500*int64(time.Millisecond))})
+		assert.NoError(t, err)
+
+		result, err := syncer.Sync(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1000, 1000, 500}, worklogs.batches)
+		assert.Equal(t, 4, result.Updated)
+		assert.Equal(t, &Checkpoint{Updated: 4000, Deleted: 500}, result.Checkpoint)
+	})
+
+	t.Run("when the start of a worklog is invalid", func(t *testing.T) {
+
+		worklogs, search := newFakes()
+		worklogs.worklogs[1].Started = "yesterday"
+
+		syncer, err := NewSyncer(worklogs, search, NewMemoryStore(), nil)
+		assert.NoError(t, err)
+
+		result, err := syncer.Sync(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, 0, result.Checkpoint.Updated)
+	})
+}
+
+func TestSyncer_Timesheet(t *testing.T) {
+
+	worklogs, search := newFakes()
+
+	syncer, err := NewSyncer(worklogs, search, NewMemoryStore(), nil)
+	assert.NoError(t, err)
+
+	_, err = syncer.Sync(context.Background())
+	assert.NoError(t, err)
+
+	rows := func(timesheet *Timesheet) []string {
+
+		var values []string
+		for _, row := range timesheet.Rows {
+			values = append(values, strings.Join(row.Values, "|")+" "+strconv.Itoa(row.Seconds))
+		}
+
+		return values
+	}
+
+	cet := time.FixedZone("CET", 3600)
+
+	timesheet, err := syncer.Timesheet(context.Background(), &Query{Location: cet, GroupBy: []Dimension{UserDimension, WeekDimension}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"account-a|2026-W02 10800", "account-b|2026-W02 1800"}, rows(timesheet))
+	assert.Equal(t, 12600, timesheet.TotalSeconds)
+
+	// The worklogs started on Sunday night in UTC were started on Monday in CET.
+	timesheet, err = syncer.Timesheet(context.Background(), &Query{GroupBy: []Dimension{UserDimension, WeekDimension}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"account-a|2026-W01 3600", "account-a|2026-W02 7200", "account-b|2026-W01 1800"}, rows(timesheet))
+
+	timesheet, err = syncer.Timesheet(context.Background(), &Query{Location: cet, GroupBy: []Dimension{DayDimension}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2026-01-05 5400", "2026-01-06 7200"}, rows(timesheet))
+
+	timesheet, err = syncer.Timesheet(context.Background(), &Query{GroupBy: []Dimension{ProjectDimension, EpicDimension}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"KP|EP-1 5400", "OPS| 7200"}, rows(timesheet))
+
+	timesheet, err = syncer.Timesheet(context.Background(), &Query{GroupBy: []Dimension{LabelDimension}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{" 9000", "api 3600", "backend 3600"}, rows(timesheet))
+	assert.Equal(t, 12600, timesheet.TotalSeconds)
+
+	timesheet, err = syncer.Timesheet(context.Background(), &Query{
+		From:     time.Date(2026, 1, 5, 0, 0, 0, 0, cet),
+		To:       time.Date(2026, 1, 6, 0, 0, 0, 0, cet),
+		Location: cet,
+		GroupBy:  []Dimension{ProjectDimension},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"KP 5400"}, rows(timesheet))
+
+	buffer := new(bytes.Buffer)
+	assert.NoError(t, timesheet.WriteCSV(buffer))
+	assert.Equal(t, "project,seconds,hours\nKP,5400,1.50\n", buffer.String())
+
+	_, err = syncer.Timesheet(context.Background(), &Query{GroupBy: []Dimension{"month"}})
+	assert.EqualError(t, err, `worklogs: invalid dimension: "month"`)
+
+	_, err = syncer.Timesheet(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrNoQueryError)
+}
@@ -0,0 +1,130 @@
+// Package middleware provides the built-in middlewares of the clients, configured with the
+// Use method of each client, e.g:
+//
+//	instance.Use(middleware.CorrelationID(""), middleware.Logging(log.Default()))
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/service/common"
+	"github.com/google/uuid"
+)
+
+// DefaultCorrelationIDHeader is the header set by the CorrelationID middleware when no header is provided.
+const DefaultCorrelationIDHeader = "X-Correlation-ID"
+
+// Logger is the logger used by the Logging middleware, e.g: *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// Logging logs the method, URL, status and duration of each request.
+func Logging(logger Logger) common.Middleware {
+
+	return func(next common.RoundTrip) common.RoundTrip {
+		return func(request *http.Request) (*http.Response, error) {
+
+			started := time.Now()
+			response, err := next(request)
+			elapsed := time.Since(started)
+
+			if err != nil {
+				logger.Printf("%v %v failed after %v: %v", request.Method, request.URL.Redacted(), elapsed, err)
+				return response, err
+			}
+
+			logger.Printf("%v %v %v in %v", request.Method, request.URL.Redacted(), response.StatusCode, elapsed)
+			return response, nil
+		}
+	}
+}
+
+// DumpOnError writes the request and the response on the writer when the request fails or the
+// response status is not 2xx, the Authorization header is redacted.
+func DumpOnError(w io.Writer) common.Middleware {
+
+	var mu sync.Mutex
+
+	return func(next common.RoundTrip) common.RoundTrip {
+		return func(request *http.Request) (*http.Response, error) {
+
+			// The request is dumped before being sent, since its body can only be read once.
+			redacted := request.Clone(request.Context())
+			if redacted.Header.Get("Authorization") != "" {
+				redacted.Header.Set("Authorization", "REDACTED")
+			}
+
+			dump, dumpErr := httputil.DumpRequestOut(redacted, true)
+			if dumpErr == nil && redacted.Body != nil {
+				request = request.Clone(request.Context())
+				request.Body = redacted.Body
+			}
+
+			response, err := next(request)
+			if err == nil && response.StatusCode >= 200 && response.StatusCode < 300 {
+				return response, nil
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if dumpErr == nil {
+				fmt.Fprintf(w, "%s\n", dump)
+			}
+
+			if err != nil {
+				fmt.Fprintf(w, "error: %v\n\n", err)
+				return response, err
+			}
+
+			if responseDump, dumpErr := httputil.DumpResponse(response, true); dumpErr == nil {
+				fmt.Fprintf(w, "%s\n\n", responseDump)
+			}
+
+			return response, nil
+		}
+	}
+}
+
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a context whose requests are sent with the correlation ID provided
+// by the CorrelationID middleware, e.g: the ID of the incoming request being served.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID sets the correlation ID header of the requests that don't have one, the ID is
+// taken from the request context or generated. The header is DefaultCorrelationIDHeader when empty.
+func CorrelationID(header string) common.Middleware {
+
+	if header == "" {
+		header = DefaultCorrelationIDHeader
+	}
+
+	return func(next common.RoundTrip) common.RoundTrip {
+		return func(request *http.Request) (*http.Response, error) {
+
+			if request.Header.Get(header) != "" {
+				return next(request)
+			}
+
+			id, ok := request.Context().Value(correlationIDKey{}).(string)
+			if !ok || id == "" {
+				id = uuid.NewString()
+			}
+
+			request = request.Clone(request.Context())
+			request.Header.Set(header, id)
+
+			return next(request)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// TokenStore persists the tokens of each tenant, e.g: on a database, so the refreshed tokens
+// survive a restart and are shared between instances.
+type TokenStore interface {
+
+	// Load returns the token of the key, or nil if there's none.
+	Load(ctx context.Context, key string) (*Token, error)
+
+	// Save persists the token of the key, it's called after each refresh.
+	Save(ctx context.Context, key string, token *Token) error
+}
+
+// NewMemoryTokenStore returns a TokenStore that keeps the tokens in memory.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string]*Token)}
+}
+
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]*Token
+}
+
+func (m *MemoryTokenStore) Load(ctx context.Context, key string) (*Token, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.tokens[key], nil
+}
+
+func (m *MemoryTokenStore) Save(ctx context.Context, key string, token *Token) error {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tokens[key] = token
+	return nil
+}
+
+// Client returns an HTTP client that authenticates the requests with the token stored under the key,
+// the token is refreshed and saved on the store when it expires.
+func (c *Config) Client(store TokenStore, key string) *Client {
+	return &Client{config: c, store: store, key: key}
+}
+
+// Client is a common.HttpClient that sends the requests with an OAuth 2.0 bearer token,
+// through the Config HTTPClient.
+type Client struct {
+	config *Config
+	store  TokenStore
+	key    string
+	mu     sync.Mutex
+}
+
+// Do sends the request with the Authorization header of a valid token.
+func (c *Client) Do(request *http.Request) (*http.Response, error) {
+
+	token, err := c.Token(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	authorized := request.Clone(request.Context())
+	authorized.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	httpClient := c.config.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return httpClient.Do(authorized)
+}
+
+// Token returns the stored token, refreshing it first if it has expired.
+func (c *Client) Token(ctx context.Context) (*Token, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	token, err := c.store.Load(ctx, c.key)
+	if err != nil {
+		return nil, err
+	}
+
+	if token == nil {
+		return nil, ErrNoTokenError
+	}
+
+	if token.Valid() {
+		return token, nil
+	}
+
+	refreshed, err := c.config.Refresh(ctx, token.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+
+	if err = c.store.Save(ctx, c.key, refreshed); err != nil {
+		return nil, err
+	}
+
+	return refreshed, nil
+}
@@ -0,0 +1,301 @@
+// Package oauth implements the Atlassian OAuth 2.0 (3LO) authorization code flow with PKCE,
+// the accessible resources lookup and an HTTP client that refreshes the access token on expiry.
+//
+// The client returned by Config.Client can be used by any client of the module, e.g:
+//
+//	httpClient := config.Client(store, cloudID)
+//	instance, err := v3.New(httpClient, oauth.SiteURL(oauth.JiraProduct, cloudID))
+package oauth
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/service/common"
+)
+
+const (
+	DefaultAuthURL      = "https://auth.atlassian.com/authorize"
+	DefaultTokenURL     = "https://auth.atlassian.com/oauth/token"
+	DefaultResourcesURL = "https://api.atlassian.com/oauth/token/accessible-resources"
+
+	apiAudience = "api.atlassian.com"
+)
+
+// The products of the site URLs, see SiteURL.
+const (
+	JiraProduct       = "jira"
+	ConfluenceProduct = "confluence"
+)
+
+var (
+	ErrNoClientIDError     = errors.New("oauth: no client id set")
+	ErrNoCodeError         = errors.New("oauth: no authorization code set")
+	ErrNoTokenError        = errors.New("oauth: no token set")
+	ErrNoRefreshTokenError = errors.New("oauth: the token has expired and has no refresh token")
+	ErrTokenRequestError   = errors.New("oauth: token request failed")
+	ErrResourcesError      = errors.New("oauth: accessible resources request failed")
+)
+
+// Config is the OAuth 2.0 (3LO) app configuration, from the developer console.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+
+	// Scopes are the scopes requested, e.g: read:jira-work offline_access. The offline_access
+	// scope is required to get a refresh token.
+	Scopes []string
+
+	// HTTPClient sends the token and accessible resources requests, http.DefaultClient by default.
+	HTTPClient common.HttpClient
+
+	// AuthURL, TokenURL and ResourcesURL override the Atlassian endpoints, e.g: on tests.
+	AuthURL, TokenURL, ResourcesURL string
+}
+
+// Token is an OAuth 2.0 access token.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	TokenType    string    `json:"token_type,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	ExpiresIn    int       `json:"expires_in,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// expiryDelta refreshes the tokens slightly before their expiry, so they don't expire in flight.
+const expiryDelta = time.Minute
+
+// Valid reports whether the token has an access token that's not about to expire.
+func (t *Token) Valid() bool {
+	return t != nil && t.AccessToken != "" && (t.Expiry.IsZero() || time.Now().Add(expiryDelta).Before(t.Expiry))
+}
+
+// Resource is a site the token grants access to.
+type Resource struct {
+	ID        string   `json:"id"`
+	URL       string   `json:"url"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	AvatarURL string   `json:"avatarUrl"`
+}
+
+// SiteURL returns the API URL of a site for the product, e.g: https://api.atlassian.com/ex/jira/{cloudID}.
+func SiteURL(product, cloudID string) string {
+	return fmt.Sprintf("https://%v/ex/%v/%v", apiAudience, product, cloudID)
+}
+
+// NewState returns a random state, to be stored on the user session and compared on the callback.
+func NewState() (string, error) {
+	return random(24)
+}
+
+// NewVerifier returns a random PKCE code verifier, to be stored on the user session and sent on Exchange.
+func NewVerifier() (string, error) {
+	return random(48)
+}
+
+// Challenge returns the S256 PKCE code challenge of the verifier.
+func Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthCodeURL returns the URL the user is redirected to for consent, the verifier is optional.
+func (c *Config) AuthCodeURL(state, verifier string) string {
+
+	params := url.Values{}
+	params.Add("audience", apiAudience)
+	params.Add("client_id", c.ClientID)
+	params.Add("scope", strings.Join(c.Scopes, " "))
+	params.Add("redirect_uri", c.RedirectURL)
+	params.Add("state", state)
+	params.Add("response_type", "code")
+	params.Add("prompt", "consent")
+
+	if verifier != "" {
+		params.Add("code_challenge", Challenge(verifier))
+		params.Add("code_challenge_method", "S256")
+	}
+
+	return fmt.Sprintf("%v?%v", c.endpoint(c.AuthURL, DefaultAuthURL), params.Encode())
+}
+
+// Exchange exchanges the authorization code of the callback for a token, the verifier is the
+// one used on AuthCodeURL, if any.
+func (c *Config) Exchange(ctx context.Context, code, verifier string) (*Token, error) {
+
+	if code == "" {
+		return nil, ErrNoCodeError
+	}
+
+	payload := map[string]string{
+		"grant_type":    "authorization_code",
+		"client_id":     c.ClientID,
+		"client_secret": c.ClientSecret,
+		"code":          code,
+		"redirect_uri":  c.RedirectURL,
+	}
+
+	if verifier != "" {
+		payload["code_verifier"] = verifier
+	}
+
+	return c.token(ctx, payload)
+}
+
+// Refresh exchanges the refresh token for a new token.
+//
+// Atlassian rotates the refresh tokens, so the token returned must replace the previous one.
+func (c *Config) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+
+	if refreshToken == "" {
+		return nil, ErrNoRefreshTokenError
+	}
+
+	return c.token(ctx, map[string]string{
+		"grant_type":    "refresh_token",
+		"client_id":     c.ClientID,
+		"client_secret": c.ClientSecret,
+		"refresh_token": refreshToken,
+	})
+}
+
+// AccessibleResources returns the sites the token grants access to, the resource ID is the cloud ID of the site.
+func (c *Config) AccessibleResources(ctx context.Context, token *Token) ([]*Resource, error) {
+
+	if token == nil || token.AccessToken == "" {
+		return nil, ErrNoTokenError
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint(c.ResourcesURL, DefaultResourcesURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	var resources []*Resource
+	if err = c.do(request, &resources, ErrResourcesError); err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// CloudID returns the cloud ID of the site accessible by the token, the site is matched by URL
+// (e.g: https://your-domain.atlassian.net) or name.
+func (c *Config) CloudID(ctx context.Context, token *Token, site string) (string, error) {
+
+	resources, err := c.AccessibleResources(ctx, token)
+	if err != nil {
+		return "", err
+	}
+
+	site = strings.TrimSuffix(site, "/")
+	for _, resource := range resources {
+		if strings.EqualFold(strings.TrimSuffix(resource.URL, "/"), site) || strings.EqualFold(resource.Name, site) {
+			return resource.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: the site %v is not accessible by the token", ErrResourcesError, site)
+}
+
+func (c *Config) token(ctx context.Context, payload map[string]string) (*Token, error) {
+
+	if c.ClientID == "" {
+		return nil, ErrNoClientIDError
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint(c.TokenURL, DefaultTokenURL), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Content-Type", "application/json")
+
+	token := new(Token)
+	if err = c.do(request, token, ErrTokenRequestError); err != nil {
+		return nil, err
+	}
+
+	if token.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+func (c *Config) do(request *http.Request, structure interface{}, failure error) error {
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+
+		var detail struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+
+		if json.Unmarshal(body, &detail) == nil && detail.Error != "" {
+			return fmt.Errorf("%w: %v %v: %v", failure, response.StatusCode, detail.Error, detail.ErrorDescription)
+		}
+
+		return fmt.Errorf("%w: %v", failure, response.StatusCode)
+	}
+
+	return json.Unmarshal(body, structure)
+}
+
+func (c *Config) endpoint(override, fallback string) string {
+
+	if override != "" {
+		return override
+	}
+
+	return fallback
+}
+
+func random(size int) (string, error) {
+
+	buffer := make([]byte, size)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buffer), nil
+}
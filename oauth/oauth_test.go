@@ -0,0 +1,150 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer(t *testing.T) (*httptest.Server, *[]map[string]string) {
+
+	var requests []map[string]string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+
+		payload := make(map[string]string)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		requests = append(requests, payload)
+
+		if payload["code"] == "invalid" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":"access_denied","error_description":"Unauthorized"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"access_token":"access-` + payload["grant_type"] + `","refresh_token":"refresh-2",` +
+			`"expires_in":3600,"token_type":"Bearer","scope":"read:jira-work offline_access"}`))
+	})
+
+	mux.HandleFunc("/oauth/token/accessible-resources", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer access-token", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`[{"id":"cloud-id","url":"https://ctreminiom.atlassian.net","name":"ctreminiom","scopes":["read:jira-work"]}]`))
+	})
+
+	mux.HandleFunc("/rest/api/3/myself", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	})
+
+	return httptest.NewServer(mux), &requests
+}
+
+func TestConfig_AuthCodeURL(t *testing.T) {
+
+	config := &Config{ClientID: "client-id", RedirectURL: "https://app.example.com/callback",
+		Scopes: []string{"read:jira-work", "offline_access"}}
+
+	link, err := url.Parse(config.AuthCodeURL("state", "verifier"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "auth.atlassian.com", link.Host)
+	assert.Equal(t, url.Values{
+		"audience":              {"api.atlassian.com"},
+		"client_id":             {"client-id"},
+		"scope":                 {"read:jira-work offline_access"},
+		"redirect_uri":          {"https://app.example.com/callback"},
+		"state":                 {"state"},
+		"response_type":         {"code"},
+		"prompt":                {"consent"},
+		"code_challenge":        {"iMnq5o6zALKXGivsnlom_0F5_WYda32GHkxlV7mq7hQ"},
+		"code_challenge_method": {"S256"},
+	}, link.Query())
+
+	verifier, err := NewVerifier()
+	assert.NoError(t, err)
+	assert.Len(t, verifier, 64)
+
+	assert.Equal(t, "https://api.atlassian.com/ex/jira/cloud-id", SiteURL(JiraProduct, "cloud-id"))
+}
+
+func TestConfig_Exchange(t *testing.T) {
+
+	server, requests := newServer(t)
+	defer server.Close()
+
+	config := &Config{ClientID: "client-id", ClientSecret: "secret", RedirectURL: "https://app.example.com/callback",
+		TokenURL: server.URL + "/oauth/token", ResourcesURL: server.URL + "/oauth/token/accessible-resources"}
+
+	token, err := config.Exchange(context.Background(), "code", "verifier")
+	assert.NoError(t, err)
+	assert.Equal(t, "access-authorization_code", token.AccessToken)
+	assert.True(t, token.Valid())
+
+	assert.Equal(t, map[string]string{
+		"grant_type":    "authorization_code",
+		"client_id":     "client-id",
+		"client_secret": "secret",
+		"code":          "code",
+		"redirect_uri":  "https://app.example.com/callback",
+		"code_verifier": "verifier",
+	}, (*requests)[0])
+
+	_, err = config.Exchange(context.Background(), "invalid", "")
+	assert.EqualError(t, err, "oauth: token request failed: 403 access_denied: Unauthorized")
+
+	cloudID, err := config.CloudID(context.Background(), &Token{AccessToken: "access-token"}, "https://ctreminiom.atlassian.net/")
+	assert.NoError(t, err)
+	assert.Equal(t, "cloud-id", cloudID)
+
+	_, err = config.CloudID(context.Background(), &Token{AccessToken: "access-token"}, "https://other.atlassian.net")
+	assert.EqualError(t, err, "oauth: accessible resources request failed: the site https://other.atlassian.net is not accessible by the token")
+}
+
+func TestClient_Do(t *testing.T) {
+
+	server, requests := newServer(t)
+	defer server.Close()
+
+	config := &Config{ClientID: "client-id", ClientSecret: "secret", TokenURL: server.URL + "/oauth/token"}
+
+	store := NewMemoryTokenStore()
+	assert.NoError(t, store.Save(context.Background(), "cloud-id", &Token{
+		AccessToken:  "expired",
+		RefreshToken: "refresh-1",
+		Expiry:       time.Now().Add(-time.Hour),
+	}))
+
+	client := config.Client(store, "cloud-id")
+
+	request, err := http.NewRequest(http.MethodGet, server.URL+"/rest/api/3/myself", nil)
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+
+		response, err := client.Do(request)
+		assert.NoError(t, err)
+
+		var body [64]byte
+		n, _ := response.Body.Read(body[:])
+		response.Body.Close()
+
+		assert.Equal(t, "Bearer access-refresh_token", string(body[:n]))
+	}
+
+	// The token is refreshed once and the rotated refresh token is saved.
+	assert.Len(t, *requests, 1)
+	assert.Equal(t, "refresh-1", (*requests)[0]["refresh_token"])
+
+	token, err := store.Load(context.Background(), "cloud-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "refresh-2", token.RefreshToken)
+
+	_, err = config.Client(store, "unknown").Do(request)
+	assert.EqualError(t, err, ErrNoTokenError.Error())
+}
@@ -21,9 +21,13 @@ type OrganizationPolicyResource struct {
 }
 
 type OrganizationPolicyAttributes struct {
-	Type      string                        `json:"type,omitempty"`
-	Name      string                        `json:"name,omitempty"`
-	Status    string                        `json:"status,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Status string `json:"status,omitempty"`
+
+	// Rule is the content of the policy, its shape depends on the policy type, e.g: the IP ranges
+	// of an ip-allowlist policy or the realm of a data-residency policy.
+	Rule      map[string]interface{}        `json:"rule,omitempty"`
 	Resources []*OrganizationPolicyResource `json:"resources,omitempty"`
 	CreatedAt time.Time                     `json:"createdAt,omitempty"`
 	UpdatedAt time.Time                     `json:"updatedAt,omitempty"`
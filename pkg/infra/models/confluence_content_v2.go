@@ -0,0 +1,35 @@
+package models
+
+type ContentLinksV2Scheme struct {
+	WebUI  string `json:"webui,omitempty"`
+	EditUI string `json:"editui,omitempty"`
+	TinyUI string `json:"tinyui,omitempty"`
+}
+
+type ContentAncestorChunkScheme struct {
+	Results []*ContentAncestorScheme `json:"results,omitempty"`
+	Links   *PageChunkLinksScheme    `json:"_links,omitempty"`
+}
+
+type ContentAncestorScheme struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+type ContentPropertyV2PayloadScheme struct {
+	Key     string                          `json:"key,omitempty"`
+	Value   interface{}                     `json:"value,omitempty"`
+	Version *PageUpdatePayloadVersionScheme `json:"version,omitempty"`
+}
+
+type ContentPropertyV2ChunkScheme struct {
+	Results []*ContentPropertyV2Scheme `json:"results,omitempty"`
+	Links   *PageChunkLinksScheme      `json:"_links,omitempty"`
+}
+
+type ContentPropertyV2Scheme struct {
+	ID      string             `json:"id,omitempty"`
+	Key     string             `json:"key,omitempty"`
+	Value   interface{}        `json:"value,omitempty"`
+	Version *PageVersionScheme `json:"version,omitempty"`
+}
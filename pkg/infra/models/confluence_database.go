@@ -0,0 +1,23 @@
+package models
+
+type DatabaseCreatePayloadScheme struct {
+	SpaceID  string `json:"spaceId,omitempty"`
+	Title    string `json:"title,omitempty"`
+	ParentID string `json:"parentId,omitempty"`
+}
+
+type DatabaseScheme struct {
+	ID         string                `json:"id,omitempty"`
+	Type       string                `json:"type,omitempty"`
+	Status     string                `json:"status,omitempty"`
+	Title      string                `json:"title,omitempty"`
+	ParentID   string                `json:"parentId,omitempty"`
+	ParentType string                `json:"parentType,omitempty"`
+	Position   int                   `json:"position,omitempty"`
+	AuthorID   string                `json:"authorId,omitempty"`
+	OwnerID    string                `json:"ownerId,omitempty"`
+	CreatedAt  string                `json:"createdAt,omitempty"`
+	SpaceID    string                `json:"spaceId,omitempty"`
+	Version    *PageVersionScheme    `json:"version,omitempty"`
+	Links      *ContentLinksV2Scheme `json:"_links,omitempty"`
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+)
+
+const (
+	RestrictionAuditDepartedUserFinding = "departed_user"
+	RestrictionAuditEmptyGroupFinding   = "empty_group"
+	RestrictionAuditMissingGroupFinding = "missing_group"
+)
+
+type RestrictionAuditOptionsScheme struct {
+
+	// Status filters the pages walked by status, the "current" pages are used by default.
+	Status []string
+
+	// MaxResults is the page size used when walking the space and the restrictions.
+	MaxResults int
+
+	// DepartedAccountIDs flags the provided account IDs as departed without looking them up,
+	// useful when the deactivated users are already known from the organization directory.
+	DepartedAccountIDs []string
+
+	// SkipSubjectValidation disables the user and group lookups, so no findings are reported.
+	SkipSubjectValidation bool
+}
+
+type RestrictionAuditReportScheme struct {
+	SpaceKey string                        `json:"spaceKey,omitempty"`
+	Pages    []*RestrictionAuditPageScheme `json:"pages,omitempty"`
+}
+
+type RestrictionAuditPageScheme struct {
+	ID          string                               `json:"id,omitempty"`
+	Title       string                               `json:"title,omitempty"`
+	AncestorIDs []string                             `json:"ancestorIds,omitempty"`
+	Read        *RestrictionAuditOperationScheme     `json:"read,omitempty"`
+	Update      *RestrictionAuditOperationScheme     `json:"update,omitempty"`
+	Inherited   []*RestrictionAuditInheritanceScheme `json:"inherited,omitempty"`
+	Findings    []*RestrictionAuditFindingScheme     `json:"findings,omitempty"`
+}
+
+// Restricted returns true if the page has its own view or edit restrictions, or inherits view restrictions.
+func (r *RestrictionAuditPageScheme) Restricted() bool {
+	return !r.Read.IsEmpty() || !r.Update.IsEmpty() || len(r.Inherited) != 0
+}
+
+type RestrictionAuditOperationScheme struct {
+	Users  []*RestrictionAuditSubjectScheme `json:"users,omitempty"`
+	Groups []*RestrictionAuditSubjectScheme `json:"groups,omitempty"`
+}
+
+// IsEmpty returns true if the operation is not restricted to any user or group.
+func (r *RestrictionAuditOperationScheme) IsEmpty() bool {
+	return r == nil || (len(r.Users) == 0 && len(r.Groups) == 0)
+}
+
+type RestrictionAuditInheritanceScheme struct {
+	SourceID    string                           `json:"sourceId,omitempty"`
+	SourceTitle string                           `json:"sourceTitle,omitempty"`
+	Read        *RestrictionAuditOperationScheme `json:"read,omitempty"`
+}
+
+type RestrictionAuditSubjectScheme struct {
+	Type string `json:"type,omitempty"`
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type RestrictionAuditFindingScheme struct {
+	Type      string                         `json:"type,omitempty"`
+	Operation string                         `json:"operation,omitempty"`
+	Subject   *RestrictionAuditSubjectScheme `json:"subject,omitempty"`
+
+	// SourceID is the ancestor the restriction is inherited from, empty for the restrictions of the page.
+	SourceID string `json:"sourceId,omitempty"`
+}
+
+// WriteJSON writes the report as an indented JSON document.
+func (r *RestrictionAuditReportScheme) WriteJSON(w io.Writer) error {
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+// WriteCSV writes the report with one row per page restriction.
+//
+// Unrestricted pages are written as a single row without operation and subject.
+func (r *RestrictionAuditReportScheme) WriteCSV(w io.Writer) error {
+
+	writer := csv.NewWriter(w)
+
+	header := []string{"page_id", "page_title", "operation", "source_id", "source_title", "subject_type",
+		"subject_id", "subject_name", "finding"}
+
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, page := range r.Pages {
+
+		findings := make(map[string]string)
+		for _, finding := range page.Findings {
+
+			sourceID := finding.SourceID
+			if sourceID == "" {
+				sourceID = page.ID
+			}
+
+			findings[sourceID+"/"+finding.Operation+"/"+finding.Subject.Type+"/"+finding.Subject.ID] = finding.Type
+		}
+
+		var rows [][]string
+		appendRows := func(operation, sourceID, sourceTitle string, restriction *RestrictionAuditOperationScheme) {
+
+			if restriction.IsEmpty() {
+				return
+			}
+
+			subjects := append(append([]*RestrictionAuditSubjectScheme{}, restriction.Users...), restriction.Groups...)
+			for _, subject := range subjects {
+
+				finding := findings[sourceID+"/"+operation+"/"+subject.Type+"/"+subject.ID]
+
+				rows = append(rows, []string{page.ID, page.Title, operation, sourceID, sourceTitle, subject.Type,
+					subject.ID, subject.Name, finding})
+			}
+		}
+
+		appendRows("read", page.ID, page.Title, page.Read)
+		appendRows("update", page.ID, page.Title, page.Update)
+
+		for _, inherited := range page.Inherited {
+			appendRows("read", inherited.SourceID, inherited.SourceTitle, inherited.Read)
+		}
+
+		if len(rows) == 0 {
+			rows = append(rows, []string{page.ID, page.Title, "", "", "", "", "", "", ""})
+		}
+
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
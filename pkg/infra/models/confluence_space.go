@@ -83,12 +83,18 @@ type SubjectPermissionScheme struct {
 
 type UserPermissionScheme struct {
 	Results []*ContentUserScheme `json:"results,omitempty"`
+	Start   int                  `json:"start,omitempty"`
+	Limit   int                  `json:"limit,omitempty"`
 	Size    int                  `json:"size,omitempty"`
+	Links   *LinkScheme          `json:"_links,omitempty"`
 }
 
 type GroupPermissionScheme struct {
 	Results []*SpaceGroupScheme `json:"results,omitempty"`
+	Start   int                 `json:"start,omitempty"`
+	Limit   int                 `json:"limit,omitempty"`
 	Size    int                 `json:"size,omitempty"`
+	Links   *LinkScheme         `json:"_links,omitempty"`
 }
 
 type SpaceGroupScheme struct {
@@ -0,0 +1,25 @@
+package models
+
+type WhiteboardCreatePayloadScheme struct {
+	SpaceID     string `json:"spaceId,omitempty"`
+	Title       string `json:"title,omitempty"`
+	ParentID    string `json:"parentId,omitempty"`
+	TemplateKey string `json:"templateKey,omitempty"`
+	Locale      string `json:"locale,omitempty"`
+}
+
+type WhiteboardScheme struct {
+	ID         string                `json:"id,omitempty"`
+	Type       string                `json:"type,omitempty"`
+	Status     string                `json:"status,omitempty"`
+	Title      string                `json:"title,omitempty"`
+	ParentID   string                `json:"parentId,omitempty"`
+	ParentType string                `json:"parentType,omitempty"`
+	Position   int                   `json:"position,omitempty"`
+	AuthorID   string                `json:"authorId,omitempty"`
+	OwnerID    string                `json:"ownerId,omitempty"`
+	CreatedAt  string                `json:"createdAt,omitempty"`
+	SpaceID    string                `json:"spaceId,omitempty"`
+	Version    *PageVersionScheme    `json:"version,omitempty"`
+	Links      *ContentLinksV2Scheme `json:"_links,omitempty"`
+}
@@ -9,6 +9,7 @@ var (
 	ErrNoSCIMValueError                    = errors.New("admin: no scim value set")
 	ErrNoSCIMPathError                     = errors.New("admin: no scim path set")
 	ErrNoSCIMOperationError                = errors.New("admin: no scim operation value set")
+	ErrInvalidSCIMFilterError              = errors.New("admin: invalid scim filter")
 	ErrNoAdminOrganizationError            = errors.New("admin: no organization id set")
 	ErrNoAdminDomainIDError                = errors.New("admin: no domain id set")
 	ErrNoEventIDError                      = errors.New("admin: no event id set")
@@ -22,6 +23,10 @@ var (
 	ErrNoCustomerMailError                 = errors.New("sm: no customer mail set")
 	ErrNoCustomerDisplayNameError          = errors.New("sm: no customer display name set")
 	ErrNoKBQueryError                      = errors.New("sm: no knowledge base query set")
+	ErrNoKBArticleError                    = errors.New("sm: no knowledge base article set")
+	ErrNoKBArticlePageError                = errors.New("sm: the knowledge base article has no confluence page")
+	ErrNoKBPageConnectorError              = errors.New("sm: no confluence page connector set")
+	ErrNoKBSpaceKeyError                   = errors.New("sm: no knowledge base space key set")
 	ErrNoOrganizationNameError             = errors.New("sm: no organization name set")
 	ErrNoOrganizationIDError               = errors.New("sm: no organization id set")
 	ErrNoCommentBodyError                  = errors.New("sm/jira: no comment body set")
@@ -31,6 +36,7 @@ var (
 	ErrNoFileNameError                     = errors.New("sm: no file name set")
 	ErrNoFileReaderError                   = errors.New("sm: no io.Reader set")
 	ErrNoCustomRequestFieldsError          = errors.New("sm: no customer request fields set")
+	ErrInvalidRequestFieldValuesError      = errors.New("sm: invalid customer request field values")
 	ErrNoSLAMetricIDError                  = errors.New("sm: no sla metric id set")
 	ErrNoContentAttachmentIDError          = errors.New("confluence: no attachment id set")
 	ErrNoContentAttachmentNameError        = errors.New("confluence: no attachment filename set")
@@ -54,6 +60,9 @@ var (
 	ValidContentTypes                      = []string{"page", "comment", "attachment"}
 	ErrNoContentLabelError                 = errors.New("confluence: no content label set")
 	ErrNoContentPropertyError              = errors.New("confluence: no content property set")
+	ErrNoContentPropertyIDError            = errors.New("confluence: no content property id set")
+	ErrNoWhiteboardIDError                 = errors.New("confluence: no whiteboard id set")
+	ErrNoDatabaseIDError                   = errors.New("confluence: no database id set")
 	ErrNoSpaceNameError                    = errors.New("confluence: no space name set")
 	ErrNoSpaceKeyError                     = errors.New("confluence: no space key set")
 	ErrNoContentRestrictionKeyError        = errors.New("confluence: no content restriction operation key set")
@@ -154,8 +154,9 @@ type IssueBulkResponseScheme struct {
 type IssueBulkResponseErrorScheme struct {
 	Status        int `json:"status"`
 	ElementErrors struct {
-		ErrorMessages []string `json:"errorMessages"`
-		Status        int      `json:"status"`
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors,omitempty"`
+		Status        int               `json:"status"`
 	} `json:"elementErrors"`
 	FailedElementNumber int `json:"failedElementNumber"`
 }
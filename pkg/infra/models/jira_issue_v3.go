@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/json"
 	"github.com/imdario/mergo"
+	"strings"
 )
 
 type IssueScheme struct {
@@ -114,6 +115,38 @@ type IssueFieldsScheme struct {
 	Security                 *SecurityScheme            `json:"security,omitempty"`
 	Attachment               []*AttachmentScheme        `json:"attachment,omitempty"`
 	Worklog                  *IssueWorklogADFPageScheme `json:"worklog,omitempty"`
+
+	// CustomFields are the raw values of the customfield_ keys of the response, they're only filled on decode.
+	CustomFields map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the system fields and keeps the raw values of the custom fields.
+func (i *IssueFieldsScheme) UnmarshalJSON(data []byte) error {
+
+	type fields IssueFieldsScheme
+	if err := json.Unmarshal(data, (*fields)(i)); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	for key, value := range raw {
+
+		if !strings.HasPrefix(key, "customfield_") || string(value) == "null" {
+			continue
+		}
+
+		if i.CustomFields == nil {
+			i.CustomFields = make(map[string]json.RawMessage)
+		}
+
+		i.CustomFields[key] = value
+	}
+
+	return nil
 }
 
 type IssueTransitionScheme struct {
@@ -126,6 +159,19 @@ type IssueTransitionScheme struct {
 	IsAvailable   bool          `json:"isAvailable,omitempty"`
 	IsConditional bool          `json:"isConditional,omitempty"`
 	IsLooped      bool          `json:"isLooped,omitempty"`
+
+	// Fields are the fields of the transition screen, returned when the transitions.fields are expanded.
+	Fields map[string]*IssueTransitionFieldScheme `json:"fields,omitempty"`
+}
+
+type IssueTransitionFieldScheme struct {
+	Required        bool                    `json:"required,omitempty"`
+	Schema          *IssueFieldSchemaScheme `json:"schema,omitempty"`
+	Name            string                  `json:"name,omitempty"`
+	Key             string                  `json:"key,omitempty"`
+	HasDefaultValue bool                    `json:"hasDefaultValue,omitempty"`
+	Operations      []string                `json:"operations,omitempty"`
+	AllowedValues   []interface{}           `json:"allowedValues,omitempty"`
 }
 
 type StatusScheme struct {
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 )
@@ -252,3 +253,46 @@ func TestIssueScheme_ToMap(t *testing.T) {
 		})
 	}
 }
+
+func TestIssueFieldsScheme_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		name    string
+		data    string
+		want    *IssueFieldsScheme
+		wantErr bool
+	}{
+		{
+			name: "when the response has custom fields",
+			data: `{"summary":"Test","customfield_10010":5,"customfield_10011":null}`,
+			want: &IssueFieldsScheme{
+				Summary:      "Test",
+				CustomFields: map[string]json.RawMessage{"customfield_10010": json.RawMessage("5")},
+			},
+		},
+		{
+			name: "when the response has no custom fields",
+			data: `{"summary":"Test"}`,
+			want: &IssueFieldsScheme{Summary: "Test"},
+		},
+		{
+			name:    "when the response isn't an object",
+			data:    `"Test"`,
+			want:    &IssueFieldsScheme{},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := &IssueFieldsScheme{}
+			err := json.Unmarshal([]byte(testCase.data), got)
+
+			if (err != nil) != testCase.wantErr {
+				t.Errorf("UnmarshalJSON() error = %v, wantErr %v", err, testCase.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("UnmarshalJSON() got = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
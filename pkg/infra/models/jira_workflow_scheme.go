@@ -19,15 +19,16 @@ type WorkflowSchemePageScheme struct {
 }
 
 type WorkflowSchemeScheme struct {
-	ID                  int         `json:"id,omitempty"`
-	Name                string      `json:"name,omitempty"`
-	Description         string      `json:"description,omitempty"`
-	DefaultWorkflow     string      `json:"defaultWorkflow,omitempty"`
-	Draft               bool        `json:"draft,omitempty"`
-	LastModifiedUser    *UserScheme `json:"lastModifiedUser,omitempty"`
-	LastModified        string      `json:"lastModified,omitempty"`
-	Self                string      `json:"self,omitempty"`
-	UpdateDraftIfNeeded bool        `json:"updateDraftIfNeeded,omitempty"`
+	ID                  int               `json:"id,omitempty"`
+	Name                string            `json:"name,omitempty"`
+	Description         string            `json:"description,omitempty"`
+	DefaultWorkflow     string            `json:"defaultWorkflow,omitempty"`
+	IssueTypeMappings   map[string]string `json:"issueTypeMappings,omitempty"`
+	Draft               bool              `json:"draft,omitempty"`
+	LastModifiedUser    *UserScheme       `json:"lastModifiedUser,omitempty"`
+	LastModified        string            `json:"lastModified,omitempty"`
+	Self                string            `json:"self,omitempty"`
+	UpdateDraftIfNeeded bool              `json:"updateDraftIfNeeded,omitempty"`
 }
 
 type WorkflowSchemeAssociationPageScheme struct {
@@ -38,3 +39,14 @@ type WorkflowSchemeAssociationsScheme struct {
 	ProjectIds     []string              `json:"projectIds,omitempty"`
 	WorkflowScheme *WorkflowSchemeScheme `json:"workflowScheme,omitempty"`
 }
+
+type WorkflowSchemePublishPayloadScheme struct {
+	StatusMappings []*WorkflowSchemeStatusMappingScheme `json:"statusMappings,omitempty"`
+}
+
+// WorkflowSchemeStatusMappingScheme maps the status of the issues of an issue type to its status on the published workflow.
+type WorkflowSchemeStatusMappingScheme struct {
+	IssueTypeID string `json:"issueTypeId,omitempty"`
+	StatusID    string `json:"statusId,omitempty"`
+	NewStatusID string `json:"newStatusId,omitempty"`
+}
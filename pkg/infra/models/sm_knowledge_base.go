@@ -26,9 +26,30 @@ type ArticleScheme struct {
 }
 
 type ArticleSourceScheme struct {
-	Type string `json:"type,omitempty"`
+	Type     string `json:"type,omitempty"`
+	PageID   string `json:"pageId,omitempty"`
+	SpaceKey string `json:"spaceKey,omitempty"`
 }
 
 type ArticleContentScheme struct {
 	IframeSrc string `json:"iframeSrc,omitempty"`
 }
+
+type KnowledgeBaseArticleScheme struct {
+	Article *ArticleScheme `json:"article,omitempty"`
+	Page    *PageScheme    `json:"page,omitempty"`
+}
+
+type KnowledgeBaseSuggestionScheme struct {
+	Article *ArticleScheme `json:"article,omitempty"`
+	Page    *PageScheme    `json:"page,omitempty"`
+	Score   float64        `json:"score,omitempty"`
+
+	// PageErr is the error returned fetching the Confluence page of the article.
+	PageErr error `json:"-"`
+}
+
+type KnowledgeBaseSpaceLinkScheme struct {
+	ApplicationLinkID string `json:"applicationLinkId,omitempty"`
+	SpaceKey          string `json:"spaceKey,omitempty"`
+}
@@ -0,0 +1,279 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	requestDateLayout     = "2006-01-02"
+	requestDateTimeLayout = "2006-01-02T15:04:05.000-0700"
+)
+
+// RequestFieldValuesBuilder builds the requestFieldValues of a customer request using the
+// field metadata of the request type returned by the TypeService.Fields method.
+//
+// The fields can be referenced by their id or by their name, the values are checked against
+// the field schema and the field valid values before being added.
+type RequestFieldValuesBuilder struct {
+	fields []*RequestTypeFieldScheme
+	values map[string]interface{}
+}
+
+// NewRequestFieldValuesBuilder returns a builder for the request type fields provided.
+func NewRequestFieldValuesBuilder(fields *RequestTypeFieldsScheme) (*RequestFieldValuesBuilder, error) {
+
+	if fields == nil {
+		return nil, ErrNoCustomRequestFieldsError
+	}
+
+	return &RequestFieldValuesBuilder{
+		fields: fields.RequestTypeFields,
+		values: make(map[string]interface{}),
+	}, nil
+}
+
+// Text sets the value of a text field, such as the summary or the description.
+func (r *RequestFieldValuesBuilder) Text(field, value string) error {
+
+	metadata, err := r.field(field, "string")
+	if err != nil {
+		return err
+	}
+
+	r.values[metadata.FieldID] = value
+	return nil
+}
+
+// Select sets the option of a single select or radio field, the option can be provided by id or label.
+func (r *RequestFieldValuesBuilder) Select(field, option string) error {
+
+	metadata, err := r.field(field, "option", "priority", "resolution")
+	if err != nil {
+		return err
+	}
+
+	id, err := r.option(metadata, option)
+	if err != nil {
+		return err
+	}
+
+	r.values[metadata.FieldID] = map[string]interface{}{"id": id}
+	return nil
+}
+
+// MultiSelect sets the options of a multi select, checkbox or components field, the options can be provided by id or label.
+func (r *RequestFieldValuesBuilder) MultiSelect(field string, options ...string) error {
+
+	metadata, err := r.field(field, "array")
+	if err != nil {
+		return err
+	}
+
+	// The arrays of users, groups or versions aren't set by option.
+	if items := metadata.JiraSchema.Items; items != "option" && items != "component" {
+		return fmt.Errorf("%w: field %q (%v) has the %q items, expected option or component", ErrInvalidRequestFieldValuesError,
+			metadata.FieldID, metadata.Name, items)
+	}
+
+	if len(options) == 0 {
+		return ErrNoMultiSelectTypeError
+	}
+
+	var values []map[string]interface{}
+	for _, option := range options {
+
+		id, err := r.option(metadata, option)
+		if err != nil {
+			return err
+		}
+
+		values = append(values, map[string]interface{}{"id": id})
+	}
+
+	r.values[metadata.FieldID] = values
+	return nil
+}
+
+// User sets the account of a single user picker field.
+func (r *RequestFieldValuesBuilder) User(field, accountID string) error {
+
+	metadata, err := r.field(field, "user")
+	if err != nil {
+		return err
+	}
+
+	if accountID == "" {
+		return ErrNoUserTypeError
+	}
+
+	r.values[metadata.FieldID] = map[string]interface{}{"accountId": accountID}
+	return nil
+}
+
+// Users sets the accounts of a multi user picker field, such as the request participants custom fields.
+func (r *RequestFieldValuesBuilder) Users(field string, accountIDs ...string) error {
+
+	metadata, err := r.field(field, "array")
+	if err != nil {
+		return err
+	}
+
+	if metadata.JiraSchema.Items != "user" {
+		return fmt.Errorf("sm: field %q (%v) is not a multi user field", metadata.FieldID, metadata.Name)
+	}
+
+	if len(accountIDs) == 0 {
+		return ErrNoMultiUserTypeError
+	}
+
+	var accounts []map[string]interface{}
+	for _, accountID := range accountIDs {
+
+		if accountID == "" {
+			return ErrNoUserTypeError
+		}
+
+		accounts = append(accounts, map[string]interface{}{"accountId": accountID})
+	}
+
+	r.values[metadata.FieldID] = accounts
+	return nil
+}
+
+// Date sets the value of a date picker or a date time picker field, the value is formatted
+// using the field schema type, e.g: 2023-05-02 or 2023-05-02T09:30:00.000+0000.
+func (r *RequestFieldValuesBuilder) Date(field string, value time.Time) error {
+
+	metadata, err := r.field(field, "date", "datetime")
+	if err != nil {
+		return err
+	}
+
+	if value.IsZero() {
+		return ErrNoDatePickerTypeError
+	}
+
+	if metadata.JiraSchema.Type == "datetime" {
+		r.values[metadata.FieldID] = value.Format(requestDateTimeLayout)
+		return nil
+	}
+
+	r.values[metadata.FieldID] = value.Format(requestDateLayout)
+	return nil
+}
+
+// Set sets the raw value of a field, the value is checked against the field valid values only.
+func (r *RequestFieldValuesBuilder) Set(field string, value interface{}) error {
+
+	metadata, err := r.field(field)
+	if err != nil {
+		return err
+	}
+
+	if err = metadata.ValidateValue(value); err != nil {
+		return fmt.Errorf("sm: %v", err)
+	}
+
+	r.values[metadata.FieldID] = value
+	return nil
+}
+
+// Build returns the requestFieldValues map, it fails if any required field is not set.
+func (r *RequestFieldValuesBuilder) Build() (map[string]interface{}, error) {
+
+	var missing []string
+	for _, field := range r.fields {
+
+		if _, ok := r.values[field.FieldID]; field.Required && !ok {
+			missing = append(missing, fmt.Sprintf("%v (%v)", field.FieldID, field.Name))
+		}
+	}
+
+	if len(missing) != 0 {
+		return nil, fmt.Errorf("%w: missing required fields %v", ErrInvalidRequestFieldValuesError, strings.Join(missing, ", "))
+	}
+
+	values := make(map[string]interface{}, len(r.values))
+	for key, value := range r.values {
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+// field returns the metadata of a field referenced by id or name, checking its schema type
+// when the types are provided.
+func (r *RequestFieldValuesBuilder) field(field string, types ...string) (*RequestTypeFieldScheme, error) {
+
+	if field == "" {
+		return nil, ErrNoCustomFieldIDError
+	}
+
+	var metadata *RequestTypeFieldScheme
+	for _, candidate := range r.fields {
+
+		if candidate.FieldID == field || strings.EqualFold(candidate.Name, field) {
+			metadata = candidate
+			break
+		}
+	}
+
+	if metadata == nil {
+
+		var available []string
+		for _, candidate := range r.fields {
+			available = append(available, fmt.Sprintf("%v (%v)", candidate.FieldID, candidate.Name))
+		}
+		sort.Strings(available)
+
+		return nil, fmt.Errorf("%w: unknown field %q, the request type fields are: %v", ErrInvalidRequestFieldValuesError,
+			field, strings.Join(available, ", "))
+	}
+
+	if len(types) == 0 {
+		return metadata, nil
+	}
+
+	var schemaType string
+	if metadata.JiraSchema != nil {
+		schemaType = metadata.JiraSchema.Type
+	}
+
+	for _, expected := range types {
+		if schemaType == expected {
+			return metadata, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: field %q (%v) has the %q type, expected %v", ErrInvalidRequestFieldValuesError,
+		metadata.FieldID, metadata.Name, schemaType, strings.Join(types, " or "))
+}
+
+// option resolves an option label or id into the option id, when the field has no valid values
+// the option is returned as it is.
+func (r *RequestFieldValuesBuilder) option(field *RequestTypeFieldScheme, option string) (string, error) {
+
+	if option == "" {
+		return "", ErrNoSelectTypeError
+	}
+
+	if len(field.ValidValues) == 0 {
+		return option, nil
+	}
+
+	var labels []string
+	for _, value := range field.ValidValues {
+
+		if value.Value == option || strings.EqualFold(value.Label, option) {
+			return value.Value, nil
+		}
+
+		labels = append(labels, value.Label)
+	}
+
+	return "", fmt.Errorf("%w: invalid option %q for field %q (%v), the valid options are: %v",
+		ErrInvalidRequestFieldValuesError, option, field.FieldID, field.Name, strings.Join(labels, ", "))
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRequestFieldValuesBuilder(t *testing.T) {
+
+	fields := &RequestTypeFieldsScheme{
+		RequestTypeFields: []*RequestTypeFieldScheme{
+			{FieldID: "summary", Name: "Summary", Required: true, JiraSchema: &RequestTypeJiraSchema{Type: "string"}},
+			{FieldID: "customfield_10010", Name: "Impact", JiraSchema: &RequestTypeJiraSchema{Type: "option"},
+				ValidValues: []*RequestTypeFieldValueScheme{
+					{Value: "10001", Label: "High"},
+					{Value: "10002", Label: "Low"},
+				}},
+			{FieldID: "customfield_10020", Name: "Approvers", JiraSchema: &RequestTypeJiraSchema{Type: "array", Items: "user"}},
+			{FieldID: "duedate", Name: "Due date", JiraSchema: &RequestTypeJiraSchema{Type: "date"}},
+			{FieldID: "customfield_10030", Name: "Outage start", JiraSchema: &RequestTypeJiraSchema{Type: "datetime"}},
+			{FieldID: "customfield_10040", Name: "Systems", JiraSchema: &RequestTypeJiraSchema{Type: "array", Items: "option"},
+				ValidValues: []*RequestTypeFieldValueScheme{
+					{Value: "10101", Label: "VPN"},
+					{Value: "10102", Label: "Mail"},
+				}},
+		},
+	}
+
+	t.Run("when the values are valid", func(t *testing.T) {
+
+		builder, err := NewRequestFieldValuesBuilder(fields)
+		assert.NoError(t, err)
+
+		assert.NoError(t, builder.Text("Summary", "Laptop screen broken"))
+		assert.NoError(t, builder.Select("customfield_10010", "high"))
+		assert.NoError(t, builder.Users("Approvers", "account-id-1", "account-id-2"))
+		assert.NoError(t, builder.Date("duedate", time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC)))
+		assert.NoError(t, builder.Date("Outage start", time.Date(2023, 5, 2, 9, 30, 0, 0, time.FixedZone("", -5*3600))))
+		assert.NoError(t, builder.MultiSelect("Systems", "vpn", "10102"))
+
+		values, err := builder.Build()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"summary":           "Laptop screen broken",
+			"customfield_10010": map[string]interface{}{"id": "10001"},
+			"customfield_10020": []map[string]interface{}{{"accountId": "account-id-1"}, {"accountId": "account-id-2"}},
+			"duedate":           "2023-05-02",
+			"customfield_10030": "2023-05-02T09:30:00.000-0500",
+			"customfield_10040": []map[string]interface{}{{"id": "10101"}, {"id": "10102"}},
+		}, values)
+	})
+
+	t.Run("when the values are invalid", func(t *testing.T) {
+
+		builder, err := NewRequestFieldValuesBuilder(fields)
+		assert.NoError(t, err)
+
+		assert.EqualError(t, builder.Select("Impact", "Medium"),
+			"sm: invalid customer request field values: invalid option \"Medium\" for field \"customfield_10010\" "+
+				"(Impact), the valid options are: High, Low")
+
+		assert.EqualError(t, builder.Text("Impact", "High"),
+			"sm: invalid customer request field values: field \"customfield_10010\" (Impact) has the \"option\" "+
+				"type, expected string")
+
+		assert.ErrorIs(t, builder.Text("Priority", "High"), ErrInvalidRequestFieldValuesError)
+
+		assert.EqualError(t, builder.MultiSelect("Approvers", "account-id-1"),
+			"sm: invalid customer request field values: field \"customfield_10020\" (Approvers) has the \"user\" "+
+				"items, expected option or component")
+
+		_, err = builder.Build()
+		assert.EqualError(t, err, "sm: invalid customer request field values: missing required fields summary (Summary)")
+	})
+
+	t.Run("when the fields are not provided", func(t *testing.T) {
+
+		_, err := NewRequestFieldValuesBuilder(nil)
+		assert.ErrorIs(t, err, ErrNoCustomRequestFieldsError)
+	})
+}
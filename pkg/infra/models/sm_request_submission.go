@@ -0,0 +1,163 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	RequestSubmissionValidationStage = "validation"
+	RequestSubmissionUploadStage     = "upload"
+	RequestSubmissionCreationStage   = "creation"
+	RequestSubmissionAttachmentStage = "attachment"
+)
+
+type RequestSubmissionOptionsScheme struct {
+
+	// Attachments are uploaded as temporary files and sent on the attachment field of the request type,
+	// or attached to the request once it's created when the request type has no attachment field.
+	Attachments []*RequestSubmissionFileScheme
+
+	// Public sets the visibility of the attachments attached once the request is created, the attachments
+	// are internal when it's false.
+	Public bool
+
+	// SkipValidation sends the payload as it is, without checking it against the request type fields.
+	SkipValidation bool
+}
+
+type RequestSubmissionFileScheme struct {
+	FileName string
+	Reader   io.Reader
+}
+
+// RequestSubmissionResultScheme describes the steps completed by a request submission.
+//
+// When the submission fails, Stage contains the step that failed and the fields of the
+// previous steps are still populated, e.g: the request created when the attachments cannot be linked.
+type RequestSubmissionResultScheme struct {
+	Stage                  string                           `json:"stage,omitempty"`
+	TemporaryAttachmentIDs []string                         `json:"temporaryAttachmentIds,omitempty"`
+	Request                *CustomerRequestScheme           `json:"request,omitempty"`
+	Attachments            *RequestAttachmentCreationScheme `json:"attachments,omitempty"`
+}
+
+// Validate checks the request field values against the request type fields.
+//
+// It returns an ErrInvalidRequestFieldValuesError wrapped error listing the unknown fields, the missing
+// required fields and the values not included in the field valid values.
+func (r *RequestTypeFieldsScheme) Validate(values map[string]interface{}) error {
+
+	fields := make(map[string]*RequestTypeFieldScheme, len(r.RequestTypeFields))
+	for _, field := range r.RequestTypeFields {
+		fields[field.FieldID] = field
+	}
+
+	var problems []string
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+
+		field, ok := fields[key]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown field %q", key))
+			continue
+		}
+
+		if err := field.ValidateValue(values[key]); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	for _, field := range r.RequestTypeFields {
+
+		if _, ok := values[field.FieldID]; field.Required && !ok {
+			problems = append(problems, fmt.Sprintf("missing required field %q (%v)", field.FieldID, field.Name))
+		}
+	}
+
+	if len(problems) != 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidRequestFieldValuesError, strings.Join(problems, ", "))
+	}
+
+	return nil
+}
+
+// ValidateValue checks the value against the field valid values, the option values can be provided
+// by id or by label, as a plain value or as an object with the id or value key.
+//
+// The fields without valid values accept any value.
+func (r *RequestTypeFieldScheme) ValidateValue(value interface{}) error {
+
+	if len(r.ValidValues) == 0 {
+		return nil
+	}
+
+	valid := make(map[string]bool)
+	for _, option := range r.ValidValues {
+		valid[option.Value] = true
+		valid[option.Label] = true
+	}
+
+	candidates, err := requestFieldOptionValues(value)
+	if err != nil {
+		return fmt.Errorf("field %q: %v", r.FieldID, err)
+	}
+
+	for _, candidate := range candidates {
+		if !valid[candidate] {
+			return fmt.Errorf("invalid value %q for field %q (%v)", candidate, r.FieldID, r.Name)
+		}
+	}
+
+	return nil
+}
+
+// requestFieldOptionValues extracts the option ids or values from a field value, the value is
+// normalized through its JSON representation, so typed slices and maps are supported.
+func requestFieldOptionValues(value interface{}) ([]string, error) {
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized interface{}
+	if err = json.Unmarshal(raw, &normalized); err != nil {
+		return nil, err
+	}
+
+	var values []string
+	var walk func(node interface{})
+	walk = func(node interface{}) {
+
+		switch typed := node.(type) {
+		case string:
+			values = append(values, typed)
+		case float64, bool:
+			values = append(values, fmt.Sprint(typed))
+		case []interface{}:
+			for _, item := range typed {
+				walk(item)
+			}
+		case map[string]interface{}:
+			for _, key := range []string{"id", "value", "name"} {
+				if option, ok := typed[key]; ok {
+					walk(option)
+					break
+				}
+			}
+		}
+	}
+
+	walk(normalized)
+	return values, nil
+}
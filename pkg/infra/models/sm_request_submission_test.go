@@ -0,0 +1,67 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRequestTypeFieldsScheme_Validate(t *testing.T) {
+
+	fields := &RequestTypeFieldsScheme{
+		RequestTypeFields: []*RequestTypeFieldScheme{
+			{FieldID: "summary", Name: "Summary", Required: true},
+			{FieldID: "customfield_10010", Name: "Impact", ValidValues: []*RequestTypeFieldValueScheme{
+				{Value: "10001", Label: "High"},
+				{Value: "10002", Label: "Low"},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string]interface{}
+		wantErr bool
+		Err     string
+	}{
+		{
+			name: "when the values are valid",
+			values: map[string]interface{}{
+				"summary":           "Laptop screen broken",
+				"customfield_10010": []map[string]string{{"id": "10001"}, {"value": "Low"}},
+			},
+		},
+		{
+			name: "when the required fields are missing",
+			values: map[string]interface{}{
+				"customfield_10010": "High",
+			},
+			wantErr: true,
+			Err:     "sm: invalid customer request field values: missing required field \"summary\" (Summary)",
+		},
+		{
+			name: "when the fields are unknown or the values are invalid",
+			values: map[string]interface{}{
+				"summary":           "Laptop screen broken",
+				"customfield_10010": map[string]interface{}{"id": "10003"},
+				"customfield_99999": "value",
+			},
+			wantErr: true,
+			Err: "sm: invalid customer request field values: invalid value \"10003\" for field \"customfield_10010\" " +
+				"(Impact), unknown field \"customfield_99999\"",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			err := fields.Validate(testCase.values)
+
+			if testCase.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidRequestFieldValuesError)
+				assert.EqualError(t, err, testCase.Err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
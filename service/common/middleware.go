@@ -0,0 +1,21 @@
+package common
+
+import "net/http"
+
+// RoundTrip sends a request and returns its response.
+type RoundTrip func(request *http.Request) (*http.Response, error)
+
+// Middleware wraps the RoundTrip of a client, e.g: to log, trace or mutate the requests.
+type Middleware func(next RoundTrip) RoundTrip
+
+// Chain returns the RoundTrip that sends the requests through the middlewares and then the HTTP client,
+// the first middleware is the outermost one.
+func Chain(client HttpClient, middlewares ...Middleware) RoundTrip {
+
+	roundTrip := RoundTrip(client.Do)
+	for index := len(middlewares) - 1; index >= 0; index-- {
+		roundTrip = middlewares[index](roundTrip)
+	}
+
+	return roundTrip
+}
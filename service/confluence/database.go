@@ -0,0 +1,43 @@
+package confluence
+
+import (
+	"context"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// DatabaseConnector represents the Confluence Cloud Databases.
+// Use it to create, get, delete databases and to fetch their ancestors.
+type DatabaseConnector interface {
+
+	// Create creates a database in the space.
+	//
+	// POST /wiki/api/v2/databases
+	//
+	// https://docs.go-atlassian.io/confluence-cloud/v2/database#create-database
+	Create(ctx context.Context, payload *models.DatabaseCreatePayloadScheme, private bool) (*models.DatabaseScheme, *models.ResponseScheme, error)
+
+	// Get returns a specific database.
+	//
+	// GET /wiki/api/v2/databases/{id}
+	//
+	// https://docs.go-atlassian.io/confluence-cloud/v2/database#get-database-by-id
+	Get(ctx context.Context, databaseID int) (*models.DatabaseScheme, *models.ResponseScheme, error)
+
+	// Delete deletes a database by id.
+	//
+	// Deleting a database moves the database to the trash, where it can be restored later.
+	//
+	// DELETE /wiki/api/v2/databases/{id}
+	//
+	// https://docs.go-atlassian.io/confluence-cloud/v2/database#delete-database
+	Delete(ctx context.Context, databaseID int) (*models.ResponseScheme, error)
+
+	// Ancestors returns all ancestors for a given database by ID in top-to-bottom order.
+	//
+	// That is, the highest ancestor is the first item in the response payload.
+	//
+	// GET /wiki/api/v2/databases/{id}/ancestors
+	//
+	// https://docs.go-atlassian.io/confluence-cloud/v2/database#get-ancestors-of-database
+	Ancestors(ctx context.Context, databaseID, limit int) (*models.ContentAncestorChunkScheme, *models.ResponseScheme, error)
+}
@@ -35,3 +35,33 @@ type ContentPropertyConnector interface {
 	// https://docs.go-atlassian.io/confluence-cloud/content/properties#delete-content-property
 	Delete(ctx context.Context, contentID, key string) (*model.ResponseScheme, error)
 }
+
+// ContentPropertyV2Connector represents the Confluence Cloud V2 content properties
+// of the content types that expose them, such as whiteboards or databases.
+type ContentPropertyV2Connector interface {
+
+	// Gets returns all content properties of a piece of content.
+	//
+	// GET /wiki/api/v2/{content-type}/{id}/properties
+	Gets(ctx context.Context, contentID int, key, sort, cursor string, limit int) (*model.ContentPropertyV2ChunkScheme, *model.ResponseScheme, error)
+
+	// Get returns a specific content property of a piece of content.
+	//
+	// GET /wiki/api/v2/{content-type}/{id}/properties/{property-id}
+	Get(ctx context.Context, contentID, propertyID int) (*model.ContentPropertyV2Scheme, *model.ResponseScheme, error)
+
+	// Create creates a new content property for a piece of content.
+	//
+	// POST /wiki/api/v2/{content-type}/{id}/properties
+	Create(ctx context.Context, contentID int, payload *model.ContentPropertyV2PayloadScheme) (*model.ContentPropertyV2Scheme, *model.ResponseScheme, error)
+
+	// Update updates a content property of a piece of content.
+	//
+	// PUT /wiki/api/v2/{content-type}/{id}/properties/{property-id}
+	Update(ctx context.Context, contentID, propertyID int, payload *model.ContentPropertyV2PayloadScheme) (*model.ContentPropertyV2Scheme, *model.ResponseScheme, error)
+
+	// Delete deletes a content property of a piece of content.
+	//
+	// DELETE /wiki/api/v2/{content-type}/{id}/properties/{property-id}
+	Delete(ctx context.Context, contentID, propertyID int) (*model.ResponseScheme, error)
+}
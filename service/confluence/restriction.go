@@ -116,3 +116,19 @@ type RestrictionUserOperationConnector interface {
 	// https://docs.go-atlassian.io/confluence-cloud/content/restrictions/operations/user#remove-user-from-content-restriction
 	Remove(ctx context.Context, contentID, operationKey, accountID string) (*model.ResponseScheme, error)
 }
+
+type RestrictionAuditConnector interface {
+
+	// Space walks all the pages of a space and resolves the effective view and edit restrictions of every page.
+	//
+	// View restrictions are inherited from the ancestors, and the restricted users and groups are checked,
+	// so pages restricted to departed users or to empty groups are flagged, the findings of the
+	// inherited restrictions carry the ID of the ancestor setting them.
+	//
+	// GET /wiki/rest/api/content
+	//
+	// GET /wiki/rest/api/content/{id}/restriction
+	//
+	// GET /wiki/rest/api/content/{id}/restriction/byOperation/{operationKey}
+	Space(ctx context.Context, spaceKey string, options *model.RestrictionAuditOptionsScheme) (*model.RestrictionAuditReportScheme, *model.ResponseScheme, error)
+}
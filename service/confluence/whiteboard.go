@@ -0,0 +1,43 @@
+package confluence
+
+import (
+	"context"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// WhiteboardConnector represents the Confluence Cloud Whiteboards.
+// Use it to create, get, delete whiteboards and to fetch their ancestors.
+type WhiteboardConnector interface {
+
+	// Create creates a whiteboard in the space.
+	//
+	// POST /wiki/api/v2/whiteboards
+	//
+	// https://docs.go-atlassian.io/confluence-cloud/v2/whiteboard#create-whiteboard
+	Create(ctx context.Context, payload *models.WhiteboardCreatePayloadScheme, private bool) (*models.WhiteboardScheme, *models.ResponseScheme, error)
+
+	// Get returns a specific whiteboard.
+	//
+	// GET /wiki/api/v2/whiteboards/{id}
+	//
+	// https://docs.go-atlassian.io/confluence-cloud/v2/whiteboard#get-whiteboard-by-id
+	Get(ctx context.Context, whiteboardID int) (*models.WhiteboardScheme, *models.ResponseScheme, error)
+
+	// Delete deletes a whiteboard by id.
+	//
+	// Deleting a whiteboard moves the whiteboard to the trash, where it can be restored later.
+	//
+	// DELETE /wiki/api/v2/whiteboards/{id}
+	//
+	// https://docs.go-atlassian.io/confluence-cloud/v2/whiteboard#delete-whiteboard
+	Delete(ctx context.Context, whiteboardID int) (*models.ResponseScheme, error)
+
+	// Ancestors returns all ancestors for a given whiteboard by ID in top-to-bottom order.
+	//
+	// That is, the highest ancestor is the first item in the response payload.
+	//
+	// GET /wiki/api/v2/whiteboards/{id}/ancestors
+	//
+	// https://docs.go-atlassian.io/confluence-cloud/v2/whiteboard#get-ancestors-of-whiteboard
+	Ancestors(ctx context.Context, whiteboardID, limit int) (*models.ContentAncestorChunkScheme, *models.ResponseScheme, error)
+}
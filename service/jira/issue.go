@@ -53,8 +53,17 @@ type IssueSharedConnector interface {
 	//
 	// https://docs.go-atlassian.io/jira-software-cloud/issues#get-transitions
 	Transitions(ctx context.Context, issueKeyOrId string) (*model.IssueTransitionsScheme, *model.ResponseScheme, error)
+
 	// TODO The Transitions methods requires more parameters such as expand, transitionId, and more
 	// The parameters are documented on this [page](https://developer.atlassian.com/cloud/jira/platform/rest/v3/api-group-issues/#api-rest-api-3-issue-issueidorkey-transitions-get)
+
+	// TransitionsWithFields returns the transitions that can be performed by the user on an issue, with the fields of
+	// their screens expanded. The fields are used to know the values required by each transition.
+	//
+	// GET /rest/api/{2-3}/issue/{issueIdOrKey}/transitions?expand=transitions.fields
+	//
+	// https://docs.go-atlassian.io/jira-software-cloud/issues#get-transitions
+	TransitionsWithFields(ctx context.Context, issueKeyOrId string) (*model.IssueTransitionsScheme, *model.ResponseScheme, error)
 }
 
 type IssueRichTextConnector interface {
@@ -25,6 +25,13 @@ type WatcherConnector interface {
 	// https://docs.go-atlassian.io/jira-software-cloud/issues/watcher#add-watcher
 	Add(ctx context.Context, issueKeyOrId string) (*model.ResponseScheme, error)
 
+	// AddUser adds the user of the account ID as a watcher of an issue.
+	//
+	// POST /rest/api/{2-3}/issue/{issueIdOrKey}/watchers
+	//
+	// https://docs.go-atlassian.io/jira-software-cloud/issues/watcher#add-watcher
+	AddUser(ctx context.Context, issueKeyOrId, accountId string) (*model.ResponseScheme, error)
+
 	// Delete deletes a user as a watcher of an issue.
 	//
 	// DELETE /rest/api/{2-3}/issue/{issueIdOrKey}/watchers
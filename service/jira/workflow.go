@@ -87,6 +87,17 @@ type WorkflowSchemeConnector interface {
 	// https://docs.go-atlassian.io/jira-software-cloud/workflow/scheme#delete-workflow-scheme
 	Delete(ctx context.Context, schemeId int) (*model.ResponseScheme, error)
 
+	// Publish publishes the draft of a workflow scheme.
+	//
+	// The issues whose status doesn't exist on the workflows of the draft are moved to the statuses mapped.
+	//
+	// The task is returned when the issues are migrated asynchronously, otherwise the draft is already published.
+	//
+	// POST /rest/api/{2-3}/workflowscheme/{id}/draft/publish
+	//
+	// https://docs.go-atlassian.io/jira-software-cloud/workflow/scheme#publish-draft-workflow-scheme
+	Publish(ctx context.Context, schemeId int, payload *model.WorkflowSchemePublishPayloadScheme, validateOnly bool) (*model.TaskScheme, *model.ResponseScheme, error)
+
 	// Associations returns a list of the workflow schemes associated with a list of projects.
 	//
 	// Each returned workflow scheme includes a list of the requested projects associated with it.
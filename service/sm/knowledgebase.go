@@ -3,6 +3,7 @@ package sm
 import (
 	"context"
 	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/ctreminiom/go-atlassian/service/confluence"
 )
 
 type KnowledgeBaseConnector interface {
@@ -20,4 +21,36 @@ type KnowledgeBaseConnector interface {
 	//
 	// https://docs.go-atlassian.io/jira-service-management-cloud/knowledgebase#get-articles
 	Gets(ctx context.Context, serviceDeskID int, query string, highlight bool, start, limit int) (*model.ArticlePageScheme, *model.ResponseScheme, error)
+
+	// Article returns a knowledge base article with its Confluence page.
+	//
+	// The page is fetched through the Confluence page connector provided, e.g: the Page service of the Confluence v2 client.
+	//
+	// GET /wiki/api/v2/pages/{id}
+	Article(ctx context.Context, pages confluence.PageConnector, article *model.ArticleScheme, format string) (*model.KnowledgeBaseArticleScheme, *model.ResponseScheme, error)
+
+	// Suggest returns the articles of a service desk relevant for a customer request summary, ordered by relevance.
+	//
+	// The Confluence page of each article is included when the Confluence page connector is provided, the
+	// error of the pages that can't be fetched is set on the PageErr of their suggestion.
+	//
+	// GET /rest/servicedeskapi/servicedesk/{serviceDeskId}/knowledgebase/article
+	Suggest(ctx context.Context, pages confluence.PageConnector, serviceDeskID int, summary string, limit int) ([]*model.KnowledgeBaseSuggestionScheme, *model.ResponseScheme, error)
+
+	// ExperimentalLinkSpace links a Confluence space to a service desk as its knowledge base, the application link
+	// identifies the Confluence site and it's only required when the site has several Confluence links.
+	//
+	// Experimental: the endpoint is the one used by the knowledge base settings of the project, it isn't part
+	// of the published REST API, it isn't supported by Atlassian and may change or be removed without notice.
+	//
+	// POST /rest/servicedesk/knowledgebase/latest/servicedesk/{serviceDeskId}/linkedspace
+	ExperimentalLinkSpace(ctx context.Context, serviceDeskID int, payload *model.KnowledgeBaseSpaceLinkScheme) (*model.ResponseScheme, error)
+
+	// ExperimentalUnlinkSpace unlinks the Confluence space linked to a service desk as its knowledge base.
+	//
+	// Experimental: the endpoint is the one used by the knowledge base settings of the project, it isn't part
+	// of the published REST API, it isn't supported by Atlassian and may change or be removed without notice.
+	//
+	// DELETE /rest/servicedesk/knowledgebase/latest/servicedesk/{serviceDeskId}/linkedspace
+	ExperimentalUnlinkSpace(ctx context.Context, serviceDeskID int) (*model.ResponseScheme, error)
 }
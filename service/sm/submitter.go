@@ -0,0 +1,27 @@
+package sm
+
+import (
+	"context"
+	model "github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+type RequestSubmitterConnector interface {
+
+	// Submit creates a customer request with its attachments.
+	//
+	// The payload is validated against the request type fields and the attachments are uploaded as temporary files.
+	//
+	// When the request type has an attachment field, the temporary files are sent on it when the customer request
+	// is created, otherwise they're attached to the customer request once it's created.
+	//
+	// The result is returned even if one of the steps fails, describing the steps already completed.
+	//
+	// GET /rest/servicedeskapi/servicedesk/{serviceDeskId}/requesttype/{requestTypeId}/field
+	//
+	// POST /rest/servicedeskapi/servicedesk/{serviceDeskId}/attachTemporaryFile
+	//
+	// POST /rest/servicedeskapi/request
+	//
+	// POST /rest/servicedeskapi/request/{issueIdOrKey}/attachment
+	Submit(ctx context.Context, payload *model.CreateCustomerRequestPayloadScheme, options *model.RequestSubmissionOptionsScheme) (*model.RequestSubmissionResultScheme, *model.ResponseScheme, error)
+}
@@ -0,0 +1,570 @@
+// Command routegen generates the routes and the services of the telemetry package from the service
+// implementations and the clients, it's run from the telemetry directory with go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ctreminiom/go-atlassian/admin"
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/bitbucket"
+	"github.com/ctreminiom/go-atlassian/confluence"
+	confluenceV2 "github.com/ctreminiom/go-atlassian/confluence/v2"
+	"github.com/ctreminiom/go-atlassian/jira/agile"
+	"github.com/ctreminiom/go-atlassian/jira/sm"
+	v2 "github.com/ctreminiom/go-atlassian/jira/v2"
+	v3 "github.com/ctreminiom/go-atlassian/jira/v3"
+)
+
+const modulePath = "github.com/ctreminiom/go-atlassian/"
+
+func main() {
+
+	routes, err := parseRoutes("..")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	services, err := walkClients()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	source, err := render(routes, services)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile("routes.go", source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseRoutes returns the endpoint templates of the implementation methods, keyed by the package,
+// type and method, e.g: jira/internal.internalIssueADFServiceImpl.Get, and of the functions of the
+// implementation packages, keyed by the package and function.
+func parseRoutes(root string) (map[string][]string, error) {
+
+	routes := make(map[string][]string)
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || filepath.Base(filepath.Dir(path)) != "internal" || !strings.HasSuffix(path, ".go") ||
+			strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+
+		pkg := filepath.ToSlash(filepath.Dir(strings.TrimPrefix(path, root+string(filepath.Separator))))
+
+		for _, declaration := range file.Decls {
+
+			function, ok := declaration.(*ast.FuncDecl)
+			if !ok || function.Body == nil {
+				continue
+			}
+
+			// The functions shared by the implementations, e.g: the v2 and v3 issue services, are
+			// keyed by package and function.
+			key, receiver := pkg+"."+function.Name.Name, ""
+			if function.Recv != nil {
+
+				var typeName string
+				if receiver, typeName = receiverOf(function); !strings.HasPrefix(typeName, "internal") {
+					continue
+				}
+
+				key = pkg + "." + typeName + "." + function.Name.Name
+			}
+
+			for _, template := range templatesOf(function.Body, receiver) {
+				routes[key] = appendUnique(routes[key], template)
+			}
+		}
+
+		return nil
+	})
+
+	return routes, err
+}
+
+func receiverOf(function *ast.FuncDecl) (string, string) {
+
+	field := function.Recv.List[0]
+
+	var receiver string
+	if len(field.Names) != 0 {
+		receiver = field.Names[0].Name
+	}
+
+	expression := field.Type
+	if star, ok := expression.(*ast.StarExpr); ok {
+		expression = star.X
+	}
+
+	if ident, ok := expression.(*ast.Ident); ok {
+		return receiver, ident.Name
+	}
+
+	return receiver, ""
+}
+
+// fragment is a piece of an endpoint, the verbs of its format are replaced by the names of the arguments.
+type fragment struct {
+	text         string
+	continuation bool
+}
+
+// templatesOf returns the endpoint templates built on the function body. The fragments written on a
+// builder after a fragment ending with a slash are appended to it.
+func templatesOf(body *ast.BlockStmt, receiver string) []string {
+
+	var (
+		fragments []fragment
+		writes    = make(map[string]int)
+	)
+
+	ast.Inspect(body, func(node ast.Node) bool {
+
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+
+			for index, left := range node.Lhs {
+
+				ident, ok := left.(*ast.Ident)
+				if !ok || (ident.Name != "endpoint" && ident.Name != "url") || index >= len(node.Rhs) {
+					continue
+				}
+
+				if text, ok := literal(node.Rhs[index]); ok {
+					fragments = append(fragments, fragment{text: text})
+				}
+			}
+
+		case *ast.ValueSpec:
+
+			for index, name := range node.Names {
+
+				if (name.Name != "endpoint" && name.Name != "url") || index >= len(node.Values) {
+					continue
+				}
+
+				if text, ok := literal(node.Values[index]); ok {
+					fragments = append(fragments, fragment{text: text})
+				}
+			}
+
+		case *ast.CallExpr:
+
+			if text, ok := sprintf(node, receiver); ok {
+
+				fragments = append(fragments, fragment{text: text, continuation: writes[builderOf(node)] > 0})
+				return false
+			}
+
+			if selector, ok := node.Fun.(*ast.SelectorExpr); ok && selector.Sel.Name == "WriteString" && len(node.Args) == 1 {
+
+				builder := builderOf(node)
+				if text, ok := literal(node.Args[0]); ok {
+					fragments = append(fragments, fragment{text: text, continuation: writes[builder] > 0})
+				}
+
+				if call, ok := node.Args[0].(*ast.CallExpr); ok {
+					if text, ok := sprintf(call, receiver); ok {
+						fragments = append(fragments, fragment{text: text, continuation: writes[builder] > 0})
+					}
+				}
+
+				writes[builder]++
+				return false
+			}
+		}
+
+		return true
+	})
+
+	var (
+		templates []string
+		prefix    string
+	)
+
+	for _, fragment := range fragments {
+
+		text := fragment.text
+		if index := strings.IndexAny(text, "?#"); index != -1 {
+			text = text[:index]
+		}
+
+		if text == "" {
+			continue
+		}
+
+		if fragment.continuation && prefix != "" && !strings.HasPrefix(text, prefix) {
+			text = prefix + text
+		}
+
+		if strings.HasSuffix(text, "/") {
+			prefix = text
+			continue
+		}
+
+		if path, ok := normalize(text); ok {
+			templates = appendUnique(templates, path)
+		}
+	}
+
+	return templates
+}
+
+// builderOf returns the name of the builder of a WriteString call, the call itself for the others.
+func builderOf(call *ast.CallExpr) string {
+
+	if selector, ok := call.Fun.(*ast.SelectorExpr); ok {
+		if ident, ok := selector.X.(*ast.Ident); ok {
+			return ident.Name
+		}
+	}
+
+	return ""
+}
+
+func literal(expression ast.Expr) (string, bool) {
+
+	basic, ok := expression.(*ast.BasicLit)
+	if !ok || basic.Kind != token.STRING {
+		return "", false
+	}
+
+	text, err := strconv.Unquote(basic.Value)
+	return text, err == nil
+}
+
+// sprintf returns the format of a fmt.Sprintf call with its verbs replaced by the placeholders of
+// the arguments: {name} for the parameters and * for the API version and the fields of the receiver.
+func sprintf(call *ast.CallExpr, receiver string) (string, bool) {
+
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "Sprintf" || len(call.Args) == 0 {
+		return "", false
+	}
+
+	if pkg, ok := selector.X.(*ast.Ident); !ok || pkg.Name != "fmt" {
+		return "", false
+	}
+
+	format, ok := literal(call.Args[0])
+	if !ok {
+		return "", false
+	}
+
+	var (
+		output   strings.Builder
+		argument = 1
+	)
+
+	for index := 0; index < len(format); index++ {
+
+		if format[index] != '%' {
+			output.WriteByte(format[index])
+			continue
+		}
+
+		index++
+		for index < len(format) && strings.IndexByte("+-# 0123456789.", format[index]) != -1 {
+			index++
+		}
+
+		if index == len(format) {
+			break
+		}
+
+		if format[index] == '%' {
+			output.WriteByte('%')
+			continue
+		}
+
+		var placeholder = "{param}"
+		if argument < len(call.Args) {
+			placeholder = placeholderOf(call.Args[argument], receiver)
+		}
+
+		argument++
+		output.WriteString(placeholder)
+	}
+
+	return output.String(), true
+}
+
+func placeholderOf(expression ast.Expr, receiver string) string {
+
+	switch expression := expression.(type) {
+	case *ast.Ident:
+
+		// The API version is a parameter of the functions shared by the versions of the clients.
+		if expression.Name == "version" {
+			return "*"
+		}
+
+		return "{" + identifier(expression.Name) + "}"
+
+	case *ast.SelectorExpr:
+
+		if ident, ok := expression.X.(*ast.Ident); ok && ident.Name == receiver {
+			return "*"
+		}
+
+		return "{" + identifier(lowerFirst(expression.Sel.Name)) + "}"
+
+	case *ast.CallExpr:
+
+		if len(expression.Args) != 0 {
+			return placeholderOf(expression.Args[0], receiver)
+		}
+
+		if selector, ok := expression.Fun.(*ast.SelectorExpr); ok {
+			return placeholderOf(selector.X, receiver)
+		}
+
+	case *ast.StarExpr:
+		return placeholderOf(expression.X, receiver)
+
+	case *ast.IndexExpr:
+		return placeholderOf(expression.X, receiver)
+	}
+
+	return "{param}"
+}
+
+// identifier returns the name with the ID acronym upper-cased, e.g: issueKeyOrId returns issueKeyOrID.
+func identifier(name string) string {
+	return acronym.ReplaceAllString(name, "ID$1")
+}
+
+var acronym = regexp.MustCompile(`Id([A-Z]|$)`)
+
+func lowerFirst(name string) string {
+
+	runes := []rune(name)
+	for index := 0; index < len(runes) && unicode.IsUpper(runes[index]); index++ {
+
+		// The last upper-case letter of an acronym followed by a word starts the word, e.g: IDList.
+		if index > 0 && index+1 < len(runes) && unicode.IsLower(runes[index+1]) {
+			break
+		}
+
+		runes[index] = unicode.ToLower(runes[index])
+	}
+
+	return string(runes)
+}
+
+// normalize returns the template without the leading slash, the templates without any literal
+// segment or with empty segments aren't routes.
+func normalize(template string) (string, bool) {
+
+	template = strings.TrimPrefix(template, "/")
+	if template == "" || strings.ContainsAny(template, " \t\n") {
+		return "", false
+	}
+
+	literals := 0
+	for _, segment := range strings.Split(template, "/") {
+
+		if segment == "" {
+			return "", false
+		}
+
+		if !strings.ContainsAny(segment, "{*") {
+			literals++
+		}
+	}
+
+	return template, literals != 0
+}
+
+func appendUnique(values []string, value string) []string {
+
+	for _, current := range values {
+		if current == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
+
+// walkClients returns the public services of the implementations, e.g: jira.Issue.Comment, the
+// implementations shared by several services are mapped to each of them.
+func walkClients() (map[string][]string, error) {
+
+	const site = "https://site.atlassian.net"
+
+	jiraV3, err := v3.New(http.DefaultClient, site)
+	if err != nil {
+		return nil, err
+	}
+
+	jiraV2, err := v2.New(http.DefaultClient, site)
+	if err != nil {
+		return nil, err
+	}
+
+	agileClient, err := agile.New(http.DefaultClient, site)
+	if err != nil {
+		return nil, err
+	}
+
+	smClient, err := sm.New(http.DefaultClient, site)
+	if err != nil {
+		return nil, err
+	}
+
+	confluenceClient, err := confluence.New(http.DefaultClient, site)
+	if err != nil {
+		return nil, err
+	}
+
+	confluenceV2Client, err := confluenceV2.New(http.DefaultClient, site)
+	if err != nil {
+		return nil, err
+	}
+
+	adminClient, err := admin.New(http.DefaultClient)
+	if err != nil {
+		return nil, err
+	}
+
+	assetsClient, err := assets.New(http.DefaultClient, site)
+	if err != nil {
+		return nil, err
+	}
+
+	bitbucketClient, err := bitbucket.New(http.DefaultClient, site)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := []struct {
+		product string
+		client  interface{}
+	}{
+		{"jira", jiraV3}, {"jira", jiraV2}, {"agile", agileClient}, {"sm", smClient}, {"confluence", confluenceClient},
+		{"confluence", confluenceV2Client}, {"admin", adminClient}, {"assets", assetsClient}, {"bitbucket", bitbucketClient},
+	}
+
+	services := make(map[string][]string)
+	for _, client := range clients {
+		walk(services, client.product, reflect.ValueOf(client.client), map[uintptr]bool{})
+	}
+
+	return services, nil
+}
+
+func walk(services map[string][]string, path string, value reflect.Value, seen map[uintptr]bool) {
+
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+
+		if value.IsNil() {
+			return
+		}
+
+		if value.Kind() == reflect.Ptr {
+
+			if seen[value.Pointer()] {
+				return
+			}
+
+			seen[value.Pointer()] = true
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return
+	}
+
+	kind := value.Type()
+	if strings.HasPrefix(kind.Name(), "internal") {
+
+		key := strings.TrimPrefix(kind.PkgPath(), modulePath) + "." + kind.Name()
+		services[key] = appendUnique(services[key], path)
+		return
+	}
+
+	for index := 0; index < kind.NumField(); index++ {
+
+		field := kind.Field(index)
+
+		// The unexported fields are the implementations of the services, e.g: internalClient.
+		next := path
+		if field.PkgPath == "" {
+			next += "." + field.Name
+		}
+
+		walk(services, next, value.Field(index), seen)
+	}
+}
+
+func render(routes, services map[string][]string) ([]byte, error) {
+
+	var buffer bytes.Buffer
+
+	buffer.WriteString("// Code generated by routegen. DO NOT EDIT.\n\npackage telemetry\n\n")
+
+	buffer.WriteString("// routes are the endpoint templates of the implementation methods, the * segments are kept as sent.\n")
+	buffer.WriteString("var routes = map[string][]string{\n")
+	writeMap(&buffer, routes)
+	buffer.WriteString("}\n\n")
+
+	buffer.WriteString("// services are the public services of the implementations, keyed by package and type.\n")
+	buffer.WriteString("var services = map[string][]string{\n")
+	writeMap(&buffer, services)
+	buffer.WriteString("}\n")
+
+	return format.Source(buffer.Bytes())
+}
+
+func writeMap(buffer *bytes.Buffer, values map[string][]string) {
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+
+		quoted := make([]string, 0, len(values[key]))
+		for _, value := range values[key] {
+			quoted = append(quoted, strconv.Quote(value))
+		}
+
+		fmt.Fprintf(buffer, "%q: {%v},\n", key, strings.Join(quoted, ", "))
+	}
+}
@@ -0,0 +1,117 @@
+// Package otel records the operations of the telemetry middleware as OpenTelemetry spans and metrics,
+// it's a separate module so the clients don't depend on OpenTelemetry, e.g:
+//
+//	instrument, err := otel.New(otel.GetTracerProvider(), otel.GetMeterProvider())
+//	instance.Use(telemetry.Middleware(instrument))
+package otel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/ctreminiom/go-atlassian/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and the meter.
+const ScopeName = "github.com/ctreminiom/go-atlassian/telemetry/otel"
+
+var (
+	ErrNoTracerProviderError = errors.New("otel: no tracer provider set")
+	ErrNoMeterProviderError  = errors.New("otel: no meter provider set")
+)
+
+// The attributes of the spans and the metrics.
+const (
+	ProductKey    = attribute.Key("atlassian.product")
+	OperationKey  = attribute.Key("atlassian.operation")
+	MethodKey     = attribute.Key("http.request.method")
+	TemplateKey   = attribute.Key("url.template")
+	StatusCodeKey = attribute.Key("http.response.status_code")
+	RetriesKey    = attribute.Key("http.request.resend_count")
+)
+
+// Instrument records each operation as a client span, a latency histogram and an error counter.
+type Instrument struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+}
+
+// New returns the instrument of the providers, e.g: the global ones.
+func New(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) (*Instrument, error) {
+
+	if tracerProvider == nil {
+		return nil, ErrNoTracerProviderError
+	}
+
+	if meterProvider == nil {
+		return nil, ErrNoMeterProviderError
+	}
+
+	meter := meterProvider.Meter(ScopeName)
+
+	duration, err := meter.Float64Histogram("atlassian.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("The duration of the requests sent by the clients."))
+	if err != nil {
+		return nil, err
+	}
+
+	errorCounter, err := meter.Int64Counter("atlassian.client.request.errors",
+		metric.WithUnit("{request}"),
+		metric.WithDescription("The requests that failed or returned a 4xx or 5xx status."))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Instrument{tracer: tracerProvider.Tracer(ScopeName), duration: duration, errors: errorCounter}, nil
+}
+
+// Start starts the span of the operation, the span is ended with the outcome of the operation.
+func (i *Instrument) Start(ctx context.Context, operation *telemetry.Operation) (context.Context, func(outcome *telemetry.Outcome)) {
+
+	attributes := []attribute.KeyValue{
+		OperationKey.String(operation.Name),
+		MethodKey.String(operation.HTTPMethod),
+		TemplateKey.String(operation.EndpointTemplate),
+	}
+
+	if operation.Product != "" {
+		attributes = append(attributes, ProductKey.String(operation.Product))
+	}
+
+	ctx, span := i.tracer.Start(ctx, operation.Name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+
+	return ctx, func(outcome *telemetry.Outcome) {
+
+		defer span.End()
+
+		if outcome.StatusCode != 0 {
+			status := StatusCodeKey.Int(outcome.StatusCode)
+			attributes = append(attributes, status)
+			span.SetAttributes(status)
+		}
+
+		if outcome.Retries > 0 {
+			span.SetAttributes(RetriesKey.Int(outcome.Retries))
+		}
+
+		i.duration.Record(ctx, outcome.Duration.Seconds(), metric.WithAttributes(attributes...))
+
+		switch {
+		case outcome.Err != nil:
+			span.RecordError(outcome.Err)
+			span.SetStatus(codes.Error, outcome.Err.Error())
+			i.errors.Add(ctx, 1, metric.WithAttributes(attributes...))
+
+		case outcome.StatusCode >= http.StatusBadRequest:
+			span.SetStatus(codes.Error, http.StatusText(outcome.StatusCode))
+			i.errors.Add(ctx, 1, metric.WithAttributes(attributes...))
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/telemetry"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInstrument(t *testing.T) {
+
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	instrument, err := New(tracerProvider, meterProvider)
+	assert.NoError(t, err)
+
+	operation := &telemetry.Operation{
+		Name:             "jira.Issue.Get",
+		Product:          "jira",
+		Service:          "Issue",
+		Method:           "Get",
+		HTTPMethod:       http.MethodGet,
+		EndpointTemplate: "rest/api/3/issue/{issueKeyOrID}",
+	}
+
+	_, end := instrument.Start(context.Background(), operation)
+	end(&telemetry.Outcome{StatusCode: http.StatusOK, Retries: 1, Duration: time.Second})
+
+	_, end = instrument.Start(context.Background(), operation)
+	end(&telemetry.Outcome{StatusCode: http.StatusNotFound, Duration: time.Second})
+
+	_, end = instrument.Start(context.Background(), operation)
+	end(&telemetry.Outcome{Err: errors.New("connection refused"), Duration: time.Second})
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 3)
+
+	assert.Equal(t, "jira.Issue.Get", spans[0].Name)
+	assert.Contains(t, spans[0].Attributes, ProductKey.String("jira"))
+	assert.Contains(t, spans[0].Attributes, TemplateKey.String("rest/api/3/issue/{issueKeyOrID}"))
+	assert.Contains(t, spans[0].Attributes, StatusCodeKey.Int(http.StatusOK))
+	assert.Contains(t, spans[0].Attributes, RetriesKey.Int(1))
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Equal(t, codes.Error, spans[2].Status.Code)
+	assert.Equal(t, "connection refused", spans[2].Status.Description)
+
+	var metrics metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &metrics))
+
+	values := map[string]int64{}
+	for _, scope := range metrics.ScopeMetrics {
+		for _, data := range scope.Metrics {
+			switch data := data.Data.(type) {
+			case metricdata.Histogram[float64]:
+				for _, point := range data.DataPoints {
+					values[metricKey("duration", point.Attributes)] += int64(point.Count)
+				}
+			case metricdata.Sum[int64]:
+				for _, point := range data.DataPoints {
+					values[metricKey("errors", point.Attributes)] += point.Value
+				}
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]int64{
+		"duration 200": 1,
+		"duration 404": 1,
+		"duration -":   1,
+		"errors 404":   1,
+		"errors -":     1,
+	}, values)
+}
+
+func TestNew(t *testing.T) {
+
+	_, err := New(nil, sdkmetric.NewMeterProvider())
+	assert.ErrorIs(t, err, ErrNoTracerProviderError)
+
+	_, err = New(sdktrace.NewTracerProvider(), nil)
+	assert.ErrorIs(t, err, ErrNoMeterProviderError)
+}
+
+func metricKey(name string, attributes attribute.Set) string {
+
+	status, ok := attributes.Value(StatusCodeKey)
+	if !ok {
+		return name + " -"
+	}
+
+	return name + " " + status.Emit()
+}
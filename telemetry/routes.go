@@ -0,0 +1,714 @@
+// Code generated by routegen. DO NOT EDIT.
+
+package telemetry
+
+// routes are the endpoint templates of the implementation methods, the * segments are kept as sent.
+var routes = map[string][]string{
+	"admin/internal.internalOrganizationDirectoryServiceImpl.Activity":       {"admin/v1/orgs/{organizationID}/directory/users/{accountID}/last-active-dates"},
+	"admin/internal.internalOrganizationDirectoryServiceImpl.Remove":         {"admin/v1/orgs/{organizationID}/directory/users/{accountID}"},
+	"admin/internal.internalOrganizationDirectoryServiceImpl.Restore":        {"admin/v1/orgs/{organizationID}/directory/users/{accountID}/restore-access"},
+	"admin/internal.internalOrganizationDirectoryServiceImpl.Suspend":        {"admin/v1/orgs/{organizationID}/directory/users/{accountID}/suspend-access"},
+	"admin/internal.internalOrganizationImpl.Actions":                        {"admin/v1/orgs/{organizationID}/event-actions"},
+	"admin/internal.internalOrganizationImpl.Domain":                         {"admin/v1/orgs/{organizationID}/domains/{domainID}"},
+	"admin/internal.internalOrganizationImpl.Domains":                        {"admin/v1/orgs/{organizationID}/domains"},
+	"admin/internal.internalOrganizationImpl.Event":                          {"admin/v1/orgs/{organizationID}/events/{eventID}"},
+	"admin/internal.internalOrganizationImpl.Events":                         {"admin/v1/orgs/{organizationID}/events"},
+	"admin/internal.internalOrganizationImpl.Get":                            {"admin/v1/orgs/{organizationID}"},
+	"admin/internal.internalOrganizationImpl.Gets":                           {"admin/v1/orgs"},
+	"admin/internal.internalOrganizationImpl.Users":                          {"admin/v1/orgs/{organizationID}/users"},
+	"admin/internal.internalOrganizationPolicyImpl.Create":                   {"admin/v1/orgs/{organizationID}/policies"},
+	"admin/internal.internalOrganizationPolicyImpl.Delete":                   {"admin/v1/orgs/{organizationID}/policies/{policyID}"},
+	"admin/internal.internalOrganizationPolicyImpl.Get":                      {"admin/v1/orgs/{organizationID}/policies/{policyID}"},
+	"admin/internal.internalOrganizationPolicyImpl.Gets":                     {"admin/v1/orgs/{organizationID}/policies"},
+	"admin/internal.internalOrganizationPolicyImpl.Update":                   {"admin/v1/orgs/{organizationID}/policies/{policyID}"},
+	"admin/internal.internalSCIMGroupImpl.Create":                            {"scim/directory/{directoryID}/Groups"},
+	"admin/internal.internalSCIMGroupImpl.Delete":                            {"scim/directory/{directoryID}/Groups/{groupID}"},
+	"admin/internal.internalSCIMGroupImpl.Get":                               {"scim/directory/{directoryID}/Groups/{groupID}"},
+	"admin/internal.internalSCIMGroupImpl.Gets":                              {"scim/directory/{directoryID}/Groups"},
+	"admin/internal.internalSCIMGroupImpl.Path":                              {"scim/directory/{directoryID}/Groups/{groupID}"},
+	"admin/internal.internalSCIMGroupImpl.Update":                            {"scim/directory/{directoryID}/Groups/{groupID}"},
+	"admin/internal.internalSCIMSchemaImpl.Enterprise":                       {"scim/directory/{directoryID}/Schemas/urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"},
+	"admin/internal.internalSCIMSchemaImpl.Feature":                          {"scim/directory/{directoryID}/ServiceProviderConfig"},
+	"admin/internal.internalSCIMSchemaImpl.Gets":                             {"scim/directory/{directoryID}/Schemas"},
+	"admin/internal.internalSCIMSchemaImpl.Group":                            {"scim/directory/{directoryID}/Schemas/urn:ietf:params:scim:schemas:core:2.0:Group"},
+	"admin/internal.internalSCIMSchemaImpl.User":                             {"scim/directory/{directoryID}/Schemas/urn:ietf:params:scim:schemas:core:2.0:User"},
+	"admin/internal.internalSCIMUserImpl.Create":                             {"scim/directory/{directoryID}/Users"},
+	"admin/internal.internalSCIMUserImpl.Deactivate":                         {"scim/directory/{directoryID}/Users/{userID}"},
+	"admin/internal.internalSCIMUserImpl.Get":                                {"scim/directory/{directoryID}/Users/{userID}"},
+	"admin/internal.internalSCIMUserImpl.Gets":                               {"scim/directory/{directoryID}/Users"},
+	"admin/internal.internalSCIMUserImpl.Path":                               {"scim/directory/{directoryID}/Users/{userID}"},
+	"admin/internal.internalSCIMUserImpl.Update":                             {"scim/directory/{directoryID}/Users/{userID}"},
+	"admin/internal.internalUserImpl.Disable":                                {"users/{accountID}/manage/lifecycle/disable"},
+	"admin/internal.internalUserImpl.Enable":                                 {"users/{accountID}/manage/lifecycle/enable"},
+	"admin/internal.internalUserImpl.Get":                                    {"users/{accountID}/manage/profile"},
+	"admin/internal.internalUserImpl.Permissions":                            {"users/{accountID}/manage"},
+	"admin/internal.internalUserImpl.Update":                                 {"users/{accountID}/manage/profile"},
+	"admin/internal.internalUserTokenImpl.Delete":                            {"users/{accountID}/manage/api-tokens/{tokenID}"},
+	"admin/internal.internalUserTokenImpl.Gets":                              {"users/{accountID}/manage/api-tokens"},
+	"assets/internal.internalAQLImpl.Filter":                                 {"jsm/assets/workspace/{workspaceID}/v1/aql/objects"},
+	"assets/internal.internalIconImpl.Get":                                   {"jsm/assets/workspace/{workspaceID}/v1/icon/{iconID}"},
+	"assets/internal.internalIconImpl.Global":                                {"jsm/assets/workspace/{workspaceID}/v1/icon/global"},
+	"assets/internal.internalObjectImpl.Attributes":                          {"jsm/assets/workspace/{workspaceID}/v1/object/{objectID}/attributes"},
+	"assets/internal.internalObjectImpl.Create":                              {"jsm/assets/workspace/{workspaceID}/v1/object/create"},
+	"assets/internal.internalObjectImpl.Delete":                              {"jsm/assets/workspace/{workspaceID}/v1/object/{objectID}"},
+	"assets/internal.internalObjectImpl.Filter":                              {"jsm/assets/workspace/{workspaceID}/v1/object/aql"},
+	"assets/internal.internalObjectImpl.Get":                                 {"jsm/assets/workspace/{workspaceID}/v1/object/{objectID}"},
+	"assets/internal.internalObjectImpl.History":                             {"jsm/assets/workspace/{workspaceID}/v1/object/{objectID}/history"},
+	"assets/internal.internalObjectImpl.References":                          {"jsm/assets/workspace/{workspaceID}/v1/object/{objectID}/referenceinfo"},
+	"assets/internal.internalObjectImpl.Relation":                            {"jsm/assets/workspace/{workspaceID}/v1/objectconnectedtickets/{objectID}/tickets"},
+	"assets/internal.internalObjectImpl.Search":                              {"jsm/assets/workspace/{workspaceID}/v1/object/navlist/aql"},
+	"assets/internal.internalObjectImpl.Update":                              {"jsm/assets/workspace/{workspaceID}/v1/object/{objectID}"},
+	"assets/internal.internalObjectSchemaImpl.Attributes":                    {"jsm/assets/workspace/{workspaceID}/v1/objectschema/{objectSchemaID}/attributes"},
+	"assets/internal.internalObjectSchemaImpl.Create":                        {"jsm/assets/workspace/{workspaceID}/v1/objectschema/create"},
+	"assets/internal.internalObjectSchemaImpl.Delete":                        {"jsm/assets/workspace/{workspaceID}/v1/objectschema/{objectSchemaID}"},
+	"assets/internal.internalObjectSchemaImpl.Get":                           {"jsm/assets/workspace/{workspaceID}/v1/objectschema/{objectSchemaID}"},
+	"assets/internal.internalObjectSchemaImpl.List":                          {"jsm/assets/workspace/{workspaceID}/v1/objectschema/list"},
+	"assets/internal.internalObjectSchemaImpl.ObjectTypes":                   {"jsm/assets/workspace/{workspaceID}/v1/objectschema/{objectSchemaID}/objecttypes"},
+	"assets/internal.internalObjectSchemaImpl.Update":                        {"jsm/assets/workspace/{workspaceID}/v1/objectschema/{objectSchemaID}"},
+	"assets/internal.internalObjectTypeAttributeImpl.Create":                 {"jsm/assets/workspace/{workspaceID}/v1/objecttypeattribute/{objectTypeID}"},
+	"assets/internal.internalObjectTypeAttributeImpl.Delete":                 {"jsm/assets/workspace/{workspaceID}/v1/objecttypeattribute/{attributeID}"},
+	"assets/internal.internalObjectTypeAttributeImpl.Update":                 {"jsm/assets/workspace/{workspaceID}/v1/objecttypeattribute/{objectTypeID}/{attributeID}"},
+	"assets/internal.internalObjectTypeImpl.Attributes":                      {"jsm/assets/workspace/{workspaceID}/v1/objecttype/{objectTypeID}/attributes"},
+	"assets/internal.internalObjectTypeImpl.Create":                          {"jsm/assets/workspace/{workspaceID}/v1/objecttype/create"},
+	"assets/internal.internalObjectTypeImpl.Delete":                          {"jsm/assets/workspace/{workspaceID}/v1/objecttype/{objectTypeID}"},
+	"assets/internal.internalObjectTypeImpl.Get":                             {"jsm/assets/workspace/{workspaceID}/v1/objecttype/{objectTypeID}"},
+	"assets/internal.internalObjectTypeImpl.Position":                        {"jsm/assets/workspace/{workspaceID}/v1/objecttype/{objectTypeID}/position"},
+	"assets/internal.internalObjectTypeImpl.Update":                          {"jsm/assets/workspace/{workspaceID}/v1/objecttype/{objectTypeID}"},
+	"bitbucket/internal.internalWorkspaceHookServiceImpl.Create":             {"2.0/workspaces/{workspace}/hooks"},
+	"bitbucket/internal.internalWorkspaceHookServiceImpl.Delete":             {"2.0/workspaces/{workspace}/hooks/{webhookID}"},
+	"bitbucket/internal.internalWorkspaceHookServiceImpl.Get":                {"2.0/workspaces/{workspace}/hooks/{webhookID}"},
+	"bitbucket/internal.internalWorkspaceHookServiceImpl.Gets":               {"2.0/workspaces/{workspace}/hooks"},
+	"bitbucket/internal.internalWorkspaceHookServiceImpl.Update":             {"2.0/workspaces/{workspace}/hooks/{webhookID}"},
+	"bitbucket/internal.internalWorkspacePermissionServiceImpl.Members":      {"2.0/workspaces/{workspace}/permissions"},
+	"bitbucket/internal.internalWorkspacePermissionServiceImpl.Repositories": {"2.0/workspaces/{workspace}/permissions/repositories"},
+	"bitbucket/internal.internalWorkspacePermissionServiceImpl.Repository":   {"2.0/workspaces/{workspace}/permissions/repositories/{repository}"},
+	"bitbucket/internal.internalWorkspaceServiceImpl.Get":                    {"2.0/workspaces/{workspace}"},
+	"bitbucket/internal.internalWorkspaceServiceImpl.Members":                {"2.0/workspaces/{workspace}/members"},
+	"bitbucket/internal.internalWorkspaceServiceImpl.Membership":             {"2.0/workspaces/{workspace}/members/{memberID}"},
+	"bitbucket/internal.internalWorkspaceServiceImpl.Projects":               {"2.0/workspaces/{workspace}/projects"},
+	"confluence/internal.internalAnalyticsServiceImpl.Distinct":              {"wiki/rest/api/analytics/content/{contentID}/viewers"},
+	"confluence/internal.internalAnalyticsServiceImpl.Get":                   {"wiki/rest/api/analytics/content/{contentID}/views"},
+	"confluence/internal.internalAttachmentImpl.Delete":                      {"wiki/api/v2/attachments/{attachmentID}"},
+	"confluence/internal.internalAttachmentImpl.Get":                         {"wiki/api/v2/attachments/{attachmentID}"},
+	"confluence/internal.internalAttachmentImpl.Gets":                        {"wiki/api/v2/{entityType}/{entityID}/attachments"},
+	"confluence/internal.internalAttachmentVersionImpl.Get":                  {"wiki/api/v2/attachments/{attachmentID}/versions/{versionID}"},
+	"confluence/internal.internalAttachmentVersionImpl.Gets":                 {"wiki/api/v2/attachments/{attachmentID}/versions"},
+	"confluence/internal.internalChildrenDescandantsImpl.Children":           {"wiki/rest/api/content/{contentID}/child"},
+	"confluence/internal.internalChildrenDescandantsImpl.ChildrenByType":     {"wiki/rest/api/content/{contentID}/child/{contentType}"},
+	"confluence/internal.internalChildrenDescandantsImpl.CopyHierarchy":      {"wiki/rest/api/content/{contentID}/pagehierarchy/copy"},
+	"confluence/internal.internalChildrenDescandantsImpl.CopyPage":           {"wiki/rest/api/content/{contentID}/copy"},
+	"confluence/internal.internalChildrenDescandantsImpl.Descendants":        {"wiki/rest/api/content/{contentID}/descendant"},
+	"confluence/internal.internalChildrenDescandantsImpl.DescendantsByType":  {"wiki/rest/api/content/{contentID}/descendant/{contentType}"},
+	"confluence/internal.internalChildrenDescandantsImpl.Move":               {"wiki/rest/api/content/{pageID}/move/{position}/{targetID}"},
+	"confluence/internal.internalCommentImpl.Gets":                           {"wiki/rest/api/content/{contentID}/child/comment"},
+	"confluence/internal.internalContentAttachmentImpl.Create":               {"wiki/rest/api/content/{attachmentID}/child/attachment"},
+	"confluence/internal.internalContentAttachmentImpl.CreateOrUpdate":       {"wiki/rest/api/content/{attachmentID}/child/attachment"},
+	"confluence/internal.internalContentAttachmentImpl.Gets":                 {"wiki/rest/api/content/{contentID}/child/attachment"},
+	"confluence/internal.internalContentImpl.Archive":                        {"wiki/rest/api/content/archive"},
+	"confluence/internal.internalContentImpl.Create":                         {"wiki/rest/api/content"},
+	"confluence/internal.internalContentImpl.Delete":                         {"wiki/rest/api/content/{contentID}"},
+	"confluence/internal.internalContentImpl.Get":                            {"wiki/rest/api/content/{contentID}"},
+	"confluence/internal.internalContentImpl.Gets":                           {"wiki/rest/api/content"},
+	"confluence/internal.internalContentImpl.History":                        {"wiki/rest/api/content/{contentID}/history"},
+	"confluence/internal.internalContentImpl.Search":                         {"wiki/rest/api/content/search"},
+	"confluence/internal.internalContentImpl.Update":                         {"wiki/rest/api/content/{contentID}"},
+	"confluence/internal.internalContentLabelImpl.Add":                       {"wiki/rest/api/content/{contentID}/label"},
+	"confluence/internal.internalContentLabelImpl.Gets":                      {"wiki/rest/api/content/{contentID}/label"},
+	"confluence/internal.internalContentLabelImpl.Remove":                    {"wiki/rest/api/content/{contentID}/label/{labelName}"},
+	"confluence/internal.internalContentPropertyV2Impl.Create":               {"wiki/api/v2/*/{contentID}/properties"},
+	"confluence/internal.internalContentPropertyV2Impl.Delete":               {"wiki/api/v2/*/{contentID}/properties/{propertyID}"},
+	"confluence/internal.internalContentPropertyV2Impl.Get":                  {"wiki/api/v2/*/{contentID}/properties/{propertyID}"},
+	"confluence/internal.internalContentPropertyV2Impl.Gets":                 {"wiki/api/v2/*/{contentID}/properties"},
+	"confluence/internal.internalContentPropertyV2Impl.Update":               {"wiki/api/v2/*/{contentID}/properties/{propertyID}"},
+	"confluence/internal.internalCustomContentServiceImpl.Create":            {"wiki/api/v2/custom-content"},
+	"confluence/internal.internalCustomContentServiceImpl.Delete":            {"wiki/api/v2/custom-content/{customContentID}"},
+	"confluence/internal.internalCustomContentServiceImpl.Get":               {"wiki/api/v2/custom-content/{customContentID}"},
+	"confluence/internal.internalCustomContentServiceImpl.Gets":              {"wiki/api/v2/custom-content"},
+	"confluence/internal.internalCustomContentServiceImpl.Update":            {"wiki/api/v2/custom-content/{customContentID}"},
+	"confluence/internal.internalDatabaseImpl.Ancestors":                     {"wiki/api/v2/databases/{databaseID}/ancestors"},
+	"confluence/internal.internalDatabaseImpl.Create":                        {"wiki/api/v2/databases"},
+	"confluence/internal.internalDatabaseImpl.Delete":                        {"wiki/api/v2/databases/{databaseID}"},
+	"confluence/internal.internalDatabaseImpl.Get":                           {"wiki/api/v2/databases/{databaseID}"},
+	"confluence/internal.internalLabelImpl.Get":                              {"wiki/rest/api/label"},
+	"confluence/internal.internalPageImpl.Create":                            {"wiki/api/v2/pages"},
+	"confluence/internal.internalPageImpl.Delete":                            {"wiki/api/v2/pages/{pageID}"},
+	"confluence/internal.internalPageImpl.Get":                               {"wiki/api/v2/pages/{pageID}"},
+	"confluence/internal.internalPageImpl.Gets":                              {"wiki/api/v2/pages"},
+	"confluence/internal.internalPageImpl.GetsByLabel":                       {"wiki/api/v2/labels/{labelID}/pages"},
+	"confluence/internal.internalPageImpl.GetsBySpace":                       {"wiki/api/v2/spaces/{spaceID}/pages"},
+	"confluence/internal.internalPageImpl.Update":                            {"wiki/api/v2/pages/{pageID}"},
+	"confluence/internal.internalPermissionImpl.Check":                       {"wiki/rest/api/content/{contentID}/permission/check"},
+	"confluence/internal.internalPropertyImpl.Create":                        {"wiki/rest/api/content/{contentID}/property"},
+	"confluence/internal.internalPropertyImpl.Delete":                        {"wiki/rest/api/content/{contentID}/property/{key}"},
+	"confluence/internal.internalPropertyImpl.Get":                           {"wiki/rest/api/content/{contentID}/property/{key}"},
+	"confluence/internal.internalPropertyImpl.Gets":                          {"wiki/rest/api/content/{contentID}/property"},
+	"confluence/internal.internalRestrictionAuditImpl.departed":              {"wiki/rest/api/user"},
+	"confluence/internal.internalRestrictionAuditImpl.group":                 {"wiki/rest/api/group/{id}/membersByGroupId", "wiki/rest/api/group/member"},
+	"confluence/internal.internalRestrictionImpl.Add":                        {"wiki/rest/api/content/{contentID}/restriction"},
+	"confluence/internal.internalRestrictionImpl.Delete":                     {"wiki/rest/api/content/{contentID}/restriction"},
+	"confluence/internal.internalRestrictionImpl.Gets":                       {"wiki/rest/api/content/{contentID}/restriction"},
+	"confluence/internal.internalRestrictionImpl.Update":                     {"wiki/rest/api/content/{contentID}/restriction"},
+	"confluence/internal.internalRestrictionOperationGroupImpl.Add":          {"wiki/rest/api/content/{contentID}/restriction/byOperation/{operationKey}/byGroupId/{groupID}", "wiki/rest/api/content/{contentID}/restriction/byOperation/{operationKey}/group/{groupNameOrID}"},
+	"confluence/internal.internalRestrictionOperationGroupImpl.Get":          {"wiki/rest/api/content/{contentID}/restriction/byOperation/{operationKey}/byGroupId/{groupID}", "wiki/rest/api/content/{contentID}/restriction/byOperation/{operationKey}/group/{groupNameOrID}"},
+	"confluence/internal.internalRestrictionOperationGroupImpl.Remove":       {"wiki/rest/api/content/{contentID}/restriction/byOperation/{operationKey}/byGroupId/{groupID}", "wiki/rest/api/content/{contentID}/restriction/byOperation/{operationKey}/group/{groupNameOrID}"},
+	"confluence/internal.internalRestrictionOperationImpl.Get":               {"wiki/rest/api/content/{contentID}/restriction/byOperation/{operationKey}"},
+	"confluence/internal.internalRestrictionOperationImpl.Gets":              {"wiki/rest/api/content/{contentID}/restriction/byOperation"},
+	"confluence/internal.internalRestrictionOperationUserImpl.Add":           {"wiki/rest/api/content/{contentID}/restriction/byOperation/{operationKey}/user"},
+	"confluence/internal.internalRestrictionOperationUserImpl.Get":           {"wiki/rest/api/content/{contentID}/restriction/byOperation/{operationKey}/user"},
+	"confluence/internal.internalRestrictionOperationUserImpl.Remove":        {"wiki/rest/api/content/{contentID}/restriction/byOperation/{operationKey}/user"},
+	"confluence/internal.internalSearchImpl.Content":                         {"wiki/rest/api/search"},
+	"confluence/internal.internalSearchImpl.Users":                           {"wiki/rest/api/search/user"},
+	"confluence/internal.internalSpaceImpl.Content":                          {"wiki/rest/api/space/{spaceKey}/content"},
+	"confluence/internal.internalSpaceImpl.ContentByType":                    {"wiki/rest/api/space/{spaceKey}/content/{contentType}"},
+	"confluence/internal.internalSpaceImpl.Create":                           {"wiki/rest/api/space", "_private"},
+	"confluence/internal.internalSpaceImpl.Delete":                           {"wiki/rest/api/space/{spaceKey}"},
+	"confluence/internal.internalSpaceImpl.Get":                              {"wiki/rest/api/space/{spaceKey}"},
+	"confluence/internal.internalSpaceImpl.Gets":                             {"wiki/rest/api/space"},
+	"confluence/internal.internalSpaceImpl.Update":                           {"wiki/rest/api/space/{spaceKey}"},
+	"confluence/internal.internalSpacePermissionImpl.Add":                    {"wiki/rest/api/space/{spaceKey}/permission"},
+	"confluence/internal.internalSpacePermissionImpl.Bulk":                   {"wiki/rest/api/space/{spaceKey}/permission/custom-content"},
+	"confluence/internal.internalSpacePermissionImpl.Remove":                 {"wiki/rest/api/space/{spaceKey}/permission/{permissionID}"},
+	"confluence/internal.internalSpaceV2Impl.Bulk":                           {"wiki/api/v2/spaces"},
+	"confluence/internal.internalSpaceV2Impl.Get":                            {"wiki/api/v2/spaces/{spaceID}"},
+	"confluence/internal.internalSpaceV2Impl.Permissions":                    {"wiki/api/v2/spaces/{spaceID}/permissions"},
+	"confluence/internal.internalTaskImpl.Get":                               {"wiki/rest/api/longtask/{taskID}"},
+	"confluence/internal.internalTaskImpl.Gets":                              {"wiki/rest/api/longtask"},
+	"confluence/internal.internalVersionImpl.Delete":                         {"wiki/rest/api/content/{contentID}/version/{versionNumber}"},
+	"confluence/internal.internalVersionImpl.Get":                            {"wiki/rest/api/content/{contentID}/version/{versionNumber}"},
+	"confluence/internal.internalVersionImpl.Gets":                           {"wiki/rest/api/content/{contentID}/version"},
+	"confluence/internal.internalVersionImpl.Restore":                        {"wiki/rest/api/content/{contentID}/version"},
+	"confluence/internal.internalWhiteboardImpl.Ancestors":                   {"wiki/api/v2/whiteboards/{whiteboardID}/ancestors"},
+	"confluence/internal.internalWhiteboardImpl.Create":                      {"wiki/api/v2/whiteboards"},
+	"confluence/internal.internalWhiteboardImpl.Delete":                      {"wiki/api/v2/whiteboards/{whiteboardID}"},
+	"confluence/internal.internalWhiteboardImpl.Get":                         {"wiki/api/v2/whiteboards/{whiteboardID}"},
+	"jira/agile/internal.internalBoardBacklogImpl.Move":                      {"rest/agile/*/backlog/issue"},
+	"jira/agile/internal.internalBoardBacklogImpl.MoveTo":                    {"rest/agile/*/backlog/{boardID}/issue"},
+	"jira/agile/internal.internalBoardImpl.Backlog":                          {"rest/agile/*/board/{boardID}/backlog"},
+	"jira/agile/internal.internalBoardImpl.Configuration":                    {"rest/agile/*/board/{boardID}/configuration"},
+	"jira/agile/internal.internalBoardImpl.Create":                           {"rest/agile/*/board"},
+	"jira/agile/internal.internalBoardImpl.Delete":                           {"rest/agile/*/board/{boardID}"},
+	"jira/agile/internal.internalBoardImpl.Epics":                            {"rest/agile/*/board/{boardID}/epic"},
+	"jira/agile/internal.internalBoardImpl.Filter":                           {"rest/agile/*/board/filter/{filterID}"},
+	"jira/agile/internal.internalBoardImpl.Get":                              {"rest/agile/*/board/{boardID}"},
+	"jira/agile/internal.internalBoardImpl.Gets":                             {"rest/agile/*/board"},
+	"jira/agile/internal.internalBoardImpl.Issues":                           {"rest/agile/*/board/{boardID}/issue"},
+	"jira/agile/internal.internalBoardImpl.IssuesByEpic":                     {"rest/agile/*/board/{boardID}/epic/{epicID}/issue"},
+	"jira/agile/internal.internalBoardImpl.IssuesBySprint":                   {"rest/agile/*/board/{boardID}/sprint/{sprintID}/issue"},
+	"jira/agile/internal.internalBoardImpl.IssuesWithoutEpic":                {"rest/agile/*/board/{boardID}/epic/none/issue"},
+	"jira/agile/internal.internalBoardImpl.Move":                             {"rest/agile/*/board/{boardID}/issue"},
+	"jira/agile/internal.internalBoardImpl.Projects":                         {"rest/agile/*/board/{boardID}/project"},
+	"jira/agile/internal.internalBoardImpl.Sprints":                          {"rest/agile/*/board/{boardID}/sprint"},
+	"jira/agile/internal.internalBoardImpl.Versions":                         {"rest/agile/*/board/{boardID}/version"},
+	"jira/agile/internal.internalEpicImpl.Get":                               {"rest/agile/*/epic/{epicIDOrKey}"},
+	"jira/agile/internal.internalEpicImpl.Issues":                            {"rest/agile/*/epic/{epicIDOrKey}/issue"},
+	"jira/agile/internal.internalEpicImpl.Move":                              {"rest/agile/*/epic/{epicIDOrKey}/issue"},
+	"jira/agile/internal.internalSprintImpl.Close":                           {"rest/agile/*/sprint/{sprintID}"},
+	"jira/agile/internal.internalSprintImpl.Create":                          {"rest/agile/*/sprint"},
+	"jira/agile/internal.internalSprintImpl.Delete":                          {"rest/agile/*/sprint/{sprintID}"},
+	"jira/agile/internal.internalSprintImpl.Get":                             {"rest/agile/*/sprint/{sprintID}"},
+	"jira/agile/internal.internalSprintImpl.Issues":                          {"rest/agile/*/sprint/{sprintID}/issue"},
+	"jira/agile/internal.internalSprintImpl.Move":                            {"rest/agile/*/sprint/{sprintID}/issue"},
+	"jira/agile/internal.internalSprintImpl.Path":                            {"rest/agile/*/sprint/{sprintID}"},
+	"jira/agile/internal.internalSprintImpl.Start":                           {"rest/agile/*/sprint/{sprintID}"},
+	"jira/agile/internal.internalSprintImpl.Update":                          {"rest/agile/*/sprint/{sprintID}"},
+	"jira/internal.assignIssue":                                              {"rest/api/*/issue/{issueKeyOrID}/assignee"},
+	"jira/internal.deleteIssue":                                              {"rest/api/*/issue/{issueKeyOrID}"},
+	"jira/internal.getTransitions":                                           {"rest/api/*/issue/{issueKeyOrID}/transitions"},
+	"jira/internal.internalAdfCommentImpl.Add":                               {"rest/api/*/issue/{issueKeyOrID}/comment"},
+	"jira/internal.internalAdfCommentImpl.Delete":                            {"rest/api/*/issue/{issueKeyOrID}/comment/{commentID}"},
+	"jira/internal.internalAdfCommentImpl.Get":                               {"rest/api/*/issue/{issueKeyOrID}/comment/{commentID}"},
+	"jira/internal.internalAdfCommentImpl.Gets":                              {"rest/api/*/issue/{issueKeyOrID}/comment"},
+	"jira/internal.internalAnnouncementBannerImpl.Get":                       {"rest/api/*/announcementBanner"},
+	"jira/internal.internalAnnouncementBannerImpl.Update":                    {"rest/api/*/announcementBanner"},
+	"jira/internal.internalApplicationRoleImpl.Get":                          {"rest/api/*/applicationrole/{key}"},
+	"jira/internal.internalApplicationRoleImpl.Gets":                         {"rest/api/*/applicationrole"},
+	"jira/internal.internalAuditRecordImpl.Get":                              {"rest/api/*/auditing/record"},
+	"jira/internal.internalDashboardImpl.Copy":                               {"rest/api/*/dashboard/{dashboardID}/copy"},
+	"jira/internal.internalDashboardImpl.Create":                             {"rest/api/*/dashboard"},
+	"jira/internal.internalDashboardImpl.Delete":                             {"rest/api/*/dashboard/{dashboardID}"},
+	"jira/internal.internalDashboardImpl.Get":                                {"rest/api/*/dashboard/{dashboardID}"},
+	"jira/internal.internalDashboardImpl.Gets":                               {"rest/api/*/dashboard"},
+	"jira/internal.internalDashboardImpl.Search":                             {"rest/api/*/dashboard/search"},
+	"jira/internal.internalDashboardImpl.Update":                             {"rest/api/*/dashboard/{dashboardID}"},
+	"jira/internal.internalFieldTrashServiceImpl.Move":                       {"rest/api/*/field/{id}/trash"},
+	"jira/internal.internalFieldTrashServiceImpl.Restore":                    {"rest/api/*/field/{id}/restore"},
+	"jira/internal.internalFieldTrashServiceImpl.Search":                     {"rest/api/*/field/search/trashed"},
+	"jira/internal.internalFilterServiceImpl.Change":                         {"rest/api/*/filter/{filterID}/owner"},
+	"jira/internal.internalFilterServiceImpl.Create":                         {"rest/api/*/filter"},
+	"jira/internal.internalFilterServiceImpl.Delete":                         {"rest/api/*/filter/{filterID}"},
+	"jira/internal.internalFilterServiceImpl.Favorite":                       {"rest/api/*/filter/favourite"},
+	"jira/internal.internalFilterServiceImpl.Get":                            {"rest/api/*/filter/{filterID}"},
+	"jira/internal.internalFilterServiceImpl.My":                             {"rest/api/*/filter/my"},
+	"jira/internal.internalFilterServiceImpl.Search":                         {"rest/api/*/filter/search"},
+	"jira/internal.internalFilterServiceImpl.Update":                         {"rest/api/*/filter/{filterID}"},
+	"jira/internal.internalFilterShareImpl.Add":                              {"rest/api/*/filter/{filterID}/permission"},
+	"jira/internal.internalFilterShareImpl.Delete":                           {"rest/api/*/filter/{filterID}/permission/{permissionID}"},
+	"jira/internal.internalFilterShareImpl.Get":                              {"rest/api/*/filter/{filterID}/permission/{permissionID}"},
+	"jira/internal.internalFilterShareImpl.Gets":                             {"rest/api/*/filter/{filterID}/permission"},
+	"jira/internal.internalFilterShareImpl.Scope":                            {"rest/api/*/filter/defaultShareScope"},
+	"jira/internal.internalFilterShareImpl.SetScope":                         {"rest/api/*/filter/defaultShareScope"},
+	"jira/internal.internalGroupServiceImpl.Add":                             {"rest/api/*/group/user"},
+	"jira/internal.internalGroupServiceImpl.Bulk":                            {"rest/api/*/group/bulk"},
+	"jira/internal.internalGroupServiceImpl.Create":                          {"rest/api/*/group"},
+	"jira/internal.internalGroupServiceImpl.Delete":                          {"rest/api/*/group"},
+	"jira/internal.internalGroupServiceImpl.Members":                         {"rest/api/*/group/member"},
+	"jira/internal.internalGroupServiceImpl.Remove":                          {"rest/api/*/group/user"},
+	"jira/internal.internalIssueADFServiceImpl.Create":                       {"rest/api/*/issue"},
+	"jira/internal.internalIssueADFServiceImpl.Creates":                      {"rest/api/*/issue/bulk"},
+	"jira/internal.internalIssueADFServiceImpl.Get":                          {"rest/api/*/issue/{issueKeyOrID}"},
+	"jira/internal.internalIssueADFServiceImpl.Move":                         {"rest/api/*/issue/{issueKeyOrID}/transitions"},
+	"jira/internal.internalIssueADFServiceImpl.Update":                       {"rest/api/*/issue/{issueKeyOrID}"},
+	"jira/internal.internalIssueAttachmentServiceImpl.Add":                   {"rest/api/*/issue/{issueKeyOrID}/attachments"},
+	"jira/internal.internalIssueAttachmentServiceImpl.Delete":                {"rest/api/*/attachment/{attachmentID}"},
+	"jira/internal.internalIssueAttachmentServiceImpl.Download":              {"rest/api/*/attachment/content/{attachmentID}"},
+	"jira/internal.internalIssueAttachmentServiceImpl.Human":                 {"rest/api/*/attachment/{attachmentID}/expand/human"},
+	"jira/internal.internalIssueAttachmentServiceImpl.Metadata":              {"rest/api/*/attachment/{attachmentID}"},
+	"jira/internal.internalIssueAttachmentServiceImpl.Settings":              {"rest/api/*/attachment/meta"},
+	"jira/internal.internalIssueFieldConfigItemServiceImpl.Gets":             {"rest/api/*/fieldconfiguration/{id}/fields"},
+	"jira/internal.internalIssueFieldConfigItemServiceImpl.Update":           {"rest/api/*/fieldconfiguration/{id}/fields"},
+	"jira/internal.internalIssueFieldConfigSchemeServiceImpl.Assign":         {"rest/api/*/fieldconfigurationscheme/project"},
+	"jira/internal.internalIssueFieldConfigSchemeServiceImpl.Create":         {"rest/api/*/fieldconfigurationscheme"},
+	"jira/internal.internalIssueFieldConfigSchemeServiceImpl.Delete":         {"rest/api/*/fieldconfigurationscheme/{schemeID}"},
+	"jira/internal.internalIssueFieldConfigSchemeServiceImpl.Gets":           {"rest/api/*/fieldconfigurationscheme"},
+	"jira/internal.internalIssueFieldConfigSchemeServiceImpl.Link":           {"rest/api/*/fieldconfigurationscheme/{schemeID}/mapping"},
+	"jira/internal.internalIssueFieldConfigSchemeServiceImpl.Mapping":        {"rest/api/*/fieldconfigurationscheme/mapping"},
+	"jira/internal.internalIssueFieldConfigSchemeServiceImpl.Project":        {"rest/api/*/fieldconfigurationscheme/project"},
+	"jira/internal.internalIssueFieldConfigSchemeServiceImpl.Unlink":         {"rest/api/*/fieldconfigurationscheme/{schemeID}/mapping/delete"},
+	"jira/internal.internalIssueFieldConfigSchemeServiceImpl.Update":         {"rest/api/*/fieldconfigurationscheme/{schemeID}"},
+	"jira/internal.internalIssueFieldConfigServiceImpl.Create":               {"rest/api/*/fieldconfiguration"},
+	"jira/internal.internalIssueFieldConfigServiceImpl.Delete":               {"rest/api/*/fieldconfiguration/{id}"},
+	"jira/internal.internalIssueFieldConfigServiceImpl.Gets":                 {"rest/api/*/fieldconfiguration"},
+	"jira/internal.internalIssueFieldConfigServiceImpl.Update":               {"rest/api/*/fieldconfiguration/{id}"},
+	"jira/internal.internalIssueFieldContextOptionServiceImpl.Create":        {"rest/api/*/field/{fieldID}/context/{contextID}/option"},
+	"jira/internal.internalIssueFieldContextOptionServiceImpl.Delete":        {"rest/api/*/field/{fieldID}/context/{contextID}/option/{optionID}"},
+	"jira/internal.internalIssueFieldContextOptionServiceImpl.Gets":          {"rest/api/*/field/{fieldID}/context/{contextID}/option"},
+	"jira/internal.internalIssueFieldContextOptionServiceImpl.Order":         {"rest/api/*/field/{fieldID}/context/{contextID}/option/move"},
+	"jira/internal.internalIssueFieldContextOptionServiceImpl.Update":        {"rest/api/*/field/{fieldID}/context/{contextID}/option"},
+	"jira/internal.internalIssueFieldContextServiceImpl.AddIssueTypes":       {"rest/api/*/field/{fieldID}/context/{contextID}/issuetype"},
+	"jira/internal.internalIssueFieldContextServiceImpl.Create":              {"rest/api/*/field/{fieldID}/context"},
+	"jira/internal.internalIssueFieldContextServiceImpl.Delete":              {"rest/api/*/field/{fieldID}/context/{contextID}"},
+	"jira/internal.internalIssueFieldContextServiceImpl.GetDefaultValues":    {"rest/api/*/field/{fieldID}/context/defaultValue"},
+	"jira/internal.internalIssueFieldContextServiceImpl.Gets":                {"rest/api/*/field/{fieldID}/context"},
+	"jira/internal.internalIssueFieldContextServiceImpl.IssueTypesContext":   {"rest/api/*/field/{fieldID}/context/issuetypemapping"},
+	"jira/internal.internalIssueFieldContextServiceImpl.Link":                {"rest/api/*/field/{fieldID}/context/{contextID}/project"},
+	"jira/internal.internalIssueFieldContextServiceImpl.ProjectsContext":     {"rest/api/*/field/{fieldID}/context/projectmapping"},
+	"jira/internal.internalIssueFieldContextServiceImpl.RemoveIssueTypes":    {"rest/api/*/field/{fieldID}/context/{contextID}/issuetype/remove"},
+	"jira/internal.internalIssueFieldContextServiceImpl.SetDefaultValue":     {"rest/api/*/field/{fieldID}/context/defaultValue"},
+	"jira/internal.internalIssueFieldContextServiceImpl.UnLink":              {"rest/api/*/field/{fieldID}/context/{contextID}/project/remove"},
+	"jira/internal.internalIssueFieldContextServiceImpl.Update":              {"rest/api/*/field/{fieldID}/context/{contextID}"},
+	"jira/internal.internalIssueFieldServiceImpl.Create":                     {"rest/api/*/field"},
+	"jira/internal.internalIssueFieldServiceImpl.Delete":                     {"rest/api/*/field/{fieldID}"},
+	"jira/internal.internalIssueFieldServiceImpl.Gets":                       {"rest/api/*/field"},
+	"jira/internal.internalIssueFieldServiceImpl.Search":                     {"rest/api/*/field/search"},
+	"jira/internal.internalJQLServiceImpl.Parse":                             {"rest/api/*/jql/parse"},
+	"jira/internal.internalLabelServiceImpl.Gets":                            {"rest/api/*/label"},
+	"jira/internal.internalLinkADFServiceImpl.Create":                        {"rest/api/*/issueLink"},
+	"jira/internal.internalLinkADFServiceImpl.Delete":                        {"rest/api/*/issueLink/{linkID}"},
+	"jira/internal.internalLinkADFServiceImpl.Get":                           {"rest/api/*/issueLink/{linkID}"},
+	"jira/internal.internalLinkADFServiceImpl.Gets":                          {"rest/api/*/issue/{issueKeyOrID}"},
+	"jira/internal.internalLinkRichTextServiceImpl.Create":                   {"rest/api/*/issueLink"},
+	"jira/internal.internalLinkRichTextServiceImpl.Delete":                   {"rest/api/*/issueLink/{linkID}"},
+	"jira/internal.internalLinkRichTextServiceImpl.Get":                      {"rest/api/*/issueLink/{linkID}"},
+	"jira/internal.internalLinkRichTextServiceImpl.Gets":                     {"rest/api/*/issue/{issueKeyOrID}"},
+	"jira/internal.internalLinkTypeImpl.Create":                              {"rest/api/*/issueLinkType"},
+	"jira/internal.internalLinkTypeImpl.Delete":                              {"rest/api/*/issueLinkType/{issueLinkTypeID}"},
+	"jira/internal.internalLinkTypeImpl.Get":                                 {"rest/api/*/issueLinkType/{issueLinkTypeID}"},
+	"jira/internal.internalLinkTypeImpl.Gets":                                {"rest/api/*/issueLinkType"},
+	"jira/internal.internalLinkTypeImpl.Update":                              {"rest/api/*/issueLinkType/{issueLinkTypeID}"},
+	"jira/internal.internalMetadataImpl.Create":                              {"rest/api/*/issue/createmeta"},
+	"jira/internal.internalMetadataImpl.Get":                                 {"rest/api/*/issue/{issueKeyOrID}/editmeta"},
+	"jira/internal.internalMySelfImpl.Details":                               {"rest/api/*/myself"},
+	"jira/internal.internalNotificationSchemeImpl.Append":                    {"rest/api/*/notificationscheme/{schemeID}/notification"},
+	"jira/internal.internalNotificationSchemeImpl.Create":                    {"rest/api/*/notificationscheme"},
+	"jira/internal.internalNotificationSchemeImpl.Delete":                    {"rest/api/*/notificationscheme/{schemeID}"},
+	"jira/internal.internalNotificationSchemeImpl.Get":                       {"rest/api/*/notificationscheme/{schemeID}"},
+	"jira/internal.internalNotificationSchemeImpl.Projects":                  {"rest/api/*/notificationscheme/project"},
+	"jira/internal.internalNotificationSchemeImpl.Remove":                    {"rest/api/*/notificationscheme/{schemeID}/notification/{notificationID}"},
+	"jira/internal.internalNotificationSchemeImpl.Search":                    {"rest/api/*/notificationscheme"},
+	"jira/internal.internalNotificationSchemeImpl.Update":                    {"rest/api/*/notificationscheme/{schemeID}"},
+	"jira/internal.internalPermissionImpl.Check":                             {"rest/api/*/permissions/check"},
+	"jira/internal.internalPermissionImpl.Gets":                              {"rest/api/*/permissions"},
+	"jira/internal.internalPermissionImpl.Projects":                          {"rest/api/*/permissions/project"},
+	"jira/internal.internalPermissionSchemeGrantImpl.Create":                 {"rest/api/*/permissionscheme/{permissionSchemeID}/permission"},
+	"jira/internal.internalPermissionSchemeGrantImpl.Delete":                 {"rest/api/*/permissionscheme/{permissionSchemeID}/permission/{permissionGrantID}"},
+	"jira/internal.internalPermissionSchemeGrantImpl.Get":                    {"rest/api/*/permissionscheme/{permissionSchemeID}/permission/{permissionGrantID}"},
+	"jira/internal.internalPermissionSchemeGrantImpl.Gets":                   {"rest/api/*/permissionscheme/{permissionSchemeID}/permission"},
+	"jira/internal.internalPermissionSchemeImpl.Create":                      {"rest/api/*/permissionscheme"},
+	"jira/internal.internalPermissionSchemeImpl.Delete":                      {"rest/api/*/permissionscheme/{permissionSchemeID}"},
+	"jira/internal.internalPermissionSchemeImpl.Get":                         {"rest/api/*/permissionscheme/{permissionSchemeID}"},
+	"jira/internal.internalPermissionSchemeImpl.Gets":                        {"rest/api/*/permissionscheme"},
+	"jira/internal.internalPermissionSchemeImpl.Update":                      {"rest/api/*/permissionscheme/{permissionSchemeID}"},
+	"jira/internal.internalPriorityImpl.Get":                                 {"rest/api/*/priority/{priorityID}"},
+	"jira/internal.internalPriorityImpl.Gets":                                {"rest/api/*/priority"},
+	"jira/internal.internalProjectCategoryImpl.Create":                       {"rest/api/*/projectCategory"},
+	"jira/internal.internalProjectCategoryImpl.Delete":                       {"rest/api/*/projectCategory/{categoryID}"},
+	"jira/internal.internalProjectCategoryImpl.Get":                          {"rest/api/*/projectCategory/{categoryID}"},
+	"jira/internal.internalProjectCategoryImpl.Gets":                         {"rest/api/*/projectCategory"},
+	"jira/internal.internalProjectCategoryImpl.Update":                       {"rest/api/*/projectCategory/{categoryID}"},
+	"jira/internal.internalProjectComponentImpl.Count":                       {"rest/api/*/component/{componentID}/relatedIssueCounts"},
+	"jira/internal.internalProjectComponentImpl.Create":                      {"rest/api/*/component"},
+	"jira/internal.internalProjectComponentImpl.Delete":                      {"rest/api/*/component/{componentID}"},
+	"jira/internal.internalProjectComponentImpl.Get":                         {"rest/api/*/component/{componentID}"},
+	"jira/internal.internalProjectComponentImpl.Gets":                        {"rest/api/*/project/{projectIDOrKey}/components"},
+	"jira/internal.internalProjectComponentImpl.Update":                      {"rest/api/*/component/{componentID}"},
+	"jira/internal.internalProjectFeatureImpl.Gets":                          {"rest/api/*/project/{projectKeyOrID}/features"},
+	"jira/internal.internalProjectFeatureImpl.Set":                           {"rest/api/*/project/{projectKeyOrID}/features/{featureKey}"},
+	"jira/internal.internalProjectImpl.Archive":                              {"rest/api/*/project/{projectKeyOrID}/archive"},
+	"jira/internal.internalProjectImpl.Create":                               {"rest/api/*/project"},
+	"jira/internal.internalProjectImpl.Delete":                               {"rest/api/*/project/{projectKeyOrID}"},
+	"jira/internal.internalProjectImpl.DeleteAsynchronously":                 {"rest/api/*/project/{projectKeyOrID}/delete"},
+	"jira/internal.internalProjectImpl.Get":                                  {"rest/api/*/project/{projectKeyOrID}"},
+	"jira/internal.internalProjectImpl.NotificationScheme":                   {"rest/api/*/project/{projectKeyOrID}/notificationscheme"},
+	"jira/internal.internalProjectImpl.Restore":                              {"rest/api/*/project/{projectKeyOrID}/restore"},
+	"jira/internal.internalProjectImpl.Search":                               {"rest/api/*/project/search"},
+	"jira/internal.internalProjectImpl.Statuses":                             {"rest/api/*/project/{projectKeyOrID}/statuses"},
+	"jira/internal.internalProjectImpl.Update":                               {"rest/api/*/project/{projectKeyOrID}"},
+	"jira/internal.internalProjectPermissionSchemeImpl.Assign":               {"rest/api/*/project/{projectKeyOrID}/permissionscheme"},
+	"jira/internal.internalProjectPermissionSchemeImpl.Get":                  {"rest/api/*/project/{projectKeyOrID}/permissionscheme"},
+	"jira/internal.internalProjectPermissionSchemeImpl.SecurityLevels":       {"rest/api/*/project/{projectKeyOrID}/securitylevel"},
+	"jira/internal.internalProjectPropertyImpl.Delete":                       {"rest/api/*/project/{projectKeyOrID}/properties/{propertyKey}"},
+	"jira/internal.internalProjectPropertyImpl.Get":                          {"rest/api/*/project/{projectKeyOrID}/properties/{propertyKey}"},
+	"jira/internal.internalProjectPropertyImpl.Gets":                         {"rest/api/*/project/{projectKeyOrID}/properties"},
+	"jira/internal.internalProjectPropertyImpl.Set":                          {"rest/api/*/project/{projectKeyOrID}/properties/{propertyKey}"},
+	"jira/internal.internalProjectRoleActorImpl.Add":                         {"rest/api/*/project/{projectKeyOrID}/role/{roleID}"},
+	"jira/internal.internalProjectRoleActorImpl.Delete":                      {"rest/api/*/project/{projectKeyOrID}/role/{roleID}"},
+	"jira/internal.internalProjectRoleImpl.Create":                           {"rest/api/*/role"},
+	"jira/internal.internalProjectRoleImpl.Details":                          {"rest/api/*/project/{projectKeyOrID}/roledetails"},
+	"jira/internal.internalProjectRoleImpl.Get":                              {"rest/api/*/project/{projectKeyOrID}/role/{roleID}"},
+	"jira/internal.internalProjectRoleImpl.Gets":                             {"rest/api/*/project/{projectKeyOrID}/role"},
+	"jira/internal.internalProjectRoleImpl.Global":                           {"rest/api/*/role"},
+	"jira/internal.internalProjectTypeImpl.Accessible":                       {"rest/api/*/project/type/{projectTypeKey}/accessible"},
+	"jira/internal.internalProjectTypeImpl.Get":                              {"rest/api/*/project/type/{projectTypeKey}"},
+	"jira/internal.internalProjectTypeImpl.Gets":                             {"rest/api/*/project/type"},
+	"jira/internal.internalProjectTypeImpl.Licensed":                         {"rest/api/*/project/type/accessible"},
+	"jira/internal.internalProjectValidatorImpl.Key":                         {"rest/api/*/projectvalidate/validProjectKey"},
+	"jira/internal.internalProjectValidatorImpl.Name":                        {"rest/api/*/projectvalidate/validProjectName"},
+	"jira/internal.internalProjectValidatorImpl.Validate":                    {"rest/api/*/projectvalidate/key"},
+	"jira/internal.internalProjectVersionImpl.Create":                        {"rest/api/*/version"},
+	"jira/internal.internalProjectVersionImpl.Get":                           {"rest/api/*/version/{versionID}"},
+	"jira/internal.internalProjectVersionImpl.Gets":                          {"rest/api/*/project/{projectKeyOrID}/versions"},
+	"jira/internal.internalProjectVersionImpl.Merge":                         {"rest/api/*/version/{versionID}/mergeto/{versionMoveIssuesTo}"},
+	"jira/internal.internalProjectVersionImpl.RelatedIssueCounts":            {"rest/api/*/version/{versionID}/relatedIssueCounts"},
+	"jira/internal.internalProjectVersionImpl.Search":                        {"rest/api/*/project/{projectKeyOrID}/version"},
+	"jira/internal.internalProjectVersionImpl.UnresolvedIssueCount":          {"rest/api/*/version/{versionID}/unresolvedIssueCount"},
+	"jira/internal.internalProjectVersionImpl.Update":                        {"rest/api/*/version/{versionID}"},
+	"jira/internal.internalRemoteLinkImpl.Create":                            {"rest/api/*/issue/{issueKeyOrID}/remotelink"},
+	"jira/internal.internalRemoteLinkImpl.DeleteByGlobalId":                  {"rest/api/*/issue/{issueKeyOrID}/remotelink"},
+	"jira/internal.internalRemoteLinkImpl.DeleteById":                        {"rest/api/*/issue/{issueKeyOrID}/remotelink/{linkID}"},
+	"jira/internal.internalRemoteLinkImpl.Get":                               {"rest/api/*/issue/{issueKeyOrID}/remotelink/{linkID}"},
+	"jira/internal.internalRemoteLinkImpl.Gets":                              {"rest/api/*/issue/{issueKeyOrID}/remotelink"},
+	"jira/internal.internalRemoteLinkImpl.Update":                            {"rest/api/*/issue/{issueKeyOrID}/remotelink/{linkID}"},
+	"jira/internal.internalResolutionImpl.Get":                               {"rest/api/*/resolution/{resolutionID}"},
+	"jira/internal.internalResolutionImpl.Gets":                              {"rest/api/*/resolution"},
+	"jira/internal.internalRichTextCommentImpl.Add":                          {"rest/api/*/issue/{issueKeyOrID}/comment"},
+	"jira/internal.internalRichTextCommentImpl.Delete":                       {"rest/api/*/issue/{issueKeyOrID}/comment/{commentID}"},
+	"jira/internal.internalRichTextCommentImpl.Get":                          {"rest/api/*/issue/{issueKeyOrID}/comment/{commentID}"},
+	"jira/internal.internalRichTextCommentImpl.Gets":                         {"rest/api/*/issue/{issueKeyOrID}/comment"},
+	"jira/internal.internalRichTextServiceImpl.Create":                       {"rest/api/*/issue"},
+	"jira/internal.internalRichTextServiceImpl.Creates":                      {"rest/api/*/issue/bulk"},
+	"jira/internal.internalRichTextServiceImpl.Get":                          {"rest/api/*/issue/{issueKeyOrID}"},
+	"jira/internal.internalRichTextServiceImpl.Move":                         {"rest/api/*/issue/{issueKeyOrID}/transitions"},
+	"jira/internal.internalRichTextServiceImpl.Update":                       {"rest/api/*/issue/{issueKeyOrID}"},
+	"jira/internal.internalScreenImpl.AddToDefault":                          {"rest/api/*/screens/addToDefault/{fieldID}"},
+	"jira/internal.internalScreenImpl.Available":                             {"rest/api/*/screens/{screenID}/availableFields"},
+	"jira/internal.internalScreenImpl.Create":                                {"rest/api/*/screens"},
+	"jira/internal.internalScreenImpl.Delete":                                {"rest/api/*/screens/{screenID}"},
+	"jira/internal.internalScreenImpl.Fields":                                {"rest/api/*/field/{fieldID}/screens"},
+	"jira/internal.internalScreenImpl.Gets":                                  {"rest/api/*/screens"},
+	"jira/internal.internalScreenImpl.Update":                                {"rest/api/*/screens/{screenID}"},
+	"jira/internal.internalScreenSchemeImpl.Create":                          {"rest/api/*/screenscheme"},
+	"jira/internal.internalScreenSchemeImpl.Delete":                          {"rest/api/*/screenscheme/{screenSchemeID}"},
+	"jira/internal.internalScreenSchemeImpl.Gets":                            {"rest/api/*/screenscheme"},
+	"jira/internal.internalScreenSchemeImpl.Update":                          {"rest/api/*/screenscheme/{screenSchemeID}"},
+	"jira/internal.internalScreenTabFieldImpl.Add":                           {"rest/api/*/screens/{screenID}/tabs/{tabID}/fields"},
+	"jira/internal.internalScreenTabFieldImpl.Gets":                          {"rest/api/*/screens/{screenID}/tabs/{tabID}/fields"},
+	"jira/internal.internalScreenTabFieldImpl.Move":                          {"rest/api/*/screens/{screenID}/tabs/{tabID}/fields/{fieldID}/move"},
+	"jira/internal.internalScreenTabFieldImpl.Remove":                        {"rest/api/*/screens/{screenID}/tabs/{tabID}/fields/{fieldID}"},
+	"jira/internal.internalScreenTabImpl.Create":                             {"rest/api/*/screens/{screenID}/tabs"},
+	"jira/internal.internalScreenTabImpl.Delete":                             {"rest/api/*/screens/{screenID}/tabs/{tabID}"},
+	"jira/internal.internalScreenTabImpl.Gets":                               {"rest/api/*/screens/{screenID}/tabs"},
+	"jira/internal.internalScreenTabImpl.Move":                               {"rest/api/*/screens/{screenID}/tabs/{tabID}/move/{position}"},
+	"jira/internal.internalScreenTabImpl.Update":                             {"rest/api/*/screens/{screenID}/tabs/{tabID}"},
+	"jira/internal.internalSearchADFImpl.Checks":                             {"rest/api/*/jql/match"},
+	"jira/internal.internalSearchADFImpl.Get":                                {"rest/api/*/search"},
+	"jira/internal.internalSearchADFImpl.Post":                               {"rest/api/*/search"},
+	"jira/internal.internalSearchRichTextImpl.Checks":                        {"rest/api/*/jql/match"},
+	"jira/internal.internalSearchRichTextImpl.Get":                           {"rest/api/*/search"},
+	"jira/internal.internalSearchRichTextImpl.Post":                          {"rest/api/*/search"},
+	"jira/internal.internalServerServiceImpl.Info":                           {"rest/api/*/serverInfo"},
+	"jira/internal.internalTaskServiceImpl.Cancel":                           {"rest/api/*/task/{taskID}/cancel"},
+	"jira/internal.internalTaskServiceImpl.Get":                              {"rest/api/*/task/{taskID}"},
+	"jira/internal.internalTeamServiceImpl.Create":                           {"rest/teams/1.0/teams/create"},
+	"jira/internal.internalTeamServiceImpl.Gets":                             {"rest/teams/1.0/teams/find"},
+	"jira/internal.internalTypeImpl.Alternatives":                            {"rest/api/*/issuetype/{issueTypeID}/alternatives"},
+	"jira/internal.internalTypeImpl.Create":                                  {"rest/api/*/issuetype"},
+	"jira/internal.internalTypeImpl.Delete":                                  {"rest/api/*/issuetype/{issueTypeID}"},
+	"jira/internal.internalTypeImpl.Get":                                     {"rest/api/*/issuetype/{issueTypeID}"},
+	"jira/internal.internalTypeImpl.Gets":                                    {"rest/api/*/issuetype"},
+	"jira/internal.internalTypeImpl.Update":                                  {"rest/api/*/issuetype/{issueTypeID}"},
+	"jira/internal.internalTypeSchemeImpl.Append":                            {"rest/api/*/issuetypescheme/{issueTypeSchemeID}/issuetype"},
+	"jira/internal.internalTypeSchemeImpl.Assign":                            {"rest/api/*/issuetypescheme/project"},
+	"jira/internal.internalTypeSchemeImpl.Create":                            {"rest/api/*/issuetypescheme"},
+	"jira/internal.internalTypeSchemeImpl.Delete":                            {"rest/api/*/issuetypescheme/{issueTypeSchemeID}"},
+	"jira/internal.internalTypeSchemeImpl.Gets":                              {"rest/api/*/issuetypescheme"},
+	"jira/internal.internalTypeSchemeImpl.Items":                             {"rest/api/*/issuetypescheme/mapping"},
+	"jira/internal.internalTypeSchemeImpl.Projects":                          {"rest/api/*/issuetypescheme/project"},
+	"jira/internal.internalTypeSchemeImpl.Remove":                            {"rest/api/*/issuetypescheme/{issueTypeSchemeID}/issuetype/{issueTypeID}"},
+	"jira/internal.internalTypeSchemeImpl.Update":                            {"rest/api/*/issuetypescheme/{issueTypeSchemeID}"},
+	"jira/internal.internalTypeScreenSchemeImpl.Append":                      {"rest/api/*/issuetypescreenscheme/{issueTypeScreenSchemeID}/mapping"},
+	"jira/internal.internalTypeScreenSchemeImpl.Assign":                      {"rest/api/*/issuetypescreenscheme/project"},
+	"jira/internal.internalTypeScreenSchemeImpl.Create":                      {"rest/api/*/issuetypescreenscheme"},
+	"jira/internal.internalTypeScreenSchemeImpl.Delete":                      {"rest/api/*/issuetypescreenscheme/{issueTypeScreenSchemeID}"},
+	"jira/internal.internalTypeScreenSchemeImpl.Gets":                        {"rest/api/*/issuetypescreenscheme"},
+	"jira/internal.internalTypeScreenSchemeImpl.Mapping":                     {"rest/api/*/issuetypescreenscheme/mapping"},
+	"jira/internal.internalTypeScreenSchemeImpl.Projects":                    {"rest/api/*/issuetypescreenscheme/project"},
+	"jira/internal.internalTypeScreenSchemeImpl.Remove":                      {"rest/api/*/issuetypescreenscheme/{issueTypeScreenSchemeID}/mapping/remove"},
+	"jira/internal.internalTypeScreenSchemeImpl.SchemesByProject":            {"rest/api/*/issuetypescreenscheme/{issueTypeScreenSchemeID}/project"},
+	"jira/internal.internalTypeScreenSchemeImpl.Update":                      {"rest/api/*/issuetypescreenscheme/{issueTypeScreenSchemeID}"},
+	"jira/internal.internalTypeScreenSchemeImpl.UpdateDefault":               {"rest/api/*/issuetypescreenscheme/{issueTypeScreenSchemeID}/mapping/default"},
+	"jira/internal.internalUserImpl.Create":                                  {"rest/api/*/user"},
+	"jira/internal.internalUserImpl.Delete":                                  {"rest/api/*/user"},
+	"jira/internal.internalUserImpl.Find":                                    {"rest/api/*/user/bulk"},
+	"jira/internal.internalUserImpl.Get":                                     {"rest/api/*/user"},
+	"jira/internal.internalUserImpl.Gets":                                    {"rest/api/*/users/search"},
+	"jira/internal.internalUserImpl.Groups":                                  {"rest/api/*/user/groups"},
+	"jira/internal.internalUserSearchImpl.Check":                             {"rest/api/*/user/permission/search"},
+	"jira/internal.internalUserSearchImpl.Do":                                {"rest/api/*/user/search"},
+	"jira/internal.internalUserSearchImpl.Projects":                          {"rest/api/*/user/assignable/multiProjectSearch"},
+	"jira/internal.internalVoteImpl.Add":                                     {"rest/api/*/issue/{issueKeyOrID}/votes"},
+	"jira/internal.internalVoteImpl.Delete":                                  {"rest/api/*/issue/{issueKeyOrID}/votes"},
+	"jira/internal.internalVoteImpl.Gets":                                    {"rest/api/*/issue/{issueKeyOrID}/votes"},
+	"jira/internal.internalWatcherImpl.Add":                                  {"rest/api/*/issue/{issueKeyOrID}/watchers"},
+	"jira/internal.internalWatcherImpl.AddUser":                              {"rest/api/*/issue/{issueKeyOrID}/watchers"},
+	"jira/internal.internalWatcherImpl.Delete":                               {"rest/api/*/issue/{issueKeyOrID}/watchers"},
+	"jira/internal.internalWatcherImpl.Gets":                                 {"rest/api/*/issue/{issueKeyOrID}/watchers"},
+	"jira/internal.internalWorkflowImpl.Create":                              {"rest/api/*/workflow"},
+	"jira/internal.internalWorkflowImpl.Delete":                              {"rest/api/*/workflow/{workflowID}"},
+	"jira/internal.internalWorkflowImpl.Gets":                                {"rest/api/*/workflow/search"},
+	"jira/internal.internalWorkflowSchemeImpl.Assign":                        {"rest/api/*/workflowscheme/project"},
+	"jira/internal.internalWorkflowSchemeImpl.Associations":                  {"rest/api/*/workflowscheme/project"},
+	"jira/internal.internalWorkflowSchemeImpl.Create":                        {"rest/api/*/workflowscheme"},
+	"jira/internal.internalWorkflowSchemeImpl.Delete":                        {"rest/api/*/workflowscheme/{schemeID}"},
+	"jira/internal.internalWorkflowSchemeImpl.Get":                           {"rest/api/*/workflowscheme/{schemeID}"},
+	"jira/internal.internalWorkflowSchemeImpl.Gets":                          {"rest/api/*/workflowscheme"},
+	"jira/internal.internalWorkflowSchemeImpl.Publish":                       {"rest/api/*/workflowscheme/{schemeID}/draft/publish"},
+	"jira/internal.internalWorkflowSchemeImpl.Update":                        {"rest/api/*/workflowscheme/{schemeID}"},
+	"jira/internal.internalWorkflowSchemeIssueTypeImpl.Delete":               {"rest/api/*/workflowscheme/{schemeID}/issuetype/{issueTypeID}"},
+	"jira/internal.internalWorkflowSchemeIssueTypeImpl.Get":                  {"rest/api/*/workflowscheme/{schemeID}/issuetype/{issueTypeID}"},
+	"jira/internal.internalWorkflowSchemeIssueTypeImpl.Mapping":              {"rest/api/*/workflowscheme/{schemeID}/workflow"},
+	"jira/internal.internalWorkflowSchemeIssueTypeImpl.Set":                  {"rest/api/*/workflowscheme/{schemeID}/issuetype/{issueTypeID}"},
+	"jira/internal.internalWorkflowStatusImpl.Bulk":                          {"rest/api/*/status"},
+	"jira/internal.internalWorkflowStatusImpl.Create":                        {"rest/api/*/statuses"},
+	"jira/internal.internalWorkflowStatusImpl.Delete":                        {"rest/api/*/statuses"},
+	"jira/internal.internalWorkflowStatusImpl.Get":                           {"rest/api/*/status/{idOrName}"},
+	"jira/internal.internalWorkflowStatusImpl.Gets":                          {"rest/api/*/statuses"},
+	"jira/internal.internalWorkflowStatusImpl.Search":                        {"rest/api/*/statuses/search"},
+	"jira/internal.internalWorkflowStatusImpl.Update":                        {"rest/api/*/statuses"},
+	"jira/internal.internalWorklogAdfImpl.Add":                               {"rest/api/*/issue/{issueKeyOrID}/worklog"},
+	"jira/internal.internalWorklogAdfImpl.Delete":                            {"rest/api/*/issue/{issueKeyOrID}/worklog/{worklogID}"},
+	"jira/internal.internalWorklogAdfImpl.Deleted":                           {"rest/api/*/worklog/deleted"},
+	"jira/internal.internalWorklogAdfImpl.Get":                               {"rest/api/*/issue/{issueKeyOrID}/worklog/{worklogID}"},
+	"jira/internal.internalWorklogAdfImpl.Gets":                              {"rest/api/*/worklog/list"},
+	"jira/internal.internalWorklogAdfImpl.Issue":                             {"rest/api/*/issue/{issueKeyOrID}/worklog"},
+	"jira/internal.internalWorklogAdfImpl.Update":                            {"rest/api/*/issue/{issueKeyOrID}/worklog/{worklogID}"},
+	"jira/internal.internalWorklogAdfImpl.Updated":                           {"rest/api/*/worklog/updated"},
+	"jira/internal.internalWorklogRichTextImpl.Add":                          {"rest/api/*/issue/{issueKeyOrID}/worklog"},
+	"jira/internal.internalWorklogRichTextImpl.Delete":                       {"rest/api/*/issue/{issueKeyOrID}/worklog/{worklogID}"},
+	"jira/internal.internalWorklogRichTextImpl.Deleted":                      {"rest/api/*/worklog/deleted"},
+	"jira/internal.internalWorklogRichTextImpl.Get":                          {"rest/api/*/issue/{issueKeyOrID}/worklog/{worklogID}"},
+	"jira/internal.internalWorklogRichTextImpl.Gets":                         {"rest/api/*/worklog/list"},
+	"jira/internal.internalWorklogRichTextImpl.Issue":                        {"rest/api/*/issue/{issueKeyOrID}/worklog"},
+	"jira/internal.internalWorklogRichTextImpl.Update":                       {"rest/api/*/issue/{issueKeyOrID}/worklog/{worklogID}"},
+	"jira/internal.internalWorklogRichTextImpl.Updated":                      {"rest/api/*/worklog/updated"},
+	"jira/internal.sendNotification":                                         {"rest/api/*/issue/{issueKeyOrID}/notify"},
+	"jira/sm/internal.internalCustomerImpl.Add":                              {"rest/servicedeskapi/servicedesk/{serviceDeskID}/customer"},
+	"jira/sm/internal.internalCustomerImpl.Create":                           {"rest/servicedeskapi/customer"},
+	"jira/sm/internal.internalCustomerImpl.Gets":                             {"rest/servicedeskapi/servicedesk/{serviceDeskID}/customer"},
+	"jira/sm/internal.internalCustomerImpl.Remove":                           {"rest/servicedeskapi/servicedesk/{serviceDeskID}/customer"},
+	"jira/sm/internal.internalInfoImpl.Get":                                  {"rest/servicedeskapi/info"},
+	"jira/sm/internal.internalKnowledgebaseImpl.ExperimentalLinkSpace":       {"rest/servicedesk/knowledgebase/latest/servicedesk/{serviceDeskID}/linkedspace"},
+	"jira/sm/internal.internalKnowledgebaseImpl.ExperimentalUnlinkSpace":     {"rest/servicedesk/knowledgebase/latest/servicedesk/{serviceDeskID}/linkedspace"},
+	"jira/sm/internal.internalKnowledgebaseImpl.Gets":                        {"rest/servicedeskapi/servicedesk/{serviceDeskID}/knowledgebase/article"},
+	"jira/sm/internal.internalKnowledgebaseImpl.Search":                      {"rest/servicedeskapi/knowledgebase/article"},
+	"jira/sm/internal.internalOrganizationImpl.Add":                          {"rest/servicedeskapi/organization/{organizationID}/user"},
+	"jira/sm/internal.internalOrganizationImpl.Associate":                    {"rest/servicedeskapi/servicedesk/{serviceDeskID}/organization"},
+	"jira/sm/internal.internalOrganizationImpl.Create":                       {"rest/servicedeskapi/organization"},
+	"jira/sm/internal.internalOrganizationImpl.Delete":                       {"rest/servicedeskapi/organization/{organizationID}"},
+	"jira/sm/internal.internalOrganizationImpl.Detach":                       {"rest/servicedeskapi/servicedesk/{serviceDeskID}/organization"},
+	"jira/sm/internal.internalOrganizationImpl.Get":                          {"rest/servicedeskapi/organization/{organizationID}"},
+	"jira/sm/internal.internalOrganizationImpl.Gets":                         {"rest/servicedeskapi/organization"},
+	"jira/sm/internal.internalOrganizationImpl.Project":                      {"rest/servicedeskapi/servicedesk/{serviceDeskID}/organization"},
+	"jira/sm/internal.internalOrganizationImpl.Remove":                       {"rest/servicedeskapi/organization/{organizationID}/user"},
+	"jira/sm/internal.internalOrganizationImpl.Users":                        {"rest/servicedeskapi/organization/{organizationID}/user"},
+	"jira/sm/internal.internalQueueServiceImpl.Get":                          {"rest/servicedeskapi/servicedesk/{serviceDeskID}/queue/{queueID}"},
+	"jira/sm/internal.internalQueueServiceImpl.Gets":                         {"rest/servicedeskapi/servicedesk/{serviceDeskID}/queue"},
+	"jira/sm/internal.internalQueueServiceImpl.Issues":                       {"rest/servicedeskapi/servicedesk/{serviceDeskID}/queue/{queueID}/issue"},
+	"jira/sm/internal.internalServiceDeskImpl.Attach":                        {"rest/servicedeskapi/servicedesk/{serviceDeskID}/attachTemporaryFile"},
+	"jira/sm/internal.internalServiceDeskImpl.Get":                           {"rest/servicedeskapi/servicedesk/{serviceDeskID}"},
+	"jira/sm/internal.internalServiceDeskImpl.Gets":                          {"rest/servicedeskapi/servicedesk"},
+	"jira/sm/internal.internalServiceLevelAgreementImpl.Get":                 {"rest/servicedeskapi/request/{issueKeyOrID}/sla/{metricID}"},
+	"jira/sm/internal.internalServiceLevelAgreementImpl.Gets":                {"rest/servicedeskapi/request/{issueKeyOrID}/sla"},
+	"jira/sm/internal.internalServiceRequestApprovalImpl.Answer":             {"rest/servicedeskapi/request/{issueKeyOrID}/approval/{approvalID}"},
+	"jira/sm/internal.internalServiceRequestApprovalImpl.Get":                {"rest/servicedeskapi/request/{issueKeyOrID}/approval/{approvalID}"},
+	"jira/sm/internal.internalServiceRequestApprovalImpl.Gets":               {"rest/servicedeskapi/request/{issueKeyOrID}/approval"},
+	"jira/sm/internal.internalServiceRequestAttachmentImpl.Create":           {"rest/servicedeskapi/request/{issueKeyOrID}/attachment"},
+	"jira/sm/internal.internalServiceRequestAttachmentImpl.Gets":             {"rest/servicedeskapi/request/{issueKeyOrID}/attachment"},
+	"jira/sm/internal.internalServiceRequestCommentImpl.Attachments":         {"rest/servicedeskapi/request/{issueKeyOrID}/comment/{commentID}/attachment"},
+	"jira/sm/internal.internalServiceRequestCommentImpl.Create":              {"rest/servicedeskapi/request/{issueKeyOrID}/comment"},
+	"jira/sm/internal.internalServiceRequestCommentImpl.Get":                 {"rest/servicedeskapi/request/{issueKeyOrID}/comment/{commentID}"},
+	"jira/sm/internal.internalServiceRequestCommentImpl.Gets":                {"rest/servicedeskapi/request/{issueKeyOrID}/comment"},
+	"jira/sm/internal.internalServiceRequestFeedbackImpl.Delete":             {"rest/servicedeskapi/request/{requestIDOrKey}/feedback"},
+	"jira/sm/internal.internalServiceRequestFeedbackImpl.Get":                {"rest/servicedeskapi/request/{requestIDOrKey}/feedback"},
+	"jira/sm/internal.internalServiceRequestFeedbackImpl.Post":               {"rest/servicedeskapi/request/{requestIDOrKey}/feedback"},
+	"jira/sm/internal.internalServiceRequestImpl.Create":                     {"rest/servicedeskapi/request"},
+	"jira/sm/internal.internalServiceRequestImpl.Get":                        {"rest/servicedeskapi/request/{issueKeyOrID}"},
+	"jira/sm/internal.internalServiceRequestImpl.Gets":                       {"rest/servicedeskapi/request"},
+	"jira/sm/internal.internalServiceRequestImpl.Subscribe":                  {"rest/servicedeskapi/request/{issueKeyOrID}/notification"},
+	"jira/sm/internal.internalServiceRequestImpl.Transition":                 {"rest/servicedeskapi/request/{issueKeyOrID}/transition"},
+	"jira/sm/internal.internalServiceRequestImpl.Transitions":                {"rest/servicedeskapi/request/{issueKeyOrID}/transition"},
+	"jira/sm/internal.internalServiceRequestImpl.Unsubscribe":                {"rest/servicedeskapi/request/{issueKeyOrID}/notification"},
+	"jira/sm/internal.internalServiceRequestParticipantImpl.Add":             {"rest/servicedeskapi/request/{issueKeyOrID}/participant"},
+	"jira/sm/internal.internalServiceRequestParticipantImpl.Gets":            {"rest/servicedeskapi/request/{issueKeyOrID}/participant"},
+	"jira/sm/internal.internalServiceRequestParticipantImpl.Remove":          {"rest/servicedeskapi/request/{issueKeyOrID}/participant"},
+	"jira/sm/internal.internalTypeImpl.Create":                               {"rest/servicedeskapi/servicedesk/{serviceDeskID}/requesttype"},
+	"jira/sm/internal.internalTypeImpl.Delete":                               {"rest/servicedeskapi/servicedesk/{serviceDeskID}/requesttype/{requestTypeID}"},
+	"jira/sm/internal.internalTypeImpl.Fields":                               {"rest/servicedeskapi/servicedesk/{serviceDeskID}/requesttype/{requestTypeID}/field"},
+	"jira/sm/internal.internalTypeImpl.Get":                                  {"rest/servicedeskapi/servicedesk/{serviceDeskID}/requesttype/{requestTypeID}"},
+	"jira/sm/internal.internalTypeImpl.Gets":                                 {"rest/servicedeskapi/servicedesk/{serviceDeskID}/requesttype"},
+	"jira/sm/internal.internalTypeImpl.Search":                               {"rest/servicedeskapi/requesttype"},
+	"jira/sm/internal.internalWorkSpaceImpl.Gets":                            {"rest/servicedeskapi/assets/workspace"},
+}
+
+// services are the public services of the implementations, keyed by package and type.
+var services = map[string][]string{
+	"admin/internal.internalOrganizationDirectoryServiceImpl":   {"admin.Organization.Directory"},
+	"admin/internal.internalOrganizationImpl":                   {"admin.Organization"},
+	"admin/internal.internalOrganizationPolicyImpl":             {"admin.Organization.Policy"},
+	"admin/internal.internalSCIMGroupImpl":                      {"admin.SCIM.Group"},
+	"admin/internal.internalSCIMSchemaImpl":                     {"admin.SCIM.Schema"},
+	"admin/internal.internalSCIMUserImpl":                       {"admin.SCIM.User"},
+	"admin/internal.internalUserImpl":                           {"admin.User"},
+	"admin/internal.internalUserTokenImpl":                      {"admin.User.Token"},
+	"assets/internal.internalAQLImpl":                           {"assets.AQL"},
+	"assets/internal.internalIconImpl":                          {"assets.Icon"},
+	"assets/internal.internalObjectImpl":                        {"assets.Object"},
+	"assets/internal.internalObjectSchemaImpl":                  {"assets.ObjectSchema"},
+	"assets/internal.internalObjectTypeAttributeImpl":           {"assets.ObjectTypeAttribute"},
+	"assets/internal.internalObjectTypeImpl":                    {"assets.ObjectType"},
+	"bitbucket/internal.internalWorkspaceHookServiceImpl":       {"bitbucket.Workspace.Hook"},
+	"bitbucket/internal.internalWorkspacePermissionServiceImpl": {"bitbucket.Workspace.Permission"},
+	"bitbucket/internal.internalWorkspaceServiceImpl":           {"bitbucket.Workspace"},
+	"confluence/internal.internalAnalyticsServiceImpl":          {"confluence.Analytics"},
+	"confluence/internal.internalAttachmentImpl":                {"confluence.Attachment"},
+	"confluence/internal.internalAttachmentVersionImpl":         {"confluence.Attachment.Version"},
+	"confluence/internal.internalChildrenDescandantsImpl":       {"confluence.Content.ChildrenDescendant"},
+	"confluence/internal.internalCommentImpl":                   {"confluence.Content.Comment"},
+	"confluence/internal.internalContentAttachmentImpl":         {"confluence.Content.Attachment"},
+	"confluence/internal.internalContentImpl":                   {"confluence.Content"},
+	"confluence/internal.internalContentLabelImpl":              {"confluence.Content.Label"},
+	"confluence/internal.internalContentPropertyV2Impl":         {"confluence.Whiteboard.Property", "confluence.Database.Property"},
+	"confluence/internal.internalCustomContentServiceImpl":      {"confluence.CustomContent"},
+	"confluence/internal.internalDatabaseImpl":                  {"confluence.Database"},
+	"confluence/internal.internalLabelImpl":                     {"confluence.Label"},
+	"confluence/internal.internalPageImpl":                      {"confluence.Page"},
+	"confluence/internal.internalPermissionImpl":                {"confluence.Content.Permission"},
+	"confluence/internal.internalPropertyImpl":                  {"confluence.Content.Property"},
+	"confluence/internal.internalRestrictionAuditImpl":          {"confluence.Content.Restriction.Audit"},
+	"confluence/internal.internalRestrictionImpl":               {"confluence.Content.Restriction"},
+	"confluence/internal.internalRestrictionOperationGroupImpl": {"confluence.Content.Restriction.Operation.Group"},
+	"confluence/internal.internalRestrictionOperationImpl":      {"confluence.Content.Restriction.Operation"},
+	"confluence/internal.internalRestrictionOperationUserImpl":  {"confluence.Content.Restriction.Operation.User"},
+	"confluence/internal.internalSearchImpl":                    {"confluence.Search"},
+	"confluence/internal.internalSpaceImpl":                     {"confluence.Space"},
+	"confluence/internal.internalSpacePermissionImpl":           {"confluence.Space.Permission"},
+	"confluence/internal.internalSpaceV2Impl":                   {"confluence.Space"},
+	"confluence/internal.internalTaskImpl":                      {"confluence.LongTask"},
+	"confluence/internal.internalVersionImpl":                   {"confluence.Content.Version"},
+	"confluence/internal.internalWhiteboardImpl":                {"confluence.Whiteboard"},
+	"jira/agile/internal.internalBoardBacklogImpl":              {"agile.Backlog"},
+	"jira/agile/internal.internalBoardImpl":                     {"agile.Board"},
+	"jira/agile/internal.internalEpicImpl":                      {"agile.Epic"},
+	"jira/agile/internal.internalSprintImpl":                    {"agile.Sprint"},
+	"jira/internal.internalAdfCommentImpl":                      {"jira.Issue.Comment"},
+	"jira/internal.internalAnnouncementBannerImpl":              {"jira.Banner"},
+	"jira/internal.internalApplicationRoleImpl":                 {"jira.Role"},
+	"jira/internal.internalAuditRecordImpl":                     {"jira.Audit"},
+	"jira/internal.internalDashboardImpl":                       {"jira.Dashboard"},
+	"jira/internal.internalFieldTrashServiceImpl":               {"jira.Issue.Field.Trash"},
+	"jira/internal.internalFilterServiceImpl":                   {"jira.Filter"},
+	"jira/internal.internalFilterShareImpl":                     {"jira.Filter.Share"},
+	"jira/internal.internalGroupServiceImpl":                    {"jira.Group"},
+	"jira/internal.internalIssueADFServiceImpl":                 {"jira.Issue"},
+	"jira/internal.internalIssueAttachmentServiceImpl":          {"jira.Issue.Attachment"},
+	"jira/internal.internalIssueFieldConfigItemServiceImpl":     {"jira.Issue.Field.Configuration.Item"},
+	"jira/internal.internalIssueFieldConfigSchemeServiceImpl":   {"jira.Issue.Field.Configuration.Scheme"},
+	"jira/internal.internalIssueFieldConfigServiceImpl":         {"jira.Issue.Field.Configuration"},
+	"jira/internal.internalIssueFieldContextOptionServiceImpl":  {"jira.Issue.Field.Context.Option"},
+	"jira/internal.internalIssueFieldContextServiceImpl":        {"jira.Issue.Field.Context"},
+	"jira/internal.internalIssueFieldServiceImpl":               {"jira.Issue.Field"},
+	"jira/internal.internalJQLServiceImpl":                      {"jira.JQL"},
+	"jira/internal.internalLabelServiceImpl":                    {"jira.Issue.Label"},
+	"jira/internal.internalLinkADFServiceImpl":                  {"jira.Issue.Link"},
+	"jira/internal.internalLinkRichTextServiceImpl":             {"jira.Issue.Link"},
+	"jira/internal.internalLinkTypeImpl":                        {"jira.Issue.Link.Type"},
+	"jira/internal.internalMetadataImpl":                        {"jira.Issue.Metadata"},
+	"jira/internal.internalMySelfImpl":                          {"jira.MySelf"},
+	"jira/internal.internalNotificationSchemeImpl":              {"jira.NotificationScheme"},
+	"jira/internal.internalPermissionImpl":                      {"jira.Permission"},
+	"jira/internal.internalPermissionSchemeGrantImpl":           {"jira.Permission.Scheme.Grant"},
+	"jira/internal.internalPermissionSchemeImpl":                {"jira.Permission.Scheme"},
+	"jira/internal.internalPriorityImpl":                        {"jira.Issue.Priority"},
+	"jira/internal.internalProjectCategoryImpl":                 {"jira.Project.Category"},
+	"jira/internal.internalProjectComponentImpl":                {"jira.Project.Component"},
+	"jira/internal.internalProjectFeatureImpl":                  {"jira.Project.Feature"},
+	"jira/internal.internalProjectImpl":                         {"jira.Project"},
+	"jira/internal.internalProjectPermissionSchemeImpl":         {"jira.Project.Permission"},
+	"jira/internal.internalProjectPropertyImpl":                 {"jira.Project.Property"},
+	"jira/internal.internalProjectRoleActorImpl":                {"jira.Project.Role.Actor"},
+	"jira/internal.internalProjectRoleImpl":                     {"jira.Project.Role"},
+	"jira/internal.internalProjectTypeImpl":                     {"jira.Project.Type"},
+	"jira/internal.internalProjectValidatorImpl":                {"jira.Project.Validator"},
+	"jira/internal.internalProjectVersionImpl":                  {"jira.Project.Version"},
+	"jira/internal.internalRemoteLinkImpl":                      {"jira.Issue.Link.Remote"},
+	"jira/internal.internalResolutionImpl":                      {"jira.Issue.Resolution"},
+	"jira/internal.internalRichTextCommentImpl":                 {"jira.Issue.Comment"},
+	"jira/internal.internalRichTextServiceImpl":                 {"jira.Issue"},
+	"jira/internal.internalScreenImpl":                          {"jira.Screen"},
+	"jira/internal.internalScreenSchemeImpl":                    {"jira.Screen.Scheme"},
+	"jira/internal.internalScreenTabFieldImpl":                  {"jira.Screen.Tab.Field"},
+	"jira/internal.internalScreenTabImpl":                       {"jira.Screen.Tab"},
+	"jira/internal.internalSearchADFImpl":                       {"jira.Issue.Search"},
+	"jira/internal.internalSearchRichTextImpl":                  {"jira.Issue.Search"},
+	"jira/internal.internalServerServiceImpl":                   {"jira.Server"},
+	"jira/internal.internalTaskServiceImpl":                     {"jira.Task"},
+	"jira/internal.internalTeamServiceImpl":                     {"jira.Team"},
+	"jira/internal.internalTypeImpl":                            {"jira.Issue.Type"},
+	"jira/internal.internalTypeSchemeImpl":                      {"jira.Issue.Type.Scheme"},
+	"jira/internal.internalTypeScreenSchemeImpl":                {"jira.Issue.Type.ScreenScheme"},
+	"jira/internal.internalUserImpl":                            {"jira.User"},
+	"jira/internal.internalUserSearchImpl":                      {"jira.User.Search"},
+	"jira/internal.internalVoteImpl":                            {"jira.Issue.Vote"},
+	"jira/internal.internalWatcherImpl":                         {"jira.Issue.Watcher"},
+	"jira/internal.internalWorkflowImpl":                        {"jira.Workflow"},
+	"jira/internal.internalWorkflowSchemeImpl":                  {"jira.Workflow.Scheme"},
+	"jira/internal.internalWorkflowSchemeIssueTypeImpl":         {"jira.Workflow.Scheme.IssueType"},
+	"jira/internal.internalWorkflowStatusImpl":                  {"jira.Workflow.Status"},
+	"jira/internal.internalWorklogAdfImpl":                      {"jira.Issue.Worklog"},
+	"jira/internal.internalWorklogRichTextImpl":                 {"jira.Issue.Worklog"},
+	"jira/sm/internal.internalCustomerImpl":                     {"sm.Customer"},
+	"jira/sm/internal.internalInfoImpl":                         {"sm.Info"},
+	"jira/sm/internal.internalKnowledgebaseImpl":                {"sm.Knowledgebase"},
+	"jira/sm/internal.internalOrganizationImpl":                 {"sm.Organization"},
+	"jira/sm/internal.internalQueueServiceImpl":                 {"sm.ServiceDesk.Queue"},
+	"jira/sm/internal.internalRequestSubmitterImpl":             {"sm.Request.Submitter"},
+	"jira/sm/internal.internalServiceDeskImpl":                  {"sm.ServiceDesk"},
+	"jira/sm/internal.internalServiceLevelAgreementImpl":        {"sm.Request.SLA"},
+	"jira/sm/internal.internalServiceRequestApprovalImpl":       {"sm.Request.Approval"},
+	"jira/sm/internal.internalServiceRequestAttachmentImpl":     {"sm.Request.Attachment"},
+	"jira/sm/internal.internalServiceRequestCommentImpl":        {"sm.Request.Comment"},
+	"jira/sm/internal.internalServiceRequestFeedbackImpl":       {"sm.Request.Feedback"},
+	"jira/sm/internal.internalServiceRequestImpl":               {"sm.Request"},
+	"jira/sm/internal.internalServiceRequestParticipantImpl":    {"sm.Request.Participant"},
+	"jira/sm/internal.internalTypeImpl":                         {"sm.Request.Type"},
+	"jira/sm/internal.internalWorkSpaceImpl":                    {"sm.WorkSpace"},
+}
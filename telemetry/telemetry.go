@@ -0,0 +1,352 @@
+// Package telemetry instruments the clients with an operation per service method, e.g: jira.Issue.Get,
+// through the client middlewares. The instrument is provided by the caller, e.g: the OpenTelemetry
+// instrument of the github.com/ctreminiom/go-atlassian/telemetry/otel module.
+//
+// The service methods send a single request through the middlewares, the telemetry middleware must
+// be the first one, so the retries of the middlewares after it are recorded on the same operation.
+//
+//	instance.Use(telemetry.Middleware(instrument), retries)
+//
+// The routes of the service methods and their public services are generated from the service
+// implementations and the clients, go generate must be run when a service or a method is added.
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
+
+	"github.com/ctreminiom/go-atlassian/service/common"
+)
+
+const modulePath = "github.com/ctreminiom/go-atlassian/"
+
+//go:generate go run ./internal/routegen
+
+// Operation describes the service method that sent a request.
+type Operation struct {
+
+	// Name is the product, service and method, e.g: jira.Issue.Comment.Gets.
+	Name    string
+	Product string
+	Service string
+	Method  string
+
+	// HTTPMethod and EndpointTemplate describe the request, the template replaces the parameters
+	// of the endpoint with placeholders, e.g: rest/api/3/issue/{issueKeyOrID}.
+	HTTPMethod       string
+	EndpointTemplate string
+}
+
+// Outcome is the result of an operation.
+type Outcome struct {
+	StatusCode int
+	Retries    int
+	Duration   time.Duration
+	Err        error
+}
+
+// Instrument records the operations, e.g: as spans and metrics.
+type Instrument interface {
+
+	// Start is called before the request is sent, the context returned is the context of the request
+	// and the function returned is called with the outcome of the operation.
+	Start(ctx context.Context, operation *Operation) (context.Context, func(outcome *Outcome))
+}
+
+type (
+	retriesKey   struct{}
+	operationKey struct{}
+)
+
+// RecordRetry counts a retry of the operation of the context, it's called by the retry middlewares
+// configured after the telemetry middleware.
+func RecordRetry(ctx context.Context) {
+	if retries, ok := ctx.Value(retriesKey{}).(*int32); ok {
+		atomic.AddInt32(retries, 1)
+	}
+}
+
+// Middleware returns a client middleware that records each request as an operation of the instrument.
+func Middleware(instrument Instrument) common.Middleware {
+
+	return func(next common.RoundTrip) common.RoundTrip {
+		return func(request *http.Request) (*http.Response, error) {
+
+			// The requests sent again by a middleware after this one belong to the operation started.
+			if request.Context().Value(operationKey{}) != nil {
+				return next(request)
+			}
+
+			operation := NewOperation(request)
+
+			var retries int32
+			ctx := context.WithValue(request.Context(), retriesKey{}, &retries)
+			ctx = context.WithValue(ctx, operationKey{}, operation)
+
+			ctx, end := instrument.Start(ctx, operation)
+
+			started := time.Now()
+			response, err := next(request.WithContext(ctx))
+
+			outcome := &Outcome{Retries: int(atomic.LoadInt32(&retries)), Duration: time.Since(started), Err: err}
+			if response != nil {
+				outcome.StatusCode = response.StatusCode
+			}
+
+			end(outcome)
+			return response, err
+		}
+	}
+}
+
+// NewOperation returns the operation of the request. The service method is the closest method of a
+// service implementation on the call stack, named after the public service of the clients, e.g:
+// jira.Issue.Comment.Gets, and the endpoint template is the route of the method matching the path.
+//
+// The requests sent without a service method are named after the HTTP method and the endpoint.
+func NewOperation(request *http.Request) *Operation {
+
+	operation := &Operation{HTTPMethod: request.Method}
+
+	frames := callers()
+	path := strings.Trim(request.URL.Path, "/")
+
+	operation.EndpointTemplate = routeTemplate(frames, path)
+
+	product, service, method := operationOf(frames, path)
+	if service == "" {
+		operation.Name = strings.TrimSpace(operation.HTTPMethod + " " + operation.EndpointTemplate)
+		return operation
+	}
+
+	operation.Product, operation.Service, operation.Method = product, service, method
+	operation.Name = product + "." + service + "." + method
+
+	return operation
+}
+
+// frame is a function of the service implementation packages found on the call stack.
+type frame struct {
+
+	// Implementation is the package and type of the method, e.g: jira/internal.internalIssueADFServiceImpl,
+	// it's empty for the functions.
+	Implementation string
+	Function       string
+}
+
+// key returns the key of the routes of the frame.
+func (f *frame) key() string {
+
+	if f.Implementation == "" {
+		return f.Function
+	}
+
+	return f.Implementation + "." + f.Function
+}
+
+// callers returns the functions of the service implementation packages on the call stack, the closest first.
+func callers() []*frame {
+
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+
+	var found []*frame
+	for {
+
+		next, more := frames.Next()
+
+		if current := parseFrame(next.Function); current != nil {
+			found = append(found, current)
+		}
+
+		if !more {
+			return found
+		}
+	}
+}
+
+// parseFrame returns the frame of the function name, e.g: github.com/ctreminiom/go-atlassian/jira/internal.(*internalIssueADFServiceImpl).Get,
+// nil when it isn't a function of a service implementation package.
+func parseFrame(function string) *frame {
+
+	if !strings.HasPrefix(function, modulePath) {
+		return nil
+	}
+
+	function = strings.TrimPrefix(function, modulePath)
+
+	separator := strings.Index(function[strings.LastIndex(function, "/")+1:], ".")
+	if separator == -1 {
+		return nil
+	}
+
+	separator += strings.LastIndex(function, "/") + 1
+
+	pkg, name := function[:separator], function[separator+1:]
+	if !strings.HasSuffix(pkg, "/internal") {
+		return nil
+	}
+
+	parts := strings.Split(strings.NewReplacer("(*", "", ")", "").Replace(name), ".")
+	if strings.HasPrefix(parts[0], "internal") && len(parts) > 1 {
+		return &frame{Implementation: pkg + "." + parts[0], Function: parts[1]}
+	}
+
+	return &frame{Function: pkg + "." + parts[0]}
+}
+
+// operationOf returns the product, service and method of the closest exported method of a service
+// implementation, the implementations shared by several services are named after the service
+// matching the segments of the path kept by the route, e.g: the content type.
+func operationOf(frames []*frame, path string) (string, string, string) {
+
+	for _, current := range frames {
+
+		if current.Implementation == "" || !unicode.IsUpper([]rune(current.Function)[0]) {
+			continue
+		}
+
+		names := services[current.Implementation]
+		if len(names) == 0 {
+			return "", "", ""
+		}
+
+		name := names[0]
+		if len(names) > 1 {
+			name = sharedService(names, keptSegments(routes[current.key()], path))
+		}
+
+		separator := strings.Index(name, ".")
+		return name[:separator], name[separator+1:], current.Function
+	}
+
+	return "", "", ""
+}
+
+func sharedService(names, segments []string) string {
+
+	for _, name := range names {
+		for _, component := range strings.Split(name, ".")[1:] {
+			for _, segment := range segments {
+				if strings.HasPrefix(strings.ToLower(segment), strings.ToLower(component)) {
+					return name
+				}
+			}
+		}
+	}
+
+	return names[0]
+}
+
+// routeTemplate returns the template of the first route of the frames matching the path, the path
+// is templated by EndpointTemplate when none does.
+func routeTemplate(frames []*frame, path string) string {
+
+	for _, current := range frames {
+		if template, _, ok := matchRoutes(routes[current.key()], path); ok {
+			return template
+		}
+	}
+
+	return EndpointTemplate(path)
+}
+
+// keptSegments returns the segments of the path kept by the route matching it.
+func keptSegments(candidates []string, path string) []string {
+	_, kept, _ := matchRoutes(candidates, path)
+	return kept
+}
+
+// matchRoutes returns the template of the route matching the path with the most literal segments and
+// the segments it keeps. The routes are matched on the end of the path, the segments before them,
+// e.g: the base path of the site, are kept.
+func matchRoutes(candidates []string, path string) (string, []string, bool) {
+
+	var (
+		template string
+		kept     []string
+		best     = -1
+		segments = strings.Split(path, "/")
+	)
+
+	for _, candidate := range candidates {
+
+		route := strings.Split(candidate, "/")
+		if len(route) > len(segments) {
+			continue
+		}
+
+		offset := len(segments) - len(route)
+		rendered := append([]string(nil), segments...)
+
+		var (
+			literals int
+			keeps    []string
+			matched  = true
+		)
+
+		for index, part := range route {
+
+			segment := segments[offset+index]
+
+			switch {
+			case part == "*":
+				keeps = append(keeps, segment)
+			case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}"):
+				rendered[offset+index] = part
+			case part == segment:
+				literals++
+			default:
+				matched = false
+			}
+
+			if !matched {
+				break
+			}
+		}
+
+		if matched && literals > best {
+			template, kept, best = strings.Join(rendered, "/"), keeps, literals
+		}
+	}
+
+	return template, kept, best != -1
+}
+
+var (
+	issueKey   = regexp.MustCompile(`^[A-Z][A-Z0-9_]+-\d+$`)
+	identifier = regexp.MustCompile(`^(\d+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|` +
+		`[0-9a-f]{24}|\d+:[0-9a-fA-F-]{36}|ari:.+|\{[0-9a-fA-F-]{36}\})$`)
+)
+
+// EndpointTemplate replaces the IDs, keys and account IDs of the path with placeholders, so the
+// operations of different resources share the same endpoint, e.g: rest/api/3/issue/KEY-1 returns
+// rest/api/3/issue/{key}. The API versions are kept.
+//
+// It templates the requests sent without a service method, the operations of the service methods
+// are templated with the routes of the methods, see NewOperation.
+func EndpointTemplate(path string) string {
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for index, segment := range segments {
+
+		if index > 0 && segments[index-1] == "api" {
+			continue
+		}
+
+		switch {
+		case issueKey.MatchString(segment):
+			segments[index] = "{key}"
+		case identifier.MatchString(segment):
+			segments[index] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,437 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/admin"
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/bitbucket"
+	"github.com/ctreminiom/go-atlassian/confluence"
+	confluenceV2 "github.com/ctreminiom/go-atlassian/confluence/v2"
+	"github.com/ctreminiom/go-atlassian/jira/agile"
+	"github.com/ctreminiom/go-atlassian/jira/sm"
+	v2 "github.com/ctreminiom/go-atlassian/jira/v2"
+	v3 "github.com/ctreminiom/go-atlassian/jira/v3"
+	"github.com/ctreminiom/go-atlassian/service/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type httpClientFunc func(request *http.Request) (*http.Response, error)
+
+func (f httpClientFunc) Do(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+type recorder struct {
+	operations []*Operation
+	outcomes   []*Outcome
+}
+
+func (r *recorder) Start(ctx context.Context, operation *Operation) (context.Context, func(outcome *Outcome)) {
+
+	r.operations = append(r.operations, operation)
+	return ctx, func(outcome *Outcome) {
+		r.outcomes = append(r.outcomes, outcome)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+
+	client := httpClientFunc(func(request *http.Request) (*http.Response, error) {
+
+		// The retries are recorded by the middlewares configured after the telemetry middleware.
+		RecordRetry(request.Context())
+		RecordRetry(request.Context())
+
+		if strings.HasSuffix(request.URL.Path, "/myself") {
+			return nil, errors.New("connection refused")
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(strings.NewReader("{}")),
+			Request:    request,
+		}, nil
+	})
+
+	instance, err := v3.New(client, "https://ctreminiom.atlassian.net")
+	assert.NoError(t, err)
+
+	instrument := &recorder{}
+	instance.Use(Middleware(instrument))
+
+	_, _, err = instance.Issue.Get(context.Background(), "KP-12", nil, nil)
+	assert.Error(t, err)
+
+	_, _, err = instance.Issue.Comment.Gets(context.Background(), "10001", "", nil, 0, 50)
+	assert.Error(t, err)
+
+	// The requests sent without a service method are named after the endpoint.
+	request, err := instance.NewRequest(context.Background(), http.MethodGet, "rest/api/3/myself", "", nil)
+	assert.NoError(t, err)
+
+	_, err = instance.Call(request, nil)
+	assert.EqualError(t, err, "connection refused")
+
+	assert.Equal(t, &Operation{
+		Name:             "jira.Issue.Get",
+		Product:          "jira",
+		Service:          "Issue",
+		Method:           "Get",
+		HTTPMethod:       http.MethodGet,
+		EndpointTemplate: "rest/api/3/issue/{issueKeyOrID}",
+	}, instrument.operations[0])
+
+	assert.Equal(t, "jira.Issue.Comment.Gets", instrument.operations[1].Name)
+	assert.Equal(t, "rest/api/3/issue/{issueKeyOrID}/comment", instrument.operations[1].EndpointTemplate)
+
+	assert.Equal(t, "GET rest/api/3/myself", instrument.operations[2].Name)
+	assert.Empty(t, instrument.operations[2].Service)
+
+	assert.Equal(t, http.StatusNotFound, instrument.outcomes[0].StatusCode)
+	assert.Equal(t, 2, instrument.outcomes[0].Retries)
+	assert.NoError(t, instrument.outcomes[0].Err)
+	assert.EqualError(t, instrument.outcomes[2].Err, "connection refused")
+}
+
+func TestMiddleware_V2(t *testing.T) {
+
+	client := httpClientFunc(func(request *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}")), Request: request}, nil
+	})
+
+	instance, err := v2.New(client, "https://ctreminiom.atlassian.net")
+	assert.NoError(t, err)
+
+	// The operations are recorded once even if the middleware is configured twice.
+	instrument := &recorder{}
+	instance.Use(Middleware(instrument), Middleware(instrument))
+
+	_, _, err = instance.Issue.Get(context.Background(), "KP-12", nil, nil)
+	assert.NoError(t, err)
+
+	assert.Len(t, instrument.operations, 1)
+	assert.Equal(t, "jira.Issue.Get", instrument.operations[0].Name)
+	assert.Equal(t, "rest/api/2/issue/{issueKeyOrID}", instrument.operations[0].EndpointTemplate)
+}
+
+func TestOperations(t *testing.T) {
+
+	client := httpClientFunc(func(request *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}")), Request: request}, nil
+	})
+
+	const site = "https://ctreminiom.atlassian.net"
+
+	testCases := []struct {
+		name     string
+		call     func(ctx context.Context, middleware common.Middleware) error
+		want     string
+		template string
+	}{
+		{
+			name: "when the project is fetched by key",
+			call: func(ctx context.Context, middleware common.Middleware) error {
+
+				instance, err := v3.New(client, site)
+				if err != nil {
+					return err
+				}
+
+				instance.Use(middleware)
+				_, _, err = instance.Project.Get(ctx, "PROJ", nil)
+				return err
+			},
+			want:     "jira.Project.Get",
+			template: "rest/api/3/project/{projectKeyOrID}",
+		},
+		{
+			name: "when the project property is fetched by key",
+			call: func(ctx context.Context, middleware common.Middleware) error {
+
+				instance, err := v2.New(client, site)
+				if err != nil {
+					return err
+				}
+
+				instance.Use(middleware)
+				_, _, err = instance.Project.Property.Get(ctx, "PROJ", "my.prop")
+				return err
+			},
+			want:     "jira.Project.Property.Get",
+			template: "rest/api/2/project/{projectKeyOrID}/properties/{propertyKey}",
+		},
+		{
+			name: "when the board is fetched",
+			call: func(ctx context.Context, middleware common.Middleware) error {
+
+				instance, err := agile.New(client, site)
+				if err != nil {
+					return err
+				}
+
+				instance.Use(middleware)
+				_, _, err = instance.Board.Get(ctx, 10)
+				return err
+			},
+			want:     "agile.Board.Get",
+			template: "rest/agile/1.0/board/{boardID}",
+		},
+		{
+			name: "when the customer request is fetched",
+			call: func(ctx context.Context, middleware common.Middleware) error {
+
+				instance, err := sm.New(client, site)
+				if err != nil {
+					return err
+				}
+
+				instance.Use(middleware)
+				_, _, err = instance.Request.Get(ctx, "DESK-1", nil)
+				return err
+			},
+			want:     "sm.Request.Get",
+			template: "rest/servicedeskapi/request/{issueKeyOrID}",
+		},
+		{
+			name: "when the space content is fetched by space key",
+			call: func(ctx context.Context, middleware common.Middleware) error {
+
+				instance, err := confluence.New(client, site)
+				if err != nil {
+					return err
+				}
+
+				instance.Use(middleware)
+				_, _, err = instance.Space.Content(ctx, "DEV", "", nil, 0, 25)
+				return err
+			},
+			want:     "confluence.Space.Content",
+			template: "wiki/rest/api/space/{spaceKey}/content",
+		},
+		{
+			name: "when the whiteboard properties are fetched",
+			call: func(ctx context.Context, middleware common.Middleware) error {
+
+				instance, err := confluenceV2.New(client, site)
+				if err != nil {
+					return err
+				}
+
+				instance.Use(middleware)
+				_, _, err = instance.Whiteboard.Property.Gets(ctx, 65538, "", "", "", 25)
+				return err
+			},
+			want:     "confluence.Whiteboard.Property.Gets",
+			template: "wiki/api/v2/whiteboards/{contentID}/properties",
+		},
+		{
+			name: "when the database properties are fetched",
+			call: func(ctx context.Context, middleware common.Middleware) error {
+
+				instance, err := confluenceV2.New(client, site)
+				if err != nil {
+					return err
+				}
+
+				instance.Use(middleware)
+				_, _, err = instance.Database.Property.Gets(ctx, 65538, "", "", "", 25)
+				return err
+			},
+			want:     "confluence.Database.Property.Gets",
+			template: "wiki/api/v2/databases/{contentID}/properties",
+		},
+		{
+			name: "when the organization is fetched",
+			call: func(ctx context.Context, middleware common.Middleware) error {
+
+				instance, err := admin.New(client)
+				if err != nil {
+					return err
+				}
+
+				instance.Use(middleware)
+				_, _, err = instance.Organization.Get(ctx, "org-id")
+				return err
+			},
+			want:     "admin.Organization.Get",
+			template: "admin/v1/orgs/{organizationID}",
+		},
+		{
+			name: "when the object is fetched",
+			call: func(ctx context.Context, middleware common.Middleware) error {
+
+				instance, err := assets.New(client, "")
+				if err != nil {
+					return err
+				}
+
+				instance.Use(middleware)
+				_, _, err = instance.Object.Get(ctx, "workspace-id", "object-id")
+				return err
+			},
+			want:     "assets.Object.Get",
+			template: "jsm/assets/workspace/{workspaceID}/v1/object/{objectID}",
+		},
+		{
+			name: "when the workspace is fetched",
+			call: func(ctx context.Context, middleware common.Middleware) error {
+
+				instance, err := bitbucket.New(client, "")
+				if err != nil {
+					return err
+				}
+
+				instance.Use(middleware)
+				_, _, err = instance.Workspace.Get(ctx, "workspace")
+				return err
+			},
+			want:     "bitbucket.Workspace.Get",
+			template: "2.0/workspaces/{workspace}",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			instrument := &recorder{}
+			_ = testCase.call(context.Background(), Middleware(instrument))
+
+			if assert.Len(t, instrument.operations, 1) {
+				assert.Equal(t, testCase.want, instrument.operations[0].Name)
+				assert.Equal(t, testCase.template, instrument.operations[0].EndpointTemplate)
+			}
+		})
+	}
+}
+
+func TestServices(t *testing.T) {
+
+	client := httpClientFunc(func(request *http.Request) (*http.Response, error) {
+		return nil, errors.New("no request expected")
+	})
+
+	const site = "https://ctreminiom.atlassian.net"
+
+	testCases := []struct {
+		product string
+		client  func() (interface{}, error)
+	}{
+		{"jira", func() (interface{}, error) { return v3.New(client, site) }},
+		{"jira", func() (interface{}, error) { return v2.New(client, site) }},
+		{"agile", func() (interface{}, error) { return agile.New(client, site) }},
+		{"sm", func() (interface{}, error) { return sm.New(client, site) }},
+		{"confluence", func() (interface{}, error) { return confluence.New(client, site) }},
+		{"confluence", func() (interface{}, error) { return confluenceV2.New(client, site) }},
+		{"admin", func() (interface{}, error) { return admin.New(client) }},
+		{"assets", func() (interface{}, error) { return assets.New(client, site) }},
+		{"bitbucket", func() (interface{}, error) { return bitbucket.New(client, site) }},
+	}
+
+	for _, testCase := range testCases {
+
+		instance, err := testCase.client()
+		assert.NoError(t, err)
+
+		t.Run(reflect.TypeOf(instance).Elem().PkgPath(), func(t *testing.T) {
+
+			// The services of the generated table must match the services of the client, run go
+			// generate when the clients change.
+			found := make(map[string][]string)
+			walkServices(found, testCase.product, reflect.ValueOf(instance), map[uintptr]bool{})
+
+			assert.NotEmpty(t, found)
+
+			for implementation, names := range found {
+				for _, name := range names {
+					assert.Contains(t, services[implementation], name, implementation)
+				}
+			}
+		})
+	}
+}
+
+// walkServices collects the public services of the implementations of the client.
+func walkServices(found map[string][]string, path string, value reflect.Value, seen map[uintptr]bool) {
+
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+
+		if value.IsNil() || (value.Kind() == reflect.Ptr && seen[value.Pointer()]) {
+			return
+		}
+
+		if value.Kind() == reflect.Ptr {
+			seen[value.Pointer()] = true
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return
+	}
+
+	kind := value.Type()
+	if strings.HasPrefix(kind.Name(), "internal") {
+
+		implementation := strings.TrimPrefix(kind.PkgPath(), modulePath) + "." + kind.Name()
+		found[implementation] = append(found[implementation], path)
+		return
+	}
+
+	for index := 0; index < kind.NumField(); index++ {
+
+		next := path
+		if field := kind.Field(index); field.PkgPath == "" {
+			next += "." + field.Name
+		}
+
+		walkServices(found, next, value.Field(index), seen)
+	}
+}
+
+func TestParseFrame(t *testing.T) {
+
+	testCases := map[string]*frame{
+		modulePath + "jira/internal.(*internalIssueADFServiceImpl).Get": {Implementation: "jira/internal.internalIssueADFServiceImpl", Function: "Get"},
+		modulePath + "jira/sm/internal.internalServiceRequestImpl.Transition": {
+			Implementation: "jira/sm/internal.internalServiceRequestImpl",
+			Function:       "Transition",
+		},
+		modulePath + "jira/internal.deleteIssue":                        {Function: "jira/internal.deleteIssue"},
+		modulePath + "jira/internal.(*internalWorkflowImpl).Gets.func1": {Implementation: "jira/internal.internalWorkflowImpl", Function: "Gets"},
+		modulePath + "jira/v3.(*Client).Call":                           nil,
+		"net/http.(*Client).Do":                                         nil,
+	}
+
+	for function, want := range testCases {
+		t.Run(function, func(t *testing.T) {
+			assert.Equal(t, want, parseFrame(function))
+		})
+	}
+}
+
+func TestEndpointTemplate(t *testing.T) {
+
+	testCases := map[string]string{
+		"/rest/api/3/issue/KP-12/transitions":                         "rest/api/3/issue/{key}/transitions",
+		"rest/agile/1.0/board/10/sprint":                              "rest/agile/1.0/board/{id}/sprint",
+		"wiki/api/v2/pages/65538":                                     "wiki/api/v2/pages/{id}",
+		"admin/v1/orgs/0e5c8a7b-9d3f-4a1e-b2c4-6f8d9e0a1b2c/users":    "admin/v1/orgs/{id}/users",
+		"rest/api/3/user/groups/5b10ac8d82e05b22cc7d4ef5":             "rest/api/3/user/groups/{id}",
+		"rest/api/3/user/557058:f58131cb-b67d-43c7-b30d-6b58d40bd077": "rest/api/3/user/{id}",
+		"2.0/repositories/workspace/repository":                       "2.0/repositories/workspace/repository",
+	}
+
+	for path, want := range testCases {
+		t.Run(path, func(t *testing.T) {
+			assert.Equal(t, want, EndpointTemplate(path))
+		})
+	}
+}